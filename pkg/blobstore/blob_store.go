@@ -0,0 +1,27 @@
+// Package blobstore - хранилище байтов по ключу. Используется архивом сырых ответов парсеров
+// (raw_archive) и копиями фото объявлений в storage-service
+package blobstore
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound - по ключу ничего не сохранено
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore - хранилище байтов по ключу. Ключи состоят из сегментов через "/".
+// Реализация на файловой системе - FSStore; S3-совместимое хранилище подключается так же
+type BlobStore interface {
+	// Put сохраняет данные, перезаписывая существующие
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get возвращает данные или ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Exists проверяет наличие ключа
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Walk обходит ключи с префиксом в лексикографическом порядке
+	Walk(ctx context.Context, prefix string, fn func(key string) error) error
+}
@@ -0,0 +1,129 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FSStore хранит блобы в каталоге: ключ "a/b/c" - файл <root>/a/b/c
+type FSStore struct {
+	root string
+}
+
+// NewFSStore создает хранилище и сам каталог, если его нет
+func NewFSStore(root string) (*FSStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("blob store root directory cannot be empty")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory '%s': %w", root, err)
+	}
+	return &FSStore{root: root}, nil
+}
+
+func (s *FSStore) Put(_ context.Context, key string, data []byte) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for '%s': %w", key, err)
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы читатель не увидел обрезанный блоб
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for '%s': %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write '%s': %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("failed to move '%s' into place: %w", key, err)
+	}
+	return nil
+}
+
+func (s *FSStore) Get(_ context.Context, key string) ([]byte, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", key, err)
+	}
+	return data, nil
+}
+
+func (s *FSStore) Exists(_ context.Context, key string) (bool, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat '%s': %w", key, err)
+	}
+	return true, nil
+}
+
+func (s *FSStore) Walk(ctx context.Context, prefix string, fn func(key string) error) error {
+	start := s.root
+	if prefix != "" {
+		p, err := s.path(prefix)
+		if err != nil {
+			return err
+		}
+		start = p
+	}
+
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel))
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		// Под префиксом еще ничего не сохраняли
+		return nil
+	}
+	return err
+}
+
+// path переводит ключ в путь внутри root, не выпуская за его пределы
+func (s *FSStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+strings.TrimSuffix(key, "/") {
+		return "", fmt.Errorf("invalid blob key '%s'", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean[1:])), nil
+}
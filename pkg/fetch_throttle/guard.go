@@ -0,0 +1,329 @@
+// Package fetch_throttle ограничивает частоту запросов парсеров к сайтам-источникам.
+// Guard объединяет адаптивную паузу между запросами (растет на 429/403/5xx и
+// учитывает Retry-After) и circuit breaker, который перестает пускать запросы,
+// пока источник нас блокирует
+package fetch_throttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// State - состояние circuit breaker
+type State string
+
+const (
+	StateClosed   State = "closed"    // запросы идут как обычно
+	StateOpen     State = "open"      // источник блокирует нас, запросы не отправляются
+	StateHalfOpen State = "half_open" // пробный запрос после паузы
+)
+
+// ErrCircuitOpen - запрос не отправлен, потому что цепь разомкнута
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// OpenError - цепь разомкнута до Until
+type OpenError struct {
+	Until time.Time
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%v until %s", ErrCircuitOpen, e.Until.Format(time.RFC3339))
+}
+
+func (e *OpenError) Unwrap() error { return ErrCircuitOpen }
+
+// RetryAfter - сколько осталось ждать до пробного запроса
+func (e *OpenError) RetryAfter() time.Duration {
+	if d := time.Until(e.Until); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Config - настройки Guard. Нулевые значения заменяются значениями по умолчанию
+type Config struct {
+	MinDelay time.Duration // пауза между запросами в спокойном режиме
+	MaxDelay time.Duration // потолок паузы при замедлении
+	Jitter   float64       // разброс паузы в долях (0.5 = ±50%)
+
+	FailureThreshold int           // сколько блокировок подряд размыкают цепь
+	OpenTimeout      time.Duration // первая пауза в состоянии open
+	MaxOpenTimeout   time.Duration // каждое повторное размыкание удваивает паузу до этого предела
+
+	// OnStateChange вызывается при смене состояния (вне блокировки Guard)
+	OnStateChange func(from, to State, snapshot Snapshot)
+}
+
+func (c *Config) setDefaults() {
+	if c.MinDelay <= 0 {
+		c.MinDelay = time.Second
+	}
+	if c.MaxDelay < c.MinDelay {
+		c.MaxDelay = 60 * time.Second
+		if c.MaxDelay < c.MinDelay {
+			c.MaxDelay = c.MinDelay
+		}
+	}
+	if c.Jitter < 0 {
+		c.Jitter = 0
+	}
+	if c.Jitter > 1 {
+		c.Jitter = 1
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = time.Minute
+	}
+	if c.MaxOpenTimeout < c.OpenTimeout {
+		c.MaxOpenTimeout = 30 * time.Minute
+		if c.MaxOpenTimeout < c.OpenTimeout {
+			c.MaxOpenTimeout = c.OpenTimeout
+		}
+	}
+}
+
+// Snapshot - состояние Guard для логов и heartbeat
+type Snapshot struct {
+	State               State
+	Delay               time.Duration // текущая пауза между запросами
+	ConsecutiveFailures int
+	Trips               int       // сколько раз подряд цепь размыкалась без успешного запроса между ними
+	OpenUntil           time.Time // для StateOpen
+}
+
+// Outcome - как Guard расценил ответ
+type Outcome struct {
+	Failure    bool          // ответ-блокировка, учтен circuit breaker
+	RetryAfter time.Duration // сколько источник просит подождать (Retry-After или пауза до пробного запроса)
+}
+
+// Guard потокобезопасен и общий на все запросы к одному источнику
+type Guard struct {
+	mu  sync.Mutex
+	cfg Config
+
+	delay       time.Duration
+	nextAllowed time.Time // раньше этого момента следующий запрос не уходит
+
+	state         State
+	failures      int
+	trips         int
+	openUntil     time.Time
+	probeInFlight bool
+}
+
+// New создает Guard в состоянии closed
+func New(cfg Config) *Guard {
+	cfg.setDefaults()
+	return &Guard{
+		cfg:   cfg,
+		delay: cfg.MinDelay,
+		state: StateClosed,
+	}
+}
+
+// Acquire ждет своей очереди на запрос. Если цепь разомкнута, сразу возвращает *OpenError.
+// В состоянии half_open пропускает только один пробный запрос
+func (g *Guard) Acquire(ctx context.Context) error {
+	g.mu.Lock()
+	now := time.Now()
+
+	var changed *stateChange
+	if g.state == StateOpen {
+		if now.Before(g.openUntil) {
+			until := g.openUntil
+			g.mu.Unlock()
+			return &OpenError{Until: until}
+		}
+		changed = g.setState(StateHalfOpen)
+	}
+	if g.state == StateHalfOpen {
+		if g.probeInFlight {
+			g.mu.Unlock()
+			g.notify(changed)
+			return &OpenError{Until: now.Add(g.cfg.MinDelay)}
+		}
+		g.probeInFlight = true
+	}
+
+	// Резервируем слот: следующий запрос уйдет не раньше чем через текущую паузу
+	start := g.nextAllowed
+	if start.Before(now) {
+		start = now
+	}
+	g.nextAllowed = start.Add(g.jittered(g.delay))
+	g.mu.Unlock()
+	g.notify(changed)
+
+	wait := time.Until(start)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		g.ReleaseProbe()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Report сообщает Guard результат запроса. status 0 означает сетевую ошибку
+func (g *Guard) Report(status int, header http.Header) Outcome {
+	now := time.Now()
+	retryAfter := ParseRetryAfter(header.Get("Retry-After"), now)
+
+	g.mu.Lock()
+	g.probeInFlight = false
+
+	if !IsFailureStatus(status) {
+		// Плавно возвращаемся к базовой паузе
+		g.delay -= (g.delay - g.cfg.MinDelay) / 4
+		g.failures = 0
+		var changed *stateChange
+		if g.state != StateClosed {
+			g.trips = 0
+			changed = g.setState(StateClosed)
+		}
+		g.mu.Unlock()
+		g.notify(changed)
+		return Outcome{}
+	}
+
+	g.failures++
+	g.delay *= 2
+	if g.delay > g.cfg.MaxDelay {
+		g.delay = g.cfg.MaxDelay
+	}
+	if retryAfter > 0 && now.Add(retryAfter).After(g.nextAllowed) {
+		g.nextAllowed = now.Add(retryAfter)
+	}
+
+	var changed *stateChange
+	if g.state == StateHalfOpen || g.failures >= g.cfg.FailureThreshold {
+		g.trips++
+		timeout := g.cfg.OpenTimeout
+		for i := 1; i < g.trips && timeout < g.cfg.MaxOpenTimeout; i++ {
+			timeout *= 2
+		}
+		if timeout > g.cfg.MaxOpenTimeout {
+			timeout = g.cfg.MaxOpenTimeout
+		}
+		if retryAfter > timeout {
+			timeout = retryAfter
+		}
+		g.openUntil = now.Add(timeout)
+		g.failures = 0
+		changed = g.setState(StateOpen)
+		retryAfter = timeout
+	}
+	g.mu.Unlock()
+	g.notify(changed)
+
+	return Outcome{Failure: true, RetryAfter: retryAfter}
+}
+
+// WaitClosed блокируется, пока цепь разомкнута. Используется, чтобы приостановить
+// потребителей очередей на время блокировки вместо того, чтобы гонять сообщения по ретраям
+func (g *Guard) WaitClosed(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		wait := time.Duration(0)
+		if g.state == StateOpen {
+			wait = time.Until(g.openUntil)
+		}
+		g.mu.Unlock()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Snapshot возвращает текущее состояние
+func (g *Guard) Snapshot() Snapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.snapshotLocked()
+}
+
+func (g *Guard) snapshotLocked() Snapshot {
+	s := Snapshot{
+		State:               g.state,
+		Delay:               g.delay,
+		ConsecutiveFailures: g.failures,
+		Trips:               g.trips,
+	}
+	if g.state == StateOpen {
+		s.OpenUntil = g.openUntil
+	}
+	return s
+}
+
+// ReleaseProbe отдает слот пробного запроса, если после Acquire запрос так и не был отправлен
+// и Report не будет вызван (например, HTTP-клиент отклонил URL). Иначе half_open не пропустит
+// ни одного запроса. После Report вызов ничего не меняет
+func (g *Guard) ReleaseProbe() {
+	g.mu.Lock()
+	if g.state == StateHalfOpen {
+		g.probeInFlight = false
+	}
+	g.mu.Unlock()
+}
+
+type stateChange struct {
+	from, to State
+	snapshot Snapshot
+}
+
+// setState вызывается под блокировкой, уведомление отправляется после ее снятия
+func (g *Guard) setState(to State) *stateChange {
+	from := g.state
+	if from == to {
+		return nil
+	}
+	g.state = to
+	if to != StateHalfOpen {
+		g.probeInFlight = false
+	}
+	return &stateChange{from: from, to: to, snapshot: g.snapshotLocked()}
+}
+
+func (g *Guard) notify(c *stateChange) {
+	if c != nil && g.cfg.OnStateChange != nil {
+		g.cfg.OnStateChange(c.from, c.to, c.snapshot)
+	}
+}
+
+func (g *Guard) jittered(d time.Duration) time.Duration {
+	if g.cfg.Jitter <= 0 {
+		return d
+	}
+	factor := 1 + g.cfg.Jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(d) * factor)
+}
+
+// IsFailureStatus - ответы, которыми источник сообщает, что нас ограничивают или он перегружен.
+// 0 - ответа нет совсем (сетевая ошибка, мертвый прокси)
+func IsFailureStatus(status int) bool {
+	return status == 0 ||
+		status == http.StatusForbidden ||
+		status == http.StatusTooManyRequests ||
+		status >= http.StatusInternalServerError
+}
@@ -0,0 +1,118 @@
+package fetch_throttle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// halfOpenGuard возвращает Guard, который только что пропустил пробный запрос в half_open
+func halfOpenGuard(t *testing.T) *Guard {
+	t.Helper()
+	g := New(Config{MinDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	g.Report(http.StatusTooManyRequests, nil)
+	if err := g.Acquire(context.Background()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Acquire in open state: got %v, want ErrCircuitOpen", err)
+	}
+
+	// Пауза open истекла
+	g.mu.Lock()
+	g.openUntil = time.Now().Add(-time.Millisecond)
+	g.mu.Unlock()
+
+	if err := g.Acquire(context.Background()); err != nil {
+		t.Fatalf("probe Acquire: %v", err)
+	}
+	if state := g.Snapshot().State; state != StateHalfOpen {
+		t.Fatalf("state after probe Acquire: got %s, want %s", state, StateHalfOpen)
+	}
+	return g
+}
+
+func TestGuardHalfOpenProbe(t *testing.T) {
+	tests := []struct {
+		name      string
+		after     func(g *Guard)
+		wantState State
+		wantErr   error // результат следующего Acquire
+		wantTrips int
+	}{
+		{
+			name:      "second request while probe is in flight",
+			after:     func(g *Guard) {},
+			wantState: StateHalfOpen,
+			wantErr:   ErrCircuitOpen,
+			wantTrips: 1,
+		},
+		{
+			name:      "probe succeeded",
+			after:     func(g *Guard) { g.Report(http.StatusOK, nil) },
+			wantState: StateClosed,
+			wantTrips: 0,
+		},
+		{
+			name:      "probe blocked again",
+			after:     func(g *Guard) { g.Report(http.StatusServiceUnavailable, nil) },
+			wantState: StateOpen,
+			wantErr:   ErrCircuitOpen,
+			wantTrips: 2,
+		},
+		{
+			name:      "probe was never sent",
+			after:     func(g *Guard) { g.ReleaseProbe() },
+			wantState: StateHalfOpen,
+			wantTrips: 1,
+		},
+		{
+			name: "release after report changes nothing",
+			after: func(g *Guard) {
+				g.Report(http.StatusOK, nil)
+				g.ReleaseProbe()
+			},
+			wantState: StateClosed,
+			wantTrips: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := halfOpenGuard(t)
+			tt.after(g)
+
+			snapshot := g.Snapshot()
+			if snapshot.State != tt.wantState {
+				t.Errorf("state: got %s, want %s", snapshot.State, tt.wantState)
+			}
+			if snapshot.Trips != tt.wantTrips {
+				t.Errorf("trips: got %d, want %d", snapshot.Trips, tt.wantTrips)
+			}
+
+			err := g.Acquire(context.Background())
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Acquire: unexpected error %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Acquire: got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGuardReopenDoublesTimeout(t *testing.T) {
+	g := halfOpenGuard(t)
+	before := time.Now()
+	outcome := g.Report(http.StatusForbidden, nil)
+
+	if !outcome.Failure {
+		t.Fatal("Report(403): want failure outcome")
+	}
+	if outcome.RetryAfter != 2*time.Minute {
+		t.Errorf("RetryAfter: got %s, want %s", outcome.RetryAfter, 2*time.Minute)
+	}
+	if until := g.Snapshot().OpenUntil; until.Before(before.Add(2 * time.Minute)) {
+		t.Errorf("OpenUntil: got %s, want at least %s", until, before.Add(2*time.Minute))
+	}
+}
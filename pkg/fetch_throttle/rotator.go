@@ -0,0 +1,40 @@
+package fetch_throttle
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Rotator по кругу выдает значения из списка (прокси, User-Agent)
+type Rotator struct {
+	items []string
+	next  atomic.Uint64
+}
+
+// NewRotator создает ротатор. Пустые элементы отбрасываются
+func NewRotator(items []string) *Rotator {
+	r := &Rotator{}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			r.items = append(r.items, item)
+		}
+	}
+	return r
+}
+
+// Len - количество значений
+func (r *Rotator) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.items)
+}
+
+// Next возвращает следующее значение или пустую строку, если список пуст
+func (r *Rotator) Next() string {
+	if r.Len() == 0 {
+		return ""
+	}
+	i := r.next.Add(1) - 1
+	return r.items[i%uint64(len(r.items))]
+}
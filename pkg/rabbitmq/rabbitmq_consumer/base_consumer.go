@@ -1,21 +1,34 @@
 package rabbitmq_consumer
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
 	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// Заголовки, которые добавляются к сообщению при отправке в финальный DLX.
+// По ним можно понять, откуда пришло сообщение и почему оно не было обработано
+const (
+	HeaderFailureReason      = "x-failure-reason"
+	HeaderOriginalExchange   = "x-original-exchange"
+	HeaderOriginalRoutingKey = "x-original-routing-key"
+	HeaderOriginalQueue      = "x-original-queue"
+)
+
 // baseConsumer содержит общую логику подключения, канала, QoS и т.д.
 type baseConsumer struct {
 	config            ConsumerConfig
 	connection        *amqp.Connection
 	channel           *amqp.Channel
 	actualQueueName   string                       // Для хранения имени очереди, особенно если оно генерируется сервером
-	finalDlxPublisher *rabbitmq_producer.Publisher 
+	finalDlxPublisher *rabbitmq_producer.Publisher
+	retryPublisher    *rabbitmq_producer.Publisher // Публикует в wait-очереди уровней через default exchange
 	wg                sync.WaitGroup               // Нужен для graceful shutdown
 
 	Logger rabbitmq_common.Logger
@@ -45,9 +58,15 @@ type ConsumerConfig struct {
 	PrefetchSize  int // 0 - без ограничений
 	QosGlobal     bool
 	// Настройки потребителя
-	ConsumerTag       string // Тег потребителя (если пустой, генерируется RabbitMQ)
+	ConsumerTag       string // Тег потребителя (если пустой, генерируется автоматически)
 	ExclusiveConsumer bool
 
+	// Настройки пула обработчиков (используются DistributingConsumer)
+	WorkerPoolSize int           // Количество одновременно работающих обработчиков; 0 или меньше - 1
+	HandlerTimeout time.Duration // Таймаут на обработку одного сообщения; 0 - без ограничения
+	DrainTimeout   time.Duration // Сколько ждать возврата оставшихся сообщений при остановке; 0 - 5 секунд
+	Gate           Gate          // Если задан, диспетчер не берет новые сообщения, пока Gate.Wait не вернет управление
+
 	// поля для ретраев
 	EnableRetryMechanism bool   // Главный флаг для включения
 	RetryExchange        string // Имя retry-обменника
@@ -58,6 +77,13 @@ type ConsumerConfig struct {
 	FinalDLQRoutingKey   string // Ключ для привязки финальной DLQ
 	MaxRetries           int    // Максимальное количество попыток
 
+	// Многоуровневые ретраи, обычно задаются через WithRetryTiers. Если список пуст, используется общая wait-очередь RetryQueue с RetryTTL.
+	// Для каждой задержки объявляется своя wait-очередь "<очередь>.retry.<задержка>",
+	// которая возвращает сообщение напрямую в эту очередь. Если попыток больше, чем уровней,
+	// повторяется последний уровень
+	RetryDelays []time.Duration // Например: 10s, 1m, 10m, 1h (по возрастанию)
+	RetryJitter float64         // Разброс задержки в долях (0.2 = ±20%)
+
 	Logger rabbitmq_common.Logger
 }
 
@@ -80,6 +106,18 @@ func newBaseConsumer(cfg ConsumerConfig, connManager *rabbitmq_common.Connection
 		return nil, fmt.Errorf("base Consumer: exchange type is required if declaring an exchange for binding")
 	}
 
+	if err := validateRetryDelays(cfg.RetryDelays); err != nil {
+		return nil, fmt.Errorf("base Consumer: %w", err)
+	}
+	if len(cfg.RetryDelays) > 0 && cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = len(cfg.RetryDelays)
+	}
+
+	if cfg.ConsumerTag == "" {
+		// Тег нужен нам самим, чтобы при остановке отменить подписку через channel.Cancel
+		cfg.ConsumerTag = fmt.Sprintf("ctag-%s-%d", cfg.QueueName, time.Now().UnixNano())
+	}
+
 	c := &baseConsumer{
 		config: cfg,
 		Logger: logger,
@@ -108,6 +146,18 @@ func newBaseConsumer(cfg ConsumerConfig, connManager *rabbitmq_common.Connection
 			return nil, fmt.Errorf("base Consumer: failed to create final DLX publisher: %w", err)
 		}
 		c.finalDlxPublisher = dlxPublisher
+
+		if len(cfg.RetryDelays) > 0 {
+			retryPublisher, err := rabbitmq_producer.NewPublisher(rabbitmq_producer.PublisherConfig{
+				Config:       rabbitmq_common.Config{URL: cfg.URL},
+				ExchangeName: "", // default exchange: ключ маршрутизации = имя wait-очереди
+			}, connManager)
+			if err != nil {
+				_ = c.Close()
+				return nil, fmt.Errorf("base Consumer: failed to create retry publisher: %w", err)
+			}
+			c.retryPublisher = retryPublisher
+		}
 	}
 
 	return c, nil
@@ -136,7 +186,7 @@ func (c *baseConsumer) connectAndSetup() error {
 		}
 	}
 
-	if c.config.EnableRetryMechanism {
+	if c.config.EnableRetryMechanism && c.config.RetryExchange != "" {
 		if c.config.QueueArgs == nil {
 			c.config.QueueArgs = amqp.Table{}
 		}
@@ -246,6 +296,17 @@ func (c *baseConsumer) connectAndSetup() error {
 			return fmt.Errorf("failed to bind final DLQ: %w", err)
 		}
 
+		if len(c.config.RetryDelays) > 0 {
+			if err := c.declareRetryTiers(); err != nil {
+				return err
+			}
+		}
+
+		if c.config.RetryExchange == "" {
+			c.Logger.Debug("Setup complete", "queue", c.actualQueueName)
+			return nil
+		}
+
 		// Объявляем обменник для ретраев (fanout)
 		c.Logger.Debug("Declaring retry exchange", "name", c.config.RetryExchange)
 		err = c.channel.ExchangeDeclare(c.config.RetryExchange, "fanout", true, false, false, false, nil)
@@ -285,6 +346,150 @@ func (c *baseConsumer) connectAndSetup() error {
 	return nil
 }
 
+// declareRetryTiers объявляет по wait-очереди на каждый уровень задержки.
+// Задержка задается через Expiration каждого сообщения (с учетом jitter), а истекшие
+// сообщения возвращаются через default exchange прямо в основную очередь потребителя
+func (c *baseConsumer) declareRetryTiers() error {
+	for _, tier := range RetryTiers(c.actualQueueName, c.config.RetryDelays) {
+		name := tier.Queue
+		c.Logger.Debug("Declaring retry tier queue", "name", name, "delay", tier.Delay)
+
+		_, err := c.channel.QueueDeclare(
+			name,
+			true,  // durable
+			false, // autoDelete
+			false, // exclusive
+			false, // noWait
+			amqp.Table{
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": c.actualQueueName,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare retry tier queue '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// handleFailure решает судьбу сообщения, которое обработчик не смог обработать:
+// повтор через wait-очередь, финальный DLX или простой Nack, если ретраи выключены
+func (c *baseConsumer) handleFailure(d amqp.Delivery, cause error) {
+	if !c.config.EnableRetryMechanism {
+		c.Logger.Info("Retry disabled. Nacking message without requeue.",
+			"consumer_tag", c.config.ConsumerTag,
+			"delivery_tag", d.DeliveryTag)
+		_ = d.Nack(false, false)
+		return
+	}
+
+	if IsPermanent(cause) {
+		c.Logger.Info("Permanent error for message. Skipping retries.",
+			"consumer_tag", c.config.ConsumerTag,
+			"delivery_tag", d.DeliveryTag)
+		c.deadLetter(d, cause)
+		return
+	}
+
+	if len(c.config.RetryDelays) > 0 {
+		c.retryWithBackoff(d, cause)
+		return
+	}
+
+	// Считаем, сколько раз сообщение уже умирало
+	deathCount := c.getDeathCount(d, c.actualQueueName)
+
+	if deathCount < int64(c.config.MaxRetries) {
+		// Лимит не достигнут, отправляем в цикл ретрая через Nack(requeue=false)
+		c.Logger.Info("Retrying message",
+			"consumer_tag", c.config.ConsumerTag,
+			"delivery_tag", d.DeliveryTag,
+			"death_count", deathCount)
+		_ = d.Nack(false, false)
+		return
+	}
+
+	// Лимит ретраев исчерпан, публикуем в финальный DLX
+	c.Logger.Info("Max retries reached for message. Publishing to final DLX.",
+		"consumer_tag", c.config.ConsumerTag,
+		"delivery_tag", d.DeliveryTag)
+	c.deadLetter(d, cause)
+}
+
+// retryWithBackoff публикует копию сообщения в wait-очередь нужного уровня и подтверждает оригинал
+func (c *baseConsumer) retryWithBackoff(d amqp.Delivery, cause error) {
+	attempt := retryAttempt(d)
+	if attempt >= c.config.MaxRetries {
+		c.Logger.Info("Max retries reached for message. Publishing to final DLX.",
+			"consumer_tag", c.config.ConsumerTag,
+			"delivery_tag", d.DeliveryTag,
+			"attempt", attempt)
+		c.deadLetter(d, cause)
+		return
+	}
+
+	tier := pickRetryTier(c.config.RetryDelays, attempt, cause)
+	delay := withJitter(tier, c.config.RetryJitter)
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[HeaderRetryAttempt] = int32(attempt + 1)
+	if cause != nil {
+		headers[HeaderFailureReason] = cause.Error()
+	}
+
+	err := c.retryPublisher.Publish(
+		context.Background(),
+		retryTierQueueName(c.actualQueueName, tier),
+		amqp.Publishing{
+			ContentType:  d.ContentType,
+			Body:         d.Body,
+			Headers:      headers,
+			Priority:     d.Priority,
+			Expiration:   strconv.FormatInt(delay.Milliseconds(), 10),
+			Timestamp:    time.Now(),
+			DeliveryMode: amqp.Persistent,
+		},
+	)
+	if err != nil {
+		// Вернуть в очередь лучше, чем потерять: без retry-обменника Nack(requeue=false) выбросил бы сообщение
+		c.Logger.Error(err, "Failed to publish message to retry tier. Requeueing.",
+			"consumer_tag", c.config.ConsumerTag,
+			"delivery_tag", d.DeliveryTag)
+		_ = d.Nack(false, true)
+		return
+	}
+
+	c.Logger.Info("Retrying message with backoff",
+		"consumer_tag", c.config.ConsumerTag,
+		"delivery_tag", d.DeliveryTag,
+		"attempt", attempt+1,
+		"tier", tier,
+		"delay", delay)
+	_ = d.Ack(false)
+}
+
+// deadLetter отправляет сообщение в финальный DLX и подтверждает оригинал
+func (c *baseConsumer) deadLetter(d amqp.Delivery, cause error) {
+	if err := c.publishToFinalDLX(d, cause); err != nil {
+		c.Logger.Error(err, "Failed to publish to final DLX. Nacking to trigger retry loop again.",
+			"consumer_tag", c.config.ConsumerTag,
+			"delivery_tag", d.DeliveryTag)
+		// Пытаемся еще раз, раз не смогли отправить в DLQ. Без общего retry-обменника
+		// Nack(requeue=false) просто выбросил бы сообщение, поэтому возвращаем его в очередь
+		_ = d.Nack(false, c.config.RetryExchange == "")
+		return
+	}
+
+	// Успешно опубликовали, подтверждаем оригинал
+	c.Logger.Info("Successfully published to final DLX. Acking original message",
+		"consumer_tag", c.config.ConsumerTag,
+		"delivery_tag", d.DeliveryTag)
+	_ = d.Ack(false)
+}
+
 // getDeathCount - работа с x-death
 func (c *baseConsumer) getDeathCount(d amqp.Delivery, queueName string) int64 {
 	if d.Headers == nil {
@@ -314,6 +519,32 @@ func (c *baseConsumer) getDeathCount(d amqp.Delivery, queueName string) int64 {
 	return 0
 }
 
+// publishToFinalDLX публикует сообщение в финальный DLX вместе с причиной ошибки и исходным маршрутом
+func (c *baseConsumer) publishToFinalDLX(d amqp.Delivery, cause error) error {
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	if cause != nil {
+		headers[HeaderFailureReason] = cause.Error()
+	}
+	headers[HeaderOriginalExchange] = d.Exchange
+	headers[HeaderOriginalRoutingKey] = d.RoutingKey
+	headers[HeaderOriginalQueue] = c.actualQueueName
+
+	return c.finalDlxPublisher.Publish(
+		context.Background(),
+		c.config.FinalDLQRoutingKey,
+		amqp.Publishing{
+			ContentType:  d.ContentType,
+			Body:         d.Body,
+			Headers:      headers,
+			Timestamp:    time.Now(),
+			DeliveryMode: amqp.Persistent,
+		},
+	)
+}
+
 // Close закрывает канал потребителя
 func (c *baseConsumer) Close() error {
 
@@ -323,6 +554,13 @@ func (c *baseConsumer) Close() error {
 
 	var firstErr error
 
+	if c.retryPublisher != nil {
+		if err := c.retryPublisher.Close(); err != nil {
+			c.Logger.Error(err, "Error closing retry publisher")
+			firstErr = err
+		}
+	}
+
 	// закрытие издателя в dlx
 	if c.finalDlxPublisher != nil {
 		if err := c.finalDlxPublisher.Close(); err != nil {
@@ -9,9 +9,19 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-// BatchMessageHandler - обработчик для пачки сообщений
-// Он принимает срез доставок и должен вернуть ошибку, если пачку нужно вернуть в очередь
-type BatchMessageHandler func(deliveries []amqp.Delivery) error
+// BatchMessageHandler - обработчик для пачки сообщений.
+// Возвращает итог по каждой доставке: outcomes[i] относится к deliveries[i], nil - сообщение обработано.
+// Пустой срез означает, что вся пачка обработана успешно
+type BatchMessageHandler func(deliveries []amqp.Delivery) (outcomes []error)
+
+// FailBatch - итог для случая, когда вся пачка завершилась одной и той же ошибкой
+func FailBatch(size int, err error) []error {
+	outcomes := make([]error, size)
+	for i := range outcomes {
+		outcomes[i] = err
+	}
+	return outcomes
+}
 
 // BatchConsumer - структура для управления пакетным потребителем
 type BatchConsumer struct {
@@ -149,13 +159,26 @@ func (c *BatchConsumer) StartConsuming(ctx context.Context) error {
 	}
 }
 
-// processBatch вызывает внешний обработчик и отправляет Ack/Nack
+// processBatch вызывает внешний обработчик и отправляет Ack/Nack по каждому сообщению
 func (c *BatchConsumer) processBatch(batch []amqp.Delivery) {
 	if len(batch) == 0 {
 		return
 	}
 
-	if err := c.handler(batch); err == nil {
+	outcomes := c.handler(batch)
+	if len(outcomes) != 0 && len(outcomes) != len(batch) {
+		// Нарушен контракт обработчика, не знаем, какие сообщения обработаны, поэтому повторяем все
+		outcomes = FailBatch(len(batch), fmt.Errorf("batch handler returned %d outcomes for %d deliveries", len(outcomes), len(batch)))
+	}
+
+	failed := 0
+	for _, err := range outcomes {
+		if err != nil {
+			failed++
+		}
+	}
+
+	if failed == 0 {
 		// Успех, подтверждаем всю пачку
 		lastTag := batch[len(batch)-1].DeliveryTag
 		_ = c.baseConsumer.channel.Ack(lastTag, true)
@@ -164,60 +187,22 @@ func (c *BatchConsumer) processBatch(batch []amqp.Delivery) {
 			"batch_size", len(batch))
 
 		return
-	} else {
-		// Ошибка при обработке пачки
-		c.baseConsumer.Logger.Error(err, "Handler returned error for batch")
-	}
-
-	if !c.baseConsumer.config.EnableRetryMechanism {
-		// Ретраи выключены, просто Nack всю пачку без requeue
-		lastTag := batch[len(batch)-1].DeliveryTag
-		_ = c.baseConsumer.channel.Nack(lastTag, true, false) // multiple=true, requeue=false
-		c.baseConsumer.Logger.Info("Retry disabled. Nacking entire batch without requeue.")
-		return
 	}
 
-	// Ретраи включены. Проверяем каждое сообщение индивидуально
-	for _, d := range batch {
-		deathCount := c.baseConsumer.getDeathCount(d, c.baseConsumer.actualQueueName)
-		if deathCount < int64(c.baseConsumer.config.MaxRetries) {
-			// Лимит не достигнут, возвращаем на ретрай
-			c.baseConsumer.Logger.Info("Nacking message for retry",
-				"delivery_tag", d.DeliveryTag,
-				"death_count", deathCount)
-
-			_ = c.baseConsumer.channel.Nack(d.DeliveryTag, false, false) // multiple, requeue=false -> to DLX/retry-loop
-		} else {
-			// Лимит достигнут, отправляем в финальный DLQ
-			c.baseConsumer.Logger.Info("Max retries reached for message. Publishing to final DLX.",
-				"delivery_tag", d.DeliveryTag)
-
-			err := c.baseConsumer.finalDlxPublisher.Publish(
-				context.Background(),
-				c.baseConsumer.config.FinalDLQRoutingKey,
-				amqp.Publishing{
-					ContentType:  d.ContentType,
-					Body:         d.Body,
-					Headers:      d.Headers,
-					Timestamp:    time.Now(),
-					DeliveryMode: amqp.Persistent,
-				},
-			)
-
-			if err != nil {
-				c.baseConsumer.Logger.Error(err, "Failed to publish to final DLX. Nacking to trigger retry loop again.",
-					"consumer_tag", c.baseConsumer.config.ConsumerTag,
-					"delivery_tag", d.DeliveryTag)
-				_ = d.Nack(false, false) // Пытаемся еще раз, раз не смогли отправить в DLQ
-			} else {
-				// Успешно опубликовали, подтверждаем оригинал
-				c.baseConsumer.Logger.Info("Successfully published to final DLX. Acking original message",
-					"consumer_tag", c.baseConsumer.config.ConsumerTag,
-					"delivery_tag", d.DeliveryTag)
-				_ = d.Ack(false)
-			}
+	c.baseConsumer.Logger.Warn("Handler reported failed messages in batch",
+		"batch_size", len(batch),
+		"failed", failed)
 
+	// Решаем судьбу каждого сообщения индивидуально: успешные подтверждаем,
+	// упавшие отправляем на ретрай или в финальный DLX
+	for i, d := range batch {
+		if outcomes[i] == nil {
+			_ = d.Ack(false)
+			continue
 		}
+		c.baseConsumer.Logger.Error(outcomes[i], "Handler returned error for message",
+			"delivery_tag", d.DeliveryTag)
+		c.baseConsumer.handleFailure(d, outcomes[i])
 	}
 }
 
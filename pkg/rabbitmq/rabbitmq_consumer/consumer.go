@@ -5,4 +5,15 @@ import "context"
 type Consumer interface {
 	StartConsuming(ctx context.Context) error
 	Close() error
-}
\ No newline at end of file
+}
+
+// Gate приостанавливает выборку сообщений. Wait блокируется, пока обработку нельзя продолжать,
+// и возвращает ошибку только при отмене ctx
+type Gate interface {
+	Wait(ctx context.Context) error
+}
+
+// GateFunc позволяет использовать обычную функцию как Gate
+type GateFunc func(ctx context.Context) error
+
+func (f GateFunc) Wait(ctx context.Context) error { return f(ctx) }
@@ -9,13 +9,19 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-// MessageHandler функция-обработчик для полученных сообщений
-type MessageHandler func(delivery amqp.Delivery) error
+// defaultDrainTimeout - сколько по умолчанию ждем возврата недоставленных воркерам сообщений при остановке
+const defaultDrainTimeout = 5 * time.Second
+
+// MessageHandler функция-обработчик для полученных сообщений.
+// Контекст ограничен HandlerTimeout и не отменяется при остановке потребителя,
+// чтобы уже начатая обработка могла корректно завершиться
+type MessageHandler func(ctx context.Context, delivery amqp.Delivery) error
 
 // Consumer структура для управления потребителем
 type DistributingConsumer struct {
 	baseConsumer *baseConsumer
 	handler      MessageHandler
+	workers      int
 }
 
 // NewConsumer создает нового потребителя
@@ -30,9 +36,20 @@ func NewDistributingConsumer(cfg ConsumerConfig, handler MessageHandler, connMan
 		return nil, fmt.Errorf("distributing Consumer: message handler is required")
 	}
 
+	workers := cfg.WorkerPoolSize
+	if workers <= 0 {
+		workers = 1
+	}
+	if cfg.PrefetchCount > 0 && workers > cfg.PrefetchCount {
+		bc.Logger.Warn("WorkerPoolSize is greater than PrefetchCount, extra workers will stay idle",
+			"worker_pool_size", workers,
+			"prefetch_count", cfg.PrefetchCount)
+	}
+
 	c := &DistributingConsumer{
 		baseConsumer: bc,
 		handler:      handler,
+		workers:      workers,
 	}
 
 	return c, nil
@@ -57,113 +74,22 @@ func (c *DistributingConsumer) StartConsuming(ctx context.Context) error {
 		return fmt.Errorf("distributing Consumer %s: failed to register a consumer on queue '%s': %w", c.baseConsumer.config.ConsumerTag, c.baseConsumer.actualQueueName, err)
 	}
 
-	c.baseConsumer.Logger.Info("[*] Waiting for messages on queue", "queue_name", c.baseConsumer.actualQueueName)
+	c.baseConsumer.Logger.Info("[*] Waiting for messages on queue",
+		"queue_name", c.baseConsumer.actualQueueName,
+		"worker_pool_size", c.workers,
+		"handler_timeout", c.baseConsumer.config.HandlerTimeout)
 
-	// Запускаем горутину, которая будет читать из канала RabbitMQ и распределять работу
-	go func() {
-		for {
-			// Приоритетная, неблокирующая проверка на отмену
-			// Это гарантирует, что мы не запустим нового работника, если уже получили команду на остановку
-			select {
-			case <-ctx.Done():
-				c.baseConsumer.Logger.Debug("(Priority Check) Context cancelled for consumer. Exiting consumption loop.",
-					"consumer_tag", c.baseConsumer.config.ConsumerTag)
-				return // Выходим из горутины-диспетчера
-			default:
-				// Контекст не отменен, продолжаем
-			}
+	// Небуферизированный канал: диспетчер берет следующее сообщение из RabbitMQ,
+	// только когда один из воркеров свободен. Остальное ждет в prefetch-буфере
+	jobs := make(chan amqp.Delivery)
 
-			// Блокирующее ожидание нового сообщения или отмены
-			select {
-			case <-ctx.Done(): // Если контекст был отменен (например, при вызове Close)
-				// Эта ветка сработает, если контекст отменили, пока мы ждали сообщение
-				c.baseConsumer.Logger.Debug("(Wait Check) Context cancelled for consumer. Exiting consumption loop.",
-					"consumer_tag", c.baseConsumer.config.ConsumerTag)
-				return // Выходим из горутины-диспетчера
-
-			case d, ok := <-msgs:
-				if !ok {
-					c.baseConsumer.Logger.Debug("Deliveries channel closed by RabbitMQ for consumer. Exiting loop.",
-						"consumer_tag", c.baseConsumer.config.ConsumerTag)
-					return
-				}
-
-				// Запускаем обработчик для каждого сообщения в новой горутине
-				c.baseConsumer.wg.Add(1) // Увеличиваем счетчик WaitGroup
-				go func(delivery amqp.Delivery) {
-					defer c.baseConsumer.wg.Done() // Уменьшаем счетчик, когда горутина завершается
-
-					c.baseConsumer.Logger.Debug("[->] Started processing message",
-						"consumer_tag", c.baseConsumer.config.ConsumerTag,
-						"delivery_tag", delivery.DeliveryTag)
-
-					processErr := c.handler(delivery) // Используем обработчик
-
-					if processErr == nil {
-						// подтверждаем
-						_ = delivery.Ack(false)
-						c.baseConsumer.Logger.Debug("[+] Message Ack'd",
-							"consumer_tag", c.baseConsumer.config.ConsumerTag,
-							"delivery_tag", delivery.DeliveryTag)
-						return
-					}
-
-					c.baseConsumer.Logger.Error(processErr, "Handler error for message",
-						"consumer_tag", c.baseConsumer.config.ConsumerTag,
-						"delivery_tag", delivery.DeliveryTag)
-
-					if !c.baseConsumer.config.EnableRetryMechanism {
-						c.baseConsumer.Logger.Info("Retry disabled. Nacking message without requeue.",
-							"consumer_tag", c.baseConsumer.config.ConsumerTag)
-						_ = delivery.Nack(false, false)
-						return
-					}
-
-					// Считаем, сколько раз сообщение уже умирало
-					deathCount := c.baseConsumer.getDeathCount(delivery, c.baseConsumer.actualQueueName)
-
-					if deathCount < int64(c.baseConsumer.config.MaxRetries) {
-						// Лимит не достигнут, отправляем в цикл ретрая через Nack(requeue=false)
-						c.baseConsumer.Logger.Info("Retrying message",
-							"consumer_tag", c.baseConsumer.config.ConsumerTag,
-							"delivery_tag", delivery.DeliveryTag,
-							"death_count", deathCount)
-						_ = delivery.Nack(false, false)
-					} else {
-						// Лимит ретраев исчерпан, публикуем в финальный DLX
-						c.baseConsumer.Logger.Info("Max retries reached for message. Publishing to final DLX.",
-							"consumer_tag", c.baseConsumer.config.ConsumerTag,
-							"delivery_tag", delivery.DeliveryTag)
-
-						err := c.baseConsumer.finalDlxPublisher.Publish(
-							context.Background(),
-							c.baseConsumer.config.FinalDLQRoutingKey,
-							amqp.Publishing{
-								ContentType:  delivery.ContentType,
-								Body:         delivery.Body,
-								Headers:      delivery.Headers,
-								Timestamp:    time.Now(),
-								DeliveryMode: amqp.Persistent,
-							},
-						)
-
-						if err != nil {
-							c.baseConsumer.Logger.Error(err, "Failed to publish to final DLX. Nacking to trigger retry loop again.",
-								"consumer_tag", c.baseConsumer.config.ConsumerTag,
-								"delivery_tag", delivery.DeliveryTag)
-							_ = delivery.Nack(false, false) // Пытаемся еще раз, раз не смогли отправить в DLQ
-						} else {
-							// Успешно опубликовали, подтверждаем оригинал
-							c.baseConsumer.Logger.Info("Successfully published to final DLX. Acking original message",
-								"consumer_tag", c.baseConsumer.config.ConsumerTag,
-								"delivery_tag", delivery.DeliveryTag)
-							_ = delivery.Ack(false)
-						}
-					}
-				}(d)
-			}
-		}
-	}()
+	for i := 0; i < c.workers; i++ {
+		c.baseConsumer.wg.Add(1)
+		go c.worker(ctx, jobs)
+	}
+
+	c.baseConsumer.wg.Add(1)
+	go c.dispatch(ctx, msgs, jobs)
 
 	// Ждем, пока соединение не будет закрыто (либо отмена внешнего контекста, либо закрытие соединения)
 	notifyClose := make(chan *amqp.Error)
@@ -174,8 +100,8 @@ func (c *DistributingConsumer) StartConsuming(ctx context.Context) error {
 		c.baseConsumer.Logger.Debug("Context cancelled. Shutting down consumer.",
 			"consumer_tag", c.baseConsumer.config.ConsumerTag)
 
-		// Это штатное завершение. Мы получили сигнал, что пора выходить
-		// Внутренняя горутина тоже увидит ctx.Done() и завершится
+		// Это штатное завершение. Диспетчер тоже увидит ctx.Done(),
+		// отменит подписку и вернет в очередь то, что не успели взять в работу
 		// nil, потому что это не ошибка, а graceful shutdown
 		return nil
 
@@ -188,6 +114,137 @@ func (c *DistributingConsumer) StartConsuming(ctx context.Context) error {
 	}
 }
 
+// dispatch читает сообщения из RabbitMQ и раздает их свободным воркерам
+func (c *DistributingConsumer) dispatch(ctx context.Context, msgs <-chan amqp.Delivery, jobs chan<- amqp.Delivery) {
+	defer c.baseConsumer.wg.Done()
+	// Воркеры доработают текущие сообщения и завершатся
+	defer close(jobs)
+
+	for {
+		// Приоритетная, неблокирующая проверка на отмену
+		// Это гарантирует, что мы не возьмем новое сообщение, если уже получили команду на остановку
+		select {
+		case <-ctx.Done():
+			c.drain(msgs)
+			return
+		default:
+			// Контекст не отменен, продолжаем
+		}
+
+		// Пауза по внешнему сигналу (например, источник временно блокирует запросы).
+		// Сообщения остаются в очереди, а не уходят в ретраи
+		if gate := c.baseConsumer.config.Gate; gate != nil {
+			if err := gate.Wait(ctx); err != nil {
+				c.drain(msgs)
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			c.drain(msgs)
+			return
+
+		case d, ok := <-msgs:
+			if !ok {
+				c.baseConsumer.Logger.Debug("Deliveries channel closed by RabbitMQ for consumer. Exiting loop.",
+					"consumer_tag", c.baseConsumer.config.ConsumerTag)
+				return
+			}
+
+			// Блокируемся, пока какой-нибудь воркер не освободится
+			select {
+			case jobs <- d:
+			case <-ctx.Done():
+				// Сообщение так и не попало в работу, возвращаем его в очередь
+				_ = d.Nack(false, true)
+				c.drain(msgs)
+				return
+			}
+		}
+	}
+}
+
+// drain отменяет подписку и возвращает в очередь сообщения,
+// которые RabbitMQ уже успел прислать, но воркеры еще не взяли
+func (c *DistributingConsumer) drain(msgs <-chan amqp.Delivery) {
+	c.baseConsumer.Logger.Debug("Context cancelled for consumer. Stopping deliveries and draining buffer.",
+		"consumer_tag", c.baseConsumer.config.ConsumerTag)
+
+	if err := c.baseConsumer.channel.Cancel(c.baseConsumer.config.ConsumerTag, false); err != nil {
+		c.baseConsumer.Logger.Error(err, "Failed to cancel consumer",
+			"consumer_tag", c.baseConsumer.config.ConsumerTag)
+	}
+
+	timeout := c.baseConsumer.config.DrainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	requeued := 0
+	for {
+		select {
+		case d, ok := <-msgs:
+			if !ok {
+				c.baseConsumer.Logger.Info("Consumer drained",
+					"consumer_tag", c.baseConsumer.config.ConsumerTag,
+					"requeued", requeued)
+				return
+			}
+			_ = d.Nack(false, true)
+			requeued++
+		case <-timer.C:
+			c.baseConsumer.Logger.Warn("Drain timeout reached, remaining messages will be requeued by the broker on channel close",
+				"consumer_tag", c.baseConsumer.config.ConsumerTag,
+				"requeued", requeued)
+			return
+		}
+	}
+}
+
+// worker последовательно обрабатывает сообщения из канала jobs
+func (c *DistributingConsumer) worker(ctx context.Context, jobs <-chan amqp.Delivery) {
+	defer c.baseConsumer.wg.Done()
+
+	// Отмена ctx не должна прерывать уже начатую обработку, поэтому наследуем только значения
+	baseCtx := context.WithoutCancel(ctx)
+
+	for delivery := range jobs {
+		c.processDelivery(baseCtx, delivery)
+	}
+}
+
+// processDelivery вызывает обработчик с таймаутом и подтверждает или отклоняет сообщение
+func (c *DistributingConsumer) processDelivery(ctx context.Context, delivery amqp.Delivery) {
+	c.baseConsumer.Logger.Debug("[->] Started processing message",
+		"consumer_tag", c.baseConsumer.config.ConsumerTag,
+		"delivery_tag", delivery.DeliveryTag)
+
+	handlerCtx, cancel := ctx, context.CancelFunc(func() {})
+	if c.baseConsumer.config.HandlerTimeout > 0 {
+		handlerCtx, cancel = context.WithTimeout(ctx, c.baseConsumer.config.HandlerTimeout)
+	}
+	processErr := c.handler(handlerCtx, delivery) // Используем обработчик
+	cancel()
+
+	if processErr == nil {
+		// подтверждаем
+		_ = delivery.Ack(false)
+		c.baseConsumer.Logger.Debug("[+] Message Ack'd",
+			"consumer_tag", c.baseConsumer.config.ConsumerTag,
+			"delivery_tag", delivery.DeliveryTag)
+		return
+	}
+
+	c.baseConsumer.Logger.Error(processErr, "Handler error for message",
+		"consumer_tag", c.baseConsumer.config.ConsumerTag,
+		"delivery_tag", delivery.DeliveryTag)
+
+	c.baseConsumer.handleFailure(delivery, processErr)
+}
+
 // Close закрывает канал потребителя
 func (c *DistributingConsumer) Close() error {
 	c.baseConsumer.Logger.Debug("Closing consumer")
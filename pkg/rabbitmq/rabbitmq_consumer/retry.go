@@ -0,0 +1,165 @@
+package rabbitmq_consumer
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// HeaderRetryAttempt - номер попытки для многоуровневых ретраев.
+// x-death здесь не подходит: сообщение подтверждается и публикуется в wait-очередь заново
+const HeaderRetryAttempt = "x-retry-attempt"
+
+// PermanentError - ошибка, повтор которой не имеет смысла (битый JSON, невалидная схема и т.п.).
+// Сообщение сразу уходит в финальный DLX, минуя ретраи
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// NewPermanentError помечает ошибку как постоянную
+func NewPermanentError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// RetryableError - временная ошибка с желаемой задержкой перед повтором (например, из Retry-After).
+// Если RetryAfter == 0, задержка берется из уровня ретраев по номеру попытки
+type RetryableError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryableError) Error() string { return e.Err.Error() }
+func (e *RetryableError) Unwrap() error { return e.Err }
+
+// NewRetryableError помечает ошибку как временную с желаемой задержкой
+func NewRetryableError(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryableError{Err: err, RetryAfter: retryAfter}
+}
+
+// IsPermanent сообщает, помечена ли ошибка (или любая из обернутых) как постоянная.
+// Ошибки без классификации считаются временными, как и раньше
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// DefaultRetryJitter - разброс задержки многоуровневых ретраев по умолчанию (±20%)
+const DefaultRetryJitter = 0.2
+
+// RetryTier - уровень многоуровневых ретраев: wait-очередь и задержка в ней
+type RetryTier struct {
+	Queue string
+	Delay time.Duration
+}
+
+// RetryTiers строит уровни ретраев для основной очереди queueName, по одному на задержку.
+// Wait-очередь уровня называется "<очередь>.retry.<задержка>" и по истечении задержки
+// возвращает сообщение напрямую в основную очередь
+func RetryTiers(queueName string, delays []time.Duration) []RetryTier {
+	tiers := make([]RetryTier, 0, len(delays))
+	for _, delay := range delays {
+		tiers = append(tiers, RetryTier{Queue: retryTierQueueName(queueName, delay), Delay: delay})
+	}
+	return tiers
+}
+
+// WithRetryTiers включает многоуровневые ретраи с нарастающей задержкой и DefaultRetryJitter.
+// MaxRetries становится равным числу уровней. Если основная очередь уже объявлена с общим
+// RetryExchange, его нужно оставить в конфиге: он записан в аргументы очереди, и без него
+// повторное объявление не совпадет с существующим. Сообщения при этом идут только в wait-очереди уровней
+func (c ConsumerConfig) WithRetryTiers(delays []time.Duration) ConsumerConfig {
+	c.RetryDelays = delays
+	c.RetryJitter = DefaultRetryJitter
+	c.MaxRetries = len(delays)
+	return c
+}
+
+// retryTierQueueName - имя wait-очереди уровня, например "link_parsing_tasks_kufar.retry.10m"
+func retryTierQueueName(queueName string, delay time.Duration) string {
+	return queueName + ".retry." + formatDelay(delay)
+}
+
+func formatDelay(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d >= time.Minute && d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	case d >= time.Second && d%time.Second == 0:
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	default:
+		return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+	}
+}
+
+// retryAttempt читает номер уже сделанной попытки из заголовков
+func retryAttempt(d amqp.Delivery) int {
+	if d.Headers == nil {
+		return 0
+	}
+	switch v := d.Headers[HeaderRetryAttempt].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// pickRetryTier выбирает задержку для очередной попытки.
+// Для RetryableError с RetryAfter берется первый уровень, который не короче запрошенной задержки
+func pickRetryTier(delays []time.Duration, attempt int, cause error) time.Duration {
+	var retryable *RetryableError
+	if errors.As(cause, &retryable) && retryable.RetryAfter > 0 {
+		for _, d := range delays {
+			if d >= retryable.RetryAfter {
+				return d
+			}
+		}
+		return delays[len(delays)-1]
+	}
+
+	if attempt >= len(delays) {
+		return delays[len(delays)-1]
+	}
+	return delays[attempt]
+}
+
+// withJitter разбрасывает задержку на ±jitter долей, чтобы ретраи не приходили одной волной
+func withJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(d) * factor)
+}
+
+func validateRetryDelays(delays []time.Duration) error {
+	for i, d := range delays {
+		if d <= 0 {
+			return fmt.Errorf("retry delay #%d must be positive, got %s", i, d)
+		}
+		if i > 0 && d < delays[i-1] {
+			return fmt.Errorf("retry delays must be sorted in ascending order, got %s after %s", d, delays[i-1])
+		}
+	}
+	return nil
+}
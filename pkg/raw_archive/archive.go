@@ -0,0 +1,203 @@
+// Package raw_archive хранит сырые ответы сайтов-источников, из которых парсеры собирают события.
+// Блобы сжимаются gzip и адресуются sha256 содержимого, поэтому повторно скачанный без изменений
+// ответ не занимает места. Поверх блобов хранятся ссылки "источник/объявление/время скачивания",
+// по которым текущий маппер можно заново прогнать по архиву
+package raw_archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"real-estate-system/pkg/blobstore"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// refTimeLayout - фиксированной ширины, чтобы лексикографический порядок ключей совпадал с хронологическим
+const refTimeLayout = "20060102T150405.000000000Z"
+
+// Payload - сырой ответ источника по одному объявлению
+type Payload struct {
+	Source      string
+	AdID        int64
+	URL         string // нужен мапперам, которые берут из него часть данных
+	ContentType string
+	FetchedAt   time.Time
+	Body        []byte
+}
+
+// Ref - ссылка на сохраненный ответ
+type Ref struct {
+	Key       string // refs/<source>/<ad_id>/<fetched_at>.json
+	SHA256    string
+	FetchedAt time.Time
+}
+
+// refRecord - содержимое файла-ссылки
+type refRecord struct {
+	SHA256      string    `json:"sha256"`
+	Source      string    `json:"source"`
+	AdID        int64     `json:"ad_id"`
+	URL         string    `json:"url,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int       `json:"size"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// Archive сохраняет и читает сырые ответы поверх blobstore.BlobStore
+type Archive struct {
+	store blobstore.BlobStore
+}
+
+func NewArchive(store blobstore.BlobStore) *Archive {
+	return &Archive{store: store}
+}
+
+// Save сохраняет ответ. Блоб с тем же содержимым повторно не пишется, ссылка создается всегда
+func (a *Archive) Save(ctx context.Context, p Payload) (Ref, error) {
+	if p.Source == "" || strings.Contains(p.Source, "/") {
+		return Ref{}, fmt.Errorf("invalid payload source '%s'", p.Source)
+	}
+	if p.FetchedAt.IsZero() {
+		p.FetchedAt = time.Now()
+	}
+	p.FetchedAt = p.FetchedAt.UTC()
+
+	sum := sha256.Sum256(p.Body)
+	hash := hex.EncodeToString(sum[:])
+	blobKey := blobKey(hash)
+
+	exists, err := a.store.Exists(ctx, blobKey)
+	if err != nil {
+		return Ref{}, err
+	}
+	if !exists {
+		compressed, err := compress(p.Body)
+		if err != nil {
+			return Ref{}, fmt.Errorf("failed to compress payload: %w", err)
+		}
+		if err := a.store.Put(ctx, blobKey, compressed); err != nil {
+			return Ref{}, err
+		}
+	}
+
+	rec := refRecord{
+		SHA256:      hash,
+		Source:      p.Source,
+		AdID:        p.AdID,
+		URL:         p.URL,
+		ContentType: p.ContentType,
+		Size:        len(p.Body),
+		FetchedAt:   p.FetchedAt,
+	}
+	recJSON, err := json.Marshal(rec)
+	if err != nil {
+		return Ref{}, fmt.Errorf("failed to marshal payload ref: %w", err)
+	}
+	refKey := fmt.Sprintf("refs/%s/%d/%s.json", p.Source, p.AdID, p.FetchedAt.Format(refTimeLayout))
+	if err := a.store.Put(ctx, refKey, recJSON); err != nil {
+		return Ref{}, err
+	}
+
+	return Ref{Key: refKey, SHA256: hash, FetchedAt: p.FetchedAt}, nil
+}
+
+// Load читает ответ по ключу ссылки
+func (a *Archive) Load(ctx context.Context, refKey string) (Payload, Ref, error) {
+	recJSON, err := a.store.Get(ctx, refKey)
+	if err != nil {
+		return Payload{}, Ref{}, err
+	}
+	var rec refRecord
+	if err := json.Unmarshal(recJSON, &rec); err != nil {
+		return Payload{}, Ref{}, fmt.Errorf("corrupted payload ref '%s': %w", refKey, err)
+	}
+
+	compressed, err := a.store.Get(ctx, blobKey(rec.SHA256))
+	if err != nil {
+		return Payload{}, Ref{}, fmt.Errorf("blob for ref '%s': %w", refKey, err)
+	}
+	body, err := decompress(compressed)
+	if err != nil {
+		return Payload{}, Ref{}, fmt.Errorf("failed to decompress blob for ref '%s': %w", refKey, err)
+	}
+
+	payload := Payload{
+		Source:      rec.Source,
+		AdID:        rec.AdID,
+		URL:         rec.URL,
+		ContentType: rec.ContentType,
+		FetchedAt:   rec.FetchedAt,
+		Body:        body,
+	}
+	return payload, Ref{Key: refKey, SHA256: rec.SHA256, FetchedAt: rec.FetchedAt}, nil
+}
+
+// WalkLatest вызывает fn для самого свежего ответа по каждому объявлению источника.
+// Старые версии не отдаются: повторная публикация устаревших данных перетерла бы новые
+func (a *Archive) WalkLatest(ctx context.Context, source string, fn func(Payload, Ref) error) error {
+	prefix := "refs/" + source
+	var lastKey, lastDir string
+
+	flush := func() error {
+		if lastKey == "" {
+			return nil
+		}
+		payload, ref, err := a.Load(ctx, lastKey)
+		if err != nil {
+			return err
+		}
+		return fn(payload, ref)
+	}
+
+	err := a.store.Walk(ctx, prefix, func(key string) error {
+		dir := path.Dir(key)
+		if _, err := strconv.ParseInt(path.Base(dir), 10, 64); err != nil || !strings.HasSuffix(key, ".json") {
+			return nil
+		}
+		if dir != lastDir {
+			if err := flush(); err != nil {
+				return err
+			}
+			lastDir = dir
+		}
+		lastKey = key
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func blobKey(hash string) string {
+	return "blobs/" + hash[:2] + "/" + hash + ".gz"
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,54 @@
+// schemacheck проверяет совместимость соседних версий каждой схемы событий.
+// Внутри одной мажорной версии новая схема обязана принимать все сообщения старой.
+// Несовместимость при смене мажорной версии допустима (старая схема остается в реестре,
+// и потребители проверяют v1-сообщения по v1), но выводится как предупреждение.
+//
+// Код возврата 1 означает, что найдены несовместимые изменения внутри мажорной версии.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"real-estate-system/schemas"
+)
+
+func main() {
+	eventTypes, err := schemas.EventTypes()
+	if err != nil {
+		log.Fatalf("schemacheck: failed to load schemas: %v", err)
+	}
+
+	failed := false
+	for _, eventType := range eventTypes {
+		versions, err := schemas.Versions(eventType)
+		if err != nil {
+			log.Fatalf("schemacheck: %v", err)
+		}
+
+		for i := 1; i < len(versions); i++ {
+			prev, next := versions[i-1], versions[i]
+			issues := schemas.CompareSchemas(prev, next)
+			if len(issues) == 0 {
+				fmt.Printf("OK   %s %s -> %s\n", eventType, prev.Version, next.Version)
+				continue
+			}
+
+			level := "FAIL"
+			if schemas.MajorVersion(next.Version) > schemas.MajorVersion(prev.Version) {
+				level = "WARN"
+			} else {
+				failed = true
+			}
+			fmt.Printf("%s %s %s -> %s\n", level, eventType, prev.Version, next.Version)
+			for _, issue := range issues {
+				fmt.Printf("     %s\n", issue)
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
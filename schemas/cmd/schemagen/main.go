@@ -0,0 +1,387 @@
+// schemagen генерирует Go-типы по JSON-схемам событий.
+// Для каждой версии схемы создается файл <тип>_v<major>.gen.go в пакете types.
+//
+// Запуск из каталога schemas: go generate ./...
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	dir := flag.String("dir", "events", "directory with event schemas")
+	out := flag.String("out", "types", "output directory for generated code")
+	pkg := flag.String("pkg", "types", "package name of generated code")
+	flag.Parse()
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatalf("failed to create output dir: %v", err)
+	}
+
+	// Внутри одной мажорной версии генерируем типы по самой свежей минорной:
+	// она совместима со старыми сообщениями (это проверяет schemacheck)
+	latest := make(map[string]parsedSchema)
+	err := filepath.WalkDir(*dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		s, err := parseSchema(path)
+		if err != nil {
+			return err
+		}
+		key := s.root.Title + "/" + s.major()
+		if prev, ok := latest[key]; !ok || versionLess(prev.root.Version, s.root.Version) {
+			latest[key] = s
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("schemagen: %v", err)
+	}
+
+	keys := make([]string, 0, len(latest))
+	for k := range latest {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := generateFile(latest[k], *out, *pkg); err != nil {
+			log.Fatalf("schemagen: %v", err)
+		}
+	}
+}
+
+type parsedSchema struct {
+	path string
+	root *node
+}
+
+func (s parsedSchema) major() string {
+	return strings.SplitN(s.root.Version, ".", 2)[0]
+}
+
+func parseSchema(path string) (parsedSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return parsedSchema{}, err
+	}
+	var root node
+	if err := json.Unmarshal(data, &root); err != nil {
+		return parsedSchema{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if root.Title == "" || root.Version == "" {
+		return parsedSchema{}, fmt.Errorf("%s: title and version are required", path)
+	}
+	return parsedSchema{path: path, root: &root}, nil
+}
+
+// versionLess сравнивает версии вида "1.2.3" покомпонентно
+func versionLess(a, b string) bool {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, _ := strconv.Atoi(pa[i])
+		nb, _ := strconv.Atoi(pb[i])
+		if na != nb {
+			return na < nb
+		}
+	}
+	return len(pa) < len(pb)
+}
+
+// node - подмножество JSON Schema, которое нужно для генерации типов
+type node struct {
+	Title                string           `json:"title"`
+	Version              string           `json:"version"`
+	Description          string           `json:"description"`
+	Type                 json.RawMessage  `json:"type"`
+	Format               string           `json:"format"`
+	Ref                  string           `json:"$ref"`
+	Properties           map[string]*node `json:"properties"`
+	Required             []string         `json:"required"`
+	Items                *node            `json:"items"`
+	AdditionalProperties json.RawMessage  `json:"additionalProperties"`
+	Defs                 map[string]*node `json:"$defs"`
+}
+
+// types возвращает список типов узла и признак допустимости null
+func (n *node) types() ([]string, bool) {
+	if len(n.Type) == 0 {
+		return nil, false
+	}
+	var single string
+	if err := json.Unmarshal(n.Type, &single); err == nil {
+		return []string{single}, false
+	}
+	var list []string
+	_ = json.Unmarshal(n.Type, &list)
+	var out []string
+	nullable := false
+	for _, t := range list {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nullable
+}
+
+type generator struct {
+	root     *node
+	suffix   string // версия в имени типа, например "V1"
+	buf      bytes.Buffer
+	pending  []pendingStruct
+	declared map[string]bool
+	imports  map[string]bool
+}
+
+type pendingStruct struct {
+	name string
+	node *node
+}
+
+func generateFile(s parsedSchema, outDir, pkg string) error {
+	root, path := s.root, s.path
+	major := s.major()
+
+	g := &generator{
+		root:     root,
+		suffix:   "V" + major,
+		declared: make(map[string]bool),
+		imports:  make(map[string]bool),
+	}
+	rootName := root.Title + g.suffix
+
+	var body bytes.Buffer
+	g.pending = append(g.pending, pendingStruct{name: rootName, node: root})
+	// Определения из $defs генерируем всегда: на них может ссылаться if/then, который генератор не разбирает
+	defNames := make([]string, 0, len(root.Defs))
+	for name := range root.Defs {
+		defNames = append(defNames, name)
+	}
+	sort.Strings(defNames)
+	for _, name := range defNames {
+		g.pending = append(g.pending, pendingStruct{name: g.defStructName(name), node: root.Defs[name]})
+	}
+	for len(g.pending) > 0 {
+		next := g.pending[0]
+		g.pending = g.pending[1:]
+		g.writeStruct(next.name, next.node)
+	}
+	body.Write(g.buf.Bytes())
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by schemagen from %s. DO NOT EDIT.\n\n", filepath.ToSlash(path))
+	fmt.Fprintf(&file, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		file.WriteString("import (\n")
+		for _, imp := range sortedKeys(g.imports) {
+			fmt.Fprintf(&file, "\t%q\n", imp)
+		}
+		file.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&file, "// %sEventType и %sVersion - значения заголовков event-type и event-version\n", rootName, rootName)
+	fmt.Fprintf(&file, "const (\n\t%sEventType = %q\n\t%sVersion = %q\n)\n\n", rootName, root.Title, rootName, root.Version)
+	file.Write(body.Bytes())
+
+	src, err := format.Source(file.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: failed to format generated code: %w", path, err)
+	}
+
+	name := toSnake(root.Title) + "_v" + major + ".gen.go"
+	return os.WriteFile(filepath.Join(outDir, name), src, 0o644)
+}
+
+func (g *generator) writeStruct(name string, n *node) {
+	if g.declared[name] {
+		return
+	}
+	g.declared[name] = true
+
+	if n.Description != "" {
+		fmt.Fprintf(&g.buf, "// %s - %s\n", name, n.Description)
+	}
+	fmt.Fprintf(&g.buf, "type %s struct {\n", name)
+
+	required := make(map[string]bool, len(n.Required))
+	for _, r := range n.Required {
+		required[r] = true
+	}
+
+	keys := make([]string, 0, len(n.Properties))
+	for k := range n.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prop := n.Properties[key]
+		fieldName := toPascal(key)
+		goType := g.goType(name+fieldName, prop)
+		tag := key
+		if !required[key] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&g.buf, "\t%s %s `json:%q`\n", fieldName, goType, tag)
+	}
+	g.buf.WriteString("}\n\n")
+}
+
+// goType подбирает Go-тип для узла схемы. Вложенные объекты становятся отдельными структурами
+func (g *generator) goType(nameHint string, n *node) string {
+	if n.Ref != "" {
+		defName := strings.TrimPrefix(strings.TrimPrefix(n.Ref, "#/$defs/"), "#/definitions/")
+		def, ok := g.root.Defs[defName]
+		if !ok {
+			g.imports["encoding/json"] = true
+			return "json.RawMessage"
+		}
+		structName := g.defStructName(defName)
+		g.pending = append(g.pending, pendingStruct{name: structName, node: def})
+		return structName
+	}
+
+	types, nullable := n.types()
+	if len(types) != 1 {
+		// Тип не задан или их несколько: оставляем сырой JSON, разбор на стороне потребителя
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	var goType string
+	switch types[0] {
+	case "string":
+		if n.Format == "date-time" {
+			g.imports["time"] = true
+			goType = "time.Time"
+		} else {
+			goType = "string"
+		}
+	case "integer":
+		goType = "int64"
+	case "number":
+		goType = "float64"
+	case "boolean":
+		goType = "bool"
+	case "array":
+		elem := "interface{}"
+		if n.Items != nil {
+			elem = g.goType(nameHint+"Item", n.Items)
+		}
+		return "[]" + elem
+	case "object":
+		if len(n.Properties) > 0 {
+			g.pending = append(g.pending, pendingStruct{name: nameHint, node: n})
+			goType = nameHint
+			break
+		}
+		valueType := "interface{}"
+		var additional node
+		if len(n.AdditionalProperties) > 0 && json.Unmarshal(n.AdditionalProperties, &additional) == nil {
+			valueType = g.goType(nameHint+"Value", &additional)
+		}
+		return "map[string]" + valueType
+	default:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	if nullable {
+		return "*" + goType
+	}
+	return goType
+}
+
+// defStructName - имя структуры для определения из $defs, например ProcessedRealEstateEventV1ApartmentDetails
+func (g *generator) defStructName(defName string) string {
+	return g.root.Title + g.suffix + toPascal(strings.TrimSuffix(defName, "Schema"))
+}
+
+// commonInitialisms - сокращения, которые по соглашениям Go пишутся заглавными
+var commonInitialisms = map[string]string{
+	"id":   "ID",
+	"url":  "URL",
+	"byn":  "BYN",
+	"usd":  "USD",
+	"eur":  "EUR",
+	"uuid": "UUID",
+}
+
+// toPascal переводит "sourceAdId" и "task_id" в "SourceAdID" и "TaskID"
+func toPascal(s string) string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && !unicode.IsUpper(runes[i-1]):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		if initialism, ok := commonInitialisms[lower]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		r := []rune(w)
+		b.WriteRune(unicode.ToUpper(r[0]))
+		b.WriteString(string(r[1:]))
+	}
+	return b.String()
+}
+
+// toSnake переводит "ProcessedRealEstateEvent" в "processed_real_estate_event"
+func toSnake(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,215 @@
+package schemas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Incompatibility - изменение схемы, из-за которого сообщения старой версии
+// перестанут проходить проверку новой версией
+type Incompatibility struct {
+	Path   string // JSON pointer-подобный путь, например "/properties/general/properties/status"
+	Reason string
+}
+
+func (i Incompatibility) String() string {
+	if i.Path == "" {
+		return i.Reason
+	}
+	return i.Path + ": " + i.Reason
+}
+
+// CheckCompatibility проверяет, что новая версия схемы принимает все сообщения, валидные для старой.
+// Это условие нужно, чтобы потребители могли перейти на v2, пока v1-издатели еще работают:
+// нельзя делать поля обязательными, сужать типы, убирать значения enum и запрещать лишние поля
+func CheckCompatibility(eventType, oldVersion, newVersion string) ([]Incompatibility, error) {
+	oldSchema, err := Lookup(eventType, oldVersion)
+	if err != nil {
+		return nil, err
+	}
+	newSchema, err := Lookup(eventType, newVersion)
+	if err != nil {
+		return nil, err
+	}
+	return CompareSchemas(oldSchema, newSchema), nil
+}
+
+// CompareSchemas сравнивает две схемы и возвращает найденные несовместимости
+func CompareSchemas(oldSchema, newSchema *Schema) []Incompatibility {
+	c := &comparer{oldRoot: oldSchema.raw, newRoot: newSchema.raw}
+	c.compare("", oldSchema.raw, newSchema.raw, 0)
+	return c.issues
+}
+
+// maxCompareDepth защищает от бесконечной рекурсии на рекурсивных $ref
+const maxCompareDepth = 32
+
+type comparer struct {
+	oldRoot, newRoot map[string]interface{}
+	issues           []Incompatibility
+}
+
+func (c *comparer) report(path, format string, args ...interface{}) {
+	c.issues = append(c.issues, Incompatibility{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (c *comparer) compare(path string, oldNode, newNode map[string]interface{}, depth int) {
+	if depth > maxCompareDepth {
+		return
+	}
+	oldNode = resolveRef(c.oldRoot, oldNode)
+	newNode = resolveRef(c.newRoot, newNode)
+	if oldNode == nil || newNode == nil {
+		return
+	}
+
+	// Типы: новая схема должна допускать все типы старой
+	oldTypes, newTypes := schemaTypes(oldNode), schemaTypes(newNode)
+	if len(newTypes) > 0 {
+		if len(oldTypes) == 0 {
+			c.report(path, "type restricted to %s", strings.Join(newTypes, ", "))
+		} else {
+			for _, t := range oldTypes {
+				if !typeAllowed(t, newTypes) {
+					c.report(path, "type '%s' is no longer allowed", t)
+				}
+			}
+		}
+	}
+
+	// enum: значения можно только добавлять
+	if newEnum, ok := newNode["enum"].([]interface{}); ok {
+		oldEnum, hadEnum := oldNode["enum"].([]interface{})
+		if !hadEnum {
+			c.report(path, "enum introduced")
+		} else {
+			for _, v := range oldEnum {
+				if !containsValue(newEnum, v) {
+					c.report(path, "enum value %v removed", v)
+				}
+			}
+		}
+	}
+
+	if newFormat, ok := newNode["format"].(string); ok {
+		if oldFormat, _ := oldNode["format"].(string); oldFormat != newFormat {
+			c.report(path, "format changed from '%s' to '%s'", oldFormat, newFormat)
+		}
+	}
+
+	// Обязательные поля: новые обязательные поля старые издатели не присылают
+	oldRequired := stringSet(oldNode["required"])
+	for _, name := range sortedKeys(stringSet(newNode["required"])) {
+		if !oldRequired[name] {
+			c.report(path+"/required", "field '%s' became required", name)
+		}
+	}
+
+	oldProps, _ := oldNode["properties"].(map[string]interface{})
+	newProps, _ := newNode["properties"].(map[string]interface{})
+	newClosed := newNode["additionalProperties"] == false
+
+	for _, name := range sortedKeys(oldProps) {
+		oldProp, _ := oldProps[name].(map[string]interface{})
+		newProp, exists := newProps[name].(map[string]interface{})
+		propPath := path + "/properties/" + name
+		if !exists {
+			if newClosed {
+				c.report(propPath, "property removed while additionalProperties is false")
+			}
+			continue
+		}
+		c.compare(propPath, oldProp, newProp, depth+1)
+	}
+
+	if newClosed && oldNode["additionalProperties"] != false {
+		c.report(path, "additional properties are no longer allowed")
+	}
+	if oldAdditional, ok := oldNode["additionalProperties"].(map[string]interface{}); ok {
+		if newAdditional, ok := newNode["additionalProperties"].(map[string]interface{}); ok {
+			c.compare(path+"/additionalProperties", oldAdditional, newAdditional, depth+1)
+		}
+	}
+
+	if oldItems, ok := oldNode["items"].(map[string]interface{}); ok {
+		if newItems, ok := newNode["items"].(map[string]interface{}); ok {
+			c.compare(path+"/items", oldItems, newItems, depth+1)
+		}
+	}
+}
+
+// resolveRef раскрывает локальные ссылки вида "#/$defs/name" и "#/definitions/name"
+func resolveRef(root, node map[string]interface{}) map[string]interface{} {
+	for i := 0; i < maxCompareDepth && node != nil; i++ {
+		ref, ok := node["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return node
+		}
+		var cur interface{} = root
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			cur = m[part]
+		}
+		node, _ = cur.(map[string]interface{})
+	}
+	return node
+}
+
+func schemaTypes(node map[string]interface{}) []string {
+	switch t := node["type"].(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// typeAllowed учитывает, что number включает integer
+func typeAllowed(t string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == t || (a == "number" && t == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, x := range values {
+		if fmt.Sprint(x) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringSet(v interface{}) map[string]bool {
+	out := make(map[string]bool)
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out[s] = true
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,3 +1,5 @@
 module real-estate-system/schemas
 
 go 1.24.4
+
+require github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
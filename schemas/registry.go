@@ -0,0 +1,267 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Заголовки, по которым потребитель понимает, какой схемой проверять сообщение
+const (
+	HeaderEventType    = "event-type"
+	HeaderEventVersion = "event-version"
+)
+
+// ErrSchemaNotFound - для пары тип/версия нет зарегистрированной схемы
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// ValidationError - сообщение не соответствует схеме
+type ValidationError struct {
+	EventType string
+	Version   string
+	Err       error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s/%s: JSON schema validation failed: %v", e.EventType, e.Version, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// Schema - одна версия схемы события
+type Schema struct {
+	EventType string
+	Version   string
+	Path      string // путь внутри SchemasFS, например "events/link-task/v1.json"
+
+	compiled *jsonschema.Schema
+	raw      map[string]interface{}
+}
+
+// Validate проверяет тело сообщения по схеме
+func (s *Schema) Validate(body []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		// Если это невалидный JSON, валидация по схеме невозможна
+		return &ValidationError{EventType: s.EventType, Version: s.Version, Err: fmt.Errorf("message body is not a valid JSON: %w", err)}
+	}
+	if err := s.compiled.Validate(v); err != nil {
+		return &ValidationError{EventType: s.EventType, Version: s.Version, Err: err}
+	}
+	return nil
+}
+
+// registry - все схемы из SchemasFS, сгруппированные по типу события.
+// Версии внутри типа отсортированы по возрастанию
+type registry struct {
+	byType map[string][]*Schema
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *registry
+	loadErr  error
+)
+
+func defaultRegistry() (*registry, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = loadRegistry(SchemasFS)
+	})
+	return loaded, loadErr
+}
+
+func loadRegistry(fsys fs.FS) (*registry, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+
+	var paths []string
+	raws := make(map[string]map[string]interface{})
+
+	// Сначала добавляем все схемы как ресурсы, чтобы они могли ссылаться друг на друга через `$ref`
+	err := fs.WalkDir(fsys, "events", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read schema %s: %w", path, err)
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("schema %s is not a valid JSON: %w", path, err)
+		}
+		if err := compiler.AddResource(path, strings.NewReader(string(data))); err != nil {
+			return fmt.Errorf("failed to add schema resource %s: %w", path, err)
+		}
+		paths = append(paths, path)
+		raws[path] = raw
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &registry{byType: make(map[string][]*Schema)}
+	for _, path := range paths {
+		compiled, err := compiler.Compile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile schema %s: %w", path, err)
+		}
+
+		raw := raws[path]
+		eventType, _ := raw["title"].(string)
+		version, _ := raw["version"].(string)
+		if eventType == "" || version == "" {
+			return nil, fmt.Errorf("schema %s must declare both title and version", path)
+		}
+		if _, err := parseVersion(version); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", path, err)
+		}
+
+		r.byType[eventType] = append(r.byType[eventType], &Schema{
+			EventType: eventType,
+			Version:   version,
+			Path:      path,
+			compiled:  compiled,
+			raw:       raw,
+		})
+	}
+
+	for eventType, versions := range r.byType {
+		sort.Slice(versions, func(i, j int) bool {
+			return compareVersions(versions[i].Version, versions[j].Version) < 0
+		})
+		for i := 1; i < len(versions); i++ {
+			if versions[i].Version == versions[i-1].Version {
+				return nil, fmt.Errorf("duplicate schema %s/%s: %s and %s", eventType, versions[i].Version, versions[i-1].Path, versions[i].Path)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Lookup возвращает схему конкретной версии события
+func Lookup(eventType, version string) (*Schema, error) {
+	r, err := defaultRegistry()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range r.byType[eventType] {
+		if s.Version == version {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: event '%s' version '%s'", ErrSchemaNotFound, eventType, version)
+}
+
+// Latest возвращает самую новую версию схемы события
+func Latest(eventType string) (*Schema, error) {
+	r, err := defaultRegistry()
+	if err != nil {
+		return nil, err
+	}
+	versions := r.byType[eventType]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: event '%s'", ErrSchemaNotFound, eventType)
+	}
+	return versions[len(versions)-1], nil
+}
+
+// Versions возвращает все версии схемы события по возрастанию
+func Versions(eventType string) ([]*Schema, error) {
+	r, err := defaultRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return append([]*Schema(nil), r.byType[eventType]...), nil
+}
+
+// EventTypes возвращает все зарегистрированные типы событий
+func EventTypes() ([]string, error) {
+	r, err := defaultRegistry()
+	if err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(r.byType))
+	for t := range r.byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// Validate проверяет тело сообщения по схеме. Используется потребителями:
+// тип и версия берутся из заголовков event-type и event-version
+func Validate(eventType, version string, body []byte) error {
+	s, err := Lookup(eventType, version)
+	if err != nil {
+		return err
+	}
+	return s.Validate(body)
+}
+
+// Marshal сериализует значение в JSON и проверяет результат по схеме.
+// Используется издателями, чтобы нарушение контракта обнаруживалось до публикации, а не после цикла ретраев
+func Marshal(eventType, version string, v interface{}) ([]byte, error) {
+	s, err := Lookup(eventType, version)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s/%s: %w", eventType, version, err)
+	}
+	if err := s.Validate(body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// parseVersion разбирает версию вида "1.2.3"
+func parseVersion(version string) ([3]int, error) {
+	var out [3]int
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return out, fmt.Errorf("invalid schema version '%s', expected MAJOR.MINOR.PATCH", version)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, fmt.Errorf("invalid schema version '%s', expected MAJOR.MINOR.PATCH", version)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// compareVersions сравнивает две корректные версии
+func compareVersions(a, b string) int {
+	va, _ := parseVersion(a)
+	vb, _ := parseVersion(b)
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// MajorVersion возвращает мажорную часть версии
+func MajorVersion(version string) int {
+	v, _ := parseVersion(version)
+	return v[0]
+}
@@ -1,6 +1,11 @@
+// Package schemas - реестр версионированных JSON-схем событий, которыми обмениваются сервисы.
+// Издатели проверяют сообщения перед публикацией (Marshal), потребители - при получении (Validate).
 package schemas
 
 import "embed"
 
+//go:generate go run ./cmd/schemagen -dir events -out types
+//go:generate go run ./cmd/schemacheck
+
 //go:embed all:events
-var SchemasFS embed.FS
\ No newline at end of file
+var SchemasFS embed.FS
@@ -0,0 +1,17 @@
+// Code generated by schemagen from events/link-task/v1.json. DO NOT EDIT.
+
+package types
+
+// LinkTaskEventV1EventType и LinkTaskEventV1Version - значения заголовков event-type и event-version
+const (
+	LinkTaskEventV1EventType = "LinkTaskEvent"
+	LinkTaskEventV1Version   = "1.0.0"
+)
+
+// LinkTaskEventV1 - Задача на парсинг одного объявления по ссылке
+type LinkTaskEventV1 struct {
+	AdID   int64  `json:"ad_id"`
+	AdURL  string `json:"ad_url"`
+	Source string `json:"source"`
+	TaskID string `json:"task_id"`
+}
@@ -0,0 +1,28 @@
+// Code generated by schemagen from events/source-heartbeat/v1.json. DO NOT EDIT.
+
+package types
+
+import (
+	"time"
+)
+
+// SourceHeartbeatEventV1EventType и SourceHeartbeatEventV1Version - значения заголовков event-type и event-version
+const (
+	SourceHeartbeatEventV1EventType = "SourceHeartbeatEvent"
+	SourceHeartbeatEventV1Version   = "1.0.0"
+)
+
+// SourceHeartbeatEventV1 - Периодическое объявление парсера о себе: что он умеет обрабатывать и куда ему слать задачи
+type SourceHeartbeatEventV1 struct {
+	Categories       []string  `json:"categories"`
+	DealTypes        []string  `json:"deal_types,omitempty"`
+	DisplayName      string    `json:"display_name,omitempty"`
+	InstanceID       string    `json:"instance_id"`
+	IntervalSeconds  int64     `json:"interval_seconds"`
+	LinkRoutingKey   string    `json:"link_routing_key"`
+	Name             string    `json:"name"`
+	Regions          []string  `json:"regions,omitempty"`
+	SearchRoutingKey string    `json:"search_routing_key"`
+	SentAt           time.Time `json:"sent_at"`
+	Status           string    `json:"status"`
+}
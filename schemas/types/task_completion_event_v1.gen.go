@@ -0,0 +1,19 @@
+// Code generated by schemagen from events/task-completion/v1.json. DO NOT EDIT.
+
+package types
+
+// TaskCompletionEventV1EventType и TaskCompletionEventV1Version - значения заголовков event-type и event-version
+const (
+	TaskCompletionEventV1EventType = "TaskCompletionEvent"
+	TaskCompletionEventV1Version   = "1.0.0"
+)
+
+// TaskCompletionEventV1 - Команда о завершении отправки подзадач: сколько результатов нужно ожидать
+type TaskCompletionEventV1 struct {
+	Results TaskCompletionEventV1Results `json:"results"`
+	TaskID  string                       `json:"task_id"`
+}
+
+type TaskCompletionEventV1Results struct {
+	ExpectedResultsCount int64 `json:"expected_results_count"`
+}
@@ -0,0 +1,87 @@
+package rabbitmq
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"actualization-service/internal/core/port/usecases_port"
+	"context"
+	"encoding/json"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
+	"real-estate-system/schemas/types"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// SourceHeartbeatConsumerAdapter - слушатель heartbeat парсеров для реестра источников
+type SourceHeartbeatConsumerAdapter struct {
+	consumer rabbitmq_consumer.Consumer
+	useCase  usecases_port.ProcessSourceHeartbeatUseCase
+	logger   port.LoggerPort
+}
+
+func NewSourceHeartbeatConsumerAdapter(
+	cfg rabbitmq_consumer.ConsumerConfig,
+	uc usecases_port.ProcessSourceHeartbeatUseCase,
+	logger port.LoggerPort,
+	connManager *rabbitmq_common.ConnectionManager,
+) (*SourceHeartbeatConsumerAdapter, error) {
+	adapter := &SourceHeartbeatConsumerAdapter{
+		useCase: uc,
+		logger:  logger.WithFields(port.Fields{"component": "SourceHeartbeatConsumerAdapter"}),
+	}
+
+	pkgLogger := logger.WithFields(port.Fields{"component": "rabbitmq_distributing_consumer", "consumer_tag": cfg.ConsumerTag})
+	cfg.Logger = NewPkgLoggerBridge(pkgLogger)
+
+	consumer, err := rabbitmq_consumer.NewDistributingConsumer(cfg, adapter.messageHandler, connManager)
+	if err != nil {
+		return nil, err
+	}
+	adapter.consumer = consumer
+	return adapter, nil
+}
+
+// messageHandler - ошибки не ретраятся: следующий heartbeat придет через интервал
+func (a *SourceHeartbeatConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) error {
+	msgLogger := a.logger.WithFields(port.Fields{"delivery_tag": d.DeliveryTag})
+
+	if err := validateEvent(d); err != nil {
+		msgLogger.Error("Heartbeat failed schema validation. Dropping.", err, nil)
+		return nil
+	}
+
+	var dto types.SourceHeartbeatEventV1
+	if err := json.Unmarshal(d.Body, &dto); err != nil {
+		msgLogger.Error("Failed to unmarshal heartbeat. Dropping.", err, nil)
+		return nil
+	}
+
+	hb := domain.SourceHeartbeat{
+		Name:             dto.Name,
+		DisplayName:      dto.DisplayName,
+		InstanceID:       dto.InstanceID,
+		SearchRoutingKey: dto.SearchRoutingKey,
+		LinkRoutingKey:   dto.LinkRoutingKey,
+		Categories:       dto.Categories,
+		Regions:          dto.Regions,
+		DealTypes:        dto.DealTypes,
+		Status:           dto.Status,
+		SentAt:           dto.SentAt,
+		Interval:         time.Duration(dto.IntervalSeconds) * time.Second,
+	}
+
+	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
+	if err := a.useCase.Execute(ctx, hb); err != nil {
+		msgLogger.Error("Failed to process heartbeat", err, port.Fields{"source": hb.Name})
+	}
+	return nil
+}
+
+func (a *SourceHeartbeatConsumerAdapter) Start(ctx context.Context) error {
+	return a.consumer.StartConsuming(ctx)
+}
+
+func (a *SourceHeartbeatConsumerAdapter) Close() error { return a.consumer.Close() }
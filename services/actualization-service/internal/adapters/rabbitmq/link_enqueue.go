@@ -1,14 +1,14 @@
 package rabbitmq
 
 import (
-	"actualization-service/internal/constants"
 	"actualization-service/internal/contextkeys"
 	"actualization-service/internal/core/domain"
 	"actualization-service/internal/core/port"
 	"context"
-	"encoding/json"
 	"fmt"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -42,15 +42,13 @@ func (a *RabbitMQLinkQueueAdapter) PublishTask(ctx context.Context, task domain.
 		"task_id":     task.Task.TaskID.String(),
 	})
 
-	var routingKey string
-	if task.Source == domain.KUFAR_SOURCE {
-		routingKey = constants.RoutingKeyLinkTasksKufar
-	}
-	if task.Source == domain.REALT_SOURCE {
-		routingKey = constants.RoutingKeyLinkTasksRealt
+	// Ключ маршрутизации подставляет use case из реестра источников
+	routingKey := task.RoutingKey
+	if routingKey == "" {
+		return fmt.Errorf("rabbitmq adapter: no routing key for source '%s'", task.Source)
 	}
 
-	taskJSON, err := json.Marshal(task.Task)
+	taskJSON, err := schemas.Marshal(types.LinkTaskEventV1EventType, types.LinkTaskEventV1Version, task.Task)
 	if err != nil {
 		adapterLogger.Error("Failed to marshal task to JSON", err, nil)
 		return fmt.Errorf("rabbitmq adapter: failed to marshal task to JSON for %s: %w", task.Task.Link, err)
@@ -62,7 +60,10 @@ func (a *RabbitMQLinkQueueAdapter) PublishTask(ctx context.Context, task domain.
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
 		Priority:     task.Priority,
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.LinkTaskEventV1EventType,
+			schemas.HeaderEventVersion: types.LinkTaskEventV1Version,
+		},
 	}
 
 	traceID := contextkeys.TraceIDFromContext(ctx)
@@ -5,9 +5,10 @@ import (
 	"actualization-service/internal/core/domain"
 	"actualization-service/internal/core/port"
 	"context"
-	"encoding/json"
 	"fmt"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -40,7 +41,7 @@ func (a *RabbitMQLinksSearchQueueAdapter) PublishTask(ctx context.Context, task
 		"region":      task.Task.Region,
 	})
 
-	taskJSON, err := json.Marshal(task.Task)
+	taskJSON, err := schemas.Marshal(types.SearchTaskEventV1EventType, types.SearchTaskEventV1Version, task.Task)
 	if err != nil {
 		adapterLogger.Error("Failed to marshal task to JSON", err, nil)
 		return fmt.Errorf("rabbitmq adapter: failed to marshal task to JSON for %s - %s: %w", task.Task.Category, task.Task.Region, err)
@@ -52,7 +53,10 @@ func (a *RabbitMQLinksSearchQueueAdapter) PublishTask(ctx context.Context, task
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
 		Priority: 	  task.Priority,
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.SearchTaskEventV1EventType,
+			schemas.HeaderEventVersion: types.SearchTaskEventV1Version,
+		},
 	}
 
 	// Извлекаем trace_id из контекста и кладем в заголовки
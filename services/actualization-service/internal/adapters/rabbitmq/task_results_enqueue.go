@@ -5,9 +5,10 @@ import (
 	"actualization-service/internal/core/domain"
 	"actualization-service/internal/core/port"
 	"context"
-	"encoding/json"
 	"fmt"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -43,7 +44,7 @@ func (a *TaskManagerPublisher) PublishCompletionCommand(ctx context.Context, cmd
 		"task_id":     cmd.TaskID.String(),
 	})
 
-	body, err := json.Marshal(cmd)
+	body, err := schemas.Marshal(types.TaskCompletionEventV1EventType, types.TaskCompletionEventV1Version, cmd)
 	if err != nil {
 		adapterLogger.Error("Failed to marshal completion command", err, nil)
 		return fmt.Errorf("failed to marshal completion command: %w", err)
@@ -54,7 +55,10 @@ func (a *TaskManagerPublisher) PublishCompletionCommand(ctx context.Context, cmd
 		Body:         body,
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.TaskCompletionEventV1EventType,
+			schemas.HeaderEventVersion: types.TaskCompletionEventV1Version,
+		},
 	}
 
 	// Извлекаем trace_id из контекста и кладем в заголовки
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"actualization-service/internal/core/port/usecases_port"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// DispatchHandlers - внутренние обработчики для task-service
+type DispatchHandlers struct {
+	dispatchTaskUC usecases_port.DispatchTaskUseCase
+}
+
+// NewDispatchHandlers - конструктор
+func NewDispatchHandlers(dispatchTaskUC usecases_port.DispatchTaskUseCase) *DispatchHandlers {
+	return &DispatchHandlers{dispatchTaskUC: dispatchTaskUC}
+}
+
+// HandleDispatchTask - POST /api/v1/internal/tasks/{taskID}/dispatch
+func (h *DispatchHandlers) HandleDispatchTask(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "HandleDispatchTask"})
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "taskID"))
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid task ID format")
+		return
+	}
+
+	var reqDTO DispatchTaskRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	userID, err := uuid.Parse(reqDTO.CreatedByUserID)
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Field 'created_by_user_id' must be a valid UUID")
+		return
+	}
+
+	cmd := domain.DispatchCommand{
+		TaskID:   taskID,
+		TaskType: reqDTO.Type,
+		UserID:   userID,
+		Params:   reqDTO.Params,
+		Items:    reqDTO.Items,
+	}
+	loggerForTask := logger.WithFields(port.Fields{
+		"task_id":    taskID.String(),
+		"task_type":  cmd.TaskType,
+		"item_count": len(cmd.Items),
+	})
+
+	if err := h.dispatchTaskUC.Execute(r.Context(), cmd); err != nil {
+		if errors.Is(err, domain.ErrInvalidDispatch) {
+			WriteJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		loggerForTask.Error("DispatchTask use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to dispatch task")
+		return
+	}
+
+	loggerForTask.Info("Task dispatched", nil)
+	RespondWithJSON(w, http.StatusAccepted, map[string]string{"task_id": taskID.String()})
+}
@@ -1,5 +1,10 @@
 package rest
 
+import (
+	"actualization-service/internal/core/domain"
+	"time"
+)
+
 
 type ActualizeRequestDTO struct {
     Category           *string `json:"category"` // Указатель, чтобы отличить "не передано" от ""
@@ -14,5 +19,79 @@ type ActualizeObjectDTO struct {
 type FindNewRequestDTO struct {
     Categories []string `json:"categories"`
     Regions    []string `json:"regions"`
+
+	// Необязательные ограничения запуска
+	MaxLinks    int        `json:"max_links"`
+	MaxPages    int        `json:"max_pages"`
+	Since       *time.Time `json:"since"`
+	Until       *time.Time `json:"until"`
+	FullRecrawl bool       `json:"full_recrawl"`
+}
+
+
+// SourceInstanceResponse - экземпляр парсера в ответе API реестра источников
+type SourceInstanceResponse struct {
+	ID              string    `json:"id"`
+	Status          string    `json:"status"`
+	Alive           bool      `json:"alive"`
+	LastHeartbeatAt time.Time `json:"last_heartbeat_at"`
+}
+
+// SourceResponse - источник в ответе API реестра источников
+type SourceResponse struct {
+	Name               string                   `json:"name"`
+	DisplayName        string                   `json:"display_name"`
+	Enabled            bool                     `json:"enabled"`
+	Alive              bool                     `json:"alive"`
+	Available          bool                     `json:"available"`
+	SearchRoutingKey   string                   `json:"search_routing_key"`
+	LinkRoutingKey     string                   `json:"link_routing_key"`
+	Categories         []string                 `json:"categories"`
+	DisabledCategories []string                 `json:"disabled_categories"`
+	Regions            []string                 `json:"regions"`
+	DealTypes          []string                 `json:"deal_types"`
+	LastHeartbeatAt    time.Time                `json:"last_heartbeat_at"`
+	Instances          []SourceInstanceResponse `json:"instances"`
+}
+
+func toSourceResponse(src domain.Source, now time.Time) SourceResponse {
+	instances := make([]SourceInstanceResponse, len(src.Instances))
+	for i, inst := range src.Instances {
+		instances[i] = SourceInstanceResponse{
+			ID:              inst.ID,
+			Status:          inst.Status,
+			Alive:           inst.IsAlive(now),
+			LastHeartbeatAt: inst.LastHeartbeatAt,
+		}
+	}
+	return SourceResponse{
+		Name:               src.Name,
+		DisplayName:        src.DisplayName,
+		Enabled:            src.Enabled,
+		Alive:              src.IsAlive(now),
+		Available:          src.IsAvailable(now),
+		SearchRoutingKey:   src.SearchRoutingKey,
+		LinkRoutingKey:     src.LinkRoutingKey,
+		Categories:         nonNil(src.Categories),
+		DisabledCategories: nonNil(src.DisabledCategories),
+		Regions:            nonNil(src.Regions),
+		DealTypes:          nonNil(src.DealTypes),
+		LastHeartbeatAt:    src.LastHeartbeatAt(),
+		Instances:          instances,
+	}
 }
 
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// DispatchTaskRequestDTO - запуск уже созданной задачи от task-service
+type DispatchTaskRequestDTO struct {
+	Type            string            `json:"type"`
+	Params          domain.TaskParams `json:"params"`
+	CreatedByUserID string            `json:"created_by_user_id"`
+	Items           []domain.TaskItem `json:"items"`
+}
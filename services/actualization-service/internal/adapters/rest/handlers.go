@@ -2,6 +2,7 @@ package rest
 
 import (
 	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
 	"actualization-service/internal/core/port"
 	"actualization-service/internal/core/port/usecases_port"
 	"encoding/json"
@@ -171,14 +172,31 @@ func (h *ActualizationHandlers) HandleFindNewObjects(w http.ResponseWriter, r *h
 		return
 	}
 
+	if reqDTO.MaxLinks < 0 || reqDTO.MaxPages < 0 {
+		WriteJSONError(w, http.StatusBadRequest, "Fields 'max_links' and 'max_pages' must not be negative")
+		return
+	}
+	if reqDTO.Since != nil && reqDTO.Until != nil && !reqDTO.Since.Before(*reqDTO.Until) {
+		WriteJSONError(w, http.StatusBadRequest, "Field 'since' must be before 'until'")
+		return
+	}
+	limits := domain.CrawlLimits{
+		MaxLinks:    reqDTO.MaxLinks,
+		MaxPages:    reqDTO.MaxPages,
+		Since:       reqDTO.Since,
+		Until:       reqDTO.Until,
+		FullRecrawl: reqDTO.FullRecrawl,
+	}
+
 	loggerForActualize := logger.WithFields(port.Fields{
 		"categories": strings.Join(reqDTO.Categories, ", "),
 		"regions":    strings.Join(reqDTO.Regions, ", "),
+		"limits":     limits,
 	})
 	loggerForActualize.Info("Received request to parse new objects for categories in regions", nil)
 
 	// Вызываем Use Case
-	taskID, err := h.findNewObjectsUC.Execute(r.Context(), userID, reqDTO.Categories, reqDTO.Regions)
+	taskID, err := h.findNewObjectsUC.Execute(r.Context(), userID, reqDTO.Categories, reqDTO.Regions, limits)
 	if err != nil {
 		loggerForActualize.Error("Use case execution failed", err, nil)
 		WriteJSONError(w, http.StatusInternalServerError, "Failed to start parsing new objects process")
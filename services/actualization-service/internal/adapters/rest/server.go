@@ -15,7 +15,7 @@ type Server struct {
 	logger     core_ports.LoggerPort
 }
 
-func NewServer(port string, handlers *ActualizationHandlers, baseLogger core_ports.LoggerPort) *Server {
+func NewServer(port string, handlers *ActualizationHandlers, sourceHandlers *SourceHandlers, dispatchHandlers *DispatchHandlers, baseLogger core_ports.LoggerPort) *Server {
 	r := chi.NewRouter()
 
 	r.Use(LoggerMiddleware(baseLogger)) // Логирует каждый запрос (метод, путь, время выполнения)
@@ -32,6 +32,23 @@ func NewServer(port string, handlers *ActualizationHandlers, baseLogger core_por
 			r.Post("/new-objects", handlers.HandleFindNewObjects)
 		})
 
+		// Реестр источников (доступ только администраторам проверяет API Gateway)
+		r.Route("/sources", func(r chi.Router) {
+
+			r.Use(AuthMiddleware)
+
+			r.Get("/", sourceHandlers.HandleGetSources)
+			r.Post("/{name}/enable", sourceHandlers.HandleEnableSource)
+			r.Post("/{name}/disable", sourceHandlers.HandleDisableSource)
+			r.Post("/{name}/categories/{category}/enable", sourceHandlers.HandleEnableCategory)
+			r.Post("/{name}/categories/{category}/disable", sourceHandlers.HandleDisableCategory)
+		})
+
+		// Внутренние маршруты для task-service, через API Gateway не публикуются
+		r.Route("/internal", func(r chi.Router) {
+			r.Post("/tasks/{taskID}/dispatch", dispatchHandlers.HandleDispatchTask)
+		})
+
 	})
 
 	return &Server{
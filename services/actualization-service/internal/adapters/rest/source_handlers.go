@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"actualization-service/internal/core/port/usecases_port"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SourceHandlers - админские обработчики реестра источников
+type SourceHandlers struct {
+	getSourcesUC  usecases_port.GetSourcesUseCase
+	updateStateUC usecases_port.UpdateSourceStateUseCase
+}
+
+// NewSourceHandlers - конструктор
+func NewSourceHandlers(getSourcesUC usecases_port.GetSourcesUseCase, updateStateUC usecases_port.UpdateSourceStateUseCase) *SourceHandlers {
+	return &SourceHandlers{
+		getSourcesUC:  getSourcesUC,
+		updateStateUC: updateStateUC,
+	}
+}
+
+// HandleGetSources - GET /api/v1/sources
+func (h *SourceHandlers) HandleGetSources(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "HandleGetSources"})
+
+	sources, err := h.getSourcesUC.Execute(r.Context())
+	if err != nil {
+		logger.Error("GetSources use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to get sources")
+		return
+	}
+
+	now := time.Now()
+	response := make([]SourceResponse, len(sources))
+	for i, src := range sources {
+		response[i] = toSourceResponse(src, now)
+	}
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleEnableSource - POST /api/v1/sources/{name}/enable
+func (h *SourceHandlers) HandleEnableSource(w http.ResponseWriter, r *http.Request) {
+	h.updateState(w, r, "HandleEnableSource", true)
+}
+
+// HandleDisableSource - POST /api/v1/sources/{name}/disable
+func (h *SourceHandlers) HandleDisableSource(w http.ResponseWriter, r *http.Request) {
+	h.updateState(w, r, "HandleDisableSource", false)
+}
+
+// HandleEnableCategory - POST /api/v1/sources/{name}/categories/{category}/enable
+func (h *SourceHandlers) HandleEnableCategory(w http.ResponseWriter, r *http.Request) {
+	h.updateState(w, r, "HandleEnableCategory", true)
+}
+
+// HandleDisableCategory - POST /api/v1/sources/{name}/categories/{category}/disable
+func (h *SourceHandlers) HandleDisableCategory(w http.ResponseWriter, r *http.Request) {
+	h.updateState(w, r, "HandleDisableCategory", false)
+}
+
+// updateState - общая часть обработчиков включения/отключения. Категория берется из пути, если она там есть
+func (h *SourceHandlers) updateState(w http.ResponseWriter, r *http.Request, handler string, enabled bool) {
+	cmd := domain.SourceStateUpdate{
+		Name:     chi.URLParam(r, "name"),
+		Category: chi.URLParam(r, "category"),
+		Enabled:  enabled,
+	}
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{
+		"handler":  handler,
+		"source":   cmd.Name,
+		"category": cmd.Category,
+	})
+
+	src, err := h.updateStateUC.Execute(r.Context(), cmd)
+	if err != nil {
+		if errors.Is(err, domain.ErrSourceNotFound) {
+			WriteJSONError(w, http.StatusNotFound, "Source not found")
+			return
+		}
+		logger.Error("UpdateSourceState use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to update source state")
+		return
+	}
+
+	logger.Info("Source state changed by admin", port.Fields{"enabled": enabled})
+	RespondWithJSON(w, http.StatusOK, toSourceResponse(*src, time.Now()))
+}
@@ -0,0 +1,217 @@
+package source_registry
+
+import (
+	"actualization-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// override - настройки источника, заданные администратором. Переживают перезапуск сервиса
+type override struct {
+	Disabled           bool     `json:"disabled"`
+	DisabledCategories []string `json:"disabled_categories,omitempty"`
+}
+
+// InMemorySourceRegistry хранит источники в памяти: их возможности и так приходят с каждым heartbeat.
+// На диск сохраняются только переопределения администратора
+type InMemorySourceRegistry struct {
+	mu            sync.RWMutex
+	sources       map[string]*domain.Source
+	overrides     map[string]override
+	overridesPath string
+}
+
+// NewInMemorySourceRegistry создает реестр. overridesPath - JSON-файл с переопределениями,
+// если путь пустой, переопределения живут только до перезапуска
+func NewInMemorySourceRegistry(overridesPath string) (*InMemorySourceRegistry, error) {
+	r := &InMemorySourceRegistry{
+		sources:       make(map[string]*domain.Source),
+		overrides:     make(map[string]override),
+		overridesPath: overridesPath,
+	}
+	if overridesPath == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(overridesPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("source registry: failed to read overrides %s: %w", overridesPath, err)
+	}
+	if err := json.Unmarshal(data, &r.overrides); err != nil {
+		return nil, fmt.Errorf("source registry: failed to parse overrides %s: %w", overridesPath, err)
+	}
+	return r, nil
+}
+
+func (r *InMemorySourceRegistry) RegisterHeartbeat(ctx context.Context, hb domain.SourceHeartbeat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	src, ok := r.sources[hb.Name]
+	if !ok {
+		src = &domain.Source{Name: hb.Name}
+		r.sources[hb.Name] = src
+	}
+
+	// Возможности источника берем из последнего heartbeat: так новая версия парсера
+	// с дополнительными категориями начинает получать задачи без изменений здесь
+	src.DisplayName = hb.DisplayName
+	src.SearchRoutingKey = hb.SearchRoutingKey
+	src.LinkRoutingKey = hb.LinkRoutingKey
+	src.Categories = append([]string(nil), hb.Categories...)
+	src.Regions = append([]string(nil), hb.Regions...)
+	src.DealTypes = append([]string(nil), hb.DealTypes...)
+
+	// Время берем свое, а не sent_at: часы парсера могут расходиться с нашими
+	now := time.Now()
+	instance := domain.SourceInstance{
+		ID:              hb.InstanceID,
+		Status:          hb.Status,
+		LastHeartbeatAt: now,
+		Interval:        hb.Interval,
+	}
+	replaced := false
+	for i := range src.Instances {
+		if src.Instances[i].ID == hb.InstanceID {
+			src.Instances[i] = instance
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		src.Instances = append(src.Instances, instance)
+	}
+
+	// Забываем остановленные и замолчавшие экземпляры (например, после пересоздания контейнера).
+	// Текущий экземпляр остается всегда, даже если прислал stopping: по нему видно, когда источник ушел
+	alive := src.Instances[:0]
+	for _, inst := range src.Instances {
+		if inst.ID == hb.InstanceID || inst.IsAlive(now) {
+			alive = append(alive, inst)
+		}
+	}
+	src.Instances = alive
+
+	return nil
+}
+
+func (r *InMemorySourceRegistry) ListSources(ctx context.Context) ([]domain.Source, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sources := make([]domain.Source, 0, len(r.sources))
+	for _, src := range r.sources {
+		sources = append(sources, r.withOverride(src))
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources, nil
+}
+
+func (r *InMemorySourceRegistry) GetSource(ctx context.Context, name string) (*domain.Source, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	src, ok := r.sources[name]
+	if !ok {
+		return nil, domain.ErrSourceNotFound
+	}
+	result := r.withOverride(src)
+	return &result, nil
+}
+
+func (r *InMemorySourceRegistry) SetSourceEnabled(ctx context.Context, name string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sources[name]; !ok {
+		return domain.ErrSourceNotFound
+	}
+	o := r.overrides[name]
+	o.Disabled = !enabled
+	return r.saveOverride(name, o)
+}
+
+func (r *InMemorySourceRegistry) SetCategoryEnabled(ctx context.Context, name, category string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sources[name]; !ok {
+		return domain.ErrSourceNotFound
+	}
+	o := r.overrides[name]
+	categories := o.DisabledCategories[:0:0]
+	for _, c := range o.DisabledCategories {
+		if c != category {
+			categories = append(categories, c)
+		}
+	}
+	if !enabled {
+		categories = append(categories, category)
+		sort.Strings(categories)
+	}
+	o.DisabledCategories = categories
+	return r.saveOverride(name, o)
+}
+
+// withOverride возвращает копию источника с примененными настройками администратора
+func (r *InMemorySourceRegistry) withOverride(src *domain.Source) domain.Source {
+	result := *src
+	result.Instances = append([]domain.SourceInstance(nil), src.Instances...)
+	o := r.overrides[src.Name]
+	result.Enabled = !o.Disabled
+	result.DisabledCategories = append([]string(nil), o.DisabledCategories...)
+	return result
+}
+
+// saveOverride обновляет переопределение и сохраняет все переопределения на диск. Вызывается под блокировкой
+func (r *InMemorySourceRegistry) saveOverride(name string, o override) error {
+	previous, existed := r.overrides[name]
+	if !o.Disabled && len(o.DisabledCategories) == 0 {
+		delete(r.overrides, name)
+	} else {
+		r.overrides[name] = o
+	}
+
+	if r.overridesPath == "" {
+		return nil
+	}
+	if err := r.persist(); err != nil {
+		// Откатываем изменение, чтобы состояние в памяти совпадало с файлом
+		if existed {
+			r.overrides[name] = previous
+		} else {
+			delete(r.overrides, name)
+		}
+		return err
+	}
+	return nil
+}
+
+// persist пишет файл через временный, чтобы при сбое не остался наполовину записанный JSON
+func (r *InMemorySourceRegistry) persist() error {
+	data, err := json.MarshalIndent(r.overrides, "", "  ")
+	if err != nil {
+		return fmt.Errorf("source registry: failed to marshal overrides: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.overridesPath), 0o755); err != nil {
+		return fmt.Errorf("source registry: failed to create overrides dir: %w", err)
+	}
+	tmp := r.overridesPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("source registry: failed to write overrides: %w", err)
+	}
+	if err := os.Rename(tmp, r.overridesPath); err != nil {
+		return fmt.Errorf("source registry: failed to replace overrides file: %w", err)
+	}
+	return nil
+}
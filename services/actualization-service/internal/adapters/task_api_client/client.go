@@ -2,6 +2,7 @@ package task_api_client
 
 import (
 	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
 	"actualization-service/internal/core/port"
 	"bytes"
 	"context"
@@ -43,7 +44,7 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 }
 
 // CreateTask создает новую задачу и возвращает ее ID
-func (c *Client) CreateTask(ctx context.Context, name, taskType string, userID uuid.UUID, params ...any) (uuid.UUID, error) {
+func (c *Client) CreateTask(ctx context.Context, name, taskType string, userID uuid.UUID, params domain.TaskParams) (uuid.UUID, error) {
 	logger := contextkeys.LoggerFromContext(ctx)
 	clientLogger := logger.WithFields(port.Fields{
 		"component": "TaskApiClient",
@@ -54,9 +55,8 @@ func (c *Client) CreateTask(ctx context.Context, name, taskType string, userID u
 		Name:            name,
 		Type:            taskType,
 		CreatedByUserID: userID.String(),
-	}
-	if len(params) > 0 {
-		req.ObjectID = params[0].(string)
+		ObjectID:        params.ObjectID,
+		Params:          params,
 	}
 
 	reqBody, _ := json.Marshal(req)
@@ -128,3 +128,34 @@ func (c *Client) UpdateTaskStatus(ctx context.Context, taskID uuid.UUID, status
 
 	return nil
 }
+
+// RegisterItems регистрирует отправленные подзадачи и ссылки задачи
+func (c *Client) RegisterItems(ctx context.Context, taskID uuid.UUID, items []domain.TaskItem) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	clientLogger := logger.WithFields(port.Fields{
+		"component": "TaskApiClient",
+		"method":    "RegisterItems",
+		"task_id":   taskID.String(),
+	})
+
+	reqBody, _ := json.Marshal(registerItemsRequest{Items: items})
+
+	url := fmt.Sprintf("%s/api/v1/tasks/%s/items", c.baseURL, taskID.String())
+	clientLogger.Debug("Sending request to register task items", port.Fields{"url": url, "item_count": len(items)})
+
+	resp, err := c.doRequest(ctx, http.MethodPost, url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		clientLogger.Error("Failed to perform request to register task items", err, nil)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		err := fmt.Errorf("task service returned non-success status code %d: %s", resp.StatusCode, string(bodyBytes))
+		clientLogger.Error("Received error response from task-service", err, port.Fields{"status_code": resp.StatusCode})
+		return err
+	}
+
+	return nil
+}
@@ -1,11 +1,14 @@
 package task_api_client
 
+import "actualization-service/internal/core/domain"
+
 // DTO для создания задачи
 type createTaskRequest struct {
 	Name            string `json:"name"`
 	Type            string `json:"type"`
 	CreatedByUserID string `json:"created_by_user_id"`
 	ObjectID		string `json:"object_id,omitempty"`
+	Params          domain.TaskParams `json:"params"`
 }
 
 type createTaskResponse struct {
@@ -15,4 +18,8 @@ type createTaskResponse struct {
 // DTO для обновления статуса
 type updateTaskRequest struct {
 	Status string `json:"status"`
-}
\ No newline at end of file
+}
+// DTO для регистрации отправленных единиц работы
+type registerItemsRequest struct {
+	Items []domain.TaskItem `json:"items"`
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	logger_adapter "actualization-service/internal/adapters/logger"
 	"actualization-service/internal/adapters/rest"
+	"actualization-service/internal/adapters/source_registry"
 	"actualization-service/internal/adapters/storage_api_client"
 	"actualization-service/internal/adapters/task_api_client"
 	"actualization-service/internal/configs"
@@ -18,8 +19,10 @@ import (
 	"os/signal"
 	fluentlogger "real-estate-system/pkg/fluent_logger"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
 	"strings"
+	"sync"
 	"syscall"
 
 	rabbitmq_adapter "actualization-service/internal/adapters/rabbitmq"
@@ -33,6 +36,9 @@ type App struct {
 
 	eventProducer *rabbitmq_producer.Publisher
 	logger        port.LoggerPort 
+
+	// Входящие порты (слушатели событий)
+	heartbeatListener port.EventListenerPort
 	fluentClient  *fluent.Fluent  
 }
 
@@ -118,6 +124,13 @@ func NewApp() (*App, error) {
 	}
 	appLogger.Debug("RabbitMQ Event Producer initialized.", nil)
 
+	sourceRegistry, err := source_registry.NewInMemorySourceRegistry(appConfig.Sources.OverridesPath)
+	if err != nil {
+		appLogger.Error("Failed to create source registry", err, nil)
+		eventProducer.Close()
+		return nil, fmt.Errorf("failed to create source registry: %w", err)
+	}
+
 	linksQueueAdapter, _ := rabbitmq_adapter.NewRabbitMQLinkQueueAdapter(eventProducer)
 	tasksResultsAdapter, _ := rabbitmq_adapter.NewTaskManagerPublisher(eventProducer, constants.RoutingKeyTasksResults)
 	storageClient := storage_api_client.NewClient(appConfig.ApiClient.STORAGE_URL)
@@ -126,16 +139,44 @@ func NewApp() (*App, error) {
 	linksSearchQueueAdapter, _ := rabbitmq_adapter.NewRabbitMQLinksSearchQueueAdapter(eventProducer)
 
 	// инициализация use cases (ядра бизнес-логики)
-	actualizeActiveObjectsUseCase := usecase.NewActualizeActiveObjectsUseCase(storageClient, linksQueueAdapter, userTasksClient, tasksResultsAdapter)
-	actualizeArchivedObjectsUseCase := usecase.NewActualizeArchivedObjectsUseCase(storageClient, linksQueueAdapter, userTasksClient, tasksResultsAdapter)
-	actualizeObjectByIdUseCase := usecase.NewActualizeObjectsByIdUseCase(storageClient, linksQueueAdapter, userTasksClient, tasksResultsAdapter)
-	findNewObjectsUseCase := usecase.NewFindNewObjectsUseCase(linksSearchQueueAdapter, userTasksClient, tasksResultsAdapter)
+	actualizeActiveObjectsUseCase := usecase.NewActualizeActiveObjectsUseCase(storageClient, linksQueueAdapter, userTasksClient, tasksResultsAdapter, sourceRegistry)
+	actualizeArchivedObjectsUseCase := usecase.NewActualizeArchivedObjectsUseCase(storageClient, linksQueueAdapter, userTasksClient, tasksResultsAdapter, sourceRegistry)
+	actualizeObjectByIdUseCase := usecase.NewActualizeObjectsByIdUseCase(storageClient, linksQueueAdapter, userTasksClient, tasksResultsAdapter, sourceRegistry)
+	findNewObjectsUseCase := usecase.NewFindNewObjectsUseCase(linksSearchQueueAdapter, userTasksClient, tasksResultsAdapter, sourceRegistry)
+	processSourceHeartbeatUseCase := usecase.NewProcessSourceHeartbeatUseCase(sourceRegistry)
+	getSourcesUseCase := usecase.NewGetSourcesUseCase(sourceRegistry)
+	updateSourceStateUseCase := usecase.NewUpdateSourceStateUseCase(sourceRegistry)
 	// findNewObjectsUseCase := usecase.NewFindNewObjectsUseCase(storageClient, tasksQueueAdapter)
 
 	appLogger.Debug("All use cases initialized", nil)
 
 	apiHandlers := rest.NewActualizationHandlers(actualizeActiveObjectsUseCase, actualizeArchivedObjectsUseCase, actualizeObjectByIdUseCase, findNewObjectsUseCase)
-	apiServer := rest.NewServer(appConfig.Rest.PORT, apiHandlers, baseLogger)
+	sourceHandlers := rest.NewSourceHandlers(getSourcesUseCase, updateSourceStateUseCase)
+	dispatchTaskUseCase := usecase.NewDispatchTaskUseCase(actualizeActiveObjectsUseCase, actualizeArchivedObjectsUseCase, actualizeObjectByIdUseCase, findNewObjectsUseCase,
+		linksQueueAdapter, linksSearchQueueAdapter, userTasksClient, tasksResultsAdapter, sourceRegistry)
+	dispatchHandlers := rest.NewDispatchHandlers(dispatchTaskUseCase)
+	apiServer := rest.NewServer(appConfig.Rest.PORT, apiHandlers, sourceHandlers, dispatchHandlers, baseLogger)
+
+	// Очередь heartbeat не ретраится: пропущенный heartbeat заменит следующий
+	heartbeatConsumerCfg := rabbitmq_consumer.ConsumerConfig{
+		Config:              rabbitmq_common.Config{URL: appConfig.RabbitMQ.URL},
+		QueueName:           "",
+		DeclareQueue:        true,
+		ExclusiveQueue:      true,
+		AutoDeleteQueue:     true,
+		RoutingKeyForBind:   constants.RoutingKeySourceHeartbeat,
+		ExchangeNameForBind: constants.MainExchange,
+		PrefetchCount:       10,
+		WorkerPoolSize:      1,
+		ConsumerTag:         constants.SourceHeartbeatConsumerTag,
+	}
+	heartbeatListener, err := rabbitmq_adapter.NewSourceHeartbeatConsumerAdapter(heartbeatConsumerCfg, processSourceHeartbeatUseCase, baseLogger, connManager)
+	if err != nil {
+		appLogger.Error("Failed to initialize Source Heartbeat Listener", err, nil)
+		eventProducer.Close()
+		return nil, err
+	}
+	appLogger.Debug("Source Heartbeat Listener initialized.", nil)
 
 	// Собираем приложение
 	application := &App{
@@ -144,6 +185,7 @@ func NewApp() (*App, error) {
 		eventProducer: eventProducer,
 		logger:        appLogger,    
 		fluentClient:  fluentClient, 
+		heartbeatListener: heartbeatListener,
 	}
 
 	return application, nil
@@ -155,9 +197,19 @@ func (a *App) Run() error {
 	appCtx, cancelApp := context.WithCancel(context.Background())
 	//defer cancelApp()
 
+	var wg sync.WaitGroup
+
 	defer func() {
 		a.logger.Debug("Shutdown sequence initiated...", nil)
 
+		// Ждем завершения слушателей
+		wg.Wait()
+		if a.heartbeatListener != nil {
+			if err := a.heartbeatListener.Close(); err != nil {
+				a.logger.Error("Error closing source heartbeat listener", err, nil)
+			}
+		}
+
 		if a.apiServer != nil {
 			if err := a.apiServer.Stop(context.Background()); err != nil {
 				a.logger.Error("Error during API server shutdown", err, nil)
@@ -190,6 +242,16 @@ func (a *App) Run() error {
 		}
 	}()
 
+	consumerErrors := make(chan error, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.logger.Debug("Starting source heartbeat listener...", nil)
+		if err := a.heartbeatListener.Start(appCtx); err != nil {
+			consumerErrors <- fmt.Errorf("source heartbeat listener error: %w", err)
+		}
+	}()
+
 	// Ожидание сигнала на завершение или ошибки от одного из компонентов
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -202,6 +264,8 @@ func (a *App) Run() error {
 		a.logger.Warn("Context was cancelled unexpectedly, shutting down...", nil)
 	case err := <-serverErrors:
 		a.logger.Error("HTTP server failed to start, shutting down", err, nil)
+	case err := <-consumerErrors:
+		a.logger.Error("A critical component failed, shutting down", err, nil)
 	}
 
 	// Инициируем graceful shutdown, отменяя главный контекст
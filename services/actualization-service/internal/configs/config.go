@@ -23,6 +23,11 @@ type ApiClientConfig struct {
 	TASKS_SERVICE_URL string
 }
 
+// SourcesConfig - настройки реестра источников
+type SourcesConfig struct {
+	OverridesPath string // JSON-файл с включением/отключением источников администратором
+}
+
 type StdoutLogConfig struct {
     Level string `mapstructure:"STDOUT_LOG_LEVEL" default:"debug"` // По умолчанию DEBUG
 }
@@ -42,6 +47,7 @@ type AppConfig struct {
 	FluentBit	FluentBitConfig
 	AppName   	string	
 	StdoutLogger StdoutLogConfig
+	Sources      SourcesConfig
 }
 
 // LoadConfig загружает конфигурацию из переменных окружения
@@ -106,6 +112,8 @@ func LoadConfig(envPath ...string) (*AppConfig, error) {
 
 	cfg.StdoutLogger.Level = getEnvAsString("STDOUT_LOG_LEVEL", "debug")
 
+	cfg.Sources.OverridesPath = getEnvAsString("SOURCE_OVERRIDES_PATH", "data/source_overrides.json")
+
 
 	return cfg, nil
 }
@@ -2,14 +2,15 @@ package constants
 
 // Ключи маршрутизации
 const (
-	RoutingKeyLinkTasksRealt = "realt.links.tasks"
-	RoutingKeyLinkTasksKufar = "kufar.links.tasks"
-
-	RoutingKeySearchTasksRealt = "realt.search.tasks"
-	RoutingKeySearchTasksKufar = "kufar.search.tasks"
+	// Ключи задач для парсеров приходят в их heartbeat, см. реестр источников
+	RoutingKeySourceHeartbeat = "sources.heartbeat"
 
 	// RoutingKeyTasksResults = "task.completion.results"
 	RoutingKeyTasksResults = "notify.task.result"
 )
 
-const MainExchange = "main_exchange"
\ No newline at end of file
+const MainExchange = "main_exchange"
+
+// Heartbeat-очередь у каждого экземпляра своя (имя выдает сервер): реестр в памяти,
+// и каждый экземпляр должен видеть все heartbeat
+const SourceHeartbeatConsumerTag = "source-heartbeat-consumer"
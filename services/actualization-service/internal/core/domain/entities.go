@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 const (
 	ACTUALIZE_ARCHIVED = 1
@@ -9,11 +13,6 @@ const (
 	ACTUALIZE_OBJECT   = 4
 )
 
-const (
-	KUFAR_SOURCE = "kufar"
-	REALT_SOURCE = "realt"
-)
-
 // Структура для ответа API
 type PropertyInfo struct {
 	// ID        string    `json:"id"`
@@ -30,6 +29,8 @@ type PropertyInfo struct {
 type ActualizationTask struct {
 	Task       PropertyInfo // Ссылка на объект для пере-парсинга
 	Source 	   string 
+	Category   string // пустая, если категория объекта неизвестна
+	RoutingKey string // Берется из реестра источников
 	Priority   uint8
 	
 }
@@ -39,11 +40,24 @@ type TaskInfo struct {
     Region         string `json:"region"`        
     Category	 string `json:"category"` 
 	TaskID    uuid.UUID `json:"task_id"`
+	ItemKey   string    `json:"item_key,omitempty"` // парсер возвращает его в отчете
+
+	CrawlLimits
+}
+
+// CrawlLimits - ограничения одного запуска поиска новых объектов. Нулевые значения - без ограничений
+type CrawlLimits struct {
+	MaxLinks    int        `json:"max_links,omitempty"` // на всю задачу, при отправке делится между поисками
+	MaxPages    int        `json:"max_pages,omitempty"` // на каждый поиск внутри парсера
+	Since       *time.Time `json:"since,omitempty"`     // явная нижняя граница вместо курсора parser_last_runs
+	Until       *time.Time `json:"until,omitempty"`
+	FullRecrawl bool       `json:"full_recrawl,omitempty"` // игнорировать курсор
 }
 
 // Задача на поиск новых объектов
 type FindNewLinksTask struct {
 	Task TaskInfo
+	Source     string
 	RoutingKey string
 	Priority   uint8
 }
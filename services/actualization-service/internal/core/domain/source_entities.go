@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Состояния экземпляра парсера из heartbeat
+const (
+	SourceStatusHealthy  = "healthy"
+	SourceStatusDegraded = "degraded"
+	SourceStatusStopping = "stopping"
+)
+
+// HeartbeatMissesAllowed - сколько интервалов heartbeat экземпляр может молчать,
+// прежде чем будет считаться недоступным
+const HeartbeatMissesAllowed = 3
+
+var ErrSourceNotFound = errors.New("source not found")
+
+// SourceHeartbeat - объявление парсера о себе
+type SourceHeartbeat struct {
+	Name             string
+	DisplayName      string
+	InstanceID       string
+	SearchRoutingKey string
+	LinkRoutingKey   string
+	Categories       []string
+	Regions          []string
+	DealTypes        []string
+	Status           string
+	SentAt           time.Time
+	Interval         time.Duration
+}
+
+// SourceInstance - один запущенный экземпляр парсера
+type SourceInstance struct {
+	ID              string
+	Status          string
+	LastHeartbeatAt time.Time
+	Interval        time.Duration
+}
+
+// IsAlive - экземпляр не завершает работу и присылал heartbeat не позже чем HeartbeatMissesAllowed интервалов назад
+func (i SourceInstance) IsAlive(now time.Time) bool {
+	if i.Status == SourceStatusStopping {
+		return false
+	}
+	return now.Sub(i.LastHeartbeatAt) <= i.Interval*HeartbeatMissesAllowed
+}
+
+// Source - источник объявлений в реестре. Возможности берутся из последнего heartbeat,
+// Enabled и DisabledCategories задает администратор
+type Source struct {
+	Name             string
+	DisplayName      string
+	SearchRoutingKey string
+	LinkRoutingKey   string
+	Categories       []string
+	Regions          []string // пустой список - все регионы
+	DealTypes        []string
+	Instances        []SourceInstance
+
+	Enabled            bool
+	DisabledCategories []string
+}
+
+// IsAlive - есть хотя бы один живой экземпляр
+func (s Source) IsAlive(now time.Time) bool {
+	for _, inst := range s.Instances {
+		if inst.IsAlive(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastHeartbeatAt - время последнего heartbeat среди всех экземпляров
+func (s Source) LastHeartbeatAt() time.Time {
+	var last time.Time
+	for _, inst := range s.Instances {
+		if inst.LastHeartbeatAt.After(last) {
+			last = inst.LastHeartbeatAt
+		}
+	}
+	return last
+}
+
+// IsAvailable - источнику можно отправлять задачи
+func (s Source) IsAvailable(now time.Time) bool {
+	return s.Enabled && s.IsAlive(now)
+}
+
+// IsCategoryEnabled - категория не отключена администратором.
+// Пустая категория означает, что она неизвестна (например, актуализация объекта по ID)
+func (s Source) IsCategoryEnabled(category string) bool {
+	return category == "" || !contains(s.DisabledCategories, category)
+}
+
+// AcceptsSearch - источник может искать новые объявления в категории и регионе
+func (s Source) AcceptsSearch(category, region string, now time.Time) bool {
+	if !s.IsAvailable(now) || !s.IsCategoryEnabled(category) {
+		return false
+	}
+	if !contains(s.Categories, category) {
+		return false
+	}
+	return len(s.Regions) == 0 || contains(s.Regions, region)
+}
+
+// AcceptsLinks - источник может пере-парсить свое объявление указанной категории
+func (s Source) AcceptsLinks(category string, now time.Time) bool {
+	return s.IsAvailable(now) && s.IsCategoryEnabled(category)
+}
+
+// SourceStateUpdate - команда администратора на включение/отключение источника или его категории
+type SourceStateUpdate struct {
+	Name     string
+	Category string // пустая - команда относится ко всему источнику
+	Enabled  bool
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
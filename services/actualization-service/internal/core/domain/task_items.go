@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Типы задач task-service
+const (
+	TaskTypeFindNew           = "FIND_NEW"
+	TaskTypeActualizeActive   = "ACTUALIZE_ACTIVE"
+	TaskTypeActualizeArchived = "ACTUALIZE_ARCHIVED"
+	TaskTypeActualizeByID     = "ACTUALIZE_BY_ID"
+)
+
+var ErrInvalidDispatch = errors.New("invalid dispatch request")
+
+// TaskParams - входные параметры задачи. task-service хранит их вместе с задачей
+// и возвращает при повторном запуске
+type TaskParams struct {
+	Category         *string  `json:"category,omitempty"`
+	LimitPerCategory int      `json:"limit_per_category,omitempty"`
+	Categories       []string `json:"categories,omitempty"`
+	Regions          []string `json:"regions,omitempty"`
+	ObjectID         string   `json:"object_id,omitempty"`
+
+	CrawlLimits
+}
+
+// Виды единиц работы задачи
+const (
+	TaskItemLink   = "link"
+	TaskItemSearch = "search"
+)
+
+// TaskItemPayload - все, что нужно, чтобы отправить единицу работы повторно
+type TaskItemPayload struct {
+	Kind     string `json:"kind"`
+	Source   string `json:"source"`
+	Category string `json:"category,omitempty"`
+	Region   string `json:"region,omitempty"`
+	AdID     int64  `json:"ad_id,omitempty"`
+	Link     string `json:"ad_url,omitempty"`
+
+	CrawlLimits
+}
+
+// TaskItem - одна отправленная подзадача или ссылка. Исполнитель возвращает ключ в отчете,
+// и task-service отмечает единицу как выполненную
+type TaskItem struct {
+	Key     string          `json:"key"`
+	Payload TaskItemPayload `json:"payload"`
+}
+
+// LinkItemKey - ключ ссылки. storage-service строит такой же ключ по сохраненной записи
+func LinkItemKey(source string, adID int64) string {
+	return fmt.Sprintf("link:%s:%d", source, adID)
+}
+
+// SearchItemKey - ключ поиска по паре регион/категория в одном источнике
+func SearchItemKey(source, region, category string) string {
+	return fmt.Sprintf("search:%s:%s:%s", source, region, category)
+}
+
+// Item - единица работы для задачи на актуализацию
+func (t ActualizationTask) Item() TaskItem {
+	return TaskItem{
+		Key: LinkItemKey(t.Source, t.Task.AdID),
+		Payload: TaskItemPayload{
+			Kind:     TaskItemLink,
+			Source:   t.Source,
+			Category: t.Category,
+			AdID:     t.Task.AdID,
+			Link:     t.Task.Link,
+		},
+	}
+}
+
+// Item - единица работы для задачи на поиск
+func (t FindNewLinksTask) Item() TaskItem {
+	return TaskItem{
+		Key: t.Task.ItemKey,
+		Payload: TaskItemPayload{
+			Kind:        TaskItemSearch,
+			Source:      t.Source,
+			Category:    t.Task.Category,
+			Region:      t.Task.Region,
+			CrawlLimits: t.Task.CrawlLimits,
+		},
+	}
+}
+
+// DispatchCommand - запуск работы по задаче, уже созданной в task-service (повтор или продолжение).
+// Без Items задача выполняется заново по Params, иначе отправляются только перечисленные единицы
+type DispatchCommand struct {
+	TaskID   uuid.UUID
+	TaskType string
+	UserID   uuid.UUID
+	Params   TaskParams
+	Items    []TaskItem
+}
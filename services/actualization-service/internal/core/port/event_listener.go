@@ -0,0 +1,16 @@
+package port
+
+import "context"
+
+// EventListenerPort определяет контракт для компонента, который слушает
+// внешние события (например, сообщения из очереди) и запускает
+// соответствующую бизнес-логику
+type EventListenerPort interface {
+	// Start запускает слушателя. Этот метод блокирующий и должен
+	// завершаться, когда переданный контекст будет отменен
+	Start(ctx context.Context) error
+
+	// Close корректно останавливает слушателя, дожидаясь завершения
+	// активных задач
+	Close() error
+}
\ No newline at end of file
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"actualization-service/internal/core/domain"
+	"context"
+)
+
+// SourceRegistryPort - реестр парсеров, по которому маршрутизируются задачи
+type SourceRegistryPort interface {
+	RegisterHeartbeat(ctx context.Context, hb domain.SourceHeartbeat) error
+	ListSources(ctx context.Context) ([]domain.Source, error)
+	GetSource(ctx context.Context, name string) (*domain.Source, error)
+
+	SetSourceEnabled(ctx context.Context, name string, enabled bool) error
+	SetCategoryEnabled(ctx context.Context, name, category string, enabled bool) error
+}
@@ -8,4 +8,5 @@ import (
 
 type ActualizeActiveObjectsUseCase interface {
 	Execute(ctx context.Context, userID uuid.UUID, category *string, limit int) (uuid.UUID, error)
+	Start(ctx context.Context, taskID uuid.UUID, category *string, limit int)
 }
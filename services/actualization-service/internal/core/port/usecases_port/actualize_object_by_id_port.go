@@ -8,4 +8,5 @@ import (
 
 type ActualizeObjectByIdUseCase interface {
 	Execute(ctx context.Context, userID uuid.UUID, id string) (uuid.UUID, error)
+	Start(ctx context.Context, taskID uuid.UUID, id string)
 }
@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"actualization-service/internal/core/domain"
+	"context"
+)
+
+type DispatchTaskUseCase interface {
+	Execute(ctx context.Context, cmd domain.DispatchCommand) error
+}
@@ -1,11 +1,13 @@
 package usecases_port
 
 import (
+	"actualization-service/internal/core/domain"
 	"context"
 
 	"github.com/google/uuid"
 )
 
 type FindNewObjectsUseCase interface {
-	Execute(ctx context.Context, userID uuid.UUID, categories []string, regions []string)  (uuid.UUID, error)
+	Execute(ctx context.Context, userID uuid.UUID, categories []string, regions []string, limits domain.CrawlLimits) (uuid.UUID, error)
+	Start(ctx context.Context, taskID uuid.UUID, categories []string, regions []string, limits domain.CrawlLimits)
 }
\ No newline at end of file
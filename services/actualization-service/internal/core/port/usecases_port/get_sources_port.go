@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"actualization-service/internal/core/domain"
+	"context"
+)
+
+type GetSourcesUseCase interface {
+	Execute(ctx context.Context) ([]domain.Source, error)
+}
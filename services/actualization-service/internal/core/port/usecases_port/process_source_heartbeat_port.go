@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"actualization-service/internal/core/domain"
+	"context"
+)
+
+type ProcessSourceHeartbeatUseCase interface {
+	Execute(ctx context.Context, hb domain.SourceHeartbeat) error
+}
@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"actualization-service/internal/core/domain"
+	"context"
+)
+
+type UpdateSourceStateUseCase interface {
+	Execute(ctx context.Context, cmd domain.SourceStateUpdate) (*domain.Source, error)
+}
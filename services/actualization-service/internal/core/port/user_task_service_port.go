@@ -1,11 +1,14 @@
 package port
 
 import (
+	"actualization-service/internal/core/domain"
 	"context"
 	"github.com/google/uuid"
 )
 
 type UserTaskServicePort interface {
-	CreateTask(ctx context.Context, name, taskType string, userID uuid.UUID, params... any) (uuid.UUID, error)
+	CreateTask(ctx context.Context, name, taskType string, userID uuid.UUID, params domain.TaskParams) (uuid.UUID, error)
 	UpdateTaskStatus(ctx context.Context, taskID uuid.UUID, status string) error
+	// RegisterItems сообщает, какие подзадачи и ссылки отправлены по задаче, чтобы ее можно было продолжить
+	RegisterItems(ctx context.Context, taskID uuid.UUID, items []domain.TaskItem) error
 }
\ No newline at end of file
@@ -6,6 +6,7 @@ import (
 	"actualization-service/internal/core/port"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,17 +16,20 @@ type ActualizeActiveObjectsUseCase struct {
 	linksQueue  port.LinksQueuePort
 	taskService port.UserTaskServicePort
 	taskResults port.TaskResultsPort
+	sources     port.SourceRegistryPort
 }
 
 func NewActualizeActiveObjectsUseCase(storage port.StoragePort,
 	taskQueue port.LinksQueuePort,
 	taskService port.UserTaskServicePort,
-	taskResults port.TaskResultsPort) *ActualizeActiveObjectsUseCase {
+	taskResults port.TaskResultsPort,
+	sources port.SourceRegistryPort) *ActualizeActiveObjectsUseCase {
 	return &ActualizeActiveObjectsUseCase{
 		storage:     storage,
 		linksQueue:  taskQueue,
 		taskService: taskService,
 		taskResults: taskResults,
+		sources:     sources,
 	}
 }
 
@@ -53,7 +57,10 @@ func (uc *ActualizeActiveObjectsUseCase) Execute(ctx context.Context, userID uui
 	}
 
 	// Создаем задачу в task-service
-	taskID, err := uc.taskService.CreateTask(ctx, taskName, "ACTUALIZE_ACTIVE", userID)
+	taskID, err := uc.taskService.CreateTask(ctx, taskName, domain.TaskTypeActualizeActive, userID, domain.TaskParams{
+		Category:         category,
+		LimitPerCategory: limit,
+	})
 	if err != nil {
 		ucLogger.Error("Could not create user task", err, nil)
 		return uuid.Nil, fmt.Errorf("could not create task: %w", err)
@@ -69,6 +76,11 @@ func (uc *ActualizeActiveObjectsUseCase) Execute(ctx context.Context, userID uui
 
 }
 
+// Start запускает работу по задаче, уже созданной в task-service
+func (uc *ActualizeActiveObjectsUseCase) Start(ctx context.Context, taskID uuid.UUID, category *string, limit int) {
+	go uc.runInBackground(detachedContext(ctx), taskID, category, limit)
+}
+
 // runInBackground - приватный метод для выполнения фоновой работы
 func (uc *ActualizeActiveObjectsUseCase) runInBackground(ctx context.Context, taskID uuid.UUID, category *string, limit int) {
 
@@ -109,21 +121,35 @@ func (uc *ActualizeActiveObjectsUseCase) runInBackground(ctx context.Context, ta
 		taskLogger.Info("Found categories to process", port.Fields{"categories": categoriesToProcess})
 	}
 
-	totalTasksToDispatch := 0
-	var allObjects []domain.PropertyInfo
+	sources, err := availableSources(ctx, uc.sources)
+	if err != nil {
+		taskLogger.Error("Failed to get sources from registry", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
+		return
+	}
+	now := time.Now()
 
-	// Собираем объекты из всех категорий
+	var allTasks []domain.ActualizationTask
+	skippedObjects := 0
+
+	// Собираем объекты из всех категорий и сразу отбрасываем те, чей источник сейчас недоступен
 	for _, cat := range categoriesToProcess {
 		objects, err := uc.storage.GetActiveObjects(ctx, cat, limit)
 		if err != nil {
-			taskLogger.Error("Failed to get archived objects for category", err, port.Fields{"category": cat})
+			taskLogger.Error("Failed to get active objects for category", err, port.Fields{"category": cat})
 			uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
 			return
 		}
-		allObjects = append(allObjects, objects...)
+		tasks, skipped := routeActualizationTasks(objects, cat, sources, taskID, domain.ACTUALIZE_ACTIVE, now)
+		allTasks = append(allTasks, tasks...)
+		skippedObjects += skipped
+	}
+
+	if skippedObjects > 0 {
+		taskLogger.Warn("Some objects skipped: their source is disabled or unavailable", port.Fields{"skipped": skippedObjects})
 	}
 
-	totalTasksToDispatch = len(allObjects)
+	totalTasksToDispatch := len(allTasks)
 
 	if totalTasksToDispatch == 0 {
 		taskLogger.Info("No active objects to actualize. Sending completion command.", nil)
@@ -136,7 +162,6 @@ func (uc *ActualizeActiveObjectsUseCase) runInBackground(ctx context.Context, ta
 		}
 		if err := uc.taskResults.PublishCompletionCommand(ctx, completionCmd); err != nil {
 			taskLogger.Error("Failed to publish zero-count completion command", err, nil)
-
 		}
 		uc.taskService.UpdateTaskStatus(ctx, taskID, "completed")
 		return
@@ -144,18 +169,12 @@ func (uc *ActualizeActiveObjectsUseCase) runInBackground(ctx context.Context, ta
 		taskLogger.Info("Total objects to actualize across all categories", port.Fields{"count": totalTasksToDispatch})
 	}
 
-	// Для каждого объекта создаем и отправляем задачу
-	for _, obj := range allObjects {
-		obj.TaskID = taskID
-
-		task := domain.ActualizationTask{
-			Task:     obj,
-			Priority: domain.ACTUALIZE_ACTIVE,
-			Source:   obj.Source,
-		}
+	registerItems(ctx, uc.taskService, taskID, linkItems(allTasks), taskLogger)
 
+	// Для каждого объекта отправляем задачу
+	for _, task := range allTasks {
 		if err := uc.linksQueue.PublishTask(ctx, task); err != nil {
-			taskLogger.Error("Failed to publish actualization active sub-task", err, port.Fields{"link": obj.Link})
+			taskLogger.Error("Failed to publish actualization active sub-task", err, port.Fields{"link": task.Task.Link})
 			uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"actualization-service/internal/core/port"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,17 +16,20 @@ type ActualizeArchivedObjectsUseCase struct {
 	taskQueue   port.LinksQueuePort
 	taskService port.UserTaskServicePort
 	taskResults port.TaskResultsPort
+	sources     port.SourceRegistryPort
 }
 
 func NewActualizeArchivedObjectsUseCase(storage port.StoragePort,
 	taskQueue port.LinksQueuePort,
 	taskService port.UserTaskServicePort,
-	taskResults port.TaskResultsPort) *ActualizeArchivedObjectsUseCase {
+	taskResults port.TaskResultsPort,
+	sources port.SourceRegistryPort) *ActualizeArchivedObjectsUseCase {
 	return &ActualizeArchivedObjectsUseCase{
 		storage:     storage,
 		taskQueue:   taskQueue,
 		taskService: taskService,
 		taskResults: taskResults,
+		sources:     sources,
 	}
 }
 
@@ -51,7 +55,10 @@ func (uc *ActualizeArchivedObjectsUseCase) Execute(ctx context.Context, userID u
 		taskName = fmt.Sprintf("Массовая актуализация архивных объектов (лимит: %d на категорию)", limit)
 	}
 
-	taskID, err := uc.taskService.CreateTask(ctx, taskName, "ACTUALIZE_ARCHIVED", userID)
+	taskID, err := uc.taskService.CreateTask(ctx, taskName, domain.TaskTypeActualizeArchived, userID, domain.TaskParams{
+		Category:         category,
+		LimitPerCategory: limit,
+	})
 	if err != nil {
 		ucLogger.Error("Could not create user task", err, nil)
 		return uuid.Nil, fmt.Errorf("could not create task: %w", err)
@@ -66,6 +73,11 @@ func (uc *ActualizeArchivedObjectsUseCase) Execute(ctx context.Context, userID u
 	return taskID, nil
 }
 
+// Start запускает работу по задаче, уже созданной в task-service
+func (uc *ActualizeArchivedObjectsUseCase) Start(ctx context.Context, taskID uuid.UUID, category *string, limit int) {
+	go uc.runInBackground(detachedContext(ctx), taskID, category, limit)
+}
+
 // runInBackground - приватный метод для выполнения фоновой работы
 func (uc *ActualizeArchivedObjectsUseCase) runInBackground(ctx context.Context, taskID uuid.UUID, category *string, limit int) {
 
@@ -106,10 +118,18 @@ func (uc *ActualizeArchivedObjectsUseCase) runInBackground(ctx context.Context,
 		taskLogger.Info("Found categories to process", port.Fields{"categories": categoriesToProcess})
 	}
 
-	totalTasksToDispatch := 0
-	var allObjects []domain.PropertyInfo
+	sources, err := availableSources(ctx, uc.sources)
+	if err != nil {
+		taskLogger.Error("Failed to get sources from registry", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
+		return
+	}
+	now := time.Now()
 
-	// Собираем объекты из всех категорий
+	var allTasks []domain.ActualizationTask
+	skippedObjects := 0
+
+	// Собираем объекты из всех категорий и сразу отбрасываем те, чей источник сейчас недоступен
 	for _, cat := range categoriesToProcess {
 		objects, err := uc.storage.GetArchivedObjects(ctx, cat, limit)
 		if err != nil {
@@ -117,15 +137,21 @@ func (uc *ActualizeArchivedObjectsUseCase) runInBackground(ctx context.Context,
 			uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
 			return
 		}
-		allObjects = append(allObjects, objects...)
+		tasks, skipped := routeActualizationTasks(objects, cat, sources, taskID, domain.ACTUALIZE_ARCHIVED, now)
+		allTasks = append(allTasks, tasks...)
+		skippedObjects += skipped
+	}
+
+	if skippedObjects > 0 {
+		taskLogger.Warn("Some objects skipped: their source is disabled or unavailable", port.Fields{"skipped": skippedObjects})
 	}
 
-	totalTasksToDispatch = len(allObjects)
-	
+	totalTasksToDispatch := len(allTasks)
+
 	if totalTasksToDispatch == 0 {
 		taskLogger.Info("No archived objects to actualize. Sending completion command.", nil)
 		completionCmd := domain.TaskCompletionCommand{
-			TaskID:               taskID,
+			TaskID: taskID,
 			Results: map[string]int{
 				"expected_results_count": 0,
 			},
@@ -140,17 +166,12 @@ func (uc *ActualizeArchivedObjectsUseCase) runInBackground(ctx context.Context,
 		taskLogger.Info("Total objects to actualize across all categories", port.Fields{"count": totalTasksToDispatch})
 	}
 
-	for _, obj := range allObjects {
-		obj.TaskID = taskID
+	registerItems(ctx, uc.taskService, taskID, linkItems(allTasks), taskLogger)
 
-		task := domain.ActualizationTask{
-			Task:     obj,
-			Priority: domain.ACTUALIZE_ARCHIVED,
-			Source: obj.Source,
-		}
-		
+	// Для каждого объекта отправляем задачу
+	for _, task := range allTasks {
 		if err := uc.taskQueue.PublishTask(ctx, task); err != nil {
-			taskLogger.Error("Failed to publish actualization archived sub-task", err, port.Fields{"link": obj.Link})
+			taskLogger.Error("Failed to publish actualization archived sub-task", err, port.Fields{"link": task.Task.Link})
 			uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"actualization-service/internal/core/port"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,17 +16,20 @@ type ActualizeObjectsByIdUseCase struct {
 	taskQueue   port.LinksQueuePort
 	taskService port.UserTaskServicePort
 	taskResults port.TaskResultsPort
+	sources     port.SourceRegistryPort
 }
 
 func NewActualizeObjectsByIdUseCase(storage port.StoragePort,
 	taskQueue port.LinksQueuePort,
 	taskService port.UserTaskServicePort,
-	taskResults port.TaskResultsPort) *ActualizeObjectsByIdUseCase {
+	taskResults port.TaskResultsPort,
+	sources port.SourceRegistryPort) *ActualizeObjectsByIdUseCase {
 	return &ActualizeObjectsByIdUseCase{
 		storage:     storage,
 		taskQueue:   taskQueue,
 		taskService: taskService,
 		taskResults: taskResults,
+		sources:     sources,
 	}
 }
 
@@ -45,7 +49,7 @@ func (uc *ActualizeObjectsByIdUseCase) Execute(ctx context.Context, userID uuid.
 
 	// Создаем задачу в task-service
 	taskName := fmt.Sprintf("Актуализация объекта (master_id: %s)", master_id)
-	taskID, err := uc.taskService.CreateTask(ctx, taskName, "ACTUALIZE_BY_ID", userID, master_id)
+	taskID, err := uc.taskService.CreateTask(ctx, taskName, domain.TaskTypeActualizeByID, userID, domain.TaskParams{ObjectID: master_id})
 	if err != nil {
 		ucLogger.Error("Could not create user task", err, nil)
 		return uuid.Nil, fmt.Errorf("could not create task: %w", err)
@@ -61,6 +65,11 @@ func (uc *ActualizeObjectsByIdUseCase) Execute(ctx context.Context, userID uuid.
 
 }
 
+// Start запускает работу по задаче, уже созданной в task-service
+func (uc *ActualizeObjectsByIdUseCase) Start(ctx context.Context, taskID uuid.UUID, id string) {
+	go uc.runInBackground(detachedContext(ctx), taskID, id)
+}
+
 // runInBackground - приватный метод для выполнения фоновой работы
 func (uc *ActualizeObjectsByIdUseCase) runInBackground(ctx context.Context, taskID uuid.UUID, id string) {
 
@@ -87,7 +96,20 @@ func (uc *ActualizeObjectsByIdUseCase) runInBackground(ctx context.Context, task
 		return
 	}
 
-	totalTasksToDispatch := len(objects)
+	sources, err := availableSources(ctx, uc.sources)
+	if err != nil {
+		taskLogger.Error("Failed to get sources from registry", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
+		return
+	}
+
+	// Категория объекта здесь неизвестна, поэтому проверяется только доступность источника
+	tasks, skipped := routeActualizationTasks(objects, "", sources, taskID, domain.ACTUALIZE_OBJECT, time.Now())
+	if skipped > 0 {
+		taskLogger.Warn("Some objects skipped: their source is disabled or unavailable", port.Fields{"skipped": skipped})
+	}
+
+	totalTasksToDispatch := len(tasks)
 
 	if totalTasksToDispatch == 0 {
 		taskLogger.Info("No active objects to actualize. Sending completion command.", nil)
@@ -105,20 +127,14 @@ func (uc *ActualizeObjectsByIdUseCase) runInBackground(ctx context.Context, task
 		uc.taskService.UpdateTaskStatus(ctx, taskID, "completed")
 		return
 	} else {
-		taskLogger.Info("Found active objects to actualize", port.Fields{"count": len(objects)})
+		taskLogger.Info("Found active objects to actualize", port.Fields{"count": totalTasksToDispatch})
 	}
 
-	for _, obj := range objects {
-		obj.TaskID = taskID
-
-		task := domain.ActualizationTask{
-			Task:     obj,
-			Priority: domain.ACTUALIZE_OBJECT,
-			Source: obj.Source,
-		}
+	registerItems(ctx, uc.taskService, taskID, linkItems(tasks), taskLogger)
 
+	for _, task := range tasks {
 		if err := uc.taskQueue.PublishTask(ctx, task); err != nil {
-			taskLogger.Error("Failed to publish actualization object sub-task", err, port.Fields{"link": obj.Link})
+			taskLogger.Error("Failed to publish actualization object sub-task", err, port.Fields{"link": task.Task.Link})
 			uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
 		}
 	}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"actualization-service/internal/core/port/usecases_port"
+	"context"
+	"fmt"
+	"time"
+)
+
+// DispatchTaskUseCase запускает работу по задаче, которую task-service создал для повтора или продолжения
+type DispatchTaskUseCase struct {
+	actualizeActive   usecases_port.ActualizeActiveObjectsUseCase
+	actualizeArchived usecases_port.ActualizeArchivedObjectsUseCase
+	actualizeByID     usecases_port.ActualizeObjectByIdUseCase
+	findNew           usecases_port.FindNewObjectsUseCase
+	linksQueue        port.LinksQueuePort
+	searchQueue       port.LinksSearchQueuePort
+	taskService       port.UserTaskServicePort
+	taskResults       port.TaskResultsPort
+	sources           port.SourceRegistryPort
+}
+
+func NewDispatchTaskUseCase(
+	actualizeActive usecases_port.ActualizeActiveObjectsUseCase,
+	actualizeArchived usecases_port.ActualizeArchivedObjectsUseCase,
+	actualizeByID usecases_port.ActualizeObjectByIdUseCase,
+	findNew usecases_port.FindNewObjectsUseCase,
+	linksQueue port.LinksQueuePort,
+	searchQueue port.LinksSearchQueuePort,
+	taskService port.UserTaskServicePort,
+	taskResults port.TaskResultsPort,
+	sources port.SourceRegistryPort) *DispatchTaskUseCase {
+	return &DispatchTaskUseCase{
+		actualizeActive:   actualizeActive,
+		actualizeArchived: actualizeArchived,
+		actualizeByID:     actualizeByID,
+		findNew:           findNew,
+		linksQueue:        linksQueue,
+		searchQueue:       searchQueue,
+		taskService:       taskService,
+		taskResults:       taskResults,
+		sources:           sources,
+	}
+}
+
+// Execute проверяет команду и запускает работу в фоне. Без единиц работы задача выполняется
+// заново по сохраненным параметрам, иначе повторно отправляются только переданные единицы
+func (uc *DispatchTaskUseCase) Execute(ctx context.Context, cmd domain.DispatchCommand) error {
+	linkPriority, ok := linkPriorityByTaskType(cmd.TaskType)
+	if !ok && cmd.TaskType != domain.TaskTypeFindNew {
+		return fmt.Errorf("%w: unknown task type %q", domain.ErrInvalidDispatch, cmd.TaskType)
+	}
+
+	if len(cmd.Items) > 0 {
+		go uc.resume(detachedContext(ctx), cmd, linkPriority)
+		return nil
+	}
+
+	params := cmd.Params
+	switch cmd.TaskType {
+	case domain.TaskTypeActualizeActive, domain.TaskTypeActualizeArchived:
+		if params.LimitPerCategory <= 0 {
+			return fmt.Errorf("%w: limit_per_category must be positive", domain.ErrInvalidDispatch)
+		}
+		// Без категории актуализируются все категории
+		category := params.Category
+		if category == nil {
+			category = new(string)
+		}
+		if cmd.TaskType == domain.TaskTypeActualizeActive {
+			uc.actualizeActive.Start(ctx, cmd.TaskID, category, params.LimitPerCategory)
+		} else {
+			uc.actualizeArchived.Start(ctx, cmd.TaskID, category, params.LimitPerCategory)
+		}
+	case domain.TaskTypeActualizeByID:
+		if params.ObjectID == "" {
+			return fmt.Errorf("%w: object_id is required", domain.ErrInvalidDispatch)
+		}
+		uc.actualizeByID.Start(ctx, cmd.TaskID, params.ObjectID)
+	case domain.TaskTypeFindNew:
+		uc.findNew.Start(ctx, cmd.TaskID, params.Categories, params.Regions, params.CrawlLimits)
+	}
+	return nil
+}
+
+// resume заново отправляет единицы работы, по которым не пришел результат. Источник каждой единицы
+// проверяется по текущему состоянию реестра, как и при обычном запуске
+func (uc *DispatchTaskUseCase) resume(ctx context.Context, cmd domain.DispatchCommand, linkPriority uint8) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	taskLogger := logger.WithFields(port.Fields{
+		"use_case":  "DispatchTask.resume",
+		"task_id":   cmd.TaskID.String(),
+		"task_type": cmd.TaskType,
+		"user_id":   cmd.UserID,
+	})
+
+	if err := uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "running"); err != nil {
+		taskLogger.Error("Failed to update task status to 'running'", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "failed")
+		return
+	}
+
+	sources, err := availableSources(ctx, uc.sources)
+	if err != nil {
+		taskLogger.Error("Failed to get sources from registry", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "failed")
+		return
+	}
+	now := time.Now()
+
+	var links []domain.ActualizationTask
+	var searches []domain.FindNewLinksTask
+	skipped := 0
+
+	for _, item := range cmd.Items {
+		p := item.Payload
+		src, ok := sources[p.Source]
+		switch p.Kind {
+		case domain.TaskItemLink:
+			if !ok || !src.AcceptsLinks(p.Category, now) {
+				skipped++
+				continue
+			}
+			links = append(links, domain.ActualizationTask{
+				Task: domain.PropertyInfo{
+					Source: p.Source,
+					AdID:   p.AdID,
+					Link:   p.Link,
+					TaskID: cmd.TaskID,
+				},
+				Source:     p.Source,
+				Category:   p.Category,
+				RoutingKey: src.LinkRoutingKey,
+				Priority:   linkPriority,
+			})
+		case domain.TaskItemSearch:
+			if !ok || !src.AcceptsSearch(p.Category, p.Region, now) {
+				skipped++
+				continue
+			}
+			searches = append(searches, domain.FindNewLinksTask{
+				Task: domain.TaskInfo{
+					Region:      p.Region,
+					Category:    p.Category,
+					TaskID:      cmd.TaskID,
+					ItemKey:     item.Key,
+					CrawlLimits: p.CrawlLimits,
+				},
+				Source:     p.Source,
+				RoutingKey: src.SearchRoutingKey,
+				Priority:   domain.FIND_NEW_OBJECTS,
+			})
+		default:
+			skipped++
+		}
+	}
+
+	if skipped > 0 {
+		taskLogger.Warn("Some items skipped: their source is disabled or unavailable", port.Fields{"skipped": skipped})
+	}
+
+	totalTasksToDispatch := len(links) + len(searches)
+
+	if totalTasksToDispatch == 0 {
+		taskLogger.Info("No items to resume. Sending completion command.", nil)
+		completionCmd := domain.TaskCompletionCommand{
+			TaskID: cmd.TaskID,
+			Results: map[string]int{
+				"expected_results_count": 0,
+			},
+		}
+		if err := uc.taskResults.PublishCompletionCommand(ctx, completionCmd); err != nil {
+			taskLogger.Error("Failed to publish zero-count completion command", err, nil)
+		}
+		uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "completed")
+		return
+	}
+
+	registerItems(ctx, uc.taskService, cmd.TaskID, append(linkItems(links), searchItems(searches)...), taskLogger)
+
+	for _, task := range links {
+		if err := uc.linksQueue.PublishTask(ctx, task); err != nil {
+			taskLogger.Error("Failed to publish resumed actualization sub-task", err, port.Fields{"link": task.Task.Link})
+			uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "failed")
+		}
+	}
+	for _, task := range searches {
+		if err := uc.searchQueue.PublishTask(ctx, task); err != nil {
+			taskLogger.Error("Failed to publish resumed search sub-task", err, port.Fields{"region_name": task.Task.Region, "category_name": task.Task.Category})
+			uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "failed")
+		}
+	}
+
+	taskLogger.Info("Resumed items dispatched", port.Fields{"count": totalTasksToDispatch})
+
+	completionCmd := domain.TaskCompletionCommand{
+		TaskID: cmd.TaskID,
+		Results: map[string]int{
+			"expected_results_count": totalTasksToDispatch,
+		},
+	}
+	if err := uc.taskResults.PublishCompletionCommand(ctx, completionCmd); err != nil {
+		taskLogger.Error("Failed to publish completion command", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, cmd.TaskID, "failed")
+	}
+}
+
+// linkPriorityByTaskType - приоритет задач на актуализацию ссылок для типа задачи
+func linkPriorityByTaskType(taskType string) (uint8, bool) {
+	switch taskType {
+	case domain.TaskTypeActualizeActive:
+		return domain.ACTUALIZE_ACTIVE, true
+	case domain.TaskTypeActualizeArchived:
+		return domain.ACTUALIZE_ARCHIVED, true
+	case domain.TaskTypeActualizeByID:
+		return domain.ACTUALIZE_OBJECT, true
+	}
+	return 0, false
+}
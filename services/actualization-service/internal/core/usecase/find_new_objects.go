@@ -1,12 +1,12 @@
 package usecase
 
 import (
-	"actualization-service/internal/constants"
 	"actualization-service/internal/contextkeys"
 	"actualization-service/internal/core/domain"
 	"actualization-service/internal/core/port"
 	"context"
 	"fmt"
+	"time"
 
 	"strings"
 
@@ -17,21 +17,24 @@ type FindNewObjectsUseCase struct {
 	taskQueue   port.LinksSearchQueuePort
 	taskService port.UserTaskServicePort
 	taskResults port.TaskResultsPort
+	sources     port.SourceRegistryPort
 }
 
 func NewFindNewObjectsUseCase(
 	taskQueue port.LinksSearchQueuePort,
 	taskService port.UserTaskServicePort,
-	taskResults port.TaskResultsPort) *FindNewObjectsUseCase {
+	taskResults port.TaskResultsPort,
+	sources port.SourceRegistryPort) *FindNewObjectsUseCase {
 	return &FindNewObjectsUseCase{
 		taskQueue:   taskQueue,
 		taskService: taskService,
 		taskResults: taskResults,
+		sources:     sources,
 	}
 }
 
 // Execute - основной метод
-func (uc *FindNewObjectsUseCase) Execute(ctx context.Context, userID uuid.UUID, categories []string, regions []string) (uuid.UUID, error) {
+func (uc *FindNewObjectsUseCase) Execute(ctx context.Context, userID uuid.UUID, categories []string, regions []string, limits domain.CrawlLimits) (uuid.UUID, error) {
 
 	logger := contextkeys.LoggerFromContext(ctx)
 	ucLogger := logger.WithFields(port.Fields{
@@ -46,7 +49,17 @@ func (uc *FindNewObjectsUseCase) Execute(ctx context.Context, userID uuid.UUID,
 
 	// Создаем задачу в task-service
 	taskName := fmt.Sprintf("Поиск новых объектов (Категории: %v, Регионы: %v)", categories, regions)
-	taskID, err := uc.taskService.CreateTask(ctx, taskName, "FIND_NEW", userID)
+	if limits.FullRecrawl {
+		taskName += ", полный обход"
+	}
+	if limits.MaxLinks > 0 {
+		taskName += fmt.Sprintf(", не более %d ссылок", limits.MaxLinks)
+	}
+	taskID, err := uc.taskService.CreateTask(ctx, taskName, domain.TaskTypeFindNew, userID, domain.TaskParams{
+		Categories:  categories,
+		Regions:     regions,
+		CrawlLimits: limits,
+	})
 	if err != nil {
 		ucLogger.Error("Could not create user task", err, nil)
 		return uuid.Nil, fmt.Errorf("could not create task: %w", err)
@@ -55,13 +68,18 @@ func (uc *FindNewObjectsUseCase) Execute(ctx context.Context, userID uuid.UUID,
 	ucLogger.Info("User task created successfully, starting background processing", port.Fields{"task_id": taskID.String()})
 
 	// Запускаем основную логику в фоновой горутине, чтобы немедленно вернуть ответ
-	go uc.runInBackground(backgroundCtx, taskID, categories, regions)
+	go uc.runInBackground(backgroundCtx, taskID, categories, regions, limits)
 
 	return taskID, nil
 }
 
+// Start запускает работу по задаче, уже созданной в task-service
+func (uc *FindNewObjectsUseCase) Start(ctx context.Context, taskID uuid.UUID, categories []string, regions []string, limits domain.CrawlLimits) {
+	go uc.runInBackground(detachedContext(ctx), taskID, categories, regions, limits)
+}
+
 // runInBackground - приватный метод для выполнения фоновой работы
-func (uc *FindNewObjectsUseCase) runInBackground(ctx context.Context, taskID uuid.UUID, categories []string, regions []string) {
+func (uc *FindNewObjectsUseCase) runInBackground(ctx context.Context, taskID uuid.UUID, categories []string, regions []string, limits domain.CrawlLimits) {
 
 	logger := contextkeys.LoggerFromContext(ctx)
 	taskLogger := logger.WithFields(port.Fields{
@@ -80,7 +98,13 @@ func (uc *FindNewObjectsUseCase) runInBackground(ctx context.Context, taskID uui
 
 	// Шаг 3.2: Выполняем старую логику
 	// 1. Получаем список объектов от storage-service
-	allTasks := uc.generateAllTasks(categories, regions, taskID)
+	sources, err := uc.sources.ListSources(ctx)
+	if err != nil {
+		taskLogger.Error("Failed to get sources from registry", err, nil)
+		uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
+		return
+	}
+	allTasks := uc.generateAllTasks(categories, regions, taskID, limits, sources)
 
 	totalTasksToDispatch := len(allTasks)
 
@@ -101,6 +125,8 @@ func (uc *FindNewObjectsUseCase) runInBackground(ctx context.Context, taskID uui
 		return
 	}
 
+	registerItems(ctx, uc.taskService, taskID, searchItems(allTasks), taskLogger)
+
 	for _, task := range allTasks {
 		if err := uc.taskQueue.PublishTask(ctx, task); err != nil {
 			uc.taskService.UpdateTaskStatus(ctx, taskID, "failed")
@@ -124,7 +150,9 @@ func (uc *FindNewObjectsUseCase) runInBackground(ctx context.Context, taskID uui
 	}
 }
 
-func (uc *FindNewObjectsUseCase) generateAllTasks(categories []string, regions []string, taskID uuid.UUID) []domain.FindNewLinksTask {
+// generateAllTasks создает задачу поиска для каждой пары регион/категория в каждом источнике,
+// который сейчас доступен и поддерживает эту пару
+func (uc *FindNewObjectsUseCase) generateAllTasks(categories []string, regions []string, taskID uuid.UUID, limits domain.CrawlLimits, sources []domain.Source) []domain.FindNewLinksTask {
 
 	// if len(categories) == 0 {
 	// 	categories = []string{"all-categories"}
@@ -134,23 +162,25 @@ func (uc *FindNewObjectsUseCase) generateAllTasks(categories []string, regions [
 	// 	regions = []string{"all-regions"}
 	// }
 
-	routingKeys := []string{
-		constants.RoutingKeySearchTasksRealt,
-		constants.RoutingKeySearchTasksKufar,
-	}
-
+	now := time.Now()
 	var searchTasks []domain.FindNewLinksTask
 
 	for _, region := range regions {
 		for _, category := range categories {
-			for _, routingKey := range routingKeys {
+			for _, src := range sources {
+				if !src.AcceptsSearch(category, region, now) {
+					continue
+				}
 				task := domain.FindNewLinksTask{
 					Task: domain.TaskInfo{
 						Category: category,
 						Region:   region,
 						TaskID:   taskID,
+						ItemKey:  domain.SearchItemKey(src.Name, region, category),
+						CrawlLimits: limits,
 					},
-					RoutingKey: routingKey,
+					Source:     src.Name,
+					RoutingKey: src.SearchRoutingKey,
 					Priority: domain.FIND_NEW_OBJECTS,
 				}
 
@@ -159,5 +189,24 @@ func (uc *FindNewObjectsUseCase) generateAllTasks(categories []string, regions [
 		}
 	}
 
-	return searchTasks
+	return splitLinkLimit(searchTasks, limits.MaxLinks)
+}
+
+// splitLinkLimit делит лимит ссылок задачи между поисками поровну, остаток достается первым.
+// Поиски, которым не хватило ни одной ссылки, не отправляются: 0 у парсера означает "без лимита"
+func splitLinkLimit(tasks []domain.FindNewLinksTask, maxLinks int) []domain.FindNewLinksTask {
+	if maxLinks <= 0 || len(tasks) == 0 {
+		return tasks
+	}
+	if len(tasks) > maxLinks {
+		tasks = tasks[:maxLinks]
+	}
+	share, rest := maxLinks/len(tasks), maxLinks%len(tasks)
+	for i := range tasks {
+		tasks[i].Task.MaxLinks = share
+		if i < rest {
+			tasks[i].Task.MaxLinks++
+		}
+	}
+	return tasks
 }
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"context"
+)
+
+type GetSourcesUseCase struct {
+	registry port.SourceRegistryPort
+}
+
+func NewGetSourcesUseCase(registry port.SourceRegistryPort) *GetSourcesUseCase {
+	return &GetSourcesUseCase{registry: registry}
+}
+
+func (uc *GetSourcesUseCase) Execute(ctx context.Context) ([]domain.Source, error) {
+	return uc.registry.ListSources(ctx)
+}
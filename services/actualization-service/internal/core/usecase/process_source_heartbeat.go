@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ProcessSourceHeartbeatUseCase struct {
+	registry port.SourceRegistryPort
+}
+
+func NewProcessSourceHeartbeatUseCase(registry port.SourceRegistryPort) *ProcessSourceHeartbeatUseCase {
+	return &ProcessSourceHeartbeatUseCase{registry: registry}
+}
+
+// Execute регистрирует heartbeat парсера. Если источник появился впервые или сменил доступность, пишем в лог
+func (uc *ProcessSourceHeartbeatUseCase) Execute(ctx context.Context, hb domain.SourceHeartbeat) error {
+	logger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"use_case":    "ProcessSourceHeartbeat",
+		"source":      hb.Name,
+		"instance_id": hb.InstanceID,
+		"status":      hb.Status,
+	})
+
+	previous, err := uc.registry.GetSource(ctx, hb.Name)
+	if err != nil && !errors.Is(err, domain.ErrSourceNotFound) {
+		return fmt.Errorf("failed to get source %s: %w", hb.Name, err)
+	}
+
+	if err := uc.registry.RegisterHeartbeat(ctx, hb); err != nil {
+		logger.Error("Failed to register heartbeat", err, nil)
+		return fmt.Errorf("failed to register heartbeat of %s: %w", hb.Name, err)
+	}
+
+	current, err := uc.registry.GetSource(ctx, hb.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get source %s: %w", hb.Name, err)
+	}
+
+	now := time.Now()
+	switch {
+	case previous == nil:
+		logger.Info("New source registered", port.Fields{"categories": current.Categories, "regions": current.Regions})
+	case previous.IsAlive(now) != current.IsAlive(now):
+		logger.Info("Source availability changed", port.Fields{"alive": current.IsAlive(now)})
+	default:
+		logger.Debug("Heartbeat registered", nil)
+	}
+	return nil
+}
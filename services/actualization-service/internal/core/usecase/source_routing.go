@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// availableSources возвращает источники реестра по имени
+func availableSources(ctx context.Context, registry port.SourceRegistryPort) (map[string]domain.Source, error) {
+	sources, err := registry.ListSources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	byName := make(map[string]domain.Source, len(sources))
+	for _, src := range sources {
+		byName[src.Name] = src
+	}
+	return byName, nil
+}
+
+// routeActualizationTasks собирает задачи на актуализацию для объектов, чей источник сейчас принимает задачи.
+// Остальные объекты пропускаются: задача для отключенного или молчащего парсера зависла бы в очереди,
+// и task-service не дождался бы ожидаемого числа результатов
+func routeActualizationTasks(objects []domain.PropertyInfo, category string, sources map[string]domain.Source,
+	taskID uuid.UUID, priority uint8, now time.Time) (tasks []domain.ActualizationTask, skipped int) {
+
+	for _, obj := range objects {
+		src, ok := sources[obj.Source]
+		if !ok || !src.AcceptsLinks(category, now) {
+			skipped++
+			continue
+		}
+
+		obj.TaskID = taskID
+		tasks = append(tasks, domain.ActualizationTask{
+			Task:       obj,
+			Source:     obj.Source,
+			Category:   category,
+			RoutingKey: src.LinkRoutingKey,
+			Priority:   priority,
+		})
+	}
+	return tasks, skipped
+}
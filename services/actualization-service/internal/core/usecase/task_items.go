@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// detachedContext переносит логгер и trace ID запроса в контекст фоновой работы,
+// который не отменяется вместе с запросом
+func detachedContext(ctx context.Context) context.Context {
+	backgroundCtx := context.Background()
+	backgroundCtx = contextkeys.ContextWithLogger(backgroundCtx, contextkeys.LoggerFromContext(ctx))
+	return contextkeys.ContextWithTraceID(backgroundCtx, contextkeys.TraceIDFromContext(ctx))
+}
+
+func linkItems(tasks []domain.ActualizationTask) []domain.TaskItem {
+	items := make([]domain.TaskItem, 0, len(tasks))
+	for _, task := range tasks {
+		items = append(items, task.Item())
+	}
+	return items
+}
+
+func searchItems(tasks []domain.FindNewLinksTask) []domain.TaskItem {
+	items := make([]domain.TaskItem, 0, len(tasks))
+	for _, task := range tasks {
+		items = append(items, task.Item())
+	}
+	return items
+}
+
+// registerItems сообщает task-service, какие единицы работы отправлены. Без них задачу нельзя
+// будет продолжить, но сама работа от этого не зависит, поэтому ошибка только логируется
+func registerItems(ctx context.Context, taskService port.UserTaskServicePort, taskID uuid.UUID, items []domain.TaskItem, logger port.LoggerPort) {
+	if len(items) == 0 {
+		return
+	}
+	if err := taskService.RegisterItems(ctx, taskID, items); err != nil {
+		logger.Warn("Failed to register task items, the task will not be resumable", port.Fields{"error": err.Error(), "item_count": len(items)})
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"actualization-service/internal/contextkeys"
+	"actualization-service/internal/core/domain"
+	"actualization-service/internal/core/port"
+	"context"
+)
+
+type UpdateSourceStateUseCase struct {
+	registry port.SourceRegistryPort
+}
+
+func NewUpdateSourceStateUseCase(registry port.SourceRegistryPort) *UpdateSourceStateUseCase {
+	return &UpdateSourceStateUseCase{registry: registry}
+}
+
+// Execute включает или отключает источник целиком либо одну его категорию.
+// Уже отправленные задачи не отзываются, изменение касается только новых
+func (uc *UpdateSourceStateUseCase) Execute(ctx context.Context, cmd domain.SourceStateUpdate) (*domain.Source, error) {
+	logger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"use_case": "UpdateSourceState",
+		"source":   cmd.Name,
+		"category": cmd.Category,
+		"enabled":  cmd.Enabled,
+	})
+
+	var err error
+	if cmd.Category == "" {
+		err = uc.registry.SetSourceEnabled(ctx, cmd.Name, cmd.Enabled)
+	} else {
+		err = uc.registry.SetCategoryEnabled(ctx, cmd.Name, cmd.Category, cmd.Enabled)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Source state updated", nil)
+	return uc.registry.GetSource(ctx, cmd.Name)
+}
@@ -14,6 +14,7 @@ import (
 	logger_adapter "api-gateway/internal/adapter/logger"
 	"api-gateway/internal/auth"
 	"api-gateway/internal/configs"
+	"api-gateway/internal/favorites"
 	"api-gateway/internal/port"
 	"api-gateway/internal/server"
 	fluentlogger "real-estate-system/pkg/fluent_logger"
@@ -88,10 +89,12 @@ func NewApp() (*App, error) {
 	// Инициализация исходящих адаптеров (клиентов)
 	authClient := auth.NewClient(appConfig.AuthServiceURL)
 	appLogger.Debug("Auth client initialized", port.Fields{"target_url": appConfig.AuthServiceURL})
+	favoritesClient := favorites.NewClient(appConfig.FavoritesServiceURL)
+	appLogger.Debug("Favorites client initialized", port.Fields{"target_url": appConfig.FavoritesServiceURL})
 
 	// Инициализация входящего адаптера (веб-сервера)
 	// Передаем ему конфигурацию и созданного клиента
-	httpServer := server.NewServer(appConfig, authClient, baseLogger)
+	httpServer := server.NewServer(appConfig, authClient, favoritesClient, baseLogger)
 
 	return &App{
 		httpServer:   httpServer,
@@ -19,6 +19,8 @@ type Config struct {
 	FavoritesServiceURL     string
 	ActualizationServiceURL string
 	TasksServiceURL  		string
+	KufarParserServiceURL   string
+	RealtParserServiceURL   string
 
 	FluentBit	FluentBitConfig
 	StdoutLogger StdoutLogConfig
@@ -58,6 +60,8 @@ func LoadConfig(envPath ...string) (*Config, error) {
 		FavoritesServiceURL:     getEnv("FAVORITES_SERVICE_URL", "http://localhost:8083"),
 		ActualizationServiceURL: getEnv("ACTUALIZATION_SERVICE_URL", "http://localhost:8084"),
 		TasksServiceURL:         getEnv("TASKS_SERVICE_URL", "http://localhost:8084"),
+		KufarParserServiceURL:   getEnv("KUFAR_PARSER_SERVICE_URL", "http://localhost:8086"),
+		RealtParserServiceURL:   getEnv("REALT_PARSER_SERVICE_URL", "http://localhost:8087"),
 		AppName: 				 getEnv("APP_NAME", "api-gateway"),
 	}
 
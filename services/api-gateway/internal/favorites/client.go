@@ -0,0 +1,64 @@
+package favorites
+
+import (
+	"api-gateway/internal/contextkeys"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Отметки объектов выдачи в favorites-service
+const (
+	MarkSeen   = "seen"
+	MarkHidden = "hidden"
+)
+
+// Client - клиент для взаимодействия с favorites-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient - конструктор клиента
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		// Запрос стоит на пути поиска, поэтому долго ждать favorites-service нельзя
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// GetMarkedObjectIDs возвращает master_object_id объектов, отмеченных пользователем (seen или hidden)
+func (c *Client) GetMarkedObjectIDs(ctx context.Context, userID, mark string) ([]string, error) {
+	url := c.baseURL + "/api/v1/favorites/marks/" + mark + "/ids"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create marks request: %w", err)
+	}
+	req.Header.Set("X-User-ID", userID)
+
+	traceID := contextkeys.TraceIDFromContext(ctx)
+	if traceID != "" {
+		req.Header.Set("X-Trace-ID", traceID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send marks request to favorites service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("favorites service returned non-200 status: %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var ids []string
+	if err := json.NewDecoder(resp.Body).Decode(&ids); err != nil {
+		return nil, fmt.Errorf("failed to decode marks response: %w", err)
+	}
+	return ids, nil
+}
@@ -51,6 +51,20 @@ func (am *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// OptionalClaims валидирует токен, если он передан, для публичных маршрутов с персональными опциями.
+// nil - анонимный запрос или невалидный токен
+func (am *AuthMiddleware) OptionalClaims(r *http.Request) *auth.Claims {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if tokenString == "" || tokenString == r.Header.Get("Authorization") {
+		return nil
+	}
+	claims, err := am.authClient.ValidateToken(r.Context(), tokenString)
+	if err != nil {
+		return nil
+	}
+	return claims
+}
+
 // RequireRole - middleware для проверки роли пользователя
 func (am *AuthMiddleware) RequireRole(requiredRole string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
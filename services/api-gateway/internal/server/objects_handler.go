@@ -0,0 +1,114 @@
+package server
+
+import (
+	"api-gateway/internal/contextkeys"
+	"api-gateway/internal/favorites"
+	"api-gateway/internal/port"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Параметры GET /objects, которые gateway разрешает сам и не передает в storage-service
+const (
+	excludeHiddenParam = "exclude_hidden"
+	onlyUnseenParam    = "only_unseen"
+)
+
+// searchObjectsRequest - тело POST /api/v1/objects/search storage-service
+type searchObjectsRequest struct {
+	ExcludeMasterIDs []string `json:"exclude_master_ids"`
+}
+
+// ObjectsHandler проксирует /objects/* в storage-service. Поиск GET /objects с exclude_hidden=true
+// или only_unseen=true для авторизованного пользователя превращается в POST /objects/search
+// с его скрытыми/просмотренными объектами из favorites-service. Анонимам параметры просто не применяются
+type ObjectsHandler struct {
+	authMiddleware  *AuthMiddleware
+	favoritesClient *favorites.Client
+	storageProxy    http.Handler
+}
+
+// NewObjectsHandler - конструктор
+func NewObjectsHandler(authMiddleware *AuthMiddleware, favoritesClient *favorites.Client, storageProxy http.Handler) *ObjectsHandler {
+	return &ObjectsHandler{
+		authMiddleware:  authMiddleware,
+		favoritesClient: favoritesClient,
+		storageProxy:    storageProxy,
+	}
+}
+
+func (h *ObjectsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet || strings.TrimSuffix(r.URL.Path, "/") != "/objects" {
+		h.storageProxy.ServeHTTP(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	excludeHidden := query.Get(excludeHiddenParam) == "true"
+	onlyUnseen := query.Get(onlyUnseenParam) == "true"
+	if !excludeHidden && !onlyUnseen {
+		h.storageProxy.ServeHTTP(w, r)
+		return
+	}
+	query.Del(excludeHiddenParam)
+	query.Del(onlyUnseenParam)
+
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{
+		"handler":        "ObjectsSearch",
+		"exclude_hidden": excludeHidden,
+		"only_unseen":    onlyUnseen,
+	})
+
+	claims := h.authMiddleware.OptionalClaims(r)
+	if claims == nil {
+		r.URL.RawQuery = query.Encode()
+		h.storageProxy.ServeHTTP(w, r)
+		return
+	}
+
+	marks := make([]string, 0, 2)
+	if excludeHidden {
+		marks = append(marks, favorites.MarkHidden)
+	}
+	if onlyUnseen {
+		marks = append(marks, favorites.MarkSeen)
+	}
+
+	excluded := make([]string, 0)
+	for _, mark := range marks {
+		ids, err := h.favoritesClient.GetMarkedObjectIDs(r.Context(), claims.UserID, mark)
+		if err != nil {
+			// Поиск важнее фильтра: без favorites-service отдаем обычную выдачу
+			logger.Warn("Failed to load listing marks, searching without exclusions", port.Fields{
+				"mark":  mark,
+				"error": err.Error(),
+			})
+			r.URL.RawQuery = query.Encode()
+			h.storageProxy.ServeHTTP(w, r)
+			return
+		}
+		excluded = append(excluded, ids...)
+	}
+
+	body, err := json.Marshal(searchObjectsRequest{ExcludeMasterIDs: excluded})
+	if err != nil {
+		logger.Error("Failed to marshal search request", err, nil)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	logger.Debug("Searching objects with user exclusions", port.Fields{"excluded_count": len(excluded)})
+
+	searchReq := r.Clone(r.Context())
+	searchReq.Method = http.MethodPost
+	searchReq.URL.Path = "/objects/search"
+	searchReq.URL.RawPath = ""
+	searchReq.URL.RawQuery = query.Encode()
+	searchReq.Body = io.NopCloser(bytes.NewReader(body))
+	searchReq.ContentLength = int64(len(body))
+	searchReq.Header.Set("Content-Type", "application/json")
+
+	h.storageProxy.ServeHTTP(w, searchReq)
+}
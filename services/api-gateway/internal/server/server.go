@@ -3,6 +3,7 @@ package server
 import (
 	"api-gateway/internal/auth"
 	"api-gateway/internal/configs"
+	"api-gateway/internal/favorites"
 	"api-gateway/internal/port"
 	"net/http"
 	"github.com/go-chi/chi/v5"
@@ -11,7 +12,7 @@ import (
 )
 
 // NewServer создает и настраивает главный роутер и HTTP-сервер
-func NewServer(cfg *configs.Config, authClient *auth.Client, baseLogger port.LoggerPort) *http.Server {
+func NewServer(cfg *configs.Config, authClient *auth.Client, favoritesClient *favorites.Client, baseLogger port.LoggerPort) *http.Server {
 	r := chi.NewRouter()
 
 	// Стандартные middleware
@@ -23,7 +24,7 @@ func NewServer(cfg *configs.Config, authClient *auth.Client, baseLogger port.Log
         AllowedOrigins:   []string{"http://localhost:5173"},
         
         // AllowedMethods - список разрешенных HTTP-методов
-        AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+        AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
         
         // AllowedHeaders - список разрешенных заголовков в запросе
         AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
@@ -46,11 +47,16 @@ func NewServer(cfg *configs.Config, authClient *auth.Client, baseLogger port.Log
 		// /auth/* -> authentication-service/api/v1/auth/*
 		r.Mount("/auth", CreateProxy(cfg.AuthServiceURL, internalApiPrefix))
 
-		// /objects/* -> storage-service/api/v1/objects/*
-		r.Mount("/objects", CreateProxy(cfg.StorageServiceURL, internalApiPrefix))
+		// /objects/* -> storage-service/api/v1/objects/* (включая /objects/{id}/images/{index} - копии фото).
+		// exclude_hidden и only_unseen в поиске разрешаются здесь по токену, если он передан
+		r.Mount("/objects", NewObjectsHandler(authMiddleware, favoritesClient, CreateProxy(cfg.StorageServiceURL, internalApiPrefix)))
+		// /sellers/* -> storage-service/api/v1/sellers/* (профили продавцов)
+		r.Mount("/sellers", CreateProxy(cfg.StorageServiceURL, internalApiPrefix))
 		r.Mount("/filters/options", CreateProxy(cfg.StorageServiceURL, internalApiPrefix))
 		r.Mount("/dictionaries", CreateProxy(cfg.StorageServiceURL, internalApiPrefix))
 		r.Mount("/stats", CreateProxy(cfg.StorageServiceURL, internalApiPrefix))
+		// /shared/{token} -> favorites-service/api/v1/shared/{token} (избранное по публичной ссылке)
+		r.Mount("/shared", CreateProxy(cfg.FavoritesServiceURL, internalApiPrefix))
 	})
 
 	// Приватные маршруты (для всех авторизованных)
@@ -74,6 +80,15 @@ func NewServer(cfg *configs.Config, authClient *auth.Client, baseLogger port.Log
 		// после более специфичных
 		r.Mount("/actualize", CreateProxy(cfg.ActualizationServiceURL, internalApiPrefix))
 		r.Mount("/tasks", CreateProxy(cfg.TasksServiceURL, internalApiPrefix))
+		// /dlq/* -> task-service/api/v1/dlq/*
+		r.Mount("/dlq", CreateProxy(cfg.TasksServiceURL, internalApiPrefix))
+		// /sources/* -> actualization-service/api/v1/sources/* (реестр парсеров)
+		r.Mount("/sources", CreateProxy(cfg.ActualizationServiceURL, internalApiPrefix))
+		// /quarantine/* -> storage-service/api/v1/quarantine/* (записи, не прошедшие правила качества)
+		r.Mount("/quarantine", CreateProxy(cfg.StorageServiceURL, internalApiPrefix))
+		// /parsers/<источник>/cursors -> курсоры parser_last_runs в самом парсере
+		r.Mount("/parsers/kufar", CreateProxy(cfg.KufarParserServiceURL, internalApiPrefix))
+		r.Mount("/parsers/realt", CreateProxy(cfg.RealtParserServiceURL, internalApiPrefix))
 	})
 
 
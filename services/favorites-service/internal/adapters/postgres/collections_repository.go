@@ -0,0 +1,223 @@
+package postgres_adapter
+
+import (
+	"context"
+	"errors"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const collectionColumns = `
+	c.id, c.user_id, c.name, c.created_at, c.updated_at,
+	(SELECT COUNT(*) FROM favorite_collection_items ci WHERE ci.collection_id = c.id)`
+
+// ListCollections - подборки пользователя в порядке создания
+func (r *PostgresFavoritesRepository) ListCollections(ctx context.Context, userID uuid.UUID) ([]domain.FavoriteCollection, error) {
+	query := `SELECT ` + collectionColumns + ` FROM favorite_collections c WHERE c.user_id = $1 ORDER BY c.created_at ASC`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := make([]domain.FavoriteCollection, 0)
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection: %w", err)
+		}
+		collections = append(collections, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during collections iteration: %w", err)
+	}
+	return collections, nil
+}
+
+func (r *PostgresFavoritesRepository) CreateCollection(ctx context.Context, collection domain.FavoriteCollection) (*domain.FavoriteCollection, error) {
+	query := `
+		INSERT INTO favorite_collections (id, user_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.pool.Exec(ctx, query, collection.ID, collection.UserID, collection.Name, collection.CreatedAt, collection.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrCollectionNameTaken
+		}
+		contextkeys.LoggerFromContext(ctx).Error("Failed to create collection", err, port.Fields{
+			"component": "PostgresFavoritesRepository",
+			"method":    "CreateCollection",
+			"user_id":   collection.UserID,
+		})
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	return &collection, nil
+}
+
+func (r *PostgresFavoritesRepository) RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) (*domain.FavoriteCollection, error) {
+	query := `
+		UPDATE favorite_collections c SET name = $3, updated_at = NOW()
+		WHERE c.id = $1 AND c.user_id = $2
+		RETURNING ` + collectionColumns
+	collection, err := scanCollection(r.pool.QueryRow(ctx, query, collectionID, userID, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCollectionNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, domain.ErrCollectionNameTaken
+		}
+		return nil, fmt.Errorf("failed to rename collection: %w", err)
+	}
+	return collection, nil
+}
+
+func (r *PostgresFavoritesRepository) DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM favorite_collections WHERE id = $1 AND user_id = $2`, collectionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+// AddToCollection добавляет объекты в избранное (если их там еще нет) и в подборку одной транзакцией
+func (r *PostgresFavoritesRepository) AddToCollection(ctx context.Context, userID, collectionID uuid.UUID, masterObjectIDs []uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockOwnCollections(ctx, tx, userID, collectionID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_favorites (user_id, master_object_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT (user_id, master_object_id) DO NOTHING
+	`, userID, masterObjectIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add objects to favorites: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO favorite_collection_items (collection_id, user_id, master_object_id)
+		SELECT $1, $2, unnest($3::uuid[])
+		ON CONFLICT (collection_id, master_object_id) DO NOTHING
+	`, collectionID, userID, masterObjectIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add objects to collection: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE favorite_collections SET updated_at = NOW() WHERE id = $1`, collectionID); err != nil {
+		return fmt.Errorf("failed to touch collection: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresFavoritesRepository) RemoveFromCollection(ctx context.Context, userID, collectionID, masterObjectID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockOwnCollections(ctx, tx, userID, collectionID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM favorite_collection_items WHERE collection_id = $1 AND master_object_id = $2`, collectionID, masterObjectID)
+	if err != nil {
+		return fmt.Errorf("failed to remove object from collection: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// TransferItems копирует объекты, которые есть в исходной подборке, в целевую; при move удаляет их из исходной
+func (r *PostgresFavoritesRepository) TransferItems(ctx context.Context, userID, fromID, toID uuid.UUID, masterObjectIDs []uuid.UUID, move bool) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockOwnCollections(ctx, tx, userID, fromID, toID); err != nil {
+		return 0, err
+	}
+
+	// Объекты, которые уже лежат в целевой подборке, тоже считаются перенесенными
+	var transferred int
+	err = tx.QueryRow(ctx, `
+		WITH source AS (
+			SELECT master_object_id FROM favorite_collection_items
+			WHERE collection_id = $1 AND master_object_id = ANY($3::uuid[])
+		), inserted AS (
+			INSERT INTO favorite_collection_items (collection_id, user_id, master_object_id)
+			SELECT $2, $4, master_object_id FROM source
+			ON CONFLICT (collection_id, master_object_id) DO NOTHING
+		)
+		SELECT COUNT(*) FROM source
+	`, fromID, toID, masterObjectIDs, userID).Scan(&transferred)
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy collection items: %w", err)
+	}
+
+	if move {
+		_, err = tx.Exec(ctx, `DELETE FROM favorite_collection_items WHERE collection_id = $1 AND master_object_id = ANY($2::uuid[])`, fromID, masterObjectIDs)
+		if err != nil {
+			return 0, fmt.Errorf("failed to remove moved items from source collection: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE favorite_collections SET updated_at = NOW() WHERE id = ANY($1::uuid[])`, []uuid.UUID{fromID, toID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to touch collections: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return transferred, nil
+}
+
+// lockOwnCollections блокирует подборки на время изменения и проверяет, что все они принадлежат пользователю
+func lockOwnCollections(ctx context.Context, tx pgx.Tx, userID uuid.UUID, collectionIDs ...uuid.UUID) error {
+	var found int
+	err := tx.QueryRow(ctx, `
+		WITH locked AS (
+			SELECT id FROM favorite_collections
+			WHERE id = ANY($1::uuid[]) AND user_id = $2
+			FOR UPDATE
+		)
+		SELECT COUNT(*) FROM locked
+	`, collectionIDs, userID).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("failed to lock collections: %w", err)
+	}
+	if found != len(collectionIDs) {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func scanCollection(row pgx.Row) (*domain.FavoriteCollection, error) {
+	var c domain.FavoriteCollection
+	if err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.CreatedAt, &c.UpdatedAt, &c.ItemsCount); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" // 23505 - unique_violation
+}
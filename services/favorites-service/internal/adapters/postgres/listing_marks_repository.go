@@ -0,0 +1,99 @@
+package postgres_adapter
+
+import (
+	"context"
+	"favorites-service/internal/core/domain"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// AddListingMarks ставит отметки одной транзакцией и следит за лимитом mark.Limit()
+func (r *PostgresFavoritesRepository) AddListingMarks(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, masterObjectIDs []uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Параллельные запросы одного пользователя не должны вместе обойти лимит
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, userID.String()+":"+string(mark)); err != nil {
+		return fmt.Errorf("failed to lock listing marks: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_listing_marks (user_id, mark, master_object_id)
+		SELECT $1, $2, unnest($3::uuid[])
+		ON CONFLICT (user_id, mark, master_object_id) DO UPDATE SET created_at = NOW()
+	`, userID, string(mark), masterObjectIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add listing marks: %w", err)
+	}
+
+	if mark.EvictsOldest() {
+		_, err = tx.Exec(ctx, `
+			DELETE FROM user_listing_marks
+			WHERE user_id = $1 AND mark = $2 AND master_object_id IN (
+				SELECT master_object_id FROM user_listing_marks
+				WHERE user_id = $1 AND mark = $2
+				ORDER BY created_at DESC
+				OFFSET $3
+			)
+		`, userID, string(mark), mark.Limit())
+		if err != nil {
+			return fmt.Errorf("failed to evict old listing marks: %w", err)
+		}
+	} else {
+		var total int
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM user_listing_marks WHERE user_id = $1 AND mark = $2`, userID, string(mark)).Scan(&total)
+		if err != nil {
+			return fmt.Errorf("failed to count listing marks: %w", err)
+		}
+		if total > mark.Limit() {
+			return fmt.Errorf("%w: at most %d %s listings per user", domain.ErrListingMarksLimit, mark.Limit(), mark)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresFavoritesRepository) RemoveListingMarks(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, masterObjectIDs []uuid.UUID) (int64, error) {
+	query := `DELETE FROM user_listing_marks WHERE user_id = $1 AND mark = $2`
+	args := []interface{}{userID, string(mark)}
+	if len(masterObjectIDs) > 0 {
+		query += ` AND master_object_id = ANY($3::uuid[])`
+		args = append(args, masterObjectIDs)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove listing marks: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// FindListingMarkIDs - отмеченные объекты, сначала недавние
+func (r *PostgresFavoritesRepository) FindListingMarkIDs(ctx context.Context, userID uuid.UUID, mark domain.ListingMark) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT master_object_id FROM user_listing_marks
+		WHERE user_id = $1 AND mark = $2
+		ORDER BY created_at DESC
+	`, userID, string(mark))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query listing marks: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan listing mark: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during listing marks iteration: %w", err)
+	}
+	return ids, nil
+}
@@ -7,8 +7,10 @@ import (
 	"favorites-service/internal/core/domain"
 	"favorites-service/internal/core/port"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -118,20 +120,41 @@ func (r *PostgresFavoritesRepository) FindFavoritesIdsByUser(ctx context.Context
 	return ids, nil
 }
 
-// FindPaginatedByUser находит ID избранных объектов с пагинацией.
-func (r *PostgresFavoritesRepository) FindPaginatedByUser(ctx context.Context, userID uuid.UUID, limit, offset int) (*domain.PaginatedFavoriteIDs, error) {
+// favoriteColumns - поля записи избранного вместе с подборками, в которых лежит объект
+const favoriteColumns = `
+	f.master_object_id, f.created_at, COALESCE(f.note, ''), f.tags,
+	ARRAY(
+		SELECT ci.collection_id FROM favorite_collection_items ci
+		WHERE ci.user_id = f.user_id AND ci.master_object_id = f.master_object_id
+		ORDER BY ci.created_at
+	)`
+
+// FindByUser находит записи избранного по фильтрам запроса с пагинацией.
+func (r *PostgresFavoritesRepository) FindByUser(ctx context.Context, userID uuid.UUID, query domain.FavoritesQuery, limit, offset int) (*domain.PaginatedFavorites, error) {
 	logger := contextkeys.LoggerFromContext(ctx)
 	repoLogger := logger.WithFields(port.Fields{
 		"component": "PostgresFavoritesRepository",
-		"method":    "FindPaginatedByUser",
+		"method":    "FindByUser",
 		"user_id":   userID,
 		"limit":     limit,
 		"offset":    offset,
 	})
 
-	repoLogger.Debug("Starting transaction to find paginated favorites.", nil)
-	// Выполняем два запроса в одной "виртуальной" транзакции для консистентности.
-	// Использование `tx.Begin` здесь не обязательно, но это хорошая практика.
+	conditions := []string{"f.user_id = $1"}
+	args := []interface{}{userID}
+	if query.CollectionID != nil {
+		args = append(args, *query.CollectionID)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM favorite_collection_items ci
+			WHERE ci.collection_id = $%d AND ci.user_id = f.user_id AND ci.master_object_id = f.master_object_id)`, len(args)))
+	}
+	if query.Tag != "" {
+		args = append(args, query.Tag)
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(f.tags)", len(args)))
+	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Выполняем два запроса в одной транзакции для консистентности.
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		repoLogger.Error("Failed to begin transaction", err, nil)
@@ -141,45 +164,48 @@ func (r *PostgresFavoritesRepository) FindPaginatedByUser(ctx context.Context, u
 
 	// 1. Запрос на общее количество
 	var totalCount int64
-	countQuery := "SELECT COUNT(*) FROM user_favorites WHERE user_id = $1"
-	if err := tx.QueryRow(ctx, countQuery, userID).Scan(&totalCount); err != nil {
+	countQuery := "SELECT COUNT(*) FROM user_favorites f " + whereClause
+	if err := tx.QueryRow(ctx, countQuery, args...).Scan(&totalCount); err != nil {
 		repoLogger.Error("Failed to count favorites", err, port.Fields{"query": countQuery})
 		return nil, fmt.Errorf("failed to count favorites: %w", err)
 	}
-	repoLogger.Debug("Total favorites for user", port.Fields{"total_count": totalCount})
 
 	// Если избранных нет, сразу возвращаем результат
 	if totalCount == 0 {
-		return &domain.PaginatedFavoriteIDs{
-			MasterObjectIDs: []uuid.UUID{},
-			TotalCount:      0,
-			CurrentPage:  offset/limit + 1, // Показываем, на какой странице мы находимся
-			ItemsPerPage: limit,               // И с какими параметрами
-		}, nil
-	}
-
-	// 2. Запрос на получение ID для текущей страницы
-	// Сортируем по `created_at DESC`, чтобы новые были первыми.
-	dataQuery := "SELECT master_object_id FROM user_favorites WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3"
-	rows, err := tx.Query(ctx, dataQuery, userID, limit, offset)
+		return &domain.PaginatedFavorites{Items: []domain.FavoriteItem{}}, nil
+	}
+
+	// 2. Запрос на получение записей для текущей страницы. По умолчанию новые первыми
+	order := "DESC"
+	if !query.Desc {
+		order = "ASC"
+	}
+	dataQuery := "SELECT " + favoriteColumns + " FROM user_favorites f " + whereClause +
+		" ORDER BY f.created_at " + order + ", f.master_object_id"
+	if limit > 0 {
+		args = append(args, limit, offset)
+		dataQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	rows, err := tx.Query(ctx, dataQuery, args...)
 	if err != nil {
-		repoLogger.Error("Failed to query favorite IDs", err, port.Fields{"query": dataQuery})
-		return nil, fmt.Errorf("failed to query favorite IDs: %w", err)
+		repoLogger.Error("Failed to query favorites", err, port.Fields{"query": dataQuery})
+		return nil, fmt.Errorf("failed to query favorites: %w", err)
 	}
 	defer rows.Close()
 
-	ids := make([]uuid.UUID, 0, limit)
+	items := make([]domain.FavoriteItem, 0, limit)
 	for rows.Next() {
-		var id uuid.UUID
-		if err := rows.Scan(&id); err != nil {
-			repoLogger.Error("Failed to scan favorite ID row", err, nil)
-			return nil, fmt.Errorf("failed to scan favorite ID: %w", err)
+		item := domain.FavoriteItem{UserID: userID}
+		if err := rows.Scan(&item.MasterObjectID, &item.CreatedAt, &item.Note, &item.Tags, &item.CollectionIDs); err != nil {
+			repoLogger.Error("Failed to scan favorite row", err, nil)
+			return nil, fmt.Errorf("failed to scan favorite: %w", err)
 		}
-		ids = append(ids, id)
+		items = append(items, item)
 	}
 	if err := rows.Err(); err != nil {
-		repoLogger.Error("Error during favorite IDs iteration", err, nil)
-		return nil, fmt.Errorf("error during favorite IDs iteration: %w", err)
+		repoLogger.Error("Error during favorites iteration", err, nil)
+		return nil, fmt.Errorf("error during favorites iteration: %w", err)
 	}
 
 	// Если все прошло успешно, коммитим транзакцию
@@ -188,11 +214,48 @@ func (r *PostgresFavoritesRepository) FindPaginatedByUser(ctx context.Context, u
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	repoLogger.Debug("Successfully found paginated favorites.", port.Fields{"found_on_page": len(ids)})
-	return &domain.PaginatedFavoriteIDs{
-		MasterObjectIDs: ids,
-		TotalCount:      totalCount,
-		CurrentPage:  offset/limit + 1, // Показываем, на какой странице мы находимся
-		ItemsPerPage: limit,               // И с какими параметрами
-	}, nil
-}
\ No newline at end of file
+	repoLogger.Debug("Successfully found favorites.", port.Fields{"found_on_page": len(items)})
+	return &domain.PaginatedFavorites{Items: items, TotalCount: totalCount}, nil
+}
+
+// UpdateDetails меняет заметку и теги записи избранного.
+func (r *PostgresFavoritesRepository) UpdateDetails(ctx context.Context, userID, masterObjectID uuid.UUID, update domain.FavoriteUpdate) (*domain.FavoriteItem, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":        "PostgresFavoritesRepository",
+		"method":           "UpdateDetails",
+		"user_id":          userID,
+		"master_object_id": masterObjectID,
+	})
+
+	// NULL в параметре означает "не менять поле"; пустая заметка хранится как NULL
+	var note, tags interface{}
+	if update.Note != nil {
+		note = *update.Note
+	}
+	if update.Tags != nil {
+		tags = *update.Tags
+	}
+
+	query := `
+		UPDATE user_favorites f SET
+			note = CASE WHEN $3::text IS NULL THEN f.note ELSE NULLIF($3::text, '') END,
+			tags = COALESCE($4::text[], f.tags),
+			updated_at = NOW()
+		WHERE f.user_id = $1 AND f.master_object_id = $2
+		RETURNING ` + favoriteColumns
+
+	item := domain.FavoriteItem{UserID: userID}
+	err := r.pool.QueryRow(ctx, query, userID, masterObjectID, note, tags).
+		Scan(&item.MasterObjectID, &item.CreatedAt, &item.Note, &item.Tags, &item.CollectionIDs)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrFavoriteNotFound
+		}
+		repoLogger.Error("Failed to update favorite details", err, nil)
+		return nil, fmt.Errorf("failed to update favorite details: %w", err)
+	}
+
+	repoLogger.Debug("Favorite details updated.", nil)
+	return &item, nil
+}
@@ -0,0 +1,133 @@
+package postgres_adapter
+
+import (
+	"context"
+	"errors"
+	"favorites-service/internal/core/domain"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const shareLinkColumns = `
+	l.id, l.token, l.user_id, l.collection_id, COALESCE(c.name, ''),
+	l.expires_at, l.revoked_at, l.views_count, l.last_viewed_at, l.created_at`
+
+// CreateShareLink сохраняет ссылку. Принадлежность подборки проверяется в том же запросе
+func (r *PostgresFavoritesRepository) CreateShareLink(ctx context.Context, link domain.ShareLink) (*domain.ShareLink, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO favorite_share_links (id, token, user_id, collection_id, expires_at, created_at)
+			SELECT $1, $2, $3, $4, $5, $6
+			WHERE $4::uuid IS NULL
+			   OR EXISTS (SELECT 1 FROM favorite_collections WHERE id = $4 AND user_id = $3)
+			RETURNING *
+		)
+		SELECT ` + shareLinkColumns + `
+		FROM inserted l
+		LEFT JOIN favorite_collections c ON c.id = l.collection_id
+	`
+	created, err := scanShareLink(r.pool.QueryRow(ctx, query, link.ID, link.Token, link.UserID, link.CollectionID, link.ExpiresAt, link.CreatedAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+	return created, nil
+}
+
+// ListShareLinks - все ссылки пользователя, включая отозванные и истекшие, новые первыми
+func (r *PostgresFavoritesRepository) ListShareLinks(ctx context.Context, userID uuid.UUID) ([]domain.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM favorite_share_links l
+		LEFT JOIN favorite_collections c ON c.id = l.collection_id
+		WHERE l.user_id = $1
+		ORDER BY l.created_at DESC
+	`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query share links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]domain.ShareLink, 0)
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+		links = append(links, *link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during share links iteration: %w", err)
+	}
+	return links, nil
+}
+
+func (r *PostgresFavoritesRepository) CountActiveShareLinks(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM favorite_share_links
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active share links: %w", err)
+	}
+	return count, nil
+}
+
+// RevokeShareLink отзывает ссылку. Повторный отзыв не ошибка, время первого отзыва сохраняется
+func (r *PostgresFavoritesRepository) RevokeShareLink(ctx context.Context, userID, linkID uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE favorite_share_links SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, linkID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrShareLinkNotFound
+	}
+	return nil
+}
+
+func (r *PostgresFavoritesRepository) FindShareLinkByToken(ctx context.Context, token string) (*domain.ShareLink, error) {
+	query := `
+		SELECT ` + shareLinkColumns + `
+		FROM favorite_share_links l
+		LEFT JOIN favorite_collections c ON c.id = l.collection_id
+		WHERE l.token = $1
+	`
+	link, err := scanShareLink(r.pool.QueryRow(ctx, query, token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to find share link: %w", err)
+	}
+	return link, nil
+}
+
+func (r *PostgresFavoritesRepository) RegisterShareLinkView(ctx context.Context, linkID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE favorite_share_links SET views_count = views_count + 1, last_viewed_at = NOW()
+		WHERE id = $1
+	`, linkID)
+	if err != nil {
+		return fmt.Errorf("failed to register share link view: %w", err)
+	}
+	return nil
+}
+
+func scanShareLink(row pgx.Row) (*domain.ShareLink, error) {
+	var l domain.ShareLink
+	err := row.Scan(&l.ID, &l.Token, &l.UserID, &l.CollectionID, &l.CollectionName,
+		&l.ExpiresAt, &l.RevokedAt, &l.ViewsCount, &l.LastViewedAt, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
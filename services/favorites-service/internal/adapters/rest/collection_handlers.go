@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"encoding/json"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/port"
+	"favorites-service/internal/core/port/usecases_port"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CollectionsHandler - обработчики подборок избранного
+type CollectionsHandler struct {
+	collectionsUC usecases_port.FavoriteCollectionsUseCasePort
+}
+
+// NewCollectionsHandler - конструктор.
+func NewCollectionsHandler(collectionsUC usecases_port.FavoriteCollectionsUseCasePort) *CollectionsHandler {
+	return &CollectionsHandler{collectionsUC: collectionsUC}
+}
+
+// ListCollections обрабатывает GET /api/v1/favorites/collections
+func (h *CollectionsHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ListCollections"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+
+	collections, err := h.collectionsUC.List(r.Context(), userID)
+	if err != nil {
+		writeFavoritesError(w, logger, "ListCollections", err)
+		return
+	}
+
+	response := CollectionsListResponse{Data: make([]CollectionResponse, len(collections))}
+	for i := range collections {
+		response.Data[i] = toCollectionResponse(&collections[i])
+	}
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
+// CreateCollection обрабатывает POST /api/v1/favorites/collections
+func (h *CollectionsHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "CreateCollection"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for create collection", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	collection, err := h.collectionsUC.Create(r.Context(), userID, reqDTO.Name)
+	if err != nil {
+		writeFavoritesError(w, logger, "CreateCollection", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusCreated, toCollectionResponse(collection))
+}
+
+// RenameCollection обрабатывает PATCH /api/v1/favorites/collections/{collectionID}
+func (h *CollectionsHandler) RenameCollection(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "RenameCollection"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	collectionID, ok := parseUUIDParam(w, r, "collectionID", logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for rename collection", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	collection, err := h.collectionsUC.Rename(r.Context(), userID, collectionID, reqDTO.Name)
+	if err != nil {
+		writeFavoritesError(w, logger, "RenameCollection", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, toCollectionResponse(collection))
+}
+
+// DeleteCollection обрабатывает DELETE /api/v1/favorites/collections/{collectionID}.
+// Объекты из подборки остаются в избранном
+func (h *CollectionsHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "DeleteCollection"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	collectionID, ok := parseUUIDParam(w, r, "collectionID", logger)
+	if !ok {
+		return
+	}
+
+	if err := h.collectionsUC.Delete(r.Context(), userID, collectionID); err != nil {
+		writeFavoritesError(w, logger, "DeleteCollection", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddCollectionItems обрабатывает POST /api/v1/favorites/collections/{collectionID}/items.
+// Объекты, которых еще нет в избранном, добавляются туда же
+func (h *CollectionsHandler) AddCollectionItems(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "AddCollectionItems"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	collectionID, ok := parseUUIDParam(w, r, "collectionID", logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO CollectionItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for add collection items", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	objectIDs, ok := parseObjectIDs(w, reqDTO.MasterObjectIDs, logger)
+	if !ok {
+		return
+	}
+
+	if err := h.collectionsUC.AddItems(r.Context(), userID, collectionID, objectIDs); err != nil {
+		writeFavoritesError(w, logger, "AddCollectionItems", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveCollectionItem обрабатывает DELETE /api/v1/favorites/collections/{collectionID}/items/{masterObjectID}
+func (h *CollectionsHandler) RemoveCollectionItem(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "RemoveCollectionItem"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	collectionID, ok := parseUUIDParam(w, r, "collectionID", logger)
+	if !ok {
+		return
+	}
+	masterObjectID, ok := parseUUIDParam(w, r, "masterObjectID", logger)
+	if !ok {
+		return
+	}
+
+	if err := h.collectionsUC.RemoveItem(r.Context(), userID, collectionID, masterObjectID); err != nil {
+		writeFavoritesError(w, logger, "RemoveCollectionItem", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MoveCollectionItems обрабатывает POST /api/v1/favorites/collections/{collectionID}/move
+func (h *CollectionsHandler) MoveCollectionItems(w http.ResponseWriter, r *http.Request) {
+	h.transferItems(w, r, true)
+}
+
+// CopyCollectionItems обрабатывает POST /api/v1/favorites/collections/{collectionID}/copy
+func (h *CollectionsHandler) CopyCollectionItems(w http.ResponseWriter, r *http.Request) {
+	h.transferItems(w, r, false)
+}
+
+func (h *CollectionsHandler) transferItems(w http.ResponseWriter, r *http.Request, move bool) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "TransferCollectionItems", "move": move})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	fromID, ok := parseUUIDParam(w, r, "collectionID", logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO TransferItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for transfer collection items", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	toID, err := uuid.Parse(reqDTO.TargetCollectionID)
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid target_collection_id format")
+		return
+	}
+	objectIDs, ok := parseObjectIDs(w, reqDTO.MasterObjectIDs, logger)
+	if !ok {
+		return
+	}
+
+	transferred, err := h.collectionsUC.TransferItems(r.Context(), userID, fromID, toID, objectIDs, move)
+	if err != nil {
+		writeFavoritesError(w, logger, "TransferCollectionItems", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, TransferItemsResponse{Transferred: transferred})
+}
+
+// userIDFromRequest извлекает userID, добавленный middleware аутентификации
+func userIDFromRequest(w http.ResponseWriter, r *http.Request, logger port.LoggerPort) (uuid.UUID, bool) {
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		logger.Error("Invalid or missing user ID in context", nil, nil)
+		WriteJSONError(w, http.StatusUnauthorized, "Invalid user ID in context")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func parseUUIDParam(w http.ResponseWriter, r *http.Request, name string, logger port.LoggerPort) (uuid.UUID, bool) {
+	value := chi.URLParam(r, name)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		logger.Warn("Invalid UUID in URL", port.Fields{"param": name, "provided_id": value})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid "+name+" in URL")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func parseObjectIDs(w http.ResponseWriter, raw []string, logger port.LoggerPort) ([]uuid.UUID, bool) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			logger.Warn("Invalid master_object_id format in request", port.Fields{"provided_id": s})
+			WriteJSONError(w, http.StatusBadRequest, "Invalid master_object_id format")
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
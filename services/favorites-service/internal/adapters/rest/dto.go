@@ -1,5 +1,12 @@
 package rest
 
+import (
+	"favorites-service/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // AddFavoriteRequest - тело запроса для добавления в избранное.
 type AddFavoriteRequest struct {
 	MasterObjectID string `json:"master_object_id"`
@@ -20,6 +27,12 @@ type ObjectCardResponse struct {
     DealType string    `json:"deal_type"`
 
     MasterObjectID	string    `json:"master_object_id"`
+
+    // Данные избранного пользователя
+    AddedAt       time.Time `json:"added_at"`
+    Note          string    `json:"note"`
+    Tags          []string  `json:"tags"`
+    CollectionIDs []string  `json:"collection_ids"`
 }
 
 // PaginatedFavoritesResponse - структура для ответа со списком избранного.
@@ -30,7 +43,218 @@ type PaginatedFavoritesResponse struct {
 	PerPage    int                  `json:"per_page"`
 }
 
+// UpdateFavoriteRequest - тело PATCH /favorites/{masterObjectID}. Отсутствующее поле не меняется
+type UpdateFavoriteRequest struct {
+	Note *string   `json:"note"`
+	Tags *[]string `json:"tags"`
+}
+
+// FavoriteDetailsResponse - заметка, теги и подборки одного избранного объекта
+type FavoriteDetailsResponse struct {
+	MasterObjectID string    `json:"master_object_id"`
+	AddedAt        time.Time `json:"added_at"`
+	Note           string    `json:"note"`
+	Tags           []string  `json:"tags"`
+	CollectionIDs  []string  `json:"collection_ids"`
+}
+
+// CollectionRequest - тело создания и переименования подборки
+type CollectionRequest struct {
+	Name string `json:"name"`
+}
+
+// CollectionItemsRequest - объекты, которые нужно положить в подборку
+type CollectionItemsRequest struct {
+	MasterObjectIDs []string `json:"master_object_ids"`
+}
+
+// TransferItemsRequest - перенос или копирование объектов в другую подборку
+type TransferItemsRequest struct {
+	TargetCollectionID string   `json:"target_collection_id"`
+	MasterObjectIDs    []string `json:"master_object_ids"`
+}
+
+// TransferItemsResponse - сколько объектов оказалось в целевой подборке
+type TransferItemsResponse struct {
+	Transferred int `json:"transferred"`
+}
+
+// CollectionResponse - подборка избранного
+type CollectionResponse struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	ItemsCount int64     `json:"items_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CollectionsListResponse - все подборки пользователя
+type CollectionsListResponse struct {
+	Data []CollectionResponse `json:"data"`
+}
+
+// CreateShareLinkRequest - тело создания публичной ссылки. Без collection_id ссылка ведет на все избранное,
+// expires_in_hours = 0 - срок по умолчанию
+type CreateShareLinkRequest struct {
+	CollectionID   *string `json:"collection_id"`
+	ExpiresInHours int     `json:"expires_in_hours"`
+}
+
+// ShareLinkResponse - публичная ссылка глазами владельца
+type ShareLinkResponse struct {
+	ID             string     `json:"id"`
+	Token          string     `json:"token"`
+	CollectionID   *string    `json:"collection_id"`
+	CollectionName string     `json:"collection_name,omitempty"`
+	Active         bool       `json:"active"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	ViewsCount     int64      `json:"views_count"`
+	LastViewedAt   *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ShareLinksListResponse - все ссылки пользователя
+type ShareLinksListResponse struct {
+	Data []ShareLinkResponse `json:"data"`
+}
+
+// SharedObjectCardResponse - карточка объекта по публичной ссылке, без личных данных владельца
+type SharedObjectCardResponse struct {
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	PriceUSD       float64   `json:"price_usd"`
+	PriceBYN       float64   `json:"price_byn"`
+	Images         []string  `json:"images"`
+	Address        string    `json:"address"`
+	Status         string    `json:"status"`
+	Category       string    `json:"category"`
+	DealType       string    `json:"deal_type"`
+	MasterObjectID string    `json:"master_object_id"`
+	AddedAt        time.Time `json:"added_at"`
+}
+
+// SharedFavoritesResponse - ответ публичного GET /shared/{token}
+type SharedFavoritesResponse struct {
+	CollectionName string                     `json:"collection_name,omitempty"`
+	ExpiresAt      time.Time                  `json:"expires_at"`
+	Data           []SharedObjectCardResponse `json:"data"`
+	Total          int64                      `json:"total"`
+	Page           int                        `json:"page"`
+	PerPage        int                        `json:"per_page"`
+}
+
+func toObjectCardResponse(card domain.FavoriteCard) ObjectCardResponse {
+	return ObjectCardResponse{
+		ID:             card.ID,
+		Title:          card.Title,
+		PriceUSD:       card.PriceUSD,
+		PriceBYN:       card.PriceBYN,
+		Images:         card.Images,
+		Address:        card.Address,
+		Status:         card.Status,
+		MasterObjectID: card.MasterObjectID,
+		Category:       card.Category,
+		DealType:       card.DealType,
+		AddedAt:        card.AddedAt,
+		Note:           card.Note,
+		Tags:           nonNilTags(card.Tags),
+		CollectionIDs:  uuidsToStrings(card.CollectionIDs),
+	}
+}
+
+func toFavoriteDetailsResponse(item *domain.FavoriteItem) FavoriteDetailsResponse {
+	return FavoriteDetailsResponse{
+		MasterObjectID: item.MasterObjectID.String(),
+		AddedAt:        item.CreatedAt,
+		Note:           item.Note,
+		Tags:           nonNilTags(item.Tags),
+		CollectionIDs:  uuidsToStrings(item.CollectionIDs),
+	}
+}
+
+func toCollectionResponse(c *domain.FavoriteCollection) CollectionResponse {
+	return CollectionResponse{
+		ID:         c.ID.String(),
+		Name:       c.Name,
+		ItemsCount: c.ItemsCount,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toShareLinkResponse(l *domain.ShareLink, now time.Time) ShareLinkResponse {
+	response := ShareLinkResponse{
+		ID:             l.ID.String(),
+		Token:          l.Token,
+		CollectionName: l.CollectionName,
+		Active:         l.IsActive(now),
+		ExpiresAt:      l.ExpiresAt,
+		RevokedAt:      l.RevokedAt,
+		ViewsCount:     l.ViewsCount,
+		LastViewedAt:   l.LastViewedAt,
+		CreatedAt:      l.CreatedAt,
+	}
+	if l.CollectionID != nil {
+		id := l.CollectionID.String()
+		response.CollectionID = &id
+	}
+	return response
+}
+
+func toSharedFavoritesResponse(shared *domain.SharedFavorites) SharedFavoritesResponse {
+	response := SharedFavoritesResponse{
+		CollectionName: shared.Link.CollectionName,
+		ExpiresAt:      shared.Link.ExpiresAt,
+		Data:           make([]SharedObjectCardResponse, len(shared.Objects.Objects)),
+		Total:          shared.Objects.TotalCount,
+		Page:           shared.Objects.CurrentPage,
+		PerPage:        shared.Objects.ItemsPerPage,
+	}
+	for i, card := range shared.Objects.Objects {
+		response.Data[i] = SharedObjectCardResponse{
+			ID:             card.ID,
+			Title:          card.Title,
+			PriceUSD:       card.PriceUSD,
+			PriceBYN:       card.PriceBYN,
+			Images:         card.Images,
+			Address:        card.Address,
+			Status:         card.Status,
+			Category:       card.Category,
+			DealType:       card.DealType,
+			MasterObjectID: card.MasterObjectID,
+			AddedAt:        card.AddedAt,
+		}
+	}
+	return response
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func uuidsToStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
+
 // ErrorResponse - стандартная структура для ответа с ошибкой.
 type ErrorResponse struct {
 	Error string `json:"error"`
-}
\ No newline at end of file
+}
+
+// ListingMarksRequest - объекты, на которые ставится или с которых снимается отметка
+type ListingMarksRequest struct {
+	MasterObjectIDs []string `json:"master_object_ids"`
+}
+
+// ListingMarksRemovedResponse - сколько отметок снято
+type ListingMarksRemovedResponse struct {
+	Removed int64 `json:"removed"`
+}
@@ -2,12 +2,15 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
 	"favorites-service/internal/core/port"
 	"favorites-service/internal/core/port/usecases_port"
 	// "log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -19,18 +22,21 @@ type FavoritesHandler struct {
 	removeUC usecases_port.RemoveFromFavoritesUseCasePort
 	getObjectsUC    usecases_port.GetUserFavoritesUseCasePort
 	getIdsUC 		usecases_port.GetUserFavoritesIdsUseCasePort
+	updateUC        usecases_port.UpdateFavoriteUseCasePort
 }
 
 // NewFavoritesHandler - конструктор.
 func NewFavoritesHandler(addUC usecases_port.AddToFavoritesUseCasePort, 
 	removeUC usecases_port.RemoveFromFavoritesUseCasePort, 
 	getObjectsUC usecases_port.GetUserFavoritesUseCasePort,
-	getIdsUC usecases_port.GetUserFavoritesIdsUseCasePort) *FavoritesHandler {
+	getIdsUC usecases_port.GetUserFavoritesIdsUseCasePort,
+	updateUC usecases_port.UpdateFavoriteUseCasePort) *FavoritesHandler {
 	return &FavoritesHandler{
 		addUC:    addUC,
 		removeUC: removeUC,
 		getObjectsUC:    getObjectsUC,
 		getIdsUC: getIdsUC,
+		updateUC: updateUC,
 	}
 }
 
@@ -60,7 +66,7 @@ func (h *FavoritesHandler) GetUserFavoritesIds(w http.ResponseWriter, r *http.Re
 	RespondWithJSON(w, http.StatusOK, ids)
 }
 
-// GetUserFavorites обрабатывает GET /api/v1/favorites
+// GetUserFavorites обрабатывает GET /api/v1/favorites?collection_id=&tag=&sort=added_at|price&order=asc|desc&limit=&offset=
 func (h *FavoritesHandler) GetUserFavorites(w http.ResponseWriter, r *http.Request) {
 	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetUserFavorites"})
 	
@@ -82,16 +88,28 @@ func (h *FavoritesHandler) GetUserFavorites(w http.ResponseWriter, r *http.Reque
 		offset = 0
 	}
 
+	query, errMsg := parseFavoritesQuery(r)
+	if errMsg != "" {
+		logger.Warn("Invalid favorites query", port.Fields{"reason": errMsg})
+		WriteJSONError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
 	handlerLogger := logger.WithFields(port.Fields{
 		"user_id": userID,
 		"limit":   limit,
 		"offset":  offset,
+		"query":   query,
 	})
 	handlerLogger.Info("Processing request to get user favorites", nil)
 	
 	// Вызываем Use Case
-	paginatedResult, err := h.getObjectsUC.Execute(r.Context(), userID, limit, offset)
+	paginatedResult, err := h.getObjectsUC.Execute(r.Context(), userID, query, limit, offset)
 	if err != nil {
+		if errors.Is(err, domain.ErrPriceSortTooLarge) {
+			WriteJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		handlerLogger.Error("Get user favorites use case failed", err, nil)
 		WriteJSONError(w, http.StatusInternalServerError, "Failed to retrieve favorites")
 		return
@@ -105,18 +123,7 @@ func (h *FavoritesHandler) GetUserFavorites(w http.ResponseWriter, r *http.Reque
 		PerPage:    paginatedResult.ItemsPerPage,
 	}
 	for i, obj := range paginatedResult.Objects {
-		response.Data[i] = ObjectCardResponse{
-			ID:       obj.ID,
-			Title:  obj.Title, // Предполагаем, что у вас есть поле Title
-			PriceUSD: obj.PriceUSD,
-			PriceBYN: obj.PriceBYN,
-			Images:   obj.Images,
-			Address:  obj.Address,
-			Status:   obj.Status,
-			MasterObjectID: obj.MasterObjectID,
-			Category: obj.Category,
-			DealType: obj.DealType,
-		}
+		response.Data[i] = toObjectCardResponse(obj)
 	}
 
 	handlerLogger.Info("Successfully retrieved user favorites", port.Fields{
@@ -201,4 +208,104 @@ func (h *FavoritesHandler) RemoveFromFavorites(w http.ResponseWriter, r *http.Re
 
 	handlerLogger.Info("Successfully removed object from favorites", nil)
 	w.WriteHeader(http.StatusNoContent) // 204 No Content - стандартный ответ на успешный DELETE
-}
\ No newline at end of file
+}
+
+// UpdateFavorite обрабатывает PATCH /api/v1/favorites/{masterObjectID} - заметка и теги
+func (h *FavoritesHandler) UpdateFavorite(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "UpdateFavorite"})
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		logger.Error("Invalid or missing user ID in context", nil, nil)
+		WriteJSONError(w, http.StatusUnauthorized, "Invalid user ID in context")
+		return
+	}
+
+	masterObjectIDStr := chi.URLParam(r, "masterObjectID")
+	masterObjectID, err := uuid.Parse(masterObjectIDStr)
+	if err != nil {
+		logger.Warn("Invalid masterObjectID in URL", port.Fields{"provided_id": masterObjectIDStr})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid masterObjectID in URL")
+		return
+	}
+
+	var reqDTO UpdateFavoriteRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for update favorite", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"user_id":          userID,
+		"master_object_id": masterObjectID,
+	})
+	handlerLogger.Info("Processing request to update favorite", nil)
+
+	item, err := h.updateUC.Execute(r.Context(), userID, masterObjectID, domain.FavoriteUpdate{Note: reqDTO.Note, Tags: reqDTO.Tags})
+	if err != nil {
+		writeFavoritesError(w, handlerLogger, "UpdateFavorite", err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, toFavoriteDetailsResponse(item))
+}
+
+// parseFavoritesQuery разбирает фильтры и сортировку списка избранного. Возвращает текст ошибки для клиента
+func parseFavoritesQuery(r *http.Request) (domain.FavoritesQuery, string) {
+	params := r.URL.Query()
+	query := domain.FavoritesQuery{
+		Tag:  strings.ToLower(strings.TrimSpace(params.Get("tag"))),
+		Sort: domain.FavoritesSortAddedAt,
+		Desc: true,
+	}
+
+	if collectionIDStr := params.Get("collection_id"); collectionIDStr != "" {
+		collectionID, err := uuid.Parse(collectionIDStr)
+		if err != nil {
+			return query, "Invalid 'collection_id' format"
+		}
+		query.CollectionID = &collectionID
+	}
+
+	switch sortField := domain.FavoritesSortField(params.Get("sort")); sortField {
+	case "":
+	case domain.FavoritesSortAddedAt, domain.FavoritesSortPrice:
+		query.Sort = sortField
+	default:
+		return query, "Invalid 'sort', expected 'added_at' or 'price'"
+	}
+
+	switch params.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Desc = false
+	default:
+		return query, "Invalid 'order', expected 'asc' or 'desc'"
+	}
+	return query, ""
+}
+
+// writeFavoritesError переводит доменные ошибки в HTTP-статусы
+func writeFavoritesError(w http.ResponseWriter, logger port.LoggerPort, useCase string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrFavoriteNotFound):
+		WriteJSONError(w, http.StatusNotFound, "Object is not in favorites")
+	case errors.Is(err, domain.ErrCollectionNotFound):
+		WriteJSONError(w, http.StatusNotFound, "Collection not found")
+	case errors.Is(err, domain.ErrCollectionNameTaken):
+		WriteJSONError(w, http.StatusConflict, "Collection with this name already exists")
+	case errors.Is(err, domain.ErrShareLinkNotFound):
+		WriteJSONError(w, http.StatusNotFound, "Share link not found")
+	case errors.Is(err, domain.ErrShareLinkInactive):
+		WriteJSONError(w, http.StatusGone, "Share link is revoked or expired")
+	case errors.Is(err, domain.ErrCollectionsLimit), errors.Is(err, domain.ErrInvalidFavoriteData),
+		errors.Is(err, domain.ErrShareLinksLimit), errors.Is(err, domain.ErrInvalidShareLink),
+		errors.Is(err, domain.ErrInvalidListingMark), errors.Is(err, domain.ErrListingMarksLimit),
+		errors.Is(err, domain.ErrPriceSortTooLarge):
+		WriteJSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		logger.Error(useCase+" use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
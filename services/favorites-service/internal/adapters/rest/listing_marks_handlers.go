@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"encoding/json"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"favorites-service/internal/core/port/usecases_port"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListingMarksHandler - обработчики отметок "просмотрено" (seen) и "скрыто" (hidden)
+type ListingMarksHandler struct {
+	marksUC usecases_port.ListingMarksUseCasePort
+}
+
+// NewListingMarksHandler - конструктор.
+func NewListingMarksHandler(marksUC usecases_port.ListingMarksUseCasePort) *ListingMarksHandler {
+	return &ListingMarksHandler{marksUC: marksUC}
+}
+
+// GetMarkedIDs обрабатывает GET /api/v1/favorites/marks/{mark}/ids.
+// Этим же запросом api-gateway собирает исключения для поиска
+func (h *ListingMarksHandler) GetMarkedIDs(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetMarkedIDs"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	mark, ok := parseMarkParam(w, r, logger)
+	if !ok {
+		return
+	}
+
+	ids, err := h.marksUC.ListIDs(r.Context(), userID, mark)
+	if err != nil {
+		writeFavoritesError(w, logger, "GetMarkedIDs", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, ids)
+}
+
+// MarkListings обрабатывает POST /api/v1/favorites/marks/{mark}
+func (h *ListingMarksHandler) MarkListings(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "MarkListings"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	mark, ok := parseMarkParam(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO ListingMarksRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for mark listings", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	objectIDs, ok := parseObjectIDs(w, reqDTO.MasterObjectIDs, logger)
+	if !ok {
+		return
+	}
+
+	if err := h.marksUC.Mark(r.Context(), userID, mark, objectIDs); err != nil {
+		writeFavoritesError(w, logger, "MarkListings", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnmarkListings обрабатывает POST /api/v1/favorites/marks/{mark}/remove
+func (h *ListingMarksHandler) UnmarkListings(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "UnmarkListings"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	mark, ok := parseMarkParam(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO ListingMarksRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for unmark listings", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	objectIDs, ok := parseObjectIDs(w, reqDTO.MasterObjectIDs, logger)
+	if !ok {
+		return
+	}
+
+	removed, err := h.marksUC.Unmark(r.Context(), userID, mark, objectIDs)
+	if err != nil {
+		writeFavoritesError(w, logger, "UnmarkListings", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, ListingMarksRemovedResponse{Removed: removed})
+}
+
+// ClearListingMarks обрабатывает DELETE /api/v1/favorites/marks/{mark} - снимает все отметки этого типа
+func (h *ListingMarksHandler) ClearListingMarks(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ClearListingMarks"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	mark, ok := parseMarkParam(w, r, logger)
+	if !ok {
+		return
+	}
+
+	removed, err := h.marksUC.Clear(r.Context(), userID, mark)
+	if err != nil {
+		writeFavoritesError(w, logger, "ClearListingMarks", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, ListingMarksRemovedResponse{Removed: removed})
+}
+
+func parseMarkParam(w http.ResponseWriter, r *http.Request, logger port.LoggerPort) (domain.ListingMark, bool) {
+	mark, err := domain.ParseListingMark(chi.URLParam(r, "mark"))
+	if err != nil {
+		logger.Warn("Invalid listing mark in URL", port.Fields{"mark": chi.URLParam(r, "mark")})
+		WriteJSONError(w, http.StatusBadRequest, "Mark must be one of: seen, hidden")
+		return "", false
+	}
+	return mark, true
+}
@@ -25,7 +25,7 @@ type Server struct {
 }
 
 // NewServer создает новый экземпляр сервера.
-func NewServer(port string, handlers *FavoritesHandler, baseLogger core_port.LoggerPort) *Server {
+func NewServer(port string, handlers *FavoritesHandler, collectionHandlers *CollectionsHandler, shareHandlers *ShareLinksHandler, marksHandlers *ListingMarksHandler, baseLogger core_port.LoggerPort) *Server {
 	r := chi.NewRouter()
 
 	// serverLogger := baseLogger.WithFields(core_port.Fields{"component": "rest_server"})
@@ -44,8 +44,40 @@ func NewServer(port string, handlers *FavoritesHandler, baseLogger core_port.Log
 		r.Get("/ids", handlers.GetUserFavoritesIds)
 		r.Post("/", handlers.AddToFavorites)
 		r.Delete("/{masterObjectID}", handlers.RemoveFromFavorites)
+		// заметка и теги объекта в избранном
+		r.Patch("/{masterObjectID}", handlers.UpdateFavorite)
+
+		// Подборки избранного. Объект может лежать в нескольких подборках сразу
+		r.Route("/collections", func(r chi.Router) {
+			r.Get("/", collectionHandlers.ListCollections)
+			r.Post("/", collectionHandlers.CreateCollection)
+			r.Patch("/{collectionID}", collectionHandlers.RenameCollection)
+			r.Delete("/{collectionID}", collectionHandlers.DeleteCollection)
+			r.Post("/{collectionID}/items", collectionHandlers.AddCollectionItems)
+			r.Delete("/{collectionID}/items/{masterObjectID}", collectionHandlers.RemoveCollectionItem)
+			r.Post("/{collectionID}/move", collectionHandlers.MoveCollectionItems)
+			r.Post("/{collectionID}/copy", collectionHandlers.CopyCollectionItems)
+		})
+
+		// Публичные ссылки на подборку или на все избранное (управляет владелец)
+		r.Route("/shares", func(r chi.Router) {
+			r.Get("/", shareHandlers.ListShareLinks)
+			r.Post("/", shareHandlers.CreateShareLink)
+			r.Delete("/{shareID}", shareHandlers.RevokeShareLink)
+		})
+
+		// Отметки "просмотрено" (seen) и "скрыто" (hidden), по ним api-gateway фильтрует поиск
+		r.Route("/marks/{mark}", func(r chi.Router) {
+			r.Get("/ids", marksHandlers.GetMarkedIDs)
+			r.Post("/", marksHandlers.MarkListings)
+			r.Post("/remove", marksHandlers.UnmarkListings)
+			r.Delete("/", marksHandlers.ClearListingMarks)
+		})
 	})
 
+	// Просмотр по публичной ссылке - без аутентификации, доступ определяет только токен
+	r.Get("/api/v1/shared/{token}", shareHandlers.GetSharedFavorites)
+
 	srv := &http.Server{
 		Addr:    ":" + port,
 		Handler: r,
@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"encoding/json"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/port"
+	"favorites-service/internal/core/port/usecases_port"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxSharedPageSize ограничивает страницу публичного просмотра: запросы идут без аутентификации
+const maxSharedPageSize = 100
+
+// ShareLinksHandler - управление публичными ссылками и их просмотр
+type ShareLinksHandler struct {
+	linksUC  usecases_port.ShareLinksUseCasePort
+	sharedUC usecases_port.GetSharedFavoritesUseCasePort
+}
+
+// NewShareLinksHandler - конструктор.
+func NewShareLinksHandler(linksUC usecases_port.ShareLinksUseCasePort, sharedUC usecases_port.GetSharedFavoritesUseCasePort) *ShareLinksHandler {
+	return &ShareLinksHandler{linksUC: linksUC, sharedUC: sharedUC}
+}
+
+// CreateShareLink обрабатывает POST /api/v1/favorites/shares
+func (h *ShareLinksHandler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "CreateShareLink"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var reqDTO CreateShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		logger.Warn("Failed to decode request body for create share link", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var collectionID *uuid.UUID
+	if reqDTO.CollectionID != nil && *reqDTO.CollectionID != "" {
+		id, err := uuid.Parse(*reqDTO.CollectionID)
+		if err != nil {
+			WriteJSONError(w, http.StatusBadRequest, "Invalid collection_id format")
+			return
+		}
+		collectionID = &id
+	}
+	if reqDTO.ExpiresInHours < 0 {
+		WriteJSONError(w, http.StatusBadRequest, "expires_in_hours must not be negative")
+		return
+	}
+
+	link, err := h.linksUC.Create(r.Context(), userID, collectionID, time.Duration(reqDTO.ExpiresInHours)*time.Hour)
+	if err != nil {
+		writeFavoritesError(w, logger, "CreateShareLink", err)
+		return
+	}
+	RespondWithJSON(w, http.StatusCreated, toShareLinkResponse(link, time.Now()))
+}
+
+// ListShareLinks обрабатывает GET /api/v1/favorites/shares
+func (h *ShareLinksHandler) ListShareLinks(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ListShareLinks"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+
+	links, err := h.linksUC.List(r.Context(), userID)
+	if err != nil {
+		writeFavoritesError(w, logger, "ListShareLinks", err)
+		return
+	}
+
+	now := time.Now()
+	response := ShareLinksListResponse{Data: make([]ShareLinkResponse, len(links))}
+	for i := range links {
+		response.Data[i] = toShareLinkResponse(&links[i], now)
+	}
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
+// RevokeShareLink обрабатывает DELETE /api/v1/favorites/shares/{shareID}.
+// Ссылка остается в списке владельца как отозванная
+func (h *ShareLinksHandler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "RevokeShareLink"})
+
+	userID, ok := userIDFromRequest(w, r, logger)
+	if !ok {
+		return
+	}
+	linkID, ok := parseUUIDParam(w, r, "shareID", logger)
+	if !ok {
+		return
+	}
+
+	if err := h.linksUC.Revoke(r.Context(), userID, linkID); err != nil {
+		writeFavoritesError(w, logger, "RevokeShareLink", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSharedFavorites обрабатывает публичный GET /api/v1/shared/{token}?sort=&order=&limit=&offset=
+func (h *ShareLinksHandler) GetSharedFavorites(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetSharedFavorites"})
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > maxSharedPageSize {
+		limit = maxSharedPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// collection_id и tag задаются самой ссылкой, из запроса берется только сортировка
+	query, errMsg := parseFavoritesQuery(r)
+	if errMsg != "" {
+		WriteJSONError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	shared, err := h.sharedUC.Execute(r.Context(), chi.URLParam(r, "token"), query, limit, offset)
+	if err != nil {
+		writeFavoritesError(w, logger, "GetSharedFavorites", err)
+		return
+	}
+
+	// Получатель не должен видеть ссылку дольше, чем она действует
+	w.Header().Set("Cache-Control", "no-store")
+	RespondWithJSON(w, http.StatusOK, toSharedFavoritesResponse(shared))
+}
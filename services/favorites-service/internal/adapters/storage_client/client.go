@@ -52,8 +52,32 @@ func (c *StorageServiceAPIClient) doRequest(ctx context.Context, method, url str
 	return c.httpClient.Do(req)
 }
 
+// maxMasterIDsPerRequest - ограничение storage-service на один запрос best-by-master-ids
+const maxMasterIDsPerRequest = 100
+
 // GetBestObjectsByMasterIDs реализует порт ObjectStoragePort.
+// Большие списки (например, все избранное для сортировки по цене) запрашиваются частями
 func (c *StorageServiceAPIClient) GetBestObjectsByMasterIDs(ctx context.Context, masterIDs []uuid.UUID) ([]domain.ObjectCard, error) {
+	if len(masterIDs) <= maxMasterIDsPerRequest {
+		return c.getBestObjectsBatch(ctx, masterIDs)
+	}
+
+	result := make([]domain.ObjectCard, 0, len(masterIDs))
+	for start := 0; start < len(masterIDs); start += maxMasterIDsPerRequest {
+		end := start + maxMasterIDsPerRequest
+		if end > len(masterIDs) {
+			end = len(masterIDs)
+		}
+		batch, err := c.getBestObjectsBatch(ctx, masterIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, batch...)
+	}
+	return result, nil
+}
+
+func (c *StorageServiceAPIClient) getBestObjectsBatch(ctx context.Context, masterIDs []uuid.UUID) ([]domain.ObjectCard, error) {
 	logger := contextkeys.LoggerFromContext(ctx)
 	clientLogger := logger.WithFields(port.Fields{
 		"component":       "StorageServiceAPIClient",
@@ -115,11 +115,19 @@ func NewApp() (*App, error) {
 	removeFromFavoritesUseCase := usecase.NewRemoveFromFavoritesUseCase(postgresStorageAdapter)
 	getUserFavoritesUseCase := usecase.NewGetUserFavoritesUseCase(postgresStorageAdapter, storageClient)
 	getUserFavoritesIdsUseCase := usecase.NewGetUserFavoritesIdsUseCase(postgresStorageAdapter)
+	updateFavoriteUseCase := usecase.NewUpdateFavoriteUseCase(postgresStorageAdapter)
+	favoriteCollectionsUseCase := usecase.NewFavoriteCollectionsUseCase(postgresStorageAdapter)
+	shareLinksUseCase := usecase.NewShareLinksUseCase(postgresStorageAdapter)
+	getSharedFavoritesUseCase := usecase.NewGetSharedFavoritesUseCase(postgresStorageAdapter, getUserFavoritesUseCase)
+	listingMarksUseCase := usecase.NewListingMarksUseCase(postgresStorageAdapter)
 	appLogger.Debug("REST API server configured.", nil)
 
 	// REST API Server
-	apiHandlers := rest.NewFavoritesHandler(addToFavoritesUseCase, removeFromFavoritesUseCase, getUserFavoritesUseCase, getUserFavoritesIdsUseCase)
-	apiServer := rest.NewServer(appConfig.Rest.PORT, apiHandlers, baseLogger)
+	apiHandlers := rest.NewFavoritesHandler(addToFavoritesUseCase, removeFromFavoritesUseCase, getUserFavoritesUseCase, getUserFavoritesIdsUseCase, updateFavoriteUseCase)
+	collectionHandlers := rest.NewCollectionsHandler(favoriteCollectionsUseCase)
+	shareHandlers := rest.NewShareLinksHandler(shareLinksUseCase, getSharedFavoritesUseCase)
+	marksHandlers := rest.NewListingMarksHandler(listingMarksUseCase)
+	apiServer := rest.NewServer(appConfig.Rest.PORT, apiHandlers, collectionHandlers, shareHandlers, marksHandlers, baseLogger)
 
 	// 5. Собираем приложение
 	application := &App{
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения на подборки, заметки и теги
+const (
+	MaxCollectionsPerUser   = 50
+	MaxCollectionNameLength = 100
+	MaxNoteLength           = 2000
+	MaxTagsPerFavorite      = 20
+	MaxTagLength            = 50
+)
+
+// FavoriteCollection - именованная подборка избранного пользователя
+type FavoriteCollection struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	ItemsCount int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+var (
+	ErrFavoriteNotFound    = errors.New("favorite not found")
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrCollectionNameTaken = errors.New("collection with this name already exists")
+	ErrCollectionsLimit    = errors.New("too many collections")
+	ErrInvalidFavoriteData = errors.New("invalid favorite data")
+	ErrPriceSortTooLarge   = errors.New("too many favorites to sort by price")
+)
+
+var (
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// ErrShareLinkInactive - ссылка отозвана владельцем или истекла
+	ErrShareLinkInactive = errors.New("share link is revoked or expired")
+	ErrShareLinksLimit   = errors.New("too many active share links")
+	ErrInvalidShareLink  = errors.New("invalid share link parameters")
+)
+
+
+var (
+	ErrInvalidListingMark = errors.New("invalid listing mark")
+	ErrListingMarksLimit  = errors.New("too many marked listings")
+)
@@ -11,12 +11,39 @@ type FavoriteItem struct {
 	UserID    uuid.UUID
 	MasterObjectID  uuid.UUID
 	CreatedAt time.Time
+	Note          string
+	Tags          []string
+	CollectionIDs []uuid.UUID // подборки, в которых лежит объект
 }
 
-// PaginatedFavoriteIDs - структура для ответа с пагинацией от репозитория.
-type PaginatedFavoriteIDs struct {
-	MasterObjectIDs []uuid.UUID
-	TotalCount      int64 // Используем int64 для COUNT(*)
-	CurrentPage  int
-    ItemsPerPage int
-}
\ No newline at end of file
+// PaginatedFavorites - страница записей избранного вместе с заметками, тегами и подборками
+type PaginatedFavorites struct {
+	Items      []FavoriteItem
+	TotalCount int64
+}
+
+// FavoritesSortField - порядок списка избранного
+type FavoritesSortField string
+
+const (
+	FavoritesSortAddedAt FavoritesSortField = "added_at"
+	FavoritesSortPrice   FavoritesSortField = "price" // цена берется из storage-service
+)
+
+// MaxPriceSortedFavorites - цены хранятся в storage-service и список сортируется в памяти,
+// поэтому по цене сортируются только списки не длиннее этого (можно сузить подборкой или тегом)
+const MaxPriceSortedFavorites = 500
+
+// FavoritesQuery - фильтры и сортировка списка избранного
+type FavoritesQuery struct {
+	CollectionID *uuid.UUID // только объекты из подборки
+	Tag          string     // только объекты с тегом
+	Sort         FavoritesSortField
+	Desc         bool
+}
+
+// FavoriteUpdate - изменение заметки и тегов. nil - поле не меняется
+type FavoriteUpdate struct {
+	Note *string
+	Tags *[]string
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "fmt"
+
+// ListingMark - отметка объекта выдачи для пользователя
+type ListingMark string
+
+const (
+	// ListingMarkSeen - объект уже просмотрен. Старые отметки вытесняются новыми сверх MaxSeenMarksPerUser
+	ListingMarkSeen ListingMark = "seen"
+	// ListingMarkHidden - объект скрыт пользователем и не должен попадаться в выдаче
+	ListingMarkHidden ListingMark = "hidden"
+)
+
+// Ограничения на отметки. Вместе они не превышают лимит исключений в поиске storage-service
+const (
+	MaxSeenMarksPerUser   = 10000
+	MaxHiddenMarksPerUser = 5000
+	MaxMarksPerRequest    = 500
+)
+
+// ParseListingMark проверяет тип отметки из URL
+func ParseListingMark(s string) (ListingMark, error) {
+	switch mark := ListingMark(s); mark {
+	case ListingMarkSeen, ListingMarkHidden:
+		return mark, nil
+	default:
+		return "", fmt.Errorf("%w: unknown mark %q", ErrInvalidListingMark, s)
+	}
+}
+
+// Limit - максимум отметок этого типа у одного пользователя
+func (m ListingMark) Limit() int {
+	if m == ListingMarkSeen {
+		return MaxSeenMarksPerUser
+	}
+	return MaxHiddenMarksPerUser
+}
+
+// EvictsOldest - при превышении лимита удаляются самые старые отметки вместо ошибки.
+// Скрытые так не теряются: объект внезапно вернулся бы в выдачу
+func (m ListingMark) EvictsOldest() bool {
+	return m == ListingMarkSeen
+}
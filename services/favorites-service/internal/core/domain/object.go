@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // ObjectCard - это представление объекта, которое мы получаем от storage-service.
 // Это "доменное" представление внешних данных.
 type ObjectCard struct {
@@ -15,9 +21,18 @@ type ObjectCard struct {
 	DealType       string
 }
 
+// FavoriteCard - карточка объекта вместе с данными избранного пользователя
+type FavoriteCard struct {
+	ObjectCard
+	AddedAt       time.Time
+	Note          string
+	Tags          []string
+	CollectionIDs []uuid.UUID
+}
+
 // PaginatedObjectsResult - структура для финального ответа use case.
 type PaginatedObjectsResult struct {
-	Objects      []ObjectCard
+	Objects      []FavoriteCard
 	TotalCount   int64
 	CurrentPage  int
 	ItemsPerPage int
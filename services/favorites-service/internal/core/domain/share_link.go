@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения на публичные ссылки. Бессрочных ссылок нет: без явного срока действует DefaultShareLinkTTL
+const (
+	MaxActiveShareLinksPerUser = 20
+	DefaultShareLinkTTL        = 7 * 24 * time.Hour
+	MaxShareLinkTTL            = 90 * 24 * time.Hour
+)
+
+// ShareLink - публичная ссылка только для чтения на подборку (CollectionID != nil) или на все избранное
+type ShareLink struct {
+	ID             uuid.UUID
+	Token          string
+	UserID         uuid.UUID
+	CollectionID   *uuid.UUID
+	CollectionName string // заполняется при чтении, если ссылка на подборку
+	ExpiresAt      time.Time
+	RevokedAt      *time.Time
+	ViewsCount     int64
+	LastViewedAt   *time.Time
+	CreatedAt      time.Time
+}
+
+// IsActive - ссылка не отозвана и не истекла
+func (l *ShareLink) IsActive(now time.Time) bool {
+	if l.RevokedAt != nil {
+		return false
+	}
+	return now.Before(l.ExpiresAt)
+}
+
+// SharedFavorites - то, что видит получатель ссылки: карточки объектов без личных заметок и тегов владельца
+type SharedFavorites struct {
+	Link    ShareLink
+	Objects *PaginatedObjectsResult
+}
@@ -11,6 +11,49 @@ import (
 type FavoritesRepositoryPort interface {
 	Add(ctx context.Context, userID, masterObjectID uuid.UUID) error
 	Remove(ctx context.Context, userID, masterObjectID uuid.UUID) error
-	FindPaginatedByUser(ctx context.Context, userID uuid.UUID, limit, offset int) (*domain.PaginatedFavoriteIDs, error)
+	// FindByUser - записи избранного по фильтрам запроса, отсортированные по дате добавления.
+	// limit <= 0 - без пагинации
+	FindByUser(ctx context.Context, userID uuid.UUID, query domain.FavoritesQuery, limit, offset int) (*domain.PaginatedFavorites, error)
 	FindFavoritesIdsByUser(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
-}
\ No newline at end of file
+	// UpdateDetails меняет заметку и теги. Возвращает ErrFavoriteNotFound, если объекта нет в избранном
+	UpdateDetails(ctx context.Context, userID, masterObjectID uuid.UUID, update domain.FavoriteUpdate) (*domain.FavoriteItem, error)
+}
+
+// CollectionsRepositoryPort - подборки избранного. Все методы проверяют, что подборка принадлежит пользователю,
+// и возвращают ErrCollectionNotFound для чужих и несуществующих подборок
+type CollectionsRepositoryPort interface {
+	ListCollections(ctx context.Context, userID uuid.UUID) ([]domain.FavoriteCollection, error)
+	CreateCollection(ctx context.Context, collection domain.FavoriteCollection) (*domain.FavoriteCollection, error)
+	RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) (*domain.FavoriteCollection, error)
+	DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) error
+	// AddToCollection кладет объекты в подборку, при необходимости добавляя их в избранное
+	AddToCollection(ctx context.Context, userID, collectionID uuid.UUID, masterObjectIDs []uuid.UUID) error
+	RemoveFromCollection(ctx context.Context, userID, collectionID, masterObjectID uuid.UUID) error
+	// TransferItems копирует (move = false) или переносит объекты из одной подборки в другую.
+	// Возвращает число объектов, попавших в целевую подборку
+	TransferItems(ctx context.Context, userID, fromID, toID uuid.UUID, masterObjectIDs []uuid.UUID, move bool) (int, error)
+}
+
+// ShareLinksRepositoryPort - публичные ссылки на избранное
+type ShareLinksRepositoryPort interface {
+	// CreateShareLink возвращает ErrCollectionNotFound, если подборка не принадлежит пользователю
+	CreateShareLink(ctx context.Context, link domain.ShareLink) (*domain.ShareLink, error)
+	ListShareLinks(ctx context.Context, userID uuid.UUID) ([]domain.ShareLink, error)
+	CountActiveShareLinks(ctx context.Context, userID uuid.UUID) (int, error)
+	// RevokeShareLink возвращает ErrShareLinkNotFound для чужих и несуществующих ссылок
+	RevokeShareLink(ctx context.Context, userID, linkID uuid.UUID) error
+	// FindShareLinkByToken возвращает ссылку в любом состоянии, активность проверяет вызывающий
+	FindShareLinkByToken(ctx context.Context, token string) (*domain.ShareLink, error)
+	RegisterShareLinkView(ctx context.Context, linkID uuid.UUID) error
+}
+
+// ListingMarksRepositoryPort - отметки "просмотрено" и "скрыто" на объектах выдачи
+type ListingMarksRepositoryPort interface {
+	// AddListingMarks ставит отметки (повторная обновляет время) с учетом mark.Limit():
+	// вытесняет самые старые или возвращает ErrListingMarksLimit, см. ListingMark.EvictsOldest
+	AddListingMarks(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, masterObjectIDs []uuid.UUID) error
+	// RemoveListingMarks снимает отметки с объектов, при пустом masterObjectIDs - все отметки этого типа.
+	// Возвращает число снятых
+	RemoveListingMarks(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, masterObjectIDs []uuid.UUID) (int64, error)
+	FindListingMarkIDs(ctx context.Context, userID uuid.UUID, mark domain.ListingMark) ([]uuid.UUID, error)
+}
@@ -0,0 +1,19 @@
+package usecases_port
+
+import (
+	"context"
+	"favorites-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteCollectionsUseCasePort interface {
+	List(ctx context.Context, userID uuid.UUID) ([]domain.FavoriteCollection, error)
+	Create(ctx context.Context, userID uuid.UUID, name string) (*domain.FavoriteCollection, error)
+	Rename(ctx context.Context, userID, collectionID uuid.UUID, name string) (*domain.FavoriteCollection, error)
+	Delete(ctx context.Context, userID, collectionID uuid.UUID) error
+	AddItems(ctx context.Context, userID, collectionID uuid.UUID, objectIDs []uuid.UUID) error
+	RemoveItem(ctx context.Context, userID, collectionID, objectID uuid.UUID) error
+	// TransferItems копирует или переносит объекты в другую подборку
+	TransferItems(ctx context.Context, userID, fromID, toID uuid.UUID, objectIDs []uuid.UUID, move bool) (int, error)
+}
@@ -8,6 +8,6 @@ import (
 )
 
 type GetUserFavoritesUseCasePort interface {
-	// Возвращает срез ID объектов
-	Execute(ctx context.Context, userID uuid.UUID, limit, offset int) (*domain.PaginatedObjectsResult, error)
+	// Возвращает карточки избранных объектов с заметками и тегами
+	Execute(ctx context.Context, userID uuid.UUID, query domain.FavoritesQuery, limit, offset int) (*domain.PaginatedObjectsResult, error)
 }
\ No newline at end of file
@@ -0,0 +1,17 @@
+package usecases_port
+
+import (
+	"context"
+	"favorites-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ListingMarksUseCasePort - отметки "просмотрено" и "скрыто" на объектах выдачи
+type ListingMarksUseCasePort interface {
+	Mark(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, objectIDs []uuid.UUID) error
+	Unmark(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, objectIDs []uuid.UUID) (int64, error)
+	// Clear снимает все отметки этого типа
+	Clear(ctx context.Context, userID uuid.UUID, mark domain.ListingMark) (int64, error)
+	ListIDs(ctx context.Context, userID uuid.UUID, mark domain.ListingMark) ([]uuid.UUID, error)
+}
@@ -0,0 +1,22 @@
+package usecases_port
+
+import (
+	"context"
+	"favorites-service/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLinksUseCasePort - управление публичными ссылками владельцем избранного
+type ShareLinksUseCasePort interface {
+	// Create создает ссылку на подборку (collectionID != nil) или на все избранное. ttl = 0 - срок по умолчанию
+	Create(ctx context.Context, userID uuid.UUID, collectionID *uuid.UUID, ttl time.Duration) (*domain.ShareLink, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.ShareLink, error)
+	Revoke(ctx context.Context, userID, linkID uuid.UUID) error
+}
+
+// GetSharedFavoritesUseCasePort - просмотр избранного по публичной ссылке без аутентификации
+type GetSharedFavoritesUseCasePort interface {
+	Execute(ctx context.Context, token string, query domain.FavoritesQuery, limit, offset int) (*domain.SharedFavorites, error)
+}
@@ -0,0 +1,12 @@
+package usecases_port
+
+import (
+	"context"
+	"favorites-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type UpdateFavoriteUseCasePort interface {
+	Execute(ctx context.Context, userID, objectID uuid.UUID, update domain.FavoriteUpdate) (*domain.FavoriteItem, error)
+}
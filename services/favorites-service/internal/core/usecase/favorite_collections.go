@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// FavoriteCollectionsUseCase - управление подборками избранного и их содержимым
+type FavoriteCollectionsUseCase struct {
+	repo port.CollectionsRepositoryPort
+}
+
+func NewFavoriteCollectionsUseCase(repo port.CollectionsRepositoryPort) *FavoriteCollectionsUseCase {
+	return &FavoriteCollectionsUseCase{repo: repo}
+}
+
+func (uc *FavoriteCollectionsUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.FavoriteCollection, error) {
+	collections, err := uc.repo.ListCollections(ctx, userID)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to list collections", err, port.Fields{"use_case": "ListCollections", "user_id": userID})
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (uc *FavoriteCollectionsUseCase) Create(ctx context.Context, userID uuid.UUID, name string) (*domain.FavoriteCollection, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "CreateCollection", "user_id": userID})
+
+	name, err := validateCollectionName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.ListCollections(ctx, userID)
+	if err != nil {
+		ucLogger.Error("Failed to count user collections", err, nil)
+		return nil, err
+	}
+	if len(existing) >= domain.MaxCollectionsPerUser {
+		return nil, fmt.Errorf("%w: at most %d collections per user", domain.ErrCollectionsLimit, domain.MaxCollectionsPerUser)
+	}
+
+	now := time.Now().UTC()
+	collection, err := uc.repo.CreateCollection(ctx, domain.FavoriteCollection{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		ucLogger.Error("Repository returned an error", err, nil)
+		return nil, err
+	}
+
+	ucLogger.Info("Collection created", port.Fields{"collection_id": collection.ID})
+	return collection, nil
+}
+
+func (uc *FavoriteCollectionsUseCase) Rename(ctx context.Context, userID, collectionID uuid.UUID, name string) (*domain.FavoriteCollection, error) {
+	name, err := validateCollectionName(name)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := uc.repo.RenameCollection(ctx, userID, collectionID, name)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to rename collection", err, port.Fields{
+			"use_case": "RenameCollection", "user_id": userID, "collection_id": collectionID,
+		})
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Delete удаляет подборку. Объекты остаются в избранном
+func (uc *FavoriteCollectionsUseCase) Delete(ctx context.Context, userID, collectionID uuid.UUID) error {
+	if err := uc.repo.DeleteCollection(ctx, userID, collectionID); err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to delete collection", err, port.Fields{
+			"use_case": "DeleteCollection", "user_id": userID, "collection_id": collectionID,
+		})
+		return err
+	}
+	return nil
+}
+
+func (uc *FavoriteCollectionsUseCase) AddItems(ctx context.Context, userID, collectionID uuid.UUID, objectIDs []uuid.UUID) error {
+	if len(objectIDs) == 0 {
+		return fmt.Errorf("%w: no objects given", domain.ErrInvalidFavoriteData)
+	}
+	if err := uc.repo.AddToCollection(ctx, userID, collectionID, objectIDs); err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to add objects to collection", err, port.Fields{
+			"use_case": "AddToCollection", "user_id": userID, "collection_id": collectionID, "objects_count": len(objectIDs),
+		})
+		return err
+	}
+	return nil
+}
+
+// RemoveItem убирает объект из подборки, но не из избранного
+func (uc *FavoriteCollectionsUseCase) RemoveItem(ctx context.Context, userID, collectionID, objectID uuid.UUID) error {
+	if err := uc.repo.RemoveFromCollection(ctx, userID, collectionID, objectID); err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to remove object from collection", err, port.Fields{
+			"use_case": "RemoveFromCollection", "user_id": userID, "collection_id": collectionID, "master_object_id": objectID,
+		})
+		return err
+	}
+	return nil
+}
+
+func (uc *FavoriteCollectionsUseCase) TransferItems(ctx context.Context, userID, fromID, toID uuid.UUID, objectIDs []uuid.UUID, move bool) (int, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":      "TransferCollectionItems",
+		"user_id":       userID,
+		"from":          fromID,
+		"to":            toID,
+		"move":          move,
+		"objects_count": len(objectIDs),
+	})
+
+	if len(objectIDs) == 0 {
+		return 0, fmt.Errorf("%w: no objects given", domain.ErrInvalidFavoriteData)
+	}
+	if fromID == toID {
+		return 0, fmt.Errorf("%w: source and target collections are the same", domain.ErrInvalidFavoriteData)
+	}
+
+	transferred, err := uc.repo.TransferItems(ctx, userID, fromID, toID, objectIDs, move)
+	if err != nil {
+		ucLogger.Error("Repository returned an error", err, nil)
+		return 0, err
+	}
+
+	ucLogger.Info("Collection items transferred", port.Fields{"transferred": transferred})
+	return transferred, nil
+}
+
+func validateCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: collection name is required", domain.ErrInvalidFavoriteData)
+	}
+	if utf8.RuneCountInString(name) > domain.MaxCollectionNameLength {
+		return "", fmt.Errorf("%w: collection name is longer than %d characters", domain.ErrInvalidFavoriteData, domain.MaxCollectionNameLength)
+	}
+	return name, nil
+}
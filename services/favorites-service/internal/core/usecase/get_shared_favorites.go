@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"favorites-service/internal/core/port/usecases_port"
+	"time"
+)
+
+// maxShareTokenLength совпадает с размером колонки token
+const maxShareTokenLength = 64
+
+// GetSharedFavoritesUseCase отдает избранное по публичной ссылке. Карточки строятся тем же
+// GetUserFavoritesUseCase, что и для владельца, но без его заметок, тегов и списка подборок
+type GetSharedFavoritesUseCase struct {
+	linksRepo   port.ShareLinksRepositoryPort
+	favoritesUC usecases_port.GetUserFavoritesUseCasePort
+}
+
+func NewGetSharedFavoritesUseCase(linksRepo port.ShareLinksRepositoryPort, favoritesUC usecases_port.GetUserFavoritesUseCasePort) *GetSharedFavoritesUseCase {
+	return &GetSharedFavoritesUseCase{linksRepo: linksRepo, favoritesUC: favoritesUC}
+}
+
+func (uc *GetSharedFavoritesUseCase) Execute(ctx context.Context, token string, query domain.FavoritesQuery, limit, offset int) (*domain.SharedFavorites, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "GetSharedFavorites"})
+
+	if token == "" || len(token) > maxShareTokenLength {
+		return nil, domain.ErrShareLinkNotFound
+	}
+
+	link, err := uc.linksRepo.FindShareLinkByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	ucLogger = ucLogger.WithFields(port.Fields{"link_id": link.ID, "owner_id": link.UserID})
+
+	if !link.IsActive(time.Now()) {
+		ucLogger.Info("Share link is revoked or expired", nil)
+		return nil, domain.ErrShareLinkInactive
+	}
+
+	// Теги - личные данные владельца, фильтровать по ним получатель не может
+	query.CollectionID = link.CollectionID
+	query.Tag = ""
+
+	result, err := uc.favoritesUC.Execute(ctx, link.UserID, query, limit, offset)
+	if err != nil {
+		ucLogger.Error("Failed to build shared favorites", err, nil)
+		return nil, err
+	}
+
+	for i := range result.Objects {
+		result.Objects[i].Note = ""
+		result.Objects[i].Tags = nil
+		result.Objects[i].CollectionIDs = nil
+	}
+
+	// Счетчик просмотров не должен ломать сам просмотр
+	if err := uc.linksRepo.RegisterShareLinkView(ctx, link.ID); err != nil {
+		ucLogger.Warn("Failed to register share link view", port.Fields{"error": err.Error()})
+	}
+
+	return &domain.SharedFavorites{Link: *link, Objects: result}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"favorites-service/internal/core/domain"
 	"favorites-service/internal/core/port"
 	"fmt"
+	"sort"
 
 	"github.com/google/uuid"
 )
@@ -25,74 +26,130 @@ func NewGetUserFavoritesUseCase(
 	}
 }
 
-func (uc *GetUserFavoritesUseCase) Execute(ctx context.Context, userID uuid.UUID, limit, offset int) (*domain.PaginatedObjectsResult, error) {
+func (uc *GetUserFavoritesUseCase) Execute(ctx context.Context, userID uuid.UUID, query domain.FavoritesQuery, limit, offset int) (*domain.PaginatedObjectsResult, error) {
 	logger := contextkeys.LoggerFromContext(ctx)
 	ucLogger := logger.WithFields(port.Fields{
 		"use_case": "GetUserFavorites",
 		"user_id":  userID,
 		"limit":    limit,
 		"offset":   offset,
+		"sort":     query.Sort,
 	})
 
 	ucLogger.Info("Use case started", nil)
-	
-	// Получаем пагинированный список ID из нашего хранилища
-	paginatedIDs, err := uc.favoritesRepo.FindPaginatedByUser(ctx, userID, limit, offset)
+
+	// Цены хранятся в storage-service, поэтому для сортировки по цене нужны все записи сразу,
+	// а страница вырезается уже после сортировки. Длинные списки так не сортируем, см. MaxPriceSortedFavorites
+	byPrice := query.Sort == domain.FavoritesSortPrice
+	repoLimit, repoOffset := limit, offset
+	if byPrice {
+		repoLimit, repoOffset = domain.MaxPriceSortedFavorites, 0
+	}
+
+	// Получаем записи избранного из нашего хранилища
+	favorites, err := uc.favoritesRepo.FindByUser(ctx, userID, query, repoLimit, repoOffset)
 	if err != nil {
-		ucLogger.Error("Failed to get favorite IDs from repository", err, nil)
+		ucLogger.Error("Failed to get favorites from repository", err, nil)
 		return nil, fmt.Errorf("failed to get favorite IDs: %w", err)
 	}
 
-	if len(paginatedIDs.MasterObjectIDs) == 0 {
+	if byPrice && favorites.TotalCount > domain.MaxPriceSortedFavorites {
+		ucLogger.Warn("Favorites list is too large to sort by price", port.Fields{"total_count": favorites.TotalCount})
+		return nil, fmt.Errorf("%w: at most %d, narrow the list with collection_id or tag",
+			domain.ErrPriceSortTooLarge, domain.MaxPriceSortedFavorites)
+	}
+
+	if len(favorites.Items) == 0 {
 		// У пользователя нет избранных
 		ucLogger.Info("No favorites on page", port.Fields{
 			"current_page": offset/limit + 1,
-			"total_count": paginatedIDs.TotalCount,
-		})	
+			"total_count":  favorites.TotalCount,
+		})
 		return &domain.PaginatedObjectsResult{
-			Objects:      []domain.ObjectCard{},
-			TotalCount:   paginatedIDs.TotalCount, 
+			Objects:      []domain.FavoriteCard{},
+			TotalCount:   favorites.TotalCount,
 			CurrentPage:  offset/limit + 1,
 			ItemsPerPage: limit,
 		}, nil
 	}
 
 	ucLogger.Info("User favorites found", port.Fields{
-		"total_favorites": paginatedIDs.TotalCount,
-		"ids_on_page":     len(paginatedIDs.MasterObjectIDs),
+		"total_favorites": favorites.TotalCount,
+		"ids_fetched":     len(favorites.Items),
 	})
 
-	// Шаг 2: Идем в storage-service, чтобы "обогатить" эти ID данными.
-	objects, err := uc.objectStorage.GetBestObjectsByMasterIDs(ctx, paginatedIDs.MasterObjectIDs)
+	ids := make([]uuid.UUID, len(favorites.Items))
+	for i, item := range favorites.Items {
+		ids[i] = item.MasterObjectID
+	}
+
+	// Идем в storage-service, чтобы "обогатить" эти ID данными.
+	objects, err := uc.objectStorage.GetBestObjectsByMasterIDs(ctx, ids)
 	if err != nil {
 		ucLogger.Error("Failed to get object details from storage service", err, nil)
 		return nil, fmt.Errorf("failed to get object details from storage: %w", err)
 	}
-    
-    // Сохраняем порядок
-    // storage-service не гарантирует порядок, а мы хотим показать избранное
-    // в том порядке, в котором пользователь его добавлял (или в обратном)
-    
-    objectMap := make(map[string]domain.ObjectCard, len(objects))
-    for _, obj := range objects {
-        objectMap[obj.MasterObjectID] = obj
-    }
-    
-    sortedObjects := make([]domain.ObjectCard, 0, len(paginatedIDs.MasterObjectIDs))
-    for _, id := range paginatedIDs.MasterObjectIDs {
-        if obj, ok := objectMap[id.String()]; ok {
-            sortedObjects = append(sortedObjects, obj)
-        }
-    }
+
+	// storage-service не гарантирует порядок, поэтому раскладываем карточки в порядке записей избранного
+	objectMap := make(map[string]domain.ObjectCard, len(objects))
+	for _, obj := range objects {
+		objectMap[obj.MasterObjectID] = obj
+	}
+
+	cards := make([]domain.FavoriteCard, 0, len(favorites.Items))
+	for _, item := range favorites.Items {
+		obj, ok := objectMap[item.MasterObjectID.String()]
+		if !ok {
+			continue
+		}
+		cards = append(cards, domain.FavoriteCard{
+			ObjectCard:    obj,
+			AddedAt:       item.CreatedAt,
+			Note:          item.Note,
+			Tags:          item.Tags,
+			CollectionIDs: item.CollectionIDs,
+		})
+	}
+
+	if byPrice {
+		sortCardsByPrice(cards, query.Desc)
+		cards = paginateCards(cards, limit, offset)
+	}
 
 	//Формируем финальный результат
 	result := &domain.PaginatedObjectsResult{
-		Objects:      sortedObjects,
-		TotalCount:   paginatedIDs.TotalCount,
+		Objects:      cards,
+		TotalCount:   favorites.TotalCount,
 		CurrentPage:  offset/limit + 1,
 		ItemsPerPage: limit,
 	}
 
 	ucLogger.Info("Use case finished successfully", nil)
 	return result, nil
-}
\ No newline at end of file
+}
+
+// sortCardsByPrice сортирует по цене в долларах. Объекты без цены всегда в конце,
+// при равной цене сохраняется порядок добавления
+func sortCardsByPrice(cards []domain.FavoriteCard, desc bool) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		pi, pj := cards[i].PriceUSD, cards[j].PriceUSD
+		if (pi > 0) != (pj > 0) {
+			return pi > 0
+		}
+		if desc {
+			return pi > pj
+		}
+		return pi < pj
+	})
+}
+
+func paginateCards(cards []domain.FavoriteCard, limit, offset int) []domain.FavoriteCard {
+	if offset >= len(cards) {
+		return []domain.FavoriteCard{}
+	}
+	end := offset + limit
+	if end > len(cards) {
+		end = len(cards)
+	}
+	return cards[offset:end]
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ListingMarksUseCase - отметки объектов выдачи, по которым api-gateway фильтрует поиск
+type ListingMarksUseCase struct {
+	repo port.ListingMarksRepositoryPort
+}
+
+func NewListingMarksUseCase(repo port.ListingMarksRepositoryPort) *ListingMarksUseCase {
+	return &ListingMarksUseCase{repo: repo}
+}
+
+func (uc *ListingMarksUseCase) Mark(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, objectIDs []uuid.UUID) error {
+	objectIDs, err := validateMarkedObjects(objectIDs)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.AddListingMarks(ctx, userID, mark, objectIDs); err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to mark listings", err, port.Fields{
+			"use_case": "MarkListings", "user_id": userID, "mark": mark, "objects_count": len(objectIDs),
+		})
+		return err
+	}
+	return nil
+}
+
+func (uc *ListingMarksUseCase) Unmark(ctx context.Context, userID uuid.UUID, mark domain.ListingMark, objectIDs []uuid.UUID) (int64, error) {
+	objectIDs, err := validateMarkedObjects(objectIDs)
+	if err != nil {
+		return 0, err
+	}
+	removed, err := uc.repo.RemoveListingMarks(ctx, userID, mark, objectIDs)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to unmark listings", err, port.Fields{
+			"use_case": "UnmarkListings", "user_id": userID, "mark": mark, "objects_count": len(objectIDs),
+		})
+		return 0, err
+	}
+	return removed, nil
+}
+
+func (uc *ListingMarksUseCase) Clear(ctx context.Context, userID uuid.UUID, mark domain.ListingMark) (int64, error) {
+	removed, err := uc.repo.RemoveListingMarks(ctx, userID, mark, nil)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to clear listing marks", err, port.Fields{
+			"use_case": "ClearListingMarks", "user_id": userID, "mark": mark,
+		})
+		return 0, err
+	}
+	contextkeys.LoggerFromContext(ctx).Info("Listing marks cleared", port.Fields{"user_id": userID, "mark": mark, "removed": removed})
+	return removed, nil
+}
+
+func (uc *ListingMarksUseCase) ListIDs(ctx context.Context, userID uuid.UUID, mark domain.ListingMark) ([]uuid.UUID, error) {
+	ids, err := uc.repo.FindListingMarkIDs(ctx, userID, mark)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to list marked listings", err, port.Fields{
+			"use_case": "ListListingMarks", "user_id": userID, "mark": mark,
+		})
+		return nil, err
+	}
+	return ids, nil
+}
+
+// validateMarkedObjects проверяет размер пачки и убирает повторы
+func validateMarkedObjects(objectIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(objectIDs) == 0 {
+		return nil, fmt.Errorf("%w: no objects given", domain.ErrInvalidListingMark)
+	}
+	if len(objectIDs) > domain.MaxMarksPerRequest {
+		return nil, fmt.Errorf("%w: at most %d objects per request", domain.ErrInvalidListingMark, domain.MaxMarksPerRequest)
+	}
+	seen := make(map[uuid.UUID]struct{}, len(objectIDs))
+	unique := make([]uuid.UUID, 0, len(objectIDs))
+	for _, id := range objectIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// shareTokenBytes - 256 бит случайности, в base64url это 43 символа
+const shareTokenBytes = 32
+
+// ShareLinksUseCase - создание, просмотр и отзыв публичных ссылок владельцем
+type ShareLinksUseCase struct {
+	repo port.ShareLinksRepositoryPort
+}
+
+func NewShareLinksUseCase(repo port.ShareLinksRepositoryPort) *ShareLinksUseCase {
+	return &ShareLinksUseCase{repo: repo}
+}
+
+func (uc *ShareLinksUseCase) Create(ctx context.Context, userID uuid.UUID, collectionID *uuid.UUID, ttl time.Duration) (*domain.ShareLink, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "CreateShareLink", "user_id": userID, "collection_id": collectionID})
+
+	if ttl == 0 {
+		ttl = domain.DefaultShareLinkTTL
+	}
+	if ttl < 0 || ttl > domain.MaxShareLinkTTL {
+		return nil, fmt.Errorf("%w: lifetime must be between 1 hour and %d days", domain.ErrInvalidShareLink, int(domain.MaxShareLinkTTL.Hours()/24))
+	}
+
+	active, err := uc.repo.CountActiveShareLinks(ctx, userID)
+	if err != nil {
+		ucLogger.Error("Failed to count active share links", err, nil)
+		return nil, err
+	}
+	if active >= domain.MaxActiveShareLinksPerUser {
+		return nil, fmt.Errorf("%w: at most %d active links per user", domain.ErrShareLinksLimit, domain.MaxActiveShareLinksPerUser)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		ucLogger.Error("Failed to generate share token", err, nil)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	link, err := uc.repo.CreateShareLink(ctx, domain.ShareLink{
+		ID:           uuid.New(),
+		Token:        token,
+		UserID:       userID,
+		CollectionID: collectionID,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		ucLogger.Error("Repository returned an error", err, nil)
+		return nil, err
+	}
+
+	// Сам токен в лог не пишем: по нему открывается избранное пользователя
+	ucLogger.Info("Share link created", port.Fields{"link_id": link.ID, "expires_at": link.ExpiresAt})
+	return link, nil
+}
+
+func (uc *ShareLinksUseCase) List(ctx context.Context, userID uuid.UUID) ([]domain.ShareLink, error) {
+	links, err := uc.repo.ListShareLinks(ctx, userID)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to list share links", err, port.Fields{"use_case": "ListShareLinks", "user_id": userID})
+		return nil, err
+	}
+	return links, nil
+}
+
+func (uc *ShareLinksUseCase) Revoke(ctx context.Context, userID, linkID uuid.UUID) error {
+	ucLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{"use_case": "RevokeShareLink", "user_id": userID, "link_id": linkID})
+	if err := uc.repo.RevokeShareLink(ctx, userID, linkID); err != nil {
+		ucLogger.Warn("Failed to revoke share link", port.Fields{"error": err.Error()})
+		return err
+	}
+	ucLogger.Info("Share link revoked", nil)
+	return nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
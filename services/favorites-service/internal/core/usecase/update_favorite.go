@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"favorites-service/internal/contextkeys"
+	"favorites-service/internal/core/domain"
+	"favorites-service/internal/core/port"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type UpdateFavoriteUseCase struct {
+	repo port.FavoritesRepositoryPort
+}
+
+func NewUpdateFavoriteUseCase(repo port.FavoritesRepositoryPort) *UpdateFavoriteUseCase {
+	return &UpdateFavoriteUseCase{repo: repo}
+}
+
+func (uc *UpdateFavoriteUseCase) Execute(ctx context.Context, userID, objectID uuid.UUID, update domain.FavoriteUpdate) (*domain.FavoriteItem, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":         "UpdateFavorite",
+		"user_id":          userID,
+		"master_object_id": objectID,
+	})
+
+	ucLogger.Info("Use case started", nil)
+
+	if update.Note != nil {
+		note := strings.TrimSpace(*update.Note)
+		if utf8.RuneCountInString(note) > domain.MaxNoteLength {
+			return nil, fmt.Errorf("%w: note is longer than %d characters", domain.ErrInvalidFavoriteData, domain.MaxNoteLength)
+		}
+		update.Note = &note
+	}
+	if update.Tags != nil {
+		tags, err := normalizeTags(*update.Tags)
+		if err != nil {
+			return nil, err
+		}
+		update.Tags = &tags
+	}
+
+	item, err := uc.repo.UpdateDetails(ctx, userID, objectID, update)
+	if err != nil {
+		ucLogger.Error("Repository returned an error", err, nil)
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", nil)
+	return item, nil
+}
+
+// normalizeTags приводит теги к нижнему регистру, убирает пустые и повторы
+func normalizeTags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > domain.MaxTagLength {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", domain.ErrInvalidFavoriteData, tag, domain.MaxTagLength)
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	if len(tags) > domain.MaxTagsPerFavorite {
+		return nil, fmt.Errorf("%w: more than %d tags", domain.ErrInvalidFavoriteData, domain.MaxTagsPerFavorite)
+	}
+	return tags, nil
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS favorite_collection_items;
+DROP TABLE IF EXISTS favorite_collections;
+DROP INDEX IF EXISTS idx_user_favorites_tags;
+ALTER TABLE user_favorites
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS note;
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- Личная заметка и теги к каждому избранному объекту
+ALTER TABLE user_favorites
+    ADD COLUMN note TEXT,
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}'::TEXT[],
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_user_favorites_tags ON user_favorites USING GIN (tags);
+
+-- Именованные подборки избранного ("Для родителей", "Инвестиция" и т.п.)
+CREATE TABLE favorite_collections (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Имена подборок уникальны в пределах пользователя без учета регистра
+CREATE UNIQUE INDEX idx_favorite_collections_user_name ON favorite_collections (user_id, LOWER(name));
+
+-- Объект может лежать в нескольких подборках. В подборку попадают только объекты из избранного:
+-- удаление из избранного убирает объект и из всех подборок
+CREATE TABLE favorite_collection_items (
+    collection_id UUID NOT NULL REFERENCES favorite_collections (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    master_object_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, master_object_id),
+    FOREIGN KEY (user_id, master_object_id) REFERENCES user_favorites (user_id, master_object_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_favorite_collection_items_user_object ON favorite_collection_items (user_id, master_object_id);
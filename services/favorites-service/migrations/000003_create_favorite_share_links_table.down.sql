@@ -0,0 +1 @@
+DROP TABLE IF EXISTS favorite_share_links;
\ No newline at end of file
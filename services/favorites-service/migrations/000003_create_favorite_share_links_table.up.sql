@@ -0,0 +1,15 @@
+-- Публичные ссылки только для чтения на подборку или на все избранное пользователя.
+-- collection_id = NULL - ссылка на все избранное
+CREATE TABLE favorite_share_links (
+    id UUID PRIMARY KEY,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    user_id UUID NOT NULL,
+    collection_id UUID REFERENCES favorite_collections (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    views_count BIGINT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_favorite_share_links_user ON favorite_share_links (user_id, created_at DESC);
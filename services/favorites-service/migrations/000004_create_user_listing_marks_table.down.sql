@@ -0,0 +1 @@
+DROP TABLE IF EXISTS user_listing_marks;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- Отметки объектов выдачи для пользователя: "просмотрено" (seen) и "скрыто" (hidden).
+-- По ним api-gateway убирает объекты из поиска (параметры only_unseen и exclude_hidden)
+CREATE TABLE user_listing_marks (
+    user_id UUID NOT NULL,
+    mark VARCHAR(16) NOT NULL CHECK (mark IN ('seen', 'hidden')),
+    master_object_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, mark, master_object_id)
+);
+
+-- Для вытеснения самых старых просмотренных при превышении лимита
+CREATE INDEX idx_user_listing_marks_created ON user_listing_marks (user_id, mark, created_at);
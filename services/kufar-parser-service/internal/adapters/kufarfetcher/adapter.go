@@ -1,49 +1,122 @@
 package kufarfetcher
 
 import (
+	"context"
 	"fmt"
-	// "log"
-	"time"
+	"kufar-parser-service/internal/core/domain"
+	"kufar-parser-service/internal/core/port"
+	"net/http"
+	"net/url"
+	"real-estate-system/pkg/fetch_throttle"
 
 	"github.com/gocolly/colly/v2"
 	"github.com/gocolly/colly/v2/extensions"
 )
 
+// Options - настройки ограничителя запросов, прокси и User-Agent
+type Options struct {
+	Throttle   fetch_throttle.Config
+	Proxies    []string // пусто - запросы идут напрямую
+	UserAgents []string // пусто - случайный User-Agent реального браузера
+}
+
 // KufarFetcherAdapter отвечает за все взаимодействия с сайтом Kufar
 type KufarFetcherAdapter struct {
 	// родительский коллектор, который разделяет лимиты
 	collector *colly.Collector
 	baseURL   string
+
+	// адаптивная пауза и circuit breaker, общие для всех запросов к Kufar
+	guard  *fetch_throttle.Guard
+	logger port.LoggerPort
 }
 
 // NewKufarFetcherAdapter - конструктор
-func NewKufarFetcherAdapter(baseURL string) (*KufarFetcherAdapter, error) {
-	
+func NewKufarFetcherAdapter(baseURL string, opts Options, logger port.LoggerPort) (*KufarFetcherAdapter, error) {
 
 	// родительский коллектор
 	c := colly.NewCollector(colly.AllowedDomains("api.kufar.by"), colly.AllowURLRevisit())
 
-	// Эти правила будут наследоваться всеми клонами коллектора
+	// Эти правила будут наследоваться всеми клонами коллектора.
+	// Паузы между запросами выставляет guard, здесь только параллелизм
 	err := c.Limit(&colly.LimitRule{
 		// Правило будет применяться только к API домену Kufar
 		DomainGlob: "api.kufar.by",
 
 		// Параллелизм на уровне HTTP-запросов
 		Parallelism: 1,
-
-		// задержка от 0 до 3 секунд после завершения предыдущего
-		RandomDelay: 3 * time.Second,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("KufarFetcherAdapter: failed to set limit rule: %w", err)
 	}
 
-	extensions.RandomUserAgent(c) // На каждый запрос будет подставлен User-Agent реального браузера
-	extensions.Referer(c)         // Автоматически подставляет заголовок Referer, имитируя навигацию
-
+	if err := setupRotation(c, opts); err != nil {
+		return nil, fmt.Errorf("KufarFetcherAdapter: %w", err)
+	}
+	extensions.Referer(c) // Автоматически подставляет заголовок Referer, имитируя навигацию
 
-	return &KufarFetcherAdapter{
+	adapter := &KufarFetcherAdapter{
 		collector: c,
 		baseURL:   baseURL,
-	}, nil
-}
\ No newline at end of file
+		logger:    logger.WithFields(port.Fields{"component": "KufarFetcherAdapter(Throttle)"}),
+	}
+
+	throttleCfg := opts.Throttle
+	throttleCfg.OnStateChange = adapter.logStateChange
+	adapter.guard = fetch_throttle.New(throttleCfg)
+
+	return adapter, nil
+}
+
+// setupRotation включает ротацию прокси и User-Agent
+func setupRotation(c *colly.Collector, opts Options) error {
+	proxies := fetch_throttle.NewRotator(opts.Proxies)
+	if proxies.Len() > 0 {
+		for _, p := range opts.Proxies {
+			if _, err := url.Parse(p); err != nil {
+				return fmt.Errorf("invalid proxy URL '%s': %w", p, err)
+			}
+		}
+		// Без keep-alive каждое соединение открывается заново, иначе прокси не меняется между запросами
+		c.WithTransport(&http.Transport{DisableKeepAlives: true})
+		c.SetProxyFunc(func(r *http.Request) (*url.URL, error) {
+			return url.Parse(proxies.Next())
+		})
+	}
+
+	userAgents := fetch_throttle.NewRotator(opts.UserAgents)
+	if userAgents.Len() == 0 {
+		extensions.RandomUserAgent(c) // На каждый запрос будет подставлен User-Agent реального браузера
+		return nil
+	}
+	c.OnRequest(func(r *colly.Request) {
+		r.Headers.Set("User-Agent", userAgents.Next())
+	})
+	return nil
+}
+
+// Health реализует FetcherHealthPort
+func (a *KufarFetcherAdapter) Health() domain.FetcherHealth {
+	s := a.guard.Snapshot()
+	return domain.FetcherHealth{State: string(s.State), Delay: s.Delay, OpenUntil: s.OpenUntil}
+}
+
+// WaitAvailable реализует FetcherHealthPort
+func (a *KufarFetcherAdapter) WaitAvailable(ctx context.Context) error {
+	return a.guard.WaitClosed(ctx)
+}
+
+func (a *KufarFetcherAdapter) logStateChange(from, to fetch_throttle.State, s fetch_throttle.Snapshot) {
+	fields := port.Fields{
+		"from":     string(from),
+		"to":       string(to),
+		"delay_ms": s.Delay.Milliseconds(),
+		"trips":    s.Trips,
+	}
+	if to == fetch_throttle.StateOpen {
+		fields["open_until"] = s.OpenUntil
+		a.logger.Warn("Kufar is blocking requests, circuit breaker opened", fields)
+		return
+	}
+	a.logger.Info("Circuit breaker state changed", fields)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"kufar-parser-service/internal/contextkeys"
 	"kufar-parser-service/internal/core/domain"
@@ -31,23 +32,42 @@ func (a *KufarFetcherAdapter) FetchAdDetails(ctx context.Context, adID int64) (*
 
 	// OnResponse сработает, когда мы получим успешный ответ от API
 	collector.OnResponse(func(r *colly.Response) {
+		_ = a.afterResponse(ctx, r, nil)
 
 		if criticalError != nil || record != nil {
 			return
 		}
 
+		raw := &domain.RawPayload{
+			Source:      "kufar",
+			AdID:        adID,
+			URL:         r.Request.URL.String(),
+			ContentType: r.Headers.Get("Content-Type"),
+			FetchedAt:   time.Now(),
+			Body:        r.Body,
+		}
+
 		rec, err := toDomainRecord(r.Body, "kufar", fetchDetailsLogger)
 		if err != nil {
 			fetchDetailsLogger.Error("Failed to map response to domain record", err, port.Fields{"ad_id": adID})
-			criticalError = fmt.Errorf("FetchAdDetails: failed to map response to domain record: %w", err)
+			criticalError = &domain.MappingError{
+				Raw: raw,
+				Err: fmt.Errorf("FetchAdDetails: failed to map response to domain record: %w", err),
+			}
 			return
 		}
+		rec.Raw = raw
 		record = rec
 		
 	})
 
 	 // колбэк для ошибок, специфичных для этого запроса
 	collector.OnError(func(r *colly.Response, err error) {	
+		if throttledErr := a.afterResponse(ctx, r, err); throttledErr != nil {
+			fetchDetailsLogger.Warn("Kufar is throttling ad requests", port.Fields{"ad_id": adID, "status": r.StatusCode})
+			criticalError = throttledErr
+			return
+		}
 
 		// Если страница не найдена 404/410, это не ошибка парсинга, а информация о том, что объект надо архивировать
 		if r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusGone {
@@ -74,10 +94,15 @@ func (a *KufarFetcherAdapter) FetchAdDetails(ctx context.Context, adID int64) (*
 
 	// Формируем URL для API, используя adID
 	apiURL := fmt.Sprintf("https://api.kufar.by/search-api/v2/item/%d/rendered", adID)
-	_ = collector.Visit(apiURL)
-	// if visitErr != nil {
-	// 	return nil, fmt.Errorf("kufar adapter (Detail): failed to visit URL %s: %w", apiURL, visitErr)
-	// }
+	if err := a.beforeRequest(ctx); err != nil {
+		return nil, err
+	}
+	if visitErr := collector.Visit(apiURL); visitErr != nil {
+		// Запрос не ушел, колбэки не вызовутся: слот пробного запроса освобождаем сами
+		a.guard.ReleaseProbe()
+		fetchDetailsLogger.Error("Failed to initiate visit for ad details", visitErr, port.Fields{"ad_id": adID, "url": apiURL})
+		return nil, fmt.Errorf("kufar adapter (Detail): failed to visit URL %s: %w", apiURL, visitErr)
+	}
 	collector.Wait() // Ждем завершения HTTP запроса и выполнения парсинга
 
 	return record, criticalError
@@ -112,6 +112,7 @@ func (a *KufarFetcherAdapter) FetchLinks(ctx context.Context, criteria domain.Se
 	})
 
 	collector.OnResponse(func(r *colly.Response) {
+		_ = a.afterResponse(ctx, r, nil)
 		
 		// Десериализуем JSON из тела ответа
 		var data kufarListings
@@ -161,6 +162,14 @@ func (a *KufarFetcherAdapter) FetchLinks(ctx context.Context, criteria domain.Se
 	})
 
 	collector.OnError(func(r *colly.Response, err error) {
+		if throttledErr := a.afterResponse(ctx, r, err); throttledErr != nil {
+			fetchLinksLogger.Warn("Kufar is throttling link requests", port.Fields{
+				"status":         r.StatusCode,
+				"retry_after_ms": throttledErr.RetryAfter.Milliseconds(),
+			})
+			responseErr = throttledErr
+			return
+		}
 		fetchLinksLogger.Error("Failed to fetch links page", err, port.Fields{
 			"url":    r.Request.URL.String(),
 			"status": r.StatusCode,
@@ -169,8 +178,12 @@ func (a *KufarFetcherAdapter) FetchLinks(ctx context.Context, criteria domain.Se
 	})
 	
 
+	if err := a.beforeRequest(ctx); err != nil {
+		return nil, "", err
+	}
 	visitErr := collector.Visit(targetURL)
 	if visitErr != nil {
+		a.guard.ReleaseProbe()
 		fetchLinksLogger.Error("Failed to initiate visit for fetching links", visitErr, port.Fields{"url": targetURL})
 		return nil, "", fmt.Errorf("kufar adapter: failed to visit URL %s: %w", targetURL, visitErr)
 	}
@@ -45,6 +45,8 @@ type apiResponse struct {
 		Body    string `json:"body"`
 		Subject string `json:"subject"`
 
+		AccountID json.RawMessage `json:"account_id"` // приходит то строкой, то числом
+
 		CompanyAd bool       `json:"company_ad"`
 		Currency  string     `json:"currency"`
 		Images    []apiImage `json:"images"`
@@ -163,6 +165,10 @@ func toDomainRecord(jsonData []byte, source string, logger port.LoggerPort) (*do
 	general.Address, _ = accountParams["address"].ParamValue.(string)
 
 	general.SellerDetails = buildSellerDetails(accountParams)
+	// По account_id storage-service склеивает объявления одного продавца
+	if accountID := strings.Trim(string(resp.Result.AccountID), `"`); accountID != "" && accountID != "null" {
+		general.SellerDetails["account_id"] = accountID
+	}
 	
 	dailyRentType := getStringPtr(adParams["booking_building_type"].ParamValue)
 
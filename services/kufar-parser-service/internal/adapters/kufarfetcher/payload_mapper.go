@@ -0,0 +1,29 @@
+package kufarfetcher
+
+import (
+	"context"
+	"fmt"
+	"kufar-parser-service/internal/contextkeys"
+	"kufar-parser-service/internal/core/domain"
+	"kufar-parser-service/internal/core/port"
+)
+
+// PayloadMapper прогоняет текущий маппер по сохраненному ответу API, не обращаясь к Kufar
+type PayloadMapper struct{}
+
+func NewPayloadMapper() *PayloadMapper {
+	return &PayloadMapper{}
+}
+
+func (m *PayloadMapper) MapPayload(ctx context.Context, payload domain.RawPayload) (*domain.RealEstateRecord, error) {
+	logger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "KufarPayloadMapper",
+		"ad_id":     payload.AdID,
+	})
+
+	record, err := toDomainRecord(payload.Body, payload.Source, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map archived payload %d: %w", payload.AdID, err)
+	}
+	return record, nil
+}
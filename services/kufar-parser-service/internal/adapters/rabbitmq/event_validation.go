@@ -0,0 +1,23 @@
+package rabbitmq
+
+import (
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
+	"real-estate-system/schemas"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// validateEvent проверяет сообщение по схеме из реестра, если издатель указал тип события.
+// Сообщения без заголовков (от издателей, еще не перешедших на реестр схем) пропускаются без проверки
+func validateEvent(d amqp.Delivery) error {
+	eventType, _ := d.Headers[schemas.HeaderEventType].(string)
+	if eventType == "" {
+		return nil
+	}
+	eventVersion, _ := d.Headers[schemas.HeaderEventVersion].(string)
+	if err := schemas.Validate(eventType, eventVersion, d.Body); err != nil {
+		// Повтор не исправит содержимое сообщения
+		return rabbitmq_consumer.NewPermanentError(err)
+	}
+	return nil
+}
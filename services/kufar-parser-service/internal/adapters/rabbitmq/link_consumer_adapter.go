@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"kufar-parser-service/internal/contextkeys"
 	"kufar-parser-service/internal/core/domain"
@@ -51,7 +52,7 @@ func NewLinkConsumerAdapter(
 }
 
 // messageHandler - приватный метод адаптера
-func (a *LinkConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
+func (a *LinkConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) (err error) {
 	
 	traceID, ok := d.Headers["x-trace-id"].(string)
 	if !ok || traceID == "" {
@@ -65,16 +66,20 @@ func (a *LinkConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 	})
 
 	// логер и trace_id в контекст
-	ctx := context.Background()
 	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
 	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
 
 	msgLogger.Debug("Received new link task", nil)
 
+	if err := validateEvent(d); err != nil {
+		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
+		return err
+	}
+
 	var taskDTO LinkTaskDTO
 	if err := json.Unmarshal(d.Body, &taskDTO); err != nil {
 		msgLogger.Error("Error unmarshalling DTO, NACKing message", err, nil)
-		return fmt.Errorf("unmarshal DTO error: %w", err)
+		return rabbitmq_consumer.NewPermanentError(fmt.Errorf("unmarshal DTO error: %w", err))
 	}
 
 	// Обогащаем логгер данными из задачи
@@ -92,6 +97,15 @@ func (a *LinkConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 
 	// Адаптер вызывает UseCase
 	err = a.useCase.Execute(ctx, linkToParse, taskDTO.TaskID)
+	var throttled *domain.ThrottledError
+	if errors.As(err, &throttled) {
+		// Источник блокирует запросы: повторяем не раньше, чем он просит
+		taskLogger.Warn("Source is throttling requests, retrying later", port.Fields{
+			"status":         throttled.StatusCode,
+			"retry_after_ms": throttled.RetryAfter.Milliseconds(),
+		})
+		return rabbitmq_consumer.NewRetryableError(err, throttled.RetryAfter)
+	}
 	if err != nil {
 		taskLogger.Error("Use case failed with a potentially transient error, requeueing", err, nil)
 		return err // Requeue=true
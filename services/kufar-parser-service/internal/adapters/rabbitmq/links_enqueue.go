@@ -2,12 +2,13 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"kufar-parser-service/internal/contextkeys"
 	"kufar-parser-service/internal/core/domain"
 	"kufar-parser-service/internal/core/port"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 
 	"time"
 
@@ -54,9 +55,10 @@ func (a *RabbitMQLinkQueueAdapter) Enqueue(ctx context.Context, link domain.Prop
 		TaskID: taskID,
 	}
 
-	linkJSON, err := json.Marshal(linkTask)
+	linkJSON, err := schemas.Marshal(types.LinkTaskEventV1EventType, types.LinkTaskEventV1Version, linkTask)
 	if err != nil {
 		adapterLogger.Error("Failed to marshal property link to JSON", err, port.Fields{"ad_id": link.AdID})
+		return fmt.Errorf("rabbitmq adapter: failed to marshal property link to JSON for AdID %d: %w", link.AdID, err)
 	}
 
 	msg := amqp.Publishing{
@@ -65,7 +67,10 @@ func (a *RabbitMQLinkQueueAdapter) Enqueue(ctx context.Context, link domain.Prop
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
 		Priority:     PARSE_NEW,
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.LinkTaskEventV1EventType,
+			schemas.HeaderEventVersion: types.LinkTaskEventV1Version,
+		},
 	}
 
 	// Пробрасываем trace_id в заголовки сообщения
@@ -2,12 +2,15 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"kufar-parser-service/internal/contextkeys"
 	"kufar-parser-service/internal/core/domain"
 	"kufar-parser-service/internal/core/port"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"reflect"
 	"time"
 
@@ -109,6 +112,14 @@ func (a *RabbitMQProcessedPropertyQueueAdapter) Enqueue(ctx context.Context, rec
 		TaskID: taskID,
 	}
 
+	if record.RawRef != nil {
+		eventDTO.RawPayload = &RawPayloadRefDTO{
+			Key:       record.RawRef.Key,
+			SHA256:    record.RawRef.SHA256,
+			FetchedAt: record.RawRef.FetchedAt,
+		}
+	}
+
 	if record.Details != nil {
 		// Получаем тип деталей
 		detailsType := reflect.TypeOf(record.Details)
@@ -132,9 +143,15 @@ func (a *RabbitMQProcessedPropertyQueueAdapter) Enqueue(ctx context.Context, rec
 		eventDTO.Details = detailsDTO
 	}
 
-	recordJSON, err := json.Marshal(eventDTO)
+	// Проверяем контракт до публикации, а не узнаем о нарушении от storage-service после цикла ретраев
+	recordJSON, err := schemas.Marshal(types.ProcessedRealEstateEventV1EventType, types.ProcessedRealEstateEventV1Version, eventDTO)
 	if err != nil {
 		adapterLogger.Error("Failed to marshal processed record to JSON", err, nil)
+		var validationErr *schemas.ValidationError
+		if errors.As(err, &validationErr) {
+			// Повтор парсинга даст то же самое событие, поэтому ссылка сразу уходит в DLQ
+			return rabbitmq_consumer.NewPermanentError(fmt.Errorf("processed record violates event contract: %w", err))
+		}
 		return fmt.Errorf("failed to marshal processed record to JSON for URL %s: %w", record.General.AdLink, err)
 	}
 
@@ -144,8 +161,8 @@ func (a *RabbitMQProcessedPropertyQueueAdapter) Enqueue(ctx context.Context, rec
 		DeliveryMode: amqp.Persistent,
 		Timestamp:    time.Now(),
 		Headers: amqp.Table{
-			"event-type":    "ProcessedRealEstateEvent", // Название события из схемы
-			"event-version": "1.0.0",                    // Версия из схемы
+			schemas.HeaderEventType:    types.ProcessedRealEstateEventV1EventType,
+			schemas.HeaderEventVersion: types.ProcessedRealEstateEventV1Version,
 		},
 	}
 
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"kufar-parser-service/internal/constants"
 	"kufar-parser-service/internal/contextkeys"
@@ -50,7 +51,7 @@ func NewTasksConsumerAdapter(
 }
 
 // messageHandler - приватный метод адаптера
-func (a *TasksConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
+func (a *TasksConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) (err error) {
 
 	traceID, ok := d.Headers["x-trace-id"].(string)
 	if !ok || traceID == "" {
@@ -64,17 +65,21 @@ func (a *TasksConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 	})
 
 	// Создаем контекст и кладем в него логгер
-	ctx := context.Background()
 	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
 	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
 
 	msgLogger.Info("Received new task for find objects", nil)
 
+	if err := validateEvent(d); err != nil {
+		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
+		return err
+	}
+
 	var taskDTO TaskInfo
 	if err := json.Unmarshal(d.Body, &taskDTO); err != nil {
 		msgLogger.Error("Error unmarshalling task DTO, NACKing message", err, nil)
 		// Ошибка разбора JSON - это постоянная ошибка, нет смысла повторять обработку сообщения
-		return fmt.Errorf("unmarshal error: %w", err)
+		return rabbitmq_consumer.NewPermanentError(fmt.Errorf("unmarshal error: %w", err))
 	}
 
 	taskLogger := msgLogger.WithFields(port.Fields{"task_id": taskDTO.TaskID.String()})
@@ -87,7 +92,12 @@ func (a *TasksConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 		return nil
 	}
 
-	if err := a.orchestrateUC.Execute(ctx, internalTasks, taskDTO.TaskID); err != nil {
+	if err := a.orchestrateUC.Execute(ctx, internalTasks, taskDTO.TaskID, taskDTO.ItemKey); err != nil {
+		var throttled *domain.ThrottledError
+		if errors.As(err, &throttled) {
+			taskLogger.Warn("Source is throttling requests, retrying search later", port.Fields{"retry_after_ms": throttled.RetryAfter.Milliseconds()})
+			return rabbitmq_consumer.NewRetryableError(err, throttled.RetryAfter)
+		}
 		msgLogger.Error("Orchestration use case failed", err, nil)
 		return err // Возвращаем ошибку для retry
 	}
@@ -108,6 +118,15 @@ func (a *TasksConsumerAdapter) translateDTOToInternalTasks(dto TaskInfo) ([]doma
 		return nil, fmt.Errorf("unknown category for Kufar: %s", dto.Category)
 	}
 
+	limits := domain.CrawlLimits{
+		MaxPages:    dto.MaxPages,
+		Since:       dto.Since,
+		Until:       dto.Until,
+		FullRecrawl: dto.FullRecrawl,
+	}
+	// Лимит ссылок общий на все поиски сообщения: лимит задачи уже поделен между сообщениями при отправке
+	budget := domain.NewLinkBudget(dto.MaxLinks)
+
 	// Создаем задачи
 	tasks := make([]domain.SearchCriteria, 0, len(kufarLocations)*len(constants.DealTypes))
 
@@ -129,7 +148,10 @@ func (a *TasksConsumerAdapter) translateDTOToInternalTasks(dto TaskInfo) ([]doma
 					AdsAmount: constants.MaxAdsAmount,
 					SortBy:    constants.SortByDateDesc,
 	
-					Name: fmt.Sprintf("FindNew_%s_%s_loc-%s_%s", dto.Region, dto.Category, location, dealType),
+					Name: fmt.Sprintf("FindNew_%s_%s_cat-%s_loc-%s_%s", dto.Region, dto.Category, kufarCategory, location, dealType),
+
+					Limits: limits,
+					Budget: budget,
 				}
 	
 				if kufarCategory == constants.TravelsCategory {
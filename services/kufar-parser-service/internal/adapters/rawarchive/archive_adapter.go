@@ -0,0 +1,50 @@
+package rawarchive
+
+import (
+	"context"
+	"kufar-parser-service/internal/core/domain"
+	"real-estate-system/pkg/blobstore"
+	"real-estate-system/pkg/raw_archive"
+)
+
+// RawArchiveAdapter реализует RawArchivePort поверх pkg/raw_archive
+type RawArchiveAdapter struct {
+	archive *raw_archive.Archive
+}
+
+// NewRawArchiveAdapter создает адаптер над любым BlobStore
+func NewRawArchiveAdapter(store blobstore.BlobStore) *RawArchiveAdapter {
+	return &RawArchiveAdapter{archive: raw_archive.NewArchive(store)}
+}
+
+func (a *RawArchiveAdapter) Save(ctx context.Context, payload domain.RawPayload) (domain.RawPayloadRef, error) {
+	ref, err := a.archive.Save(ctx, raw_archive.Payload{
+		Source:      payload.Source,
+		AdID:        payload.AdID,
+		URL:         payload.URL,
+		ContentType: payload.ContentType,
+		FetchedAt:   payload.FetchedAt,
+		Body:        payload.Body,
+	})
+	if err != nil {
+		return domain.RawPayloadRef{}, err
+	}
+	return toDomainRef(ref), nil
+}
+
+func (a *RawArchiveAdapter) WalkLatest(ctx context.Context, source string, fn func(domain.RawPayload, domain.RawPayloadRef) error) error {
+	return a.archive.WalkLatest(ctx, source, func(p raw_archive.Payload, ref raw_archive.Ref) error {
+		return fn(domain.RawPayload{
+			Source:      p.Source,
+			AdID:        p.AdID,
+			URL:         p.URL,
+			ContentType: p.ContentType,
+			FetchedAt:   p.FetchedAt,
+			Body:        p.Body,
+		}, toDomainRef(ref))
+	})
+}
+
+func toDomainRef(ref raw_archive.Ref) domain.RawPayloadRef {
+	return domain.RawPayloadRef{Key: ref.Key, SHA256: ref.SHA256, FetchedAt: ref.FetchedAt}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -14,10 +15,16 @@ import (
 	logger_adapter "kufar-parser-service/internal/adapters/logger"
 	postgres_adapter "kufar-parser-service/internal/adapters/postgres"
 	rabbitmq_adapter "kufar-parser-service/internal/adapters/rabbitmq"
+	"kufar-parser-service/internal/adapters/rawarchive"
+	"kufar-parser-service/internal/adapters/rest"
 	"kufar-parser-service/internal/configs"
+	"kufar-parser-service/internal/contextkeys"
 	"kufar-parser-service/internal/constants"
+	"kufar-parser-service/internal/core/domain"
 	"kufar-parser-service/internal/core/port"
 	"kufar-parser-service/internal/core/usecase"
+	"real-estate-system/pkg/blobstore"
+	"real-estate-system/pkg/fetch_throttle"
 	fluentlogger "real-estate-system/pkg/fluent_logger"
 	"real-estate-system/pkg/postgres"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
@@ -25,8 +32,7 @@ import (
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
 	"sync"
 	"syscall"
-
-	// "time"
+	"time"
 
 	"github.com/fluent/fluent-logger-golang/fluent"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -45,6 +51,13 @@ type App struct {
 	// Входящие порты (слушатели событий)
 	linkEventsListener   port.EventListenerPort
 	searchEventsListener port.EventListenerPort
+
+	// Объявляет парсер в реестре источников actualization-service
+	heartbeat     port.SourceHeartbeatPort
+	fetcherHealth port.FetcherHealthPort
+
+	// Админское API курсоров
+	apiServer *rest.Server
 }
 
 // NewApp создает новый экземпляр приложения
@@ -141,6 +154,19 @@ func NewApp() (*App, error) {
 
 	kufarAdapter, err := kufarfetcher.NewKufarFetcherAdapter(
 		"https://api.kufar.by/search-api/v2/search/rendered-paginated",
+		kufarfetcher.Options{
+			Throttle: fetch_throttle.Config{
+				MinDelay:         appConfig.Fetcher.MinDelay,
+				MaxDelay:         appConfig.Fetcher.MaxDelay,
+				Jitter:           1, // от 0 до 2*MinDelay, как прежний RandomDelay
+				FailureThreshold: appConfig.Fetcher.BreakerThreshold,
+				OpenTimeout:      appConfig.Fetcher.BreakerOpenTimeout,
+				MaxOpenTimeout:   appConfig.Fetcher.BreakerMaxTimeout,
+			},
+			Proxies:    appConfig.Fetcher.Proxies,
+			UserAgents: appConfig.Fetcher.UserAgents,
+		},
+		baseLogger,
 	)
 	if err != nil {
 		appLogger.Error("Failed to create Kufar Fetcher Adapter", err, nil)
@@ -148,30 +174,56 @@ func NewApp() (*App, error) {
 		dbPool.Close()
 		return nil, fmt.Errorf("failed to initialize kufar fetcher: %w", err)
 	}
-	appLogger.Debug("Kufar Fetcher Adapter initialized.", nil)
+	appLogger.Debug("Kufar Fetcher Adapter initialized.", port.Fields{
+		"proxies":     len(appConfig.Fetcher.Proxies),
+		"user_agents": len(appConfig.Fetcher.UserAgents),
+	})
 
 	pgLastRunRepo, _ := postgres_adapter.NewPostgresLastRunRepository(dbPool)
 
 	linkQueueAdapter, _ := rabbitmq_adapter.NewRabbitMQLinkQueueAdapter(eventProducer, constants.RoutingKeyLinkTasks)
 	tasksResultsQueueAdapter, _ := rabbitmq_adapter.NewTaskReporterAdapter(eventProducer, constants.RoutingKeyTaskResults)
 	processedPropertyQueueAdapter, _ := rabbitmq_adapter.NewRabbitMQProcessedPropertyQueueAdapter(eventProducer, constants.RoutingKeyProcessedProperties)
+	heartbeatAdapter, _ := rabbitmq_adapter.NewSourceHeartbeatAdapter(eventProducer, constants.RoutingKeySourceHeartbeat)
+
+	// Архив сырых ответов: по нему исправленный маппер прогоняется без повторного обхода сайта
+	var rawArchiveAdapter port.RawArchivePort
+	if appConfig.RawArchive.Dir != "" {
+		blobStore, err := blobstore.NewFSStore(appConfig.RawArchive.Dir)
+		if err != nil {
+			appLogger.Error("Failed to create raw payload archive", err, port.Fields{"dir": appConfig.RawArchive.Dir})
+			eventProducer.Close()
+			dbPool.Close()
+			return nil, fmt.Errorf("failed to create raw payload archive: %w", err)
+		}
+		rawArchiveAdapter = rawarchive.NewRawArchiveAdapter(blobStore)
+	}
 
 	appLogger.Debug("All outgoing adapters initialized.", nil)
 
 	// инициализация use cases
 	fetchKufarUseCase := usecase.NewFetchAndEnqueueLinksUseCase(kufarAdapter, linkQueueAdapter, pgLastRunRepo, "kufar")
-	processLinkUseCase := usecase.NewProcessLinkUseCase(kufarAdapter, processedPropertyQueueAdapter)
+	processLinkUseCase := usecase.NewProcessLinkUseCase(kufarAdapter, processedPropertyQueueAdapter, rawArchiveAdapter)
 	orchestrateParsingUseCase := usecase.NewOrchestrateParsingUseCase(fetchKufarUseCase, tasksResultsQueueAdapter)
 	// savePropertyUseCase := usecase.NewSavePropertyUseCase(postgresStorageAdapter)
+	getParserCursorsUseCase := usecase.NewGetParserCursorsUseCase(pgLastRunRepo)
+	resetParserCursorUseCase := usecase.NewResetParserCursorUseCase(pgLastRunRepo)
+	resetParserCursorsByPrefixUseCase := usecase.NewResetParserCursorsByPrefixUseCase(pgLastRunRepo)
 	appLogger.Debug("All use cases initialized.", nil)
 
+	cursorHandlers := rest.NewCursorHandlers(getParserCursorsUseCase, resetParserCursorUseCase, resetParserCursorsByPrefixUseCase)
+	apiServer := rest.NewServer(appConfig.Rest.PORT, cursorHandlers, baseLogger.WithFields(port.Fields{"component": "http_server"}))
+
 	// инициализация входящих адаптеров
 	linksConsumerCfg := rabbitmq_consumer.ConsumerConfig{
 		Config:              rabbitmq_common.Config{URL: appConfig.RabbitMQ.URL},
 		QueueName:           constants.QueueLinkTasks,
 		RoutingKeyForBind:   constants.RoutingKeyLinkTasks,
 		ExchangeNameForBind: constants.MainExchange,
-		PrefetchCount:       5,
+		PrefetchCount:       2,
+		// Коллектор Kufar работает с Parallelism: 1, больше воркеров не ускорят обработку
+		WorkerPoolSize:      1,
+		HandlerTimeout:      2 * time.Minute,
 		DurableQueue:        true,
 		ConsumerTag:         "link-processor-adapter",
 		DeclareQueue:        true,
@@ -196,8 +248,9 @@ func NewApp() (*App, error) {
 		FinalDLQ:           constants.FinalDLQ,
 		FinalDLQRoutingKey: constants.FinalDLQRoutingKey,
 
-		MaxRetries: 3,
-	}
+		// Пока Kufar нас блокирует, ссылки не берем в работу, а оставляем в очереди
+		Gate: rabbitmq_consumer.GateFunc(kufarAdapter.WaitAvailable),
+	}.WithRetryTiers(constants.LinkTasksRetryDelays)
 	linkListener, err := rabbitmq_adapter.NewLinkConsumerAdapter(linksConsumerCfg, processLinkUseCase, baseLogger, connManager)
 	if err != nil {
 		appLogger.Error("Failed to initialize Link Events Listener", err, nil)
@@ -213,6 +266,7 @@ func NewApp() (*App, error) {
 		RoutingKeyForBind:   constants.RoutingKeySearchTasks,
 		ExchangeNameForBind: constants.MainExchange,
 		PrefetchCount:       1,
+		WorkerPoolSize:      1, // Сбор ссылок может идти долго, поэтому без HandlerTimeout
 		DurableQueue:        true,
 		ConsumerTag:         "search-tasks-processor-adapter",
 		DeclareQueue:        true,
@@ -228,14 +282,12 @@ func NewApp() (*App, error) {
 		RetryExchange: constants.RetryExchange,
 		RetryQueue: constants.WaitQueue,
 		RetryTTL: constants.RetryTTL,
-		
+
 		
 		FinalDLXExchange:   constants.FinalDLXExchangeForSearchTasks,
 		FinalDLQ:           constants.FinalDLQForSearchTasks,
 		FinalDLQRoutingKey: constants.FinalDLQRoutingKeyForSearchTasks,
-
-		MaxRetries: 3,
-	}
+	}.WithRetryTiers(constants.SearchTasksRetryDelays)
 	searchTasksListener, err := rabbitmq_adapter.NewTasksConsumerAdapter(tasksConsumerCfg, orchestrateParsingUseCase, baseLogger, connManager)
 	if err != nil {
 		appLogger.Error("Failed to initialize Search Events Listener", err, nil)
@@ -256,6 +308,9 @@ func NewApp() (*App, error) {
 		// fetchKufarLinksUseCase:      fetchKufarUseCase, // Нужен для прямого вызова
 		linkEventsListener:   linkListener,
 		searchEventsListener: searchTasksListener,
+		heartbeat:            heartbeatAdapter,
+		fetcherHealth:        kufarAdapter,
+		apiServer:            apiServer,
 	}
 
 	return application, nil
@@ -294,6 +349,12 @@ func (a *App) Run() error {
 		wg.Wait()
 		a.logger.Debug("All background processes finished.", nil)
 
+		if a.apiServer != nil {
+			if err := a.apiServer.Stop(context.Background()); err != nil {
+				a.logger.Error("Error during API server shutdown", err, nil)
+			}
+		}
+
 		// Теперь безопасно закрываем ресурсы
 		if a.linkEventsListener != nil {
 			if err := a.linkEventsListener.Close(); err != nil {
@@ -340,6 +401,13 @@ func (a *App) Run() error {
 
 	consumerErrors := make(chan error, 1)
 
+	go func() {
+		a.logger.Debug("Starting HTTP server...", port.Fields{"port": a.config.Rest.PORT})
+		if err := a.apiServer.Start(); err != nil && err != http.ErrServerClosed {
+			consumerErrors <- fmt.Errorf("HTTP server start error: %w", err)
+		}
+	}()
+
 	// Функция-хелпер для запуска слушателей
 	startListener := func(name string, listener port.EventListenerPort) {
 		defer wg.Done()
@@ -358,6 +426,12 @@ func (a *App) Run() error {
 	go startListener("Links Events Listener", a.linkEventsListener)
 	go startListener("Search Events Listener", a.searchEventsListener)
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.runHeartbeat(appCtx)
+	}()
+
 	// Ожидание сигнала на завершение или ошибки от одного из компонентов
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -378,6 +452,53 @@ func (a *App) Run() error {
 	return nil
 }
 
+// runHeartbeat сразу и затем раз в интервал объявляет парсер в реестре источников.
+// При остановке отправляет статус stopping, чтобы задачи перестали маршрутизироваться сюда,
+// не дожидаясь истечения TTL heartbeat
+func (a *App) runHeartbeat(ctx context.Context) {
+	hbLogger := a.logger.WithFields(port.Fields{"component": "source_heartbeat"})
+	hbCtx := contextkeys.ContextWithLogger(ctx, hbLogger)
+
+	ticker := time.NewTicker(a.config.Heartbeat.Interval)
+	defer ticker.Stop()
+
+	_ = a.heartbeat.PublishHeartbeat(hbCtx, a.newHeartbeat(a.currentStatus()))
+	for {
+		select {
+		case <-ctx.Done():
+			stopCtx := contextkeys.ContextWithLogger(context.Background(), hbLogger)
+			_ = a.heartbeat.PublishHeartbeat(stopCtx, a.newHeartbeat(domain.SourceStatusStopping))
+			return
+		case <-ticker.C:
+			_ = a.heartbeat.PublishHeartbeat(hbCtx, a.newHeartbeat(a.currentStatus()))
+		}
+	}
+}
+
+// currentStatus - degraded, пока Kufar блокирует запросы и circuit breaker не замкнут
+func (a *App) currentStatus() string {
+	if a.fetcherHealth.Health().State != domain.FetcherStateClosed {
+		return domain.SourceStatusDegraded
+	}
+	return domain.SourceStatusHealthy
+}
+
+func (a *App) newHeartbeat(status string) domain.SourceHeartbeat {
+	return domain.SourceHeartbeat{
+		Name:             constants.SourceName,
+		DisplayName:      constants.SourceDisplayName,
+		InstanceID:       a.config.Heartbeat.InstanceID,
+		SearchRoutingKey: constants.RoutingKeySearchTasks,
+		LinkRoutingKey:   constants.RoutingKeyLinkTasks,
+		Categories:       constants.SupportedCategories(),
+		Regions:          constants.SupportedRegions(),
+		DealTypes:        constants.SupportedDealTypes,
+		Status:           status,
+		SentAt:           time.Now().UTC(),
+		Interval:         a.config.Heartbeat.Interval,
+	}
+}
+
 func parseLogLevel(levelStr string) slog.Level {
 	switch strings.ToLower(levelStr) {
 	case "debug":
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 	"github.com/joho/godotenv"
 )
 
@@ -18,10 +20,39 @@ type DBconfig struct {
 	URL string
 }
 
+// FetcherConfig - ограничение запросов к сайту-источнику
+type FetcherConfig struct {
+	MinDelay time.Duration // пауза между запросами в спокойном режиме
+	MaxDelay time.Duration // потолок паузы, когда источник отвечает 429/5xx
+
+	BreakerThreshold   int           // сколько блокировок подряд размыкают цепь
+	BreakerOpenTimeout time.Duration // первая пауза после размыкания
+	BreakerMaxTimeout  time.Duration
+
+	Proxies    []string // FETCH_PROXIES, через запятую
+	UserAgents []string // FETCH_USER_AGENTS, через "|": в самих User-Agent встречаются запятые
+}
+
+// RawArchiveConfig - архив сырых ответов источника. Пустой Dir выключает архив
+type RawArchiveConfig struct {
+	Dir string
+}
+
+// RESTconfig - админское API парсера (курсоры parser_last_runs)
+type RESTconfig struct {
+	PORT string
+}
+
 type StdoutLogConfig struct {
     Level string `mapstructure:"STDOUT_LOG_LEVEL" default:"debug"` // По умолчанию DEBUG
 }
 
+// HeartbeatConfig - как часто парсер объявляет себя в реестре источников actualization-service
+type HeartbeatConfig struct {
+	Interval   time.Duration
+	InstanceID string
+}
+
 type FluentBitConfig struct {
 	Host string
 	Port int
@@ -36,6 +67,10 @@ type AppConfig struct {
 	RabbitMQ    RabbitMQConfig 
 	FluentBit	FluentBitConfig
 	StdoutLogger StdoutLogConfig
+	Heartbeat    HeartbeatConfig
+	Rest         RESTconfig
+	Fetcher      FetcherConfig
+	RawArchive   RawArchiveConfig
 }
 
 // LoadConfig загружает конфигурацию из переменных окружения.
@@ -91,6 +126,25 @@ func LoadConfig(envPath ...string) (*AppConfig, error) {
 
 	cfg.StdoutLogger.Level = getEnvAsString("STDOUT_LOG_LEVEL", "debug")
 
+	cfg.Rest.PORT = getEnvAsString("PORT", "8086")
+
+	cfg.Fetcher.MinDelay = time.Duration(getEnvAsInt("FETCH_MIN_DELAY_MS", 1500)) * time.Millisecond
+	cfg.Fetcher.MaxDelay = time.Duration(getEnvAsInt("FETCH_MAX_DELAY_SECONDS", 60)) * time.Second
+	cfg.Fetcher.BreakerThreshold = getEnvAsInt("FETCH_BREAKER_THRESHOLD", 5)
+	cfg.Fetcher.BreakerOpenTimeout = time.Duration(getEnvAsInt("FETCH_BREAKER_OPEN_SECONDS", 60)) * time.Second
+	cfg.Fetcher.BreakerMaxTimeout = time.Duration(getEnvAsInt("FETCH_BREAKER_MAX_OPEN_SECONDS", 1800)) * time.Second
+	cfg.Fetcher.Proxies = getEnvAsList("FETCH_PROXIES", ",")
+	cfg.Fetcher.UserAgents = getEnvAsList("FETCH_USER_AGENTS", "|")
+
+	cfg.RawArchive.Dir = getEnvAsString("RAW_ARCHIVE_DIR", "./data/raw_archive")
+
+	cfg.Heartbeat.Interval = time.Duration(getEnvAsInt("HEARTBEAT_INTERVAL_SECONDS", 30)) * time.Second
+	if cfg.Heartbeat.Interval <= 0 {
+		cfg.Heartbeat.Interval = 30 * time.Second
+	}
+	hostname, _ := os.Hostname()
+	cfg.Heartbeat.InstanceID = getEnvAsString("INSTANCE_ID", hostname)
+
 	return cfg, nil
 }
 
@@ -102,6 +156,17 @@ func getEnvAsString(key string, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsList читает переменную окружения как список значений через разделитель
+func getEnvAsList(key, sep string) []string {
+	var out []string
+	for _, item := range strings.Split(os.Getenv(key), sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 // getEnvAsInt читает переменную окружения как int или возвращает значение по умолчанию
 // Логирует ошибку, если переменная есть, но не может быть преобразована в int
 func getEnvAsInt(key string, defaultValue int) int {
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 // Имена очередей
 const (
 	QueueLinkTasks          = "link_parsing_tasks_kufar"
@@ -13,6 +15,7 @@ const (
 	RoutingKeySearchTasks		 = "kufar.search.tasks"
 	RoutingKeyProcessedProperties = "db.properties.save"
 	RoutingKeyTaskResults          = "notify.task.result"
+	RoutingKeySourceHeartbeat      = "sources.heartbeat"
 )
 
 const (
@@ -33,4 +36,11 @@ const (
 	RetryExchange = "shared_retry_exchange"
 	WaitQueue     = "shared_wait_10s"
 	RetryTTL      = 10000 // 10 секунд
-)
\ No newline at end of file
+)
+
+// Уровни ретраев. Kufar отвечает 429 на всплески запросов, поэтому первая пауза короткая,
+// а дальше ждем дольше, чтобы не уйти в бан
+var (
+	LinkTasksRetryDelays   = []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute, time.Hour}
+	SearchTasksRetryDelays = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+)
@@ -0,0 +1,32 @@
+package constants
+
+import "sort"
+
+// Описание источника для реестра источников actualization-service
+const (
+	SourceName        = "kufar"
+	SourceDisplayName = "Kufar"
+)
+
+// SupportedDealTypes - типы сделок, объявления по которым собирает парсер
+var SupportedDealTypes = []string{"sale", "rent"}
+
+// SupportedCategories возвращает бизнес-категории, для которых есть соответствие на Kufar
+func SupportedCategories() []string {
+	categories := make([]string, 0, len(BusinessCategoryToKufarMap))
+	for category := range BusinessCategoryToKufarMap {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// SupportedRegions возвращает регионы, для которых есть соответствие на Kufar
+func SupportedRegions() []string {
+	regions := make([]string, 0, len(RegionToKufarMap))
+	for region := range RegionToKufarMap {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
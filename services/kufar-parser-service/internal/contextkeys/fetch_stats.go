@@ -0,0 +1,21 @@
+package contextkeys
+
+import (
+	"context"
+	"kufar-parser-service/internal/core/domain"
+)
+
+type fetchStatsKeyType struct{}
+
+var fetchStatsKey = fetchStatsKeyType{}
+
+// ContextWithFetchStats помещает в контекст счетчики ограничений текущей задачи
+func ContextWithFetchStats(ctx context.Context, stats *domain.FetchStats) context.Context {
+	return context.WithValue(ctx, fetchStatsKey, stats)
+}
+
+// FetchStatsFromContext извлекает счетчики. Если их нет, возвращает nil (методы FetchStats это допускают)
+func FetchStatsFromContext(ctx context.Context) *domain.FetchStats {
+	stats, _ := ctx.Value(fetchStatsKey).(*domain.FetchStats)
+	return stats
+}
@@ -25,4 +25,7 @@ type SearchCriteria struct {
 	Query			string
 	// Пагинация
 	Cursor string 
+
+	Limits CrawlLimits
+	Budget *LinkBudget // общий для всех поисков одного сообщения
 }
\ No newline at end of file
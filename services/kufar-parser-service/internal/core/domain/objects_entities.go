@@ -14,6 +14,9 @@ const (
 type RealEstateRecord struct {
     General GeneralProperty
     Details interface{} // Сюда будет помещен указатель на конкретные детали
+
+    Raw    *RawPayload    // ответ источника, заполняет фетчер
+    RawRef *RawPayloadRef // где ответ лежит в архиве
 }
 
 // GeneralProperty представляет основную информацию для любого объекта недвижимости
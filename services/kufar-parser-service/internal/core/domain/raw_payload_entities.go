@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// RawPayload - сырой ответ источника, из которого маппер собрал RealEstateRecord
+type RawPayload struct {
+	Source      string
+	AdID        int64
+	URL         string
+	ContentType string
+	FetchedAt   time.Time
+	Body        []byte
+}
+
+// RawPayloadRef - ссылка на ответ в архиве, уходит вместе с событием
+type RawPayloadRef struct {
+	Key       string
+	SHA256    string
+	FetchedAt time.Time
+}
+
+// RemapOptions - параметры повторного прогона маппера по архиву
+type RemapOptions struct {
+	Limit  int  // 0 - без ограничения
+	DryRun bool // только смапить, ничего не публиковать
+}
+
+// RemapStats - итог прогона
+type RemapStats struct {
+	Scanned   int
+	Mapped    int
+	Published int
+	Failed    int // маппер вернул ошибку
+}
+
+// MappingError - ответ получен, но маппер не смог его разобрать.
+// Несет сам ответ, чтобы его сохранили в архив и смапили после исправления маппера
+type MappingError struct {
+	Raw *RawPayload
+	Err error
+}
+
+func (e *MappingError) Error() string { return e.Err.Error() }
+
+func (e *MappingError) Unwrap() error { return e.Err }
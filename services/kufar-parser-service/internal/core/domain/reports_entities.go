@@ -1,6 +1,23 @@
 package domain
 
+import "time"
+
 type ParsingTasksStats struct {
 	SearchesCompleted   int // Количество завершённых поисков
 	NewLinksFound   int // Количество найденных ссылок
+
+	ThrottledRequests int // Ответы-блокировки источника (429/403/5xx)
+	CircuitRejections int // Запросы, не отправленные из-за разомкнутой цепи
+
+	Items []string // Ключи выполненных единиц работы задачи (search-task item_key)
+
+	Subtasks []SubtaskStats // Итоги отдельных поисков
+}
+
+// SubtaskStats - итог одного поиска (SearchCriteria) в рамках задачи
+type SubtaskStats struct {
+	Name       string
+	LinksFound int
+	Err        error
+	Duration   time.Duration
 }
\ No newline at end of file
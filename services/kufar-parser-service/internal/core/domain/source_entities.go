@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Состояния парсера, которые он сообщает в heartbeat
+const (
+	SourceStatusHealthy  = "healthy"
+	SourceStatusDegraded = "degraded"
+	SourceStatusStopping = "stopping" // парсер завершает работу, задачи ему больше не нужны
+)
+
+// SourceHeartbeat - объявление парсера о себе для реестра источников actualization-service
+type SourceHeartbeat struct {
+	Name             string
+	DisplayName      string
+	InstanceID       string
+	SearchRoutingKey string
+	LinkRoutingKey   string
+	Categories       []string
+	Regions          []string
+	DealTypes        []string
+	Status           string
+	SentAt           time.Time
+	Interval         time.Duration
+}
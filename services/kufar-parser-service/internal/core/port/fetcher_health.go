@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+	"kufar-parser-service/internal/core/domain"
+)
+
+// FetcherHealthPort сообщает состояние ограничителя запросов к источнику
+type FetcherHealthPort interface {
+	Health() domain.FetcherHealth
+	// WaitAvailable блокируется, пока источник нас блокирует (цепь разомкнута)
+	WaitAvailable(ctx context.Context) error
+}
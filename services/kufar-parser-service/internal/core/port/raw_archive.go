@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"kufar-parser-service/internal/core/domain"
+)
+
+// RawArchivePort хранит сырые ответы источника, чтобы исправленный маппер можно было
+// прогнать по уже скачанным данным без повторного обхода сайта
+type RawArchivePort interface {
+	Save(ctx context.Context, payload domain.RawPayload) (domain.RawPayloadRef, error)
+
+	// WalkLatest отдает самый свежий ответ по каждому объявлению источника
+	WalkLatest(ctx context.Context, source string, fn func(domain.RawPayload, domain.RawPayloadRef) error) error
+}
+
+// PayloadMapperPort - маппер сырого ответа в доменную запись
+type PayloadMapperPort interface {
+	MapPayload(ctx context.Context, payload domain.RawPayload) (*domain.RealEstateRecord, error)
+}
@@ -8,5 +8,5 @@ import (
 )
 
 type OrchestrateParsingPort interface {
-	Execute(ctx context.Context, internalTasks []domain.SearchCriteria, taskID uuid.UUID) error
+	Execute(ctx context.Context, internalTasks []domain.SearchCriteria, taskID uuid.UUID, itemKey string) error
 }
\ No newline at end of file
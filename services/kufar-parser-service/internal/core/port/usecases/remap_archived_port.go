@@ -0,0 +1,12 @@
+package usecases_port
+
+import (
+	"context"
+	"kufar-parser-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type RemapArchivedPort interface {
+	Execute(ctx context.Context, opts domain.RemapOptions, taskID uuid.UUID) (domain.RemapStats, error)
+}
@@ -59,23 +59,55 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 
 	parserLogger := ucLogger.WithFields(port.Fields{"parser_key": parserNameKey})
 
-	lastRunTime, err := uc.lastRunRepo.GetLastRunTimestamp(ctx, parserNameKey)
+	limits := initialCriteria.Limits
+	storedRunTime, err := uc.lastRunRepo.GetLastRunTimestamp(ctx, parserNameKey)
 	if err != nil {
 		parserLogger.Warn("Could not get last run timestamp, fetching from the beginning.", port.Fields{"error": err.Error()})
-		lastRunTime = time.Time{}
+		storedRunTime = time.Time{}
 	} else {
-		parserLogger.Info("Last run timestamp found", port.Fields{"last_run_time": lastRunTime})
+		parserLogger.Info("Last run timestamp found", port.Fields{"last_run_time": storedRunTime})
+	}
+
+	// Нижняя граница поиска: явная из задачи, затем курсор, если не запрошен полный обход
+	lastRunTime := storedRunTime
+	switch {
+	case limits.Since != nil:
+		lastRunTime = *limits.Since
+	case limits.FullRecrawl:
+		lastRunTime = time.Time{}
+	}
+	// Прошлый поиск остановлен лимитом: продолжаем ниже последней обработанной ссылки,
+	// более новые объявления заберем, когда участок будет досмотрен
+	until := limits.Until
+	var resume *domain.ResumePoint
+	if !limits.HasExplicitWindow() && !limits.FullRecrawl {
+		resume, err = uc.lastRunRepo.GetResumePoint(ctx, parserNameKey)
+		if err != nil {
+			parserLogger.Warn("Could not get resume point, continuing from the newest listings.", port.Fields{"error": err.Error()})
+			resume = nil
+		}
+		if resume != nil {
+			until = &resume.Before
+			parserLogger.Info("Resuming truncated search", port.Fields{"before": resume.Before, "newest": resume.Newest})
+		}
+	}
+
+	if !lastRunTime.Equal(storedRunTime) {
+		parserLogger.Info("Search window overridden by task", port.Fields{
+			"since":        lastRunTime,
+			"until":        limits.Until,
+			"full_recrawl": limits.FullRecrawl,
+		})
 	}
 
-	//FOR DEBUG
-	// lastRunTime = time.Time{}
-	const debugLinkLimit = 30 // Собираем не больше 5 ссылок для каждого вызова
-	stopFetching := false
+	truncated := false // поиск остановлен лимитом, выдача пройдена не до конца
 
 	currentCriteria := initialCriteria
 	newLinksFoundOverall := 0
 	totalPagesProcessed := 0
+	limitedPages := 0 // страницы в счет MaxPages
 	var latestAdTimeOnCurrentRun time.Time // Для сохранения самой новой даты объявления в текущем запуске
+	var lastProcessedAdTime time.Time      // дата последней поставленной в очередь ссылки (выдача по убыванию)
 
 	
 	for {
@@ -85,6 +117,16 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
         default:
         }
 
+		if currentCriteria.Budget.Exhausted() {
+			parserLogger.Info("Task link limit reached. Stopping fetch process.", port.Fields{"total_found": newLinksFoundOverall})
+			truncated = true
+			break
+		}
+		if limits.MaxPages > 0 && limitedPages >= limits.MaxPages {
+			parserLogger.Info("Page limit reached. Stopping fetch process.", port.Fields{"max_pages": limits.MaxPages})
+			truncated = true
+			break
+		}
 			
 		totalPagesProcessed++
 		pageLogger := parserLogger.WithFields(port.Fields{
@@ -107,10 +149,23 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 		}
 		
 		newLinksOnPage := 0
+		belowResumeOnPage := 0
 		for _, link := range links {
+			// Выдача отсортирована по убыванию даты, поэтому объявления новее until просто пропускаем
+			if until != nil && link.ListedAt.After(*until) {
+				continue
+			}
+			if resume != nil && link.ListedAt.Before(resume.Before) {
+				belowResumeOnPage++
+			}
+			if !currentCriteria.Budget.Take() {
+				truncated = true
+				break
+			}
 			link.Source = uc.sourceName // Добавляем источник
 			err = uc.queueRepo.Enqueue(ctx, link, taskID)
 			if err != nil {
+				currentCriteria.Budget.Release()
 				pageLogger.Error("Error enqueuing link, skipping", err, port.Fields{"ad_id": link.AdID})
 				continue // Пропускаем эту ссылку, но продолжаем с остальными
 			}
@@ -119,27 +174,24 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 			if link.ListedAt.After(latestAdTimeOnCurrentRun) { // Обновляем самое свежее время
 				latestAdTimeOnCurrentRun = link.ListedAt
 			}
-
-			//FOR DEBUG
-			if newLinksFoundOverall >= debugLinkLimit {
-				parserLogger.Warn("DEBUG: Link limit reached. Stopping fetch process.", port.Fields{
-					"limit": debugLinkLimit,
-					"total_found": newLinksFoundOverall,
-				})
-				stopFetching = true
-				break
-			}
+			lastProcessedAdTime = link.ListedAt
 		}
 
-		//FOR DEBUG
-		if stopFetching {
-			break 
+		// При продолжении страницы выше точки продолжения уже обработаны, их лимит страниц не считает,
+		// иначе поиск не дойдет до недосмотренного участка
+		if resume == nil || len(links) == 0 || belowResumeOnPage > 0 {
+			limitedPages++
 		}
 
 		if newLinksOnPage > 0 {
 			pageLogger.Debug("Enqueued new links from page", port.Fields{"count": newLinksOnPage})
 		}
 
+		if truncated {
+			parserLogger.Info("Task link limit reached. Stopping fetch process.", port.Fields{"total_found": newLinksFoundOverall})
+			break
+		}
+
 		if nextCursor == "" {
 			parserLogger.Debug("No next cursor. Pagination finished.", nil)
 			break
@@ -149,22 +201,7 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 		currentCriteria.Cursor = nextCursor
 	}
 
-	// Обновляем lastRunTime, если были найдены новые ссылки
-	if newLinksFoundOverall > 0 && !latestAdTimeOnCurrentRun.IsZero() {
-		// Устанавливаем время самого нового объявления, которое мы обработали в этом запуске
-		err = uc.lastRunRepo.SetLastRunTimestamp(ctx, parserNameKey, latestAdTimeOnCurrentRun)
-		if err != nil {
-			parserLogger.Error("Error setting last run timestamp", err, port.Fields{"new_timestamp": latestAdTimeOnCurrentRun})
-		} else {
-			parserLogger.Info("Successfully set last run timestamp", port.Fields{"new_timestamp": latestAdTimeOnCurrentRun})
-		}
-	} else if newLinksFoundOverall == 0 && totalPagesProcessed > 0 && !lastRunTime.IsZero() {
-        // Если мы прошлись по страницам, но не нашли ни одной новой ссылки
-        // можно его обновить на текущее время, чтобы показать, что мы проверяли
-        currentTime := time.Now().UTC()
-        uc.lastRunRepo.SetLastRunTimestamp(ctx, parserNameKey, currentTime)
-		parserLogger.Info("No new links found, but checked. Updated last run to current time.", port.Fields{"new_timestamp": currentTime})
-    }
+	uc.updateLastRun(ctx, parserLogger, parserNameKey, storedRunTime, resume, latestAdTimeOnCurrentRun, lastProcessedAdTime, newLinksFoundOverall, limits, truncated)
 
 	ucLogger.Info("Finished fetching links", port.Fields{
 		"total_links_enqueued": newLinksFoundOverall,
@@ -174,3 +211,68 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 	return newLinksFoundOverall, nil
 }
 
+// updateLastRun сдвигает курсор parser_last_runs после поиска.
+// Курсор не трогаем, если окно задано явно. Если поиск остановлен лимитом, курсор остается на месте,
+// а недосмотренный участок ниже последней обработанной ссылки запоминается в ResumePoint.
+// Когда участок досмотрен, курсор переходит на самое новое обработанное объявление. Назад курсор не сдвигается
+func (uc *FetchAndEnqueueLinksUseCase) updateLastRun(
+	ctx context.Context,
+	logger port.LoggerPort,
+	parserNameKey string,
+	storedRunTime time.Time,
+	resume *domain.ResumePoint,
+	latestAdTime, lastProcessedAdTime time.Time,
+	linksFound int,
+	limits domain.CrawlLimits,
+	truncated bool,
+) {
+	if limits.HasExplicitWindow() {
+		logger.Info("Last run timestamp left unchanged", port.Fields{"explicit_window": true})
+		return
+	}
+
+	newest := latestAdTime
+	if resume != nil && resume.Newest.After(newest) {
+		newest = resume.Newest
+	}
+
+	if truncated {
+		if linksFound == 0 {
+			logger.Info("Last run timestamp left unchanged", port.Fields{"truncated": true})
+			return
+		}
+		point := &domain.ResumePoint{Before: lastProcessedAdTime, Newest: newest}
+		if err := uc.lastRunRepo.SetResumePoint(ctx, parserNameKey, point); err != nil {
+			logger.Error("Error setting resume point", err, port.Fields{"before": point.Before})
+			return
+		}
+		logger.Info("Search truncated, resume point saved", port.Fields{"before": point.Before, "newest": point.Newest})
+		return
+	}
+
+	var newTimestamp time.Time
+	switch {
+	case (linksFound > 0 || resume != nil) && newest.After(storedRunTime):
+		// время самого нового объявления, которое мы обработали в этом запуске или до остановки лимитом
+		newTimestamp = newest
+	case linksFound == 0 && resume == nil && !storedRunTime.IsZero():
+		// Если мы прошлись по страницам, но не нашли ни одной новой ссылки,
+		// обновляем курсор на текущее время, чтобы показать, что мы проверяли
+		newTimestamp = time.Now().UTC()
+	}
+
+	if !newTimestamp.IsZero() {
+		if err := uc.lastRunRepo.SetLastRunTimestamp(ctx, parserNameKey, newTimestamp); err != nil {
+			logger.Error("Error setting last run timestamp", err, port.Fields{"new_timestamp": newTimestamp})
+			return
+		}
+		logger.Info("Successfully set last run timestamp", port.Fields{"new_timestamp": newTimestamp})
+	}
+
+	// Участок досмотрен: точку продолжения сбрасываем только после сдвига курсора
+	if resume != nil {
+		if err := uc.lastRunRepo.SetResumePoint(ctx, parserNameKey, nil); err != nil {
+			logger.Error("Error clearing resume point", err, nil)
+		}
+	}
+}
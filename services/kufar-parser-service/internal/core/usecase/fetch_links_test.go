@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"kufar-parser-service/internal/core/domain"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeFetcher отдает объявления по убыванию даты страницами по pageSize и останавливается на since, как Kufar
+type fakeFetcher struct {
+	ads      []domain.PropertyLink
+	pageSize int
+}
+
+func (f *fakeFetcher) FetchLinks(_ context.Context, criteria domain.SearchCriteria, since time.Time) ([]domain.PropertyLink, string, error) {
+	offset, _ := strconv.Atoi(criteria.Cursor)
+	var links []domain.PropertyLink
+	for i := offset; i < len(f.ads) && i < offset+f.pageSize; i++ {
+		if !since.IsZero() && !f.ads[i].ListedAt.After(since) {
+			return links, "", nil
+		}
+		links = append(links, f.ads[i])
+	}
+	if offset+f.pageSize >= len(f.ads) {
+		return links, "", nil
+	}
+	return links, strconv.Itoa(offset + f.pageSize), nil
+}
+
+func (f *fakeFetcher) FetchAdDetails(context.Context, int64) (*domain.RealEstateRecord, error) {
+	return nil, nil
+}
+
+// publish добавляет объявления новее уже опубликованных. Последнее из ids датировано at
+func (f *fakeFetcher) publish(at time.Time, ids ...int64) {
+	for i, id := range ids {
+		link := domain.PropertyLink{AdID: id, ListedAt: at.Add(-time.Duration(len(ids)-1-i) * time.Microsecond)}
+		f.ads = append([]domain.PropertyLink{link}, f.ads...)
+	}
+}
+
+type fakeQueue struct {
+	enqueued map[int64]int
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, link domain.PropertyLink, _ uuid.UUID) error {
+	q.enqueued[link.AdID]++
+	return nil
+}
+
+type fakeLastRunRepo struct {
+	cursors map[string]time.Time
+	resume  map[string]*domain.ResumePoint
+}
+
+func (r *fakeLastRunRepo) GetLastRunTimestamp(_ context.Context, name string) (time.Time, error) {
+	return r.cursors[name], nil
+}
+
+func (r *fakeLastRunRepo) SetLastRunTimestamp(_ context.Context, name string, t time.Time) error {
+	r.cursors[name] = t
+	return nil
+}
+
+func (r *fakeLastRunRepo) GetResumePoint(_ context.Context, name string) (*domain.ResumePoint, error) {
+	return r.resume[name], nil
+}
+
+func (r *fakeLastRunRepo) SetResumePoint(_ context.Context, name string, point *domain.ResumePoint) error {
+	r.resume[name] = point
+	return nil
+}
+
+func (r *fakeLastRunRepo) ListLastRuns(context.Context, string) ([]domain.ParserCursor, error) {
+	return nil, nil
+}
+
+func (r *fakeLastRunRepo) DeleteLastRun(context.Context, string) error { return nil }
+
+func (r *fakeLastRunRepo) DeleteLastRunsByPrefix(context.Context, string) (int64, error) {
+	return 0, nil
+}
+
+func TestFetchLinksTruncatedRunsLeaveNoGap(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxLinks int
+		maxPages int
+	}{
+		{name: "link limit", maxLinks: 3},
+		{name: "page limit", maxPages: 1},
+		{name: "no limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &fakeFetcher{pageSize: 2}
+			fetcher.publish(time.Now().UTC().Add(-time.Hour), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+			queue := &fakeQueue{enqueued: make(map[int64]int)}
+			lastRun := &fakeLastRunRepo{cursors: make(map[string]time.Time), resume: make(map[string]*domain.ResumePoint)}
+			uc := NewFetchAndEnqueueLinksUseCase(fetcher, queue, lastRun, "kufar")
+
+			run := func() {
+				criteria := domain.SearchCriteria{
+					Category: "1010",
+					Limits:   domain.CrawlLimits{MaxPages: tt.maxPages},
+					Budget:   domain.NewLinkBudget(tt.maxLinks),
+				}
+				if _, err := uc.Execute(context.Background(), criteria, uuid.New()); err != nil {
+					t.Fatalf("Execute: %v", err)
+				}
+			}
+
+			for i := 0; i < 10; i++ {
+				run()
+				if i == 1 {
+					// Пока участок досматривается, появляются новые объявления
+					time.Sleep(time.Millisecond) // позже отметки пустого прохода
+					fetcher.publish(time.Now().UTC(), 11, 12)
+				}
+			}
+
+			for id := int64(1); id <= 12; id++ {
+				if queue.enqueued[id] == 0 {
+					t.Errorf("ad %d was never enqueued", id)
+				}
+			}
+			for name, point := range lastRun.resume {
+				if point != nil {
+					t.Errorf("resume point for %s left after the gap was closed: %+v", name, *point)
+				}
+			}
+			newest := fetcher.ads[0].ListedAt
+			for name, cursor := range lastRun.cursors {
+				if want := newest; cursor.Before(want) {
+					t.Errorf("cursor for %s: got %s, want at least %s", name, cursor, want)
+				}
+			}
+		})
+	}
+}
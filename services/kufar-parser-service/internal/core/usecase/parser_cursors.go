@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kufar-parser-service/internal/contextkeys"
+	"kufar-parser-service/internal/core/domain"
+	"kufar-parser-service/internal/core/port"
+)
+
+// GetParserCursorsUseCase возвращает сохраненные курсоры parser_last_runs
+type GetParserCursorsUseCase struct {
+	lastRunRepo port.LastRunRepositoryPort
+}
+
+func NewGetParserCursorsUseCase(lastRun port.LastRunRepositoryPort) *GetParserCursorsUseCase {
+	return &GetParserCursorsUseCase{lastRunRepo: lastRun}
+}
+
+func (uc *GetParserCursorsUseCase) Execute(ctx context.Context, prefix string) ([]domain.ParserCursor, error) {
+	cursors, err := uc.lastRunRepo.ListLastRuns(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parser cursors: %w", err)
+	}
+	return cursors, nil
+}
+
+// ResetParserCursorUseCase удаляет курсор одного ключа, чтобы следующий поиск прошел выдачу заново
+type ResetParserCursorUseCase struct {
+	lastRunRepo port.LastRunRepositoryPort
+}
+
+func NewResetParserCursorUseCase(lastRun port.LastRunRepositoryPort) *ResetParserCursorUseCase {
+	return &ResetParserCursorUseCase{lastRunRepo: lastRun}
+}
+
+func (uc *ResetParserCursorUseCase) Execute(ctx context.Context, parserName string) error {
+	logger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"use_case":    "ResetParserCursor",
+		"parser_name": parserName,
+	})
+
+	if err := uc.lastRunRepo.DeleteLastRun(ctx, parserName); err != nil {
+		if errors.Is(err, domain.ErrCursorNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to reset parser cursor: %w", err)
+	}
+	logger.Info("Parser cursor reset", nil)
+	return nil
+}
+
+// ResetParserCursorsByPrefixUseCase удаляет все курсоры с заданным префиксом ключа
+type ResetParserCursorsByPrefixUseCase struct {
+	lastRunRepo port.LastRunRepositoryPort
+}
+
+func NewResetParserCursorsByPrefixUseCase(lastRun port.LastRunRepositoryPort) *ResetParserCursorsByPrefixUseCase {
+	return &ResetParserCursorsByPrefixUseCase{lastRunRepo: lastRun}
+}
+
+func (uc *ResetParserCursorsByPrefixUseCase) Execute(ctx context.Context, prefix string) (int64, error) {
+	logger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"use_case": "ResetParserCursorsByPrefix",
+		"prefix":   prefix,
+	})
+
+	deleted, err := uc.lastRunRepo.DeleteLastRunsByPrefix(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset parser cursors: %w", err)
+	}
+	logger.Info("Parser cursors reset", port.Fields{"deleted": deleted})
+	return deleted, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"kufar-parser-service/internal/contextkeys"
 	"kufar-parser-service/internal/core/domain"
@@ -15,16 +16,19 @@ import (
 type ProcessLinkUseCase struct {
 	detailsFetcher port.KufarFetcherPort
 	resultQueue    port.ProcessedPropertyQueuePort
+	rawArchive     port.RawArchivePort // nil - архив выключен
 }
 
 // NewProcessLinkUseCase создает новый экземпляр use case
 func NewProcessLinkUseCase(
 	fetcher port.KufarFetcherPort,
 	queue port.ProcessedPropertyQueuePort,
+	rawArchive port.RawArchivePort,
 ) *ProcessLinkUseCase {
 	return &ProcessLinkUseCase{
 		detailsFetcher: fetcher,
 		resultQueue:    queue,
+		rawArchive:     rawArchive,
 	}
 }
 
@@ -44,6 +48,11 @@ func (uc *ProcessLinkUseCase) Execute(ctx context.Context, linkToParse domain.Pr
 	propertyRecord, fetchErr := uc.detailsFetcher.FetchAdDetails(ctx, linkToParse.AdID)
 	
 	if fetchErr != nil {
+		// Ответ, который не удалось смапить, тоже архивируем: после исправления маппера его прогонят заново
+		var mappingErr *domain.MappingError
+		if errors.As(fetchErr, &mappingErr) {
+			uc.archiveRaw(ctx, mappingErr.Raw, ucLogger)
+		}
 		ucLogger.Error("Failed to fetch/parse details", fetchErr, nil)
 		return fmt.Errorf("failed to fetch/parse details for %d: %w", linkToParse.AdID, fetchErr)
 	}
@@ -54,6 +63,8 @@ func (uc *ProcessLinkUseCase) Execute(ctx context.Context, linkToParse domain.Pr
 		ucLogger.Debug("Successfully parsed details.", nil)
 	}
 
+	propertyRecord.RawRef = uc.archiveRaw(ctx, propertyRecord.Raw, ucLogger)
+
 
 	// Используем порт для отправки результата в очередь
 	err := uc.resultQueue.Enqueue(ctx, *propertyRecord, taskID)
@@ -65,4 +76,18 @@ func (uc *ProcessLinkUseCase) Execute(ctx context.Context, linkToParse domain.Pr
 	// log.Printf("ProcessLinkUseCase: Successfully enqueued processed data for '%d'.\n", linkToParse.AdID)
 	ucLogger.Info("Successfully enqueued processed data", nil)
 	return nil
-}
\ No newline at end of file
+}
+
+// archiveRaw сохраняет ответ источника в архив. Ошибка архива не мешает обработке ссылки,
+// событие просто уйдет без ссылки на сырой ответ
+func (uc *ProcessLinkUseCase) archiveRaw(ctx context.Context, raw *domain.RawPayload, logger port.LoggerPort) *domain.RawPayloadRef {
+	if uc.rawArchive == nil || raw == nil {
+		return nil
+	}
+	ref, err := uc.rawArchive.Save(ctx, *raw)
+	if err != nil {
+		logger.Warn("Failed to archive raw payload", port.Fields{"error": err.Error()})
+		return nil
+	}
+	return &ref
+}
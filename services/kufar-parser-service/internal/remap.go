@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"kufar-parser-service/internal/adapters/kufarfetcher"
+	logger_adapter "kufar-parser-service/internal/adapters/logger"
+	rabbitmq_adapter "kufar-parser-service/internal/adapters/rabbitmq"
+	"kufar-parser-service/internal/adapters/rawarchive"
+	"kufar-parser-service/internal/configs"
+	"kufar-parser-service/internal/constants"
+	"kufar-parser-service/internal/contextkeys"
+	"kufar-parser-service/internal/core/domain"
+	"kufar-parser-service/internal/core/port"
+	"kufar-parser-service/internal/core/usecase"
+	"real-estate-system/pkg/blobstore"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+
+	"github.com/google/uuid"
+)
+
+// RunRemap прогоняет текущий маппер по архиву сырых ответов и публикует события заново.
+// Запускается отдельной командой (cmd/remap) с той же конфигурацией, что и сервис
+func RunRemap(ctx context.Context, opts domain.RemapOptions, taskID uuid.UUID) (domain.RemapStats, error) {
+	appConfig, err := configs.LoadConfig()
+	if err != nil {
+		return domain.RemapStats{}, fmt.Errorf("error loading application configuration: %w", err)
+	}
+	if appConfig.RawArchive.Dir == "" {
+		return domain.RemapStats{}, fmt.Errorf("RAW_ARCHIVE_DIR is empty, nothing to remap")
+	}
+
+	baseLogger := logger_adapter.NewSlogAdapter(logger_adapter.SlogConfig{
+		Level:    parseLogLevel(appConfig.StdoutLogger.Level),
+		UseColor: true,
+	}).WithFields(port.Fields{"service_name": appConfig.AppName, "component": "remap"})
+	ctx = contextkeys.ContextWithLogger(ctx, baseLogger)
+
+	blobStore, err := blobstore.NewFSStore(appConfig.RawArchive.Dir)
+	if err != nil {
+		return domain.RemapStats{}, fmt.Errorf("failed to open raw payload archive: %w", err)
+	}
+	rawArchiveAdapter := rawarchive.NewRawArchiveAdapter(blobStore)
+
+	var queue port.ProcessedPropertyQueuePort = discardQueue{}
+	if !opts.DryRun {
+		connManager, err := rabbitmq_common.GetManager(appConfig.RabbitMQ.URL, rabbitmq_adapter.NewPkgLoggerBridge(baseLogger))
+		if err != nil {
+			return domain.RemapStats{}, fmt.Errorf("failed to create connection manager: %w", err)
+		}
+		defer connManager.Close()
+
+		eventProducer, err := rabbitmq_producer.NewPublisher(rabbitmq_producer.PublisherConfig{
+			Config:                   rabbitmq_common.Config{URL: appConfig.RabbitMQ.URL},
+			ExchangeName:             constants.MainExchange,
+			ExchangeType:             "direct",
+			DurableExchange:          true,
+			DeclareExchangeIfMissing: true,
+			Logger:                   rabbitmq_adapter.NewPkgLoggerBridge(baseLogger),
+		}, connManager)
+		if err != nil {
+			return domain.RemapStats{}, fmt.Errorf("failed to create event producer: %w", err)
+		}
+		defer eventProducer.Close()
+
+		queue, err = rabbitmq_adapter.NewRabbitMQProcessedPropertyQueueAdapter(eventProducer, constants.RoutingKeyProcessedProperties)
+		if err != nil {
+			return domain.RemapStats{}, err
+		}
+	}
+
+	remapUseCase := usecase.NewRemapArchivedUseCase(rawArchiveAdapter, kufarfetcher.NewPayloadMapper(), queue, constants.SourceName)
+	return remapUseCase.Execute(ctx, opts, taskID)
+}
+
+// discardQueue - очередь для dry-run, use case до нее не доходит
+type discardQueue struct{}
+
+func (discardQueue) Enqueue(context.Context, domain.RealEstateRecord, uuid.UUID) error { return nil }
@@ -0,0 +1,3 @@
+ALTER TABLE parser_last_runs
+    DROP COLUMN IF EXISTS resume_before,
+    DROP COLUMN IF EXISTS resume_newest;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Недосмотренный участок выдачи после поиска, остановленного лимитом ссылок или страниц
+ALTER TABLE parser_last_runs
+    ADD COLUMN IF NOT EXISTS resume_before TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS resume_newest TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1,43 @@
+// remap заново прогоняет текущий маппер по архиву сырых ответов Realt (RAW_ARCHIVE_DIR)
+// и публикует события в storage-service. Нужен после исправления маппинга вместо повторного обхода сайта.
+//
+// Запуск из каталога сервиса: go run ./cmd/remap [-limit N] [-dry-run] [-task-id UUID]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"realt-parser-service/internal"
+	"realt-parser-service/internal/core/domain"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/google/uuid"
+)
+
+func main() {
+	limit := flag.Int("limit", 0, "max number of ads to remap, 0 - all")
+	dryRun := flag.Bool("dry-run", false, "only run the mapper, do not publish events")
+	taskIDStr := flag.String("task-id", "", "task to report results to; empty - results are not reported")
+	flag.Parse()
+
+	taskID := uuid.Nil
+	if *taskIDStr != "" {
+		parsed, err := uuid.Parse(*taskIDStr)
+		if err != nil {
+			log.Fatalf("invalid -task-id: %v", err)
+		}
+		taskID = parsed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	stats, err := internal.RunRemap(ctx, domain.RemapOptions{Limit: *limit, DryRun: *dryRun}, taskID)
+	if err != nil {
+		log.Fatalf("remap failed: %v", err)
+	}
+	fmt.Printf("scanned: %d, mapped: %d, published: %d, failed: %d\n", stats.Scanned, stats.Mapped, stats.Published, stats.Failed)
+}
@@ -4,12 +4,14 @@ go 1.24.4
 
 require (
 	github.com/fluent/fluent-logger-golang v1.10.1
+	github.com/go-chi/chi/v5 v5.2.3
 	github.com/gocolly/colly/v2 v2.2.0
 	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.7.6
 	github.com/joho/godotenv v1.5.1
 	github.com/lmittmann/tint v1.1.2
 	github.com/rabbitmq/amqp091-go v1.10.0
+	golang.org/x/text v0.31.0
 )
 
 require (
@@ -35,7 +37,6 @@ require (
 	golang.org/x/crypto v0.44.0 // indirect
 	golang.org/x/net v0.46.0 // indirect
 	golang.org/x/sync v0.18.0 // indirect
-	golang.org/x/text v0.31.0 // indirect
 	google.golang.org/appengine v1.6.8 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
 )
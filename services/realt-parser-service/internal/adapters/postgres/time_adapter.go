@@ -6,6 +6,7 @@ import (
 	"fmt"
 	// "log"
 	"realt-parser-service/internal/contextkeys"
+	"realt-parser-service/internal/core/domain"
 	"realt-parser-service/internal/core/port"
 	"time"
 
@@ -97,4 +98,120 @@ func (r *PostgresLastRunRepository) SetLastRunTimestamp(ctx context.Context, par
 // );
 
 // -- Можно добавить индекс для ускорения поиска
-// CREATE INDEX IF NOT EXISTS idx_parser_last_runs_parser_name ON parser_last_runs(parser_name);
\ No newline at end of file
+// CREATE INDEX IF NOT EXISTS idx_parser_last_runs_parser_name ON parser_last_runs(parser_name);
+
+
+// GetResumePoint возвращает точку продолжения поиска, остановленного лимитом. nil - точки нет
+func (r *PostgresLastRunRepository) GetResumePoint(ctx context.Context, parserName string) (*domain.ResumePoint, error) {
+	repoLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "PostgresLastRunRepository",
+		"method":    "GetResumePoint",
+	})
+
+	var before, newest *time.Time
+	query := `SELECT resume_before, resume_newest FROM parser_last_runs WHERE parser_name = $1`
+	err := r.dbPool.QueryRow(ctx, query, parserName).Scan(&before, &newest)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		repoLogger.Error("Error getting resume point", err, port.Fields{"parser_name": parserName})
+		return nil, fmt.Errorf("error querying resume point for parser '%s': %w", parserName, err)
+	}
+	if before == nil || newest == nil {
+		return nil, nil
+	}
+	return &domain.ResumePoint{Before: *before, Newest: *newest}, nil
+}
+
+// SetResumePoint сохраняет точку продолжения поиска, nil ее сбрасывает.
+// Если курсора еще нет, строка создается с нулевым временем: выдача ниже Before не просмотрена
+func (r *PostgresLastRunRepository) SetResumePoint(ctx context.Context, parserName string, point *domain.ResumePoint) error {
+	repoLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "PostgresLastRunRepository",
+		"method":    "SetResumePoint",
+	})
+
+	var before, newest *time.Time
+	if point != nil {
+		before, newest = &point.Before, &point.Newest
+	}
+
+	query := `
+        INSERT INTO parser_last_runs (parser_name, last_run_timestamp, resume_before, resume_newest)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (parser_name) DO UPDATE
+        SET resume_before = EXCLUDED.resume_before, resume_newest = EXCLUDED.resume_newest
+    `
+	if _, err := r.dbPool.Exec(ctx, query, parserName, time.Time{}, before, newest); err != nil {
+		repoLogger.Error("Error setting resume point", err, port.Fields{"parser_name": parserName})
+		return fmt.Errorf("error setting resume point for parser '%s': %w", parserName, err)
+	}
+	return nil
+}
+
+// ListLastRuns возвращает курсоры, ключ которых начинается с prefix
+func (r *PostgresLastRunRepository) ListLastRuns(ctx context.Context, prefix string) ([]domain.ParserCursor, error) {
+	repoLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "PostgresLastRunRepository",
+		"method":    "ListLastRuns",
+	})
+
+	query := `
+        SELECT parser_name, last_run_timestamp FROM parser_last_runs
+        WHERE left(parser_name, length($1)) = $1
+        ORDER BY parser_name
+    `
+	rows, err := r.dbPool.Query(ctx, query, prefix)
+	if err != nil {
+		repoLogger.Error("Error listing last run timestamps", err, port.Fields{"prefix": prefix})
+		return nil, fmt.Errorf("error listing last runs with prefix '%s': %w", prefix, err)
+	}
+	defer rows.Close()
+
+	cursors := make([]domain.ParserCursor, 0)
+	for rows.Next() {
+		var c domain.ParserCursor
+		if err := rows.Scan(&c.ParserName, &c.LastRunTimestamp); err != nil {
+			return nil, fmt.Errorf("error scanning last run row: %w", err)
+		}
+		cursors = append(cursors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating last run rows: %w", err)
+	}
+	return cursors, nil
+}
+
+// DeleteLastRun сбрасывает курсор одного ключа. Следующий поиск по нему начнется с начала
+func (r *PostgresLastRunRepository) DeleteLastRun(ctx context.Context, parserName string) error {
+	repoLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "PostgresLastRunRepository",
+		"method":    "DeleteLastRun",
+	})
+
+	tag, err := r.dbPool.Exec(ctx, `DELETE FROM parser_last_runs WHERE parser_name = $1`, parserName)
+	if err != nil {
+		repoLogger.Error("Error deleting last run timestamp", err, port.Fields{"parser_name": parserName})
+		return fmt.Errorf("error deleting last run for parser '%s': %w", parserName, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCursorNotFound
+	}
+	return nil
+}
+
+// DeleteLastRunsByPrefix сбрасывает все курсоры с заданным префиксом ключа
+func (r *PostgresLastRunRepository) DeleteLastRunsByPrefix(ctx context.Context, prefix string) (int64, error) {
+	repoLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "PostgresLastRunRepository",
+		"method":    "DeleteLastRunsByPrefix",
+	})
+
+	tag, err := r.dbPool.Exec(ctx, `DELETE FROM parser_last_runs WHERE left(parser_name, length($1)) = $1`, prefix)
+	if err != nil {
+		repoLogger.Error("Error deleting last run timestamps", err, port.Fields{"prefix": prefix})
+		return 0, fmt.Errorf("error deleting last runs with prefix '%s': %w", prefix, err)
+	}
+	return tag.RowsAffected(), nil
+}
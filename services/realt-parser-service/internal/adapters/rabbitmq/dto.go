@@ -11,6 +11,14 @@ type TaskInfo struct {
 	Category string `json:"category"`
 
     TaskID uuid.UUID `json:"task_id"`
+	ItemKey string `json:"item_key,omitempty"` // search-task 1.2, возвращается в отчете
+
+	// Необязательные ограничения поиска (search-task 1.1)
+	MaxLinks    int        `json:"max_links,omitempty"`
+	MaxPages    int        `json:"max_pages,omitempty"`
+	Since       *time.Time `json:"since,omitempty"`
+	Until       *time.Time `json:"until,omitempty"`
+	FullRecrawl bool       `json:"full_recrawl,omitempty"`
 }
 
 type LinkTaskDTO struct {
@@ -30,6 +38,15 @@ type ProcessedRealEstateEventDTO struct {
     Details     interface{}        `json:"details"`
 
 	TaskID 		uuid.UUID 			`json:"task_id"`
+
+	RawPayload *RawPayloadRefDTO `json:"raw_payload,omitempty"`
+}
+
+// RawPayloadRefDTO - ссылка на сырой ответ источника в архиве парсера
+type RawPayloadRefDTO struct {
+	Key       string    `json:"key"`
+	SHA256    string    `json:"sha256"`
+	FetchedAt time.Time `json:"fetched_at"`
 }
 
 // GeneralPropertyDTO - часть контракта для общей информации.
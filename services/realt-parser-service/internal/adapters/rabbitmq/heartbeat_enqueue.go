@@ -0,0 +1,97 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
+	"realt-parser-service/internal/contextkeys"
+	"realt-parser-service/internal/core/domain"
+	"realt-parser-service/internal/core/port"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// SourceHeartbeatAdapter реализует SourceHeartbeatPort для RabbitMQ
+type SourceHeartbeatAdapter struct {
+	producer   *rabbitmq_producer.Publisher
+	routingKey string
+}
+
+func NewSourceHeartbeatAdapter(producer *rabbitmq_producer.Publisher, routingKey string) (*SourceHeartbeatAdapter, error) {
+	if producer == nil {
+		return nil, fmt.Errorf("rabbitmq adapter: producer cannot be nil")
+	}
+	if routingKey == "" {
+		return nil, fmt.Errorf("rabbitmq adapter: routingKey cannot be empty")
+	}
+	return &SourceHeartbeatAdapter{
+		producer:   producer,
+		routingKey: routingKey,
+	}, nil
+}
+
+func (a *SourceHeartbeatAdapter) PublishHeartbeat(ctx context.Context, hb domain.SourceHeartbeat) error {
+
+	logger := contextkeys.LoggerFromContext(ctx)
+	adapterLogger := logger.WithFields(port.Fields{
+		"component":   "SourceHeartbeatAdapter",
+		"routing_key": a.routingKey,
+		"status":      hb.Status,
+	})
+
+	dto := types.SourceHeartbeatEventV1{
+		Name:             hb.Name,
+		DisplayName:      hb.DisplayName,
+		InstanceID:       hb.InstanceID,
+		SearchRoutingKey: hb.SearchRoutingKey,
+		LinkRoutingKey:   hb.LinkRoutingKey,
+		Categories:       nonNilStrings(hb.Categories),
+		Regions:          hb.Regions,
+		DealTypes:        hb.DealTypes,
+		Status:           hb.Status,
+		SentAt:           hb.SentAt,
+		IntervalSeconds:  int64(hb.Interval / time.Second),
+	}
+
+	body, err := schemas.Marshal(types.SourceHeartbeatEventV1EventType, types.SourceHeartbeatEventV1Version, dto)
+	if err != nil {
+		adapterLogger.Error("Failed to marshal heartbeat", err, nil)
+		return fmt.Errorf("rabbitmq adapter: failed to marshal heartbeat: %w", err)
+	}
+
+	msg := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+		// Устаревший heartbeat бесполезен, поэтому сообщение не сохраняется на диск и живет один интервал
+		DeliveryMode: amqp.Transient,
+		Expiration:   strconv.FormatInt(hb.Interval.Milliseconds(), 10),
+		Timestamp:    hb.SentAt,
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.SourceHeartbeatEventV1EventType,
+			schemas.HeaderEventVersion: types.SourceHeartbeatEventV1Version,
+		},
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := a.producer.Publish(publishCtx, a.routingKey, msg); err != nil {
+		adapterLogger.Error("Failed to publish heartbeat", err, nil)
+		return fmt.Errorf("rabbitmq adapter: failed to publish heartbeat: %w", err)
+	}
+
+	adapterLogger.Debug("Heartbeat published", nil)
+	return nil
+}
+
+// nonNilStrings - в схеме categories обязателен, nil сериализовался бы в null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"realt-parser-service/internal/contextkeys"
 	"realt-parser-service/internal/core/domain"
@@ -57,7 +58,7 @@ func NewLinkConsumerAdapter(
 }
 
 // messageHandler - приватный метод адаптера.
-func (a *LinkConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
+func (a *LinkConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) (err error) {
 	// 1. ИЗВЛЕКАЕМ ИЛИ ГЕНЕРИРУЕМ TRACE_ID
 	traceID, ok := d.Headers["x-trace-id"].(string)
 	if !ok || traceID == "" {
@@ -72,17 +73,21 @@ func (a *LinkConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 	})
 
 	// 3. ПОМЕЩАЕМ ЛОГГЕР И TRACE_ID В КОНТЕКСТ
-	ctx := context.Background()
 	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
 	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
 
 	msgLogger.Debug("Received new link task", nil)
 
+	if err := validateEvent(d); err != nil {
+		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
+		return err
+	}
+
 	var taskDTO LinkTaskDTO
 	if err := json.Unmarshal(d.Body, &taskDTO); err != nil {
 		msgLogger.Error("Error unmarshalling DTO, NACKing message", err, nil)
 		// Ошибка разбора JSON - это ПОСТОЯННАЯ ошибка. Нет смысла повторять.
-		return fmt.Errorf("unmarshal DTO error: %w", err)
+		return rabbitmq_consumer.NewPermanentError(fmt.Errorf("unmarshal DTO error: %w", err))
 	}
 
 	// Обогащаем логгер данными из задачи
@@ -101,6 +106,15 @@ func (a *LinkConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 
 	// Адаптер вызывает UseCase
 	err = a.useCase.Execute(ctx, linkToParse, taskDTO.TaskID)
+	var throttled *domain.ThrottledError
+	if errors.As(err, &throttled) {
+		// Источник блокирует запросы: повторяем не раньше, чем он просит
+		taskLogger.Warn("Source is throttling requests, retrying later", port.Fields{
+			"status":         throttled.StatusCode,
+			"retry_after_ms": throttled.RetryAfter.Milliseconds(),
+		})
+		return rabbitmq_consumer.NewRetryableError(err, throttled.RetryAfter)
+	}
 	if err != nil {
 		taskLogger.Error("Use case failed with a potentially transient error, requeueing", err, nil)
 		return err // Requeue=true
@@ -2,11 +2,12 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	// "log"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer" // Путь к вашему пакету продюсера
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"realt-parser-service/internal/contextkeys"
 	"realt-parser-service/internal/core/domain"
 	"realt-parser-service/internal/core/port"
@@ -57,7 +58,7 @@ func (a *RabbitMQLinkQueueAdapter) Enqueue(ctx context.Context, link domain.Prop
 		TaskID: taskID,
 	}
 
-	linkJSON, err := json.Marshal(linkTask)
+	linkJSON, err := schemas.Marshal(types.LinkTaskEventV1EventType, types.LinkTaskEventV1Version, linkTask)
 	if err != nil {
 		return fmt.Errorf("rabbitmq adapter: failed to marshal property link to JSON for AdID %d: %w", link.AdID, err)
 	}
@@ -68,7 +69,10 @@ func (a *RabbitMQLinkQueueAdapter) Enqueue(ctx context.Context, link domain.Prop
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
 		Priority:     PARSE_NEW,
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.LinkTaskEventV1EventType,
+			schemas.HeaderEventVersion: types.LinkTaskEventV1Version,
+		},
 	}
 
 	// Пробрасываем trace_id в заголовки сообщения
@@ -2,12 +2,15 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 
 	// "log"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"realt-parser-service/internal/contextkeys"
 	"realt-parser-service/internal/core/domain"
 	"realt-parser-service/internal/core/port"
@@ -110,6 +113,14 @@ func (a *RabbitMQProcessedPropertyQueueAdapter) Enqueue(ctx context.Context, rec
 		TaskID: taskID,
 	}
 
+	if record.RawRef != nil {
+		eventDTO.RawPayload = &RawPayloadRefDTO{
+			Key:       record.RawRef.Key,
+			SHA256:    record.RawRef.SHA256,
+			FetchedAt: record.RawRef.FetchedAt,
+		}
+	}
+
 	if record.Details != nil {
 		// 1. Получаем тип деталей (например, reflect.TypeOf(&domain.Apartment{}))
 		detailsType := reflect.TypeOf(record.Details)
@@ -133,9 +144,15 @@ func (a *RabbitMQProcessedPropertyQueueAdapter) Enqueue(ctx context.Context, rec
 		eventDTO.Details = detailsDTO
 	}
 
-	recordJSON, err := json.Marshal(eventDTO)
+	// Проверяем контракт до публикации, а не узнаем о нарушении от storage-service после цикла ретраев
+	recordJSON, err := schemas.Marshal(types.ProcessedRealEstateEventV1EventType, types.ProcessedRealEstateEventV1Version, eventDTO)
 	if err != nil {
 		adapterLogger.Error("Failed to marshal processed record to JSON", err, nil)
+		var validationErr *schemas.ValidationError
+		if errors.As(err, &validationErr) {
+			// Повтор парсинга даст то же самое событие, поэтому ссылка сразу уходит в DLQ
+			return rabbitmq_consumer.NewPermanentError(fmt.Errorf("processed record violates event contract: %w", err))
+		}
 		return fmt.Errorf("failed to marshal property record to JSON for URL %s: %w", record.General.Source, err)
 	}
 
@@ -145,8 +162,8 @@ func (a *RabbitMQProcessedPropertyQueueAdapter) Enqueue(ctx context.Context, rec
 		DeliveryMode: amqp.Persistent,
 		Timestamp:    time.Now(),
 		Headers: amqp.Table{
-			"event-type":    "ProcessedRealEstateEvent", // Название события из схемы
-			"event-version": "1.0.0",                    // Версия из схемы
+			schemas.HeaderEventType:    types.ProcessedRealEstateEventV1EventType,
+			schemas.HeaderEventVersion: types.ProcessedRealEstateEventV1Version,
 		},
 	}
 
@@ -2,11 +2,13 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	// "log"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
+	"realt-parser-service/internal/constants"
 	"realt-parser-service/internal/contextkeys"
 	"realt-parser-service/internal/core/domain"
 	"realt-parser-service/internal/core/port"
@@ -20,6 +22,19 @@ import (
 type TaskResultDTO struct {
 	TaskID  uuid.UUID      `json:"task_id"`
 	Results map[string]int `json:"results"`
+	Items   []string       `json:"items,omitempty"`
+
+	Subtasks []SubtaskProgressDTO `json:"subtasks,omitempty"`
+}
+
+// SubtaskProgressDTO - итог одного поиска для разбивки прогресса задачи (task-result 1.2)
+type SubtaskProgressDTO struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	LinksFound int    `json:"links_found"`
+	Errors     int    `json:"errors,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	LastError  string `json:"last_error,omitempty"`
 }
 
 type TaskReporterAdapter struct {
@@ -49,21 +64,54 @@ func (a *TaskReporterAdapter) ReportResults(ctx context.Context, taskID uuid.UUI
 	})
 
 	dto := TaskResultDTO{
-		TaskID: taskID,
-		Results: map[string]int{
-			"searches_completed": stats.SearchesCompleted,
-			"new_links_found":    stats.NewLinksFound,
-		},
+		TaskID:  taskID,
+		Results: map[string]int{},
+		Items:   stats.Items,
+	}
+
+	// Отчет только о неудачных поисках идет без счетчиков и не продвигает задачу
+	if stats.SearchesCompleted > 0 || len(stats.Subtasks) == 0 {
+		dto.Results["searches_completed"] = stats.SearchesCompleted
+		dto.Results["new_links_found"] = stats.NewLinksFound
+	}
+
+	// Счетчики ограничений добавляем, только если они были: сводка задачи суммирует все ключи
+	if stats.ThrottledRequests > 0 {
+		dto.Results["throttled_requests"] = stats.ThrottledRequests
+	}
+	if stats.CircuitRejections > 0 {
+		dto.Results["circuit_rejections"] = stats.CircuitRejections
 	}
 
-	body, _ := json.Marshal(dto)
+	for _, sub := range stats.Subtasks {
+		subtask := SubtaskProgressDTO{
+			Name:       sub.Name,
+			Source:     constants.SourceName,
+			LinksFound: sub.LinksFound,
+			DurationMs: sub.Duration.Milliseconds(),
+		}
+		if sub.Err != nil {
+			subtask.Errors = 1
+			subtask.LastError = sub.Err.Error()
+		}
+		dto.Subtasks = append(dto.Subtasks, subtask)
+	}
+
+	body, err := schemas.Marshal(types.TaskResultEventV1EventType, types.TaskResultEventV1Version, dto)
+	if err != nil {
+		adapterLogger.Error("Failed to marshal report for task", err, nil)
+		return fmt.Errorf("rabbitmq adapter: failed to marshal report for task %s: %w", taskID, err)
+	}
 
 	msg := amqp.Publishing{
 		ContentType:  "application/json",
 		Body:         body,
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.TaskResultEventV1EventType,
+			schemas.HeaderEventVersion: types.TaskResultEventV1Version,
+		},
 	}
 
 	traceID := contextkeys.TraceIDFromContext(ctx)
@@ -76,7 +124,7 @@ func (a *TaskReporterAdapter) ReportResults(ctx context.Context, taskID uuid.UUI
 	defer cancel()
 
 	adapterLogger.Debug("Publishing report for task", nil)
-	err := a.producer.Publish(publishCtx, a.routingKey, msg)
+	err = a.producer.Publish(publishCtx, a.routingKey, msg)
 	if err != nil {
 		adapterLogger.Error("Failed to publish report for task", err, nil)
 		return fmt.Errorf("rabbitmq adapter: failed to publish report for task %s: %w", taskID, err)
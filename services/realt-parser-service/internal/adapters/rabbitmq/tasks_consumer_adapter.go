@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"realt-parser-service/internal/constants"
 	"realt-parser-service/internal/contextkeys"
@@ -56,7 +57,7 @@ func NewTasksConsumerAdapter(
 }
 
 // messageHandler - приватный метод адаптера.
-func (a *TasksConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
+func (a *TasksConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) (err error) {
 	traceID, ok := d.Headers["x-trace-id"].(string)
 	if !ok || traceID == "" {
 		traceID = uuid.New().String()
@@ -69,17 +70,21 @@ func (a *TasksConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 	})
 
 	// Создаем контекст и кладем в него логгер
-	ctx := context.Background()
 	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
 	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
 
 	msgLogger.Info("Received new task", nil)
 
+	if err := validateEvent(d); err != nil {
+		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
+		return err
+	}
+
 	var taskDTO TaskInfo
 	if err := json.Unmarshal(d.Body, &taskDTO); err != nil {
 		msgLogger.Error("Error unmarshalling task DTO, NACKing message", err, nil)
 		// Ошибка разбора JSON - это ПОСТОЯННАЯ ошибка. Нет смысла повторять.
-		return fmt.Errorf("unmarshal error: %w", err)
+		return rabbitmq_consumer.NewPermanentError(fmt.Errorf("unmarshal error: %w", err))
 	}
 
 	// Обогащаем логгер ID задачи для всех последующих сообщений
@@ -93,7 +98,12 @@ func (a *TasksConsumerAdapter) messageHandler(d amqp.Delivery) (err error) {
 		return nil
 	}
 
-	if err := a.orchestrateUC.Execute(ctx, internalTasks, taskDTO.TaskID); err != nil {
+	if err := a.orchestrateUC.Execute(ctx, internalTasks, taskDTO.TaskID, taskDTO.ItemKey); err != nil {
+		var throttled *domain.ThrottledError
+		if errors.As(err, &throttled) {
+			taskLogger.Warn("Source is throttling requests, retrying search later", port.Fields{"retry_after_ms": throttled.RetryAfter.Milliseconds()})
+			return rabbitmq_consumer.NewRetryableError(err, throttled.RetryAfter)
+		}
 		msgLogger.Error("Orchestration use case failed", err, nil)
 		return err // Возвращаем ошибку для retry
 	}
@@ -134,6 +144,15 @@ func (a *TasksConsumerAdapter) translateDTOToInternalTasks(dto TaskInfo) ([]doma
 		return nil, fmt.Errorf("no search templates found for category: %s", dto.Category)
 	}
 
+	limits := domain.CrawlLimits{
+		MaxPages:    dto.MaxPages,
+		Since:       dto.Since,
+		Until:       dto.Until,
+		FullRecrawl: dto.FullRecrawl,
+	}
+	// Лимит ссылок общий на все поиски сообщения: лимит задачи уже поделен между сообщениями при отправке
+	budget := domain.NewLinkBudget(dto.MaxLinks)
+
 	// 2. Создаем итоговый срез задач
 	tasks := make([]domain.SearchCriteria, 0, len(templates))
 
@@ -165,6 +184,9 @@ func (a *TasksConsumerAdapter) translateDTOToInternalTasks(dto TaskInfo) ([]doma
 			// },
 			// Генерируем имя для логов
 			Name: fmt.Sprintf("FindNew_%s_%s", dto.Region, tmpl.Name),
+
+			Limits: limits,
+			Budget: budget,
 		}
 		tasks = append(tasks, task)
 	}
@@ -1,48 +1,117 @@
 package realtfetcher
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"net/http"
+	"net/url"
+	"real-estate-system/pkg/fetch_throttle"
+	"realt-parser-service/internal/core/domain"
+	"realt-parser-service/internal/core/port"
 
 	"github.com/gocolly/colly/v2"
 	"github.com/gocolly/colly/v2/extensions"
 )
 
+// Options - настройки ограничителя запросов, прокси и User-Agent
+type Options struct {
+	Throttle   fetch_throttle.Config
+	Proxies    []string // пусто - запросы идут напрямую
+	UserAgents []string // пусто - случайный User-Agent реального браузера
+}
+
 // RealtFetcherAdapter отвечает за все взаимодействия с сайтом Realt
 type RealtFetcherAdapter struct {
 	collector *colly.Collector
 	baseURL   string
+
+	// адаптивная пауза и circuit breaker, общие для всех запросов к Realt
+	guard  *fetch_throttle.Guard
+	logger port.LoggerPort
 }
 
 // NewRealtFetcherAdapter - конструктор
-func NewRealtFetcherAdapter(baseURL string) (*RealtFetcherAdapter, error) {
+func NewRealtFetcherAdapter(baseURL string, opts Options, logger port.LoggerPort) (*RealtFetcherAdapter, error) {
 
 	// Создаем родительский коллектор
 	c := colly.NewCollector(colly.AllowedDomains("realt.by"), colly.AllowURLRevisit())
 
+	// Паузы между запросами выставляет guard, здесь только параллелизм
 	err := c.Limit(&colly.LimitRule{
-		DomainGlob: "realt.by",
+		DomainGlob:  "realt.by",
 		Parallelism: 1,
-		RandomDelay: 3 * time.Second,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("RealtFetcherAdapter: Failed to set limit rule: %w", err)
 	}
 
-	
-	extensions.RandomUserAgent(c) // На каждый запрос будет подставлен User-Agent реального браузера
-	extensions.Referer(c)         // Автоматически подставляет заголовок Referer, имитируя навигацию
-
-
-	// c.OnError(func(r *colly.Response, err error) {
-	// 	log.Printf("RealtFetcherAdapter: Error during request to %s: Status=%d, Error=%v", r.Request.URL, r.StatusCode, err)
-	// })
-	// c.OnRequest(func(r *colly.Request) {
-	// 	log.Printf("RealtFetcherAdapter: Making request to %s", r.URL.String())
-	// })
+	if err := setupRotation(c, opts); err != nil {
+		return nil, fmt.Errorf("RealtFetcherAdapter: %w", err)
+	}
+	extensions.Referer(c) // Автоматически подставляет заголовок Referer, имитируя навигацию
 
-	return &RealtFetcherAdapter{
+	adapter := &RealtFetcherAdapter{
 		collector: c,
 		baseURL:   baseURL,
-	}, nil
-}
\ No newline at end of file
+		logger:    logger.WithFields(port.Fields{"component": "RealtFetcherAdapter(Throttle)"}),
+	}
+
+	throttleCfg := opts.Throttle
+	throttleCfg.OnStateChange = adapter.logStateChange
+	adapter.guard = fetch_throttle.New(throttleCfg)
+
+	return adapter, nil
+}
+
+// setupRotation включает ротацию прокси и User-Agent
+func setupRotation(c *colly.Collector, opts Options) error {
+	proxies := fetch_throttle.NewRotator(opts.Proxies)
+	if proxies.Len() > 0 {
+		for _, p := range opts.Proxies {
+			if _, err := url.Parse(p); err != nil {
+				return fmt.Errorf("invalid proxy URL '%s': %w", p, err)
+			}
+		}
+		// Без keep-alive каждое соединение открывается заново, иначе прокси не меняется между запросами
+		c.WithTransport(&http.Transport{DisableKeepAlives: true})
+		c.SetProxyFunc(func(r *http.Request) (*url.URL, error) {
+			return url.Parse(proxies.Next())
+		})
+	}
+
+	userAgents := fetch_throttle.NewRotator(opts.UserAgents)
+	if userAgents.Len() == 0 {
+		extensions.RandomUserAgent(c) // На каждый запрос будет подставлен User-Agent реального браузера
+		return nil
+	}
+	c.OnRequest(func(r *colly.Request) {
+		r.Headers.Set("User-Agent", userAgents.Next())
+	})
+	return nil
+}
+
+// Health реализует FetcherHealthPort
+func (a *RealtFetcherAdapter) Health() domain.FetcherHealth {
+	s := a.guard.Snapshot()
+	return domain.FetcherHealth{State: string(s.State), Delay: s.Delay, OpenUntil: s.OpenUntil}
+}
+
+// WaitAvailable реализует FetcherHealthPort
+func (a *RealtFetcherAdapter) WaitAvailable(ctx context.Context) error {
+	return a.guard.WaitClosed(ctx)
+}
+
+func (a *RealtFetcherAdapter) logStateChange(from, to fetch_throttle.State, s fetch_throttle.Snapshot) {
+	fields := port.Fields{
+		"from":     string(from),
+		"to":       string(to),
+		"delay_ms": s.Delay.Milliseconds(),
+		"trips":    s.Trips,
+	}
+	if to == fetch_throttle.StateOpen {
+		fields["open_until"] = s.OpenUntil
+		a.logger.Warn("Realt is blocking requests, circuit breaker opened", fields)
+		return
+	}
+	a.logger.Info("Circuit breaker state changed", fields)
+}
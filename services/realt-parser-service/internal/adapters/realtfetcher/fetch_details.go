@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	// "encoding/json"
 	// "net/url"
@@ -58,7 +59,16 @@ func (a *RealtFetcherAdapter) FetchAdDetails(ctx context.Context, adURL string,
 		rawJson = e.Text
 	})
 
+	collector.OnResponse(func(r *colly.Response) {
+		_ = a.afterResponse(ctx, r, nil)
+	})
+
 	collector.OnError(func(r *colly.Response, err error) {
+		if throttledErr := a.afterResponse(ctx, r, err); throttledErr != nil {
+			fetchDetailsLogger.Warn("Realt is throttling ad requests", port.Fields{"ad_id": adID, "status": r.StatusCode})
+			criticalError = throttledErr
+			return
+		}
 		
 		if r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusGone {
 			fetchDetailsLogger.Warn("Ad is not available (404/410), creating archive record", port.Fields{"ad_id": adID})
@@ -97,20 +107,39 @@ func (a *RealtFetcherAdapter) FetchAdDetails(ctx context.Context, adURL string,
 		}
 
 		
+		// В архив кладем только __NEXT_DATA__: маппер работает с ним, а не со всей страницей
+		raw := &domain.RawPayload{
+			Source:      "realt",
+			AdID:        adID,
+			URL:         adURL,
+			ContentType: "application/json",
+			FetchedAt:   time.Now(),
+			Body:        []byte(rawJson),
+		}
+
 		rec, err := toDomainRecord(rawJson, adURL, "realt", fetchDetailsLogger)
 		if err != nil {
 			fetchDetailsLogger.Error("Failed to map response to domain record", err, port.Fields{"ad_id": adID})
-			criticalError = fmt.Errorf("FetchAdDetails: failed to map response to domain record: %w", err)
+			criticalError = &domain.MappingError{
+				Raw: raw,
+				Err: fmt.Errorf("FetchAdDetails: failed to map response to domain record: %w", err),
+			}
 			return
 		}
+		rec.Raw = raw
 		record = rec
 		
 	})
 
-	_ = collector.Visit(adURL)
-	// if visitErr != nil {
-	// 	return nil, fmt.Errorf("FetchAdDetails: failed to visit URL %s: %w", adURL, visitErr)
-	// }
+	if err := a.beforeRequest(ctx); err != nil {
+		return nil, err
+	}
+	if visitErr := collector.Visit(adURL); visitErr != nil {
+		// Запрос не ушел, колбэки не вызовутся: слот пробного запроса освобождаем сами
+		a.guard.ReleaseProbe()
+		fetchDetailsLogger.Error("Failed to initiate visit for ad details", visitErr, port.Fields{"ad_id": adID, "url": adURL})
+		return nil, fmt.Errorf("FetchAdDetails: failed to visit URL %s: %w", adURL, visitErr)
+	}
 	collector.Wait() // Ждем завершения HTTP запроса и выполнения OnHTML
 
 	return record, criticalError
@@ -87,6 +87,8 @@ func (a *RealtFetcherAdapter) FetchLinks(ctx context.Context, criteria domain.Se
 	})
 
 	collector.OnResponse(func(r *colly.Response) {
+		_ = a.afterResponse(ctx, r, nil)
+
 		var data responseRoot
 		if err := json.Unmarshal(r.Body, &data); err != nil {
 			fetchLinksLogger.Error("failed to unmarshal links json", err, port.Fields{
@@ -130,6 +132,14 @@ func (a *RealtFetcherAdapter) FetchLinks(ctx context.Context, criteria domain.Se
 	})
 	
 	collector.OnError(func(r *colly.Response, err error) {
+		if throttledErr := a.afterResponse(ctx, r, err); throttledErr != nil {
+			fetchLinksLogger.Warn("Realt is throttling link requests", port.Fields{
+				"status":         r.StatusCode,
+				"retry_after_ms": throttledErr.RetryAfter.Milliseconds(),
+			})
+			responseErr = throttledErr
+			return
+		}
 		fetchLinksLogger.Error("Failed to fetch links page", err, port.Fields{
 			"url":    r.Request.URL.String(),
 			"status": r.StatusCode,
@@ -137,7 +147,11 @@ func (a *RealtFetcherAdapter) FetchLinks(ctx context.Context, criteria domain.Se
 		responseErr = fmt.Errorf("realt adapter: request to %s failed with status %d: %w", r.Request.URL, r.StatusCode, err)
 	})
 
+	if err := a.beforeRequest(ctx); err != nil {
+		return nil, 0, err
+	}
 	if err := collector.PostRaw(a.baseURL, jsonData); err != nil {
+		a.guard.ReleaseProbe()
 		fetchLinksLogger.Error("Failed to post request", err, port.Fields{"url": a.baseURL})
 		return nil, 0, fmt.Errorf("realt adapter: failed to post request: %w", err)
 	}
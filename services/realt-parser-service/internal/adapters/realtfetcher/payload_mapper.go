@@ -0,0 +1,29 @@
+package realtfetcher
+
+import (
+	"context"
+	"fmt"
+	"realt-parser-service/internal/contextkeys"
+	"realt-parser-service/internal/core/domain"
+	"realt-parser-service/internal/core/port"
+)
+
+// PayloadMapper прогоняет текущий маппер по сохраненному __NEXT_DATA__, не обращаясь к Realt
+type PayloadMapper struct{}
+
+func NewPayloadMapper() *PayloadMapper {
+	return &PayloadMapper{}
+}
+
+func (m *PayloadMapper) MapPayload(ctx context.Context, payload domain.RawPayload) (*domain.RealEstateRecord, error) {
+	logger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component": "RealtPayloadMapper",
+		"ad_id":     payload.AdID,
+	})
+
+	record, err := toDomainRecord(string(payload.Body), payload.URL, payload.Source, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map archived payload %d: %w", payload.AdID, err)
+	}
+	return record, nil
+}
@@ -0,0 +1,38 @@
+package realtfetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"real-estate-system/pkg/fetch_throttle"
+	"realt-parser-service/internal/contextkeys"
+	"realt-parser-service/internal/core/domain"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// beforeRequest дожидается очереди на запрос. При разомкнутой цепи возвращает *domain.ThrottledError
+func (a *RealtFetcherAdapter) beforeRequest(ctx context.Context) error {
+	err := a.guard.Acquire(ctx)
+	var open *fetch_throttle.OpenError
+	if errors.As(err, &open) {
+		contextkeys.FetchStatsFromContext(ctx).AddCircuitRejection()
+		return &domain.ThrottledError{RetryAfter: open.RetryAfter(), Err: err}
+	}
+	return err
+}
+
+// afterResponse передает guard результат запроса. Для ответа-блокировки возвращает *domain.ThrottledError
+func (a *RealtFetcherAdapter) afterResponse(ctx context.Context, r *colly.Response, cause error) *domain.ThrottledError {
+	var header http.Header
+	if r.Headers != nil {
+		header = *r.Headers
+	}
+	outcome := a.guard.Report(r.StatusCode, header)
+	// Сетевые ошибки guard учитывает, но блокировкой со стороны источника они не являются
+	if !outcome.Failure || r.StatusCode == 0 {
+		return nil
+	}
+	contextkeys.FetchStatsFromContext(ctx).AddThrottled()
+	return &domain.ThrottledError{StatusCode: r.StatusCode, RetryAfter: outcome.RetryAfter, Err: cause}
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"errors"
+	"realt-parser-service/internal/contextkeys"
+	"realt-parser-service/internal/core/domain"
+	"realt-parser-service/internal/core/port"
+	usecases_port "realt-parser-service/internal/core/port/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CursorHandlers - админское API для курсоров parser_last_runs
+type CursorHandlers struct {
+	getCursorsUC    usecases_port.GetParserCursorsPort
+	resetCursorUC   usecases_port.ResetParserCursorPort
+	resetByPrefixUC usecases_port.ResetParserCursorsByPrefixPort
+}
+
+func NewCursorHandlers(
+	getCursorsUC usecases_port.GetParserCursorsPort,
+	resetCursorUC usecases_port.ResetParserCursorPort,
+	resetByPrefixUC usecases_port.ResetParserCursorsByPrefixPort,
+) *CursorHandlers {
+	return &CursorHandlers{
+		getCursorsUC:    getCursorsUC,
+		resetCursorUC:   resetCursorUC,
+		resetByPrefixUC: resetByPrefixUC,
+	}
+}
+
+// GetCursors - GET /cursors?prefix=
+func (h *CursorHandlers) GetCursors(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetCursors"})
+
+	cursors, err := h.getCursorsUC.Execute(r.Context(), r.URL.Query().Get("prefix"))
+	if err != nil {
+		logger.Error("Failed to list parser cursors", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to list parser cursors")
+		return
+	}
+
+	response := make([]ParserCursorResponse, 0, len(cursors))
+	for _, c := range cursors {
+		response = append(response, toParserCursorResponse(c))
+	}
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
+// ResetCursor - DELETE /cursors/{key}
+func (h *CursorHandlers) ResetCursor(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ResetCursor", "parser_name": key})
+
+	if err := h.resetCursorUC.Execute(r.Context(), key); err != nil {
+		if errors.Is(err, domain.ErrCursorNotFound) {
+			WriteJSONError(w, http.StatusNotFound, "Cursor not found")
+			return
+		}
+		logger.Error("Failed to reset parser cursor", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to reset parser cursor")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetCursorsByPrefix - DELETE /cursors?prefix=
+// Префикс обязателен, чтобы случайный запрос не сбросил все курсоры сразу
+func (h *CursorHandlers) ResetCursorsByPrefix(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ResetCursorsByPrefix", "prefix": prefix})
+
+	if prefix == "" {
+		WriteJSONError(w, http.StatusBadRequest, "Query parameter 'prefix' is required")
+		return
+	}
+
+	deleted, err := h.resetByPrefixUC.Execute(r.Context(), prefix)
+	if err != nil {
+		logger.Error("Failed to reset parser cursors", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to reset parser cursors")
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, map[string]int64{"deleted": deleted})
+}
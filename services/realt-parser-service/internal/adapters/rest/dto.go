@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"realt-parser-service/internal/core/domain"
+	"time"
+)
+
+// ParserCursorResponse - курсор parser_last_runs для админского API
+type ParserCursorResponse struct {
+	ParserName       string    `json:"parser_name"`
+	LastRunTimestamp time.Time `json:"last_run_timestamp"`
+}
+
+func toParserCursorResponse(c domain.ParserCursor) ParserCursorResponse {
+	return ParserCursorResponse{
+		ParserName:       c.ParserName,
+		LastRunTimestamp: c.LastRunTimestamp,
+	}
+}
@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	core_port "realt-parser-service/internal/core/port"
+)
+
+type Server struct {
+	httpServer *http.Server
+	logger     core_port.LoggerPort
+}
+
+func NewServer(port string, cursorHandlers *CursorHandlers, baseLogger core_port.LoggerPort) *Server {
+	r := chi.NewRouter()
+
+	// Общие middleware
+	r.Use(LoggerMiddleware(baseLogger))
+	r.Use(middleware.Recoverer)
+
+	// Админское API курсоров (доступ проверяется на API Gateway)
+	r.Route("/api/v1/parsers/realt/cursors", func(r chi.Router) {
+		r.Get("/", cursorHandlers.GetCursors)
+		r.Delete("/", cursorHandlers.ResetCursorsByPrefix)
+		r.Delete("/{key}", cursorHandlers.ResetCursor)
+	})
+
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: r,
+	}
+
+	return &Server{
+		httpServer: srv,
+		logger:     baseLogger,
+	}
+}
+
+// Start запускает HTTP-сервер
+func (s *Server) Start() error {
+	s.logger.Info("Starting REST API server", core_port.Fields{"address": s.httpServer.Addr})
+	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		s.logger.Error("Could not start server", err, nil)
+		return fmt.Errorf("could not start server: %w", err)
+	}
+	return nil
+}
+
+// Stop корректно останавливает сервер
+func (s *Server) Stop(ctx context.Context) error {
+	s.logger.Info("Stopping REST API server...", nil)
+	return s.httpServer.Shutdown(ctx)
+}
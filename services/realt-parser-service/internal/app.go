@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -13,13 +14,18 @@ import (
 	logger_adapter "realt-parser-service/internal/adapters/logger"
 	postgres_adapter "realt-parser-service/internal/adapters/postgres"
 	rabbitmq_adapter "realt-parser-service/internal/adapters/rabbitmq"
+	"realt-parser-service/internal/adapters/rawarchive"
+	"realt-parser-service/internal/adapters/rest"
 	"realt-parser-service/internal/adapters/realtfetcher"
 	"realt-parser-service/internal/configs"
+	"realt-parser-service/internal/contextkeys"
 	"realt-parser-service/internal/constants"
 
-	// "realt-parser-service/internal/core/domain"
+	"realt-parser-service/internal/core/domain"
 	"realt-parser-service/internal/core/port"
 	// usecases_port "realt-parser-service/internal/core/port/usecases"
+	"real-estate-system/pkg/blobstore"
+	"real-estate-system/pkg/fetch_throttle"
 	fluentlogger "real-estate-system/pkg/fluent_logger"
 	"real-estate-system/pkg/postgres"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
@@ -28,8 +34,7 @@ import (
 	"realt-parser-service/internal/core/usecase"
 	"sync"
 	"syscall"
-
-	// "time"
+	"time"
 
 	"github.com/fluent/fluent-logger-golang/fluent"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -50,6 +55,13 @@ type App struct {
 	// Входящие порты (слушатели событий)
 	linkEventsListener   port.EventListenerPort
 	searchEventsListener port.EventListenerPort
+
+	// Объявляет парсер в реестре источников actualization-service
+	heartbeat     port.SourceHeartbeatPort
+	fetcherHealth port.FetcherHealthPort
+
+	// Админское API курсоров
+	apiServer *rest.Server
 }
 
 // NewApp создает новый экземпляр приложения.
@@ -149,6 +161,19 @@ func NewApp() (*App, error) {
 
 	realtAdapter, err := realtfetcher.NewRealtFetcherAdapter(
 		"https://realt.by/bff/graphql",
+		realtfetcher.Options{
+			Throttle: fetch_throttle.Config{
+				MinDelay:         appConfig.Fetcher.MinDelay,
+				MaxDelay:         appConfig.Fetcher.MaxDelay,
+				Jitter:           1, // от 0 до 2*MinDelay, как прежний RandomDelay
+				FailureThreshold: appConfig.Fetcher.BreakerThreshold,
+				OpenTimeout:      appConfig.Fetcher.BreakerOpenTimeout,
+				MaxOpenTimeout:   appConfig.Fetcher.BreakerMaxTimeout,
+			},
+			Proxies:    appConfig.Fetcher.Proxies,
+			UserAgents: appConfig.Fetcher.UserAgents,
+		},
+		baseLogger,
 	)
 	if err != nil {
 		appLogger.Error("Failed to create Kufar Fetcher Adapter", err, nil)
@@ -157,28 +182,55 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to initialize kufar fetcher: %w", err)
 	}
 
-	appLogger.Debug("Kufar Fetcher Adapter initialized.", nil)
+	appLogger.Debug("Realt Fetcher Adapter initialized.", port.Fields{
+		"proxies":     len(appConfig.Fetcher.Proxies),
+		"user_agents": len(appConfig.Fetcher.UserAgents),
+	})
 
 	linkQueueAdapter, _ := rabbitmq_adapter.NewRabbitMQLinkQueueAdapter(eventProducer, constants.RoutingKeyLinkTasks)
 	tasksResultsQueueAdapter, _ := rabbitmq_adapter.NewTaskReporterAdapter(eventProducer, constants.RoutingKeyTaskResults)
 	processedPropertyQueueAdapter, _ := rabbitmq_adapter.NewRabbitMQProcessedPropertyQueueAdapter(eventProducer, constants.RoutingKeyProcessedProperties)
+	heartbeatAdapter, _ := rabbitmq_adapter.NewSourceHeartbeatAdapter(eventProducer, constants.RoutingKeySourceHeartbeat)
+
+	// Архив сырых ответов: по нему исправленный маппер прогоняется без повторного обхода сайта
+	var rawArchiveAdapter port.RawArchivePort
+	if appConfig.RawArchive.Dir != "" {
+		blobStore, err := blobstore.NewFSStore(appConfig.RawArchive.Dir)
+		if err != nil {
+			appLogger.Error("Failed to create raw payload archive", err, port.Fields{"dir": appConfig.RawArchive.Dir})
+			eventProducer.Close()
+			dbPool.Close()
+			return nil, fmt.Errorf("failed to create raw payload archive: %w", err)
+		}
+		rawArchiveAdapter = rawarchive.NewRawArchiveAdapter(blobStore)
+	}
 	pgLastRunRepo, _ := postgres_adapter.NewPostgresLastRunRepository(dbPool)
 
 	appLogger.Debug("All outgoing adapters initialized.", nil)
 
 	// 3. ИНИЦИАЛИЗАЦИЯ USE CASES (ядра бизнес-логики)
 	fetchRealtUseCase := usecase.NewFetchAndEnqueueLinksUseCase(realtAdapter, linkQueueAdapter, pgLastRunRepo, "realt")
-	processLinkUseCase := usecase.NewProcessLinkUseCase(realtAdapter, processedPropertyQueueAdapter)
+	processLinkUseCase := usecase.NewProcessLinkUseCase(realtAdapter, processedPropertyQueueAdapter, rawArchiveAdapter)
 	orchestrateParsingUseCase := usecase.NewOrchestrateParsingUseCase(fetchRealtUseCase, tasksResultsQueueAdapter)
+	getParserCursorsUseCase := usecase.NewGetParserCursorsUseCase(pgLastRunRepo)
+	resetParserCursorUseCase := usecase.NewResetParserCursorUseCase(pgLastRunRepo)
+	resetParserCursorsByPrefixUseCase := usecase.NewResetParserCursorsByPrefixUseCase(pgLastRunRepo)
 	appLogger.Debug("All use cases initialized.", nil)
 
+	cursorHandlers := rest.NewCursorHandlers(getParserCursorsUseCase, resetParserCursorUseCase, resetParserCursorsByPrefixUseCase)
+	apiServer := rest.NewServer(appConfig.Rest.PORT, cursorHandlers, baseLogger.WithFields(port.Fields{"component": "http_server"}))
+
 	// 4. ИНИЦИАЛИЗАЦИЯ ВХОДЯЩИХ АДАПТЕРОВ (те, которые ВЫЗЫВАЮТ наше ядро)
 	linksConsumerCfg := rabbitmq_consumer.ConsumerConfig{
 		Config:              rabbitmq_common.Config{URL: appConfig.RabbitMQ.URL},
 		QueueName:           constants.QueueLinkTasks,
 		RoutingKeyForBind:   constants.RoutingKeyLinkTasks,
 		ExchangeNameForBind: constants.MainExchange,
-		PrefetchCount:       20,
+		PrefetchCount:       2,
+		// realt.by обходится одним коллектором с Parallelism: 1, поэтому больше одного воркера
+		// только держало бы неподтвержденные сообщения в ожидании лимитера
+		WorkerPoolSize:      1,
+		HandlerTimeout:      2 * time.Minute,
 		DurableQueue:        true,
 		ConsumerTag:         "link-processor-adapter",
 		DeclareQueue:        true,
@@ -205,9 +257,9 @@ func NewApp() (*App, error) {
 		FinalDLQ:           constants.FinalDLQ,
 		FinalDLQRoutingKey: constants.FinalDLQRoutingKey,
 
-		// 4. Задаем количество ретраев (помимо первой попытки).
-		MaxRetries: 3,
-	}
+		// Пока Realt нас блокирует, ссылки не берем в работу, а оставляем в очереди
+		Gate: rabbitmq_consumer.GateFunc(realtAdapter.WaitAvailable),
+	}.WithRetryTiers(constants.LinkTasksRetryDelays)
 	linkListener, err := rabbitmq_adapter.NewLinkConsumerAdapter(linksConsumerCfg, processLinkUseCase, baseLogger, connManager)
 	if err != nil {
 		appLogger.Error("Failed to initialize Search Events Listener", err, nil)
@@ -223,6 +275,7 @@ func NewApp() (*App, error) {
 		RoutingKeyForBind:   constants.RoutingKeySearchTasks,
 		ExchangeNameForBind: constants.MainExchange,
 		PrefetchCount:       1,
+		WorkerPoolSize:      1, // Сбор ссылок может идти долго, поэтому без HandlerTimeout
 		DurableQueue:        true,
 		ConsumerTag:         "search-tasks-processor-adapter",
 		DeclareQueue:        true,
@@ -245,10 +298,7 @@ func NewApp() (*App, error) {
 		FinalDLXExchange:   constants.FinalDLXExchangeForSearchTasks,
 		FinalDLQ:           constants.FinalDLQForSearchTasks,
 		FinalDLQRoutingKey: constants.FinalDLQRoutingKeyForSearchTasks,
-
-		// 4. Задаем количество ретраев (помимо первой попытки).
-		MaxRetries: 3,
-	}
+	}.WithRetryTiers(constants.SearchTasksRetryDelays)
 	searchTasksListener, err := rabbitmq_adapter.NewTasksConsumerAdapter(tasksConsumerCfg, orchestrateParsingUseCase, baseLogger, connManager)
 	if err != nil {
 		appLogger.Error("Failed to initialize Search Events Listener", err, nil)
@@ -268,6 +318,9 @@ func NewApp() (*App, error) {
 		// fetchRealtLinksUseCase:      fetchRealtUseCase, // Нужен для прямого вызова
 		linkEventsListener:   linkListener,
 		searchEventsListener: searchTasksListener,
+		heartbeat:            heartbeatAdapter,
+		fetcherHealth:        realtAdapter,
+		apiServer:            apiServer,
 	}
 
 	return application, nil
@@ -306,6 +359,12 @@ func (a *App) Run() error {
 		wg.Wait()
 		a.logger.Debug("All background processes finished.", nil)
 
+		if a.apiServer != nil {
+			if err := a.apiServer.Stop(context.Background()); err != nil {
+				a.logger.Error("Error during API server shutdown", err, nil)
+			}
+		}
+
 		// Теперь безопасно закрываем ресурсы
 		if a.linkEventsListener != nil {
 			if err := a.linkEventsListener.Close(); err != nil {
@@ -343,6 +402,13 @@ func (a *App) Run() error {
 
 	consumerErrors := make(chan error, 1)
 
+	go func() {
+		a.logger.Debug("Starting HTTP server...", port.Fields{"port": a.config.Rest.PORT})
+		if err := a.apiServer.Start(); err != nil && err != http.ErrServerClosed {
+			consumerErrors <- fmt.Errorf("HTTP server start error: %w", err)
+		}
+	}()
+
 	// Функция-хелпер для запуска слушателей
 	startListener := func(name string, listener port.EventListenerPort) {
 		defer wg.Done()
@@ -360,6 +426,12 @@ func (a *App) Run() error {
 	go startListener("Links Events Listener", a.linkEventsListener)
 	go startListener("Search Events Listener", a.searchEventsListener)
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.runHeartbeat(appCtx)
+	}()
+
 	// Ожидание сигнала на завершение или ошибки от одного из компонентов
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -381,6 +453,53 @@ func (a *App) Run() error {
 }
 
 
+// runHeartbeat сразу и затем раз в интервал объявляет парсер в реестре источников.
+// При остановке отправляет статус stopping, чтобы задачи перестали маршрутизироваться сюда,
+// не дожидаясь истечения TTL heartbeat
+func (a *App) runHeartbeat(ctx context.Context) {
+	hbLogger := a.logger.WithFields(port.Fields{"component": "source_heartbeat"})
+	hbCtx := contextkeys.ContextWithLogger(ctx, hbLogger)
+
+	ticker := time.NewTicker(a.config.Heartbeat.Interval)
+	defer ticker.Stop()
+
+	_ = a.heartbeat.PublishHeartbeat(hbCtx, a.newHeartbeat(a.currentStatus()))
+	for {
+		select {
+		case <-ctx.Done():
+			stopCtx := contextkeys.ContextWithLogger(context.Background(), hbLogger)
+			_ = a.heartbeat.PublishHeartbeat(stopCtx, a.newHeartbeat(domain.SourceStatusStopping))
+			return
+		case <-ticker.C:
+			_ = a.heartbeat.PublishHeartbeat(hbCtx, a.newHeartbeat(a.currentStatus()))
+		}
+	}
+}
+
+// currentStatus - degraded, пока Realt блокирует запросы и circuit breaker не замкнут
+func (a *App) currentStatus() string {
+	if a.fetcherHealth.Health().State != domain.FetcherStateClosed {
+		return domain.SourceStatusDegraded
+	}
+	return domain.SourceStatusHealthy
+}
+
+func (a *App) newHeartbeat(status string) domain.SourceHeartbeat {
+	return domain.SourceHeartbeat{
+		Name:             constants.SourceName,
+		DisplayName:      constants.SourceDisplayName,
+		InstanceID:       a.config.Heartbeat.InstanceID,
+		SearchRoutingKey: constants.RoutingKeySearchTasks,
+		LinkRoutingKey:   constants.RoutingKeyLinkTasks,
+		Categories:       constants.SupportedCategories(),
+		Regions:          constants.SupportedRegions(),
+		DealTypes:        constants.SupportedDealTypes,
+		Status:           status,
+		SentAt:           time.Now().UTC(),
+		Interval:         a.config.Heartbeat.Interval,
+	}
+}
+
 func parseLogLevel(levelStr string) slog.Level {
 	switch strings.ToLower(levelStr) {
 	case "debug":
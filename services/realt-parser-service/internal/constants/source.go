@@ -0,0 +1,32 @@
+package constants
+
+import "sort"
+
+// Описание источника для реестра источников actualization-service
+const (
+	SourceName        = "realt"
+	SourceDisplayName = "Realt"
+)
+
+// SupportedDealTypes - типы сделок, объявления по которым собирает парсер
+var SupportedDealTypes = []string{"sale", "rent"}
+
+// SupportedCategories возвращает бизнес-категории, для которых есть соответствие на Realt
+func SupportedCategories() []string {
+	categories := make([]string, 0, len(BusinessCategoryToTemplatesMap))
+	for category := range BusinessCategoryToTemplatesMap {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// SupportedRegions возвращает регионы, для которых есть соответствие на Realt
+func SupportedRegions() []string {
+	regions := make([]string, 0, len(RegionToRealtMap))
+	for region := range RegionToRealtMap {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCursorNotFound - для ключа парсера нет сохраненного курсора
+var ErrCursorNotFound = errors.New("parser cursor not found")
+
+// ParserCursor - сохраненная отметка времени, с которой парсер продолжает поиск
+type ParserCursor struct {
+	ParserName       string
+	LastRunTimestamp time.Time
+}
+
+// ResumePoint - недосмотренный участок выдачи после поиска, остановленного лимитом.
+// Объявления новее курсора и старше Before еще не просмотрены, от Before до Newest - уже в очереди.
+// Следующие поиски продолжают ниже Before, а курсор переходит на Newest, когда участок досмотрен
+type ResumePoint struct {
+	Before time.Time
+	Newest time.Time
+}
+
+// CrawlLimits - ограничения одного поиска, приходят вместе с задачей FIND_NEW
+type CrawlLimits struct {
+	MaxPages    int        // 0 - без ограничения
+	Since       *time.Time // явная нижняя граница вместо курсора
+	Until       *time.Time // объявления новее пропускаются
+	FullRecrawl bool       // игнорировать курсор и обходить выдачу целиком
+}
+
+// HasExplicitWindow сообщает, что окно поиска задано явно, а не курсором
+func (l CrawlLimits) HasExplicitWindow() bool {
+	return l.Since != nil || l.Until != nil
+}
+
+// LinkBudget - лимит ссылок, общий для всех поисков одного сообщения с задачей.
+// nil означает отсутствие лимита
+type LinkBudget struct {
+	remaining atomic.Int64
+}
+
+// NewLinkBudget создает бюджет на max ссылок. При max <= 0 возвращает nil
+func NewLinkBudget(max int) *LinkBudget {
+	if max <= 0 {
+		return nil
+	}
+	b := &LinkBudget{}
+	b.remaining.Store(int64(max))
+	return b
+}
+
+// Take резервирует одну ссылку. false - бюджет исчерпан
+func (b *LinkBudget) Take() bool {
+	if b == nil {
+		return true
+	}
+	if b.remaining.Add(-1) >= 0 {
+		return true
+	}
+	b.remaining.Add(1)
+	return false
+}
+
+// Release возвращает зарезервированную ссылку, если ее не удалось отправить
+func (b *LinkBudget) Release() {
+	if b != nil {
+		b.remaining.Add(1)
+	}
+}
+
+// Exhausted сообщает, что ссылок больше брать нельзя
+func (b *LinkBudget) Exhausted() bool {
+	return b != nil && b.remaining.Load() <= 0
+}
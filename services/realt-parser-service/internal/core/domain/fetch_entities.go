@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Состояния ограничителя запросов к источнику
+const (
+	FetcherStateClosed   = "closed"
+	FetcherStateOpen     = "open"
+	FetcherStateHalfOpen = "half_open"
+)
+
+// ThrottledError - источник ограничивает нас (429/403/5xx) или запросы приостановлены
+// circuit breaker. RetryAfter - когда имеет смысл попробовать снова
+type ThrottledError struct {
+	StatusCode int // 0, если запрос не отправлялся
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("source is throttling requests (status %d, retry after %s): %v", e.StatusCode, e.RetryAfter, e.Err)
+}
+
+func (e *ThrottledError) Unwrap() error { return e.Err }
+
+// FetcherHealth - состояние ограничителя для логов и heartbeat
+type FetcherHealth struct {
+	State     string
+	Delay     time.Duration // текущая пауза между запросами
+	OpenUntil time.Time
+}
+
+// FetchStats - счетчики ограничений за одну задачу, попадают в сводку задачи.
+// Методы безопасны для nil
+type FetchStats struct {
+	throttled         atomic.Int64
+	circuitRejections atomic.Int64
+}
+
+// AddThrottled учитывает ответ-блокировку источника
+func (s *FetchStats) AddThrottled() {
+	if s != nil {
+		s.throttled.Add(1)
+	}
+}
+
+// AddCircuitRejection учитывает запрос, не отправленный из-за разомкнутой цепи
+func (s *FetchStats) AddCircuitRejection() {
+	if s != nil {
+		s.circuitRejections.Add(1)
+	}
+}
+
+func (s *FetchStats) Throttled() int {
+	if s == nil {
+		return 0
+	}
+	return int(s.throttled.Load())
+}
+
+func (s *FetchStats) CircuitRejections() int {
+	if s == nil {
+		return 0
+	}
+	return int(s.circuitRejections.Load())
+}
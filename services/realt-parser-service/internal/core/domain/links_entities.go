@@ -39,4 +39,7 @@ type SearchCriteria struct {
 	Price  interface{}
 
 	Rooms		[]int
+
+	Limits CrawlLimits
+	Budget *LinkBudget // общий для всех поисков одного сообщения
 }
\ No newline at end of file
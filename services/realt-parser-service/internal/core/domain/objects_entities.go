@@ -15,6 +15,9 @@ const (
 type RealEstateRecord struct {
     General GeneralProperty
     Details interface{} // Сюда будет помещен указатель на Apartment, House, Commercial и т.д.
+
+    Raw    *RawPayload    // ответ источника, заполняет фетчер
+    RawRef *RawPayloadRef // где ответ лежит в архиве
 }
 
 // GeneralProperty представляет основную, общую информацию для любого объекта недвижимости.
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"realt-parser-service/internal/core/domain"
 	"time"
 )
 
@@ -10,4 +11,13 @@ import (
 type LastRunRepositoryPort interface {
 	GetLastRunTimestamp(ctx context.Context, parserName string) (time.Time, error)
 	SetLastRunTimestamp(ctx context.Context, parserName string, t time.Time) error
+
+	// Точка продолжения поиска, остановленного лимитом. nil - выдача досмотрена до курсора
+	GetResumePoint(ctx context.Context, parserName string) (*domain.ResumePoint, error)
+	SetResumePoint(ctx context.Context, parserName string, point *domain.ResumePoint) error
+
+	// Админские операции над курсорами. Пустой prefix означает все ключи
+	ListLastRuns(ctx context.Context, prefix string) ([]domain.ParserCursor, error)
+	DeleteLastRun(ctx context.Context, parserName string) error
+	DeleteLastRunsByPrefix(ctx context.Context, prefix string) (int64, error)
 }
\ No newline at end of file
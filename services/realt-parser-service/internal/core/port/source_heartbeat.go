@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"realt-parser-service/internal/core/domain"
+)
+
+// SourceHeartbeatPort публикует heartbeat парсера в реестр источников
+type SourceHeartbeatPort interface {
+	PublishHeartbeat(ctx context.Context, hb domain.SourceHeartbeat) error
+}
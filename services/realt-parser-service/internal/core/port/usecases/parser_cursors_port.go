@@ -0,0 +1,18 @@
+package usecases_port
+
+import (
+	"context"
+	"realt-parser-service/internal/core/domain"
+)
+
+type GetParserCursorsPort interface {
+	Execute(ctx context.Context, prefix string) ([]domain.ParserCursor, error)
+}
+
+type ResetParserCursorPort interface {
+	Execute(ctx context.Context, parserName string) error
+}
+
+type ResetParserCursorsByPrefixPort interface {
+	Execute(ctx context.Context, prefix string) (int64, error)
+}
@@ -57,24 +57,56 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 
 	parserLogger := ucLogger.WithFields(port.Fields{"parser_key": parserNameKey})
 
-	lastRunTime, err := uc.lastRunRepo.GetLastRunTimestamp(ctx, parserNameKey) // Делаем ключ уникальным для комбинации фильтров
+	limits := initialCriteria.Limits
+	storedRunTime, err := uc.lastRunRepo.GetLastRunTimestamp(ctx, parserNameKey) // Делаем ключ уникальным для комбинации фильтров
 	if err != nil {
-		// Если ошибка (например, нет записи), можем начать с "начала времен" или за определенный период назад
+		// Если ошибка (например, нет записи), начинаем с "начала времен"
 		parserLogger.Warn("Could not get last run timestamp, fetching from the beginning.", port.Fields{"error": err.Error()})
-		lastRunTime = time.Time{} // Или, например, time.Now().Add(-24 * time.Hour)
+		storedRunTime = time.Time{}
 	} else {
-		parserLogger.Info("Last run timestamp found", port.Fields{"last_run_time": lastRunTime})
+		parserLogger.Info("Last run timestamp found", port.Fields{"last_run_time": storedRunTime})
 	}
 
-	//FOR DEBUG
-	// lastRunTime = time.Time{}
-	const debugLinkLimit = 30 // Собираем не больше 5 ссылок для каждого вызова
-	stopFetching := false
+	// Нижняя граница поиска: явная из задачи, затем курсор, если не запрошен полный обход
+	lastRunTime := storedRunTime
+	switch {
+	case limits.Since != nil:
+		lastRunTime = *limits.Since
+	case limits.FullRecrawl:
+		lastRunTime = time.Time{}
+	}
+	// Прошлый поиск остановлен лимитом: продолжаем ниже последней обработанной ссылки,
+	// более новые объявления заберем, когда участок будет досмотрен
+	until := limits.Until
+	var resume *domain.ResumePoint
+	if !limits.HasExplicitWindow() && !limits.FullRecrawl {
+		resume, err = uc.lastRunRepo.GetResumePoint(ctx, parserNameKey)
+		if err != nil {
+			parserLogger.Warn("Could not get resume point, continuing from the newest listings.", port.Fields{"error": err.Error()})
+			resume = nil
+		}
+		if resume != nil {
+			until = &resume.Before
+			parserLogger.Info("Resuming truncated search", port.Fields{"before": resume.Before, "newest": resume.Newest})
+		}
+	}
+
+	if !lastRunTime.Equal(storedRunTime) {
+		parserLogger.Info("Search window overridden by task", port.Fields{
+			"since":        lastRunTime,
+			"until":        limits.Until,
+			"full_recrawl": limits.FullRecrawl,
+		})
+	}
+
+	truncated := false // поиск остановлен лимитом, выдача пройдена не до конца
 
 	currentCriteria := initialCriteria
 	newLinksFoundOverall := 0
 	totalPagesProcessed := 0
+	limitedPages := 0 // страницы в счет MaxPages
 	var latestAdTimeOnCurrentRun time.Time // Для сохранения самой новой даты объявления в текущем запуске
+	var lastProcessedAdTime time.Time      // дата последней поставленной в очередь ссылки (выдача по убыванию)
 
 	for {
 		select {
@@ -82,6 +114,17 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
             return 0, ctx.Err() // Прерываемся, если пришел сигнал о завершении
         default:
         }
+
+		if currentCriteria.Budget.Exhausted() {
+			parserLogger.Info("Task link limit reached. Stopping fetch process.", port.Fields{"total_found": newLinksFoundOverall})
+			truncated = true
+			break
+		}
+		if limits.MaxPages > 0 && limitedPages >= limits.MaxPages {
+			parserLogger.Info("Page limit reached. Stopping fetch process.", port.Fields{"max_pages": limits.MaxPages})
+			truncated = true
+			break
+		}
 		
 		totalPagesProcessed++
 		pageLogger := parserLogger.WithFields(port.Fields{
@@ -92,7 +135,7 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 		links, nextPage, fetchErr := uc.fetcherRepo.FetchLinks(ctx, currentCriteria, lastRunTime)
 		if fetchErr != nil {
 			pageLogger.Error("Error fetching links", fetchErr, nil)
-			return 0, fmt.Errorf("use case: error fetching links for source '%s' with criteria %+v: %w", uc.sourceName, currentCriteria, fetchErr)
+			return 0, fmt.Errorf("use case: error fetching links for source '%s' with criteria %s: %w", uc.sourceName, currentCriteria.Name, fetchErr)
 		}
 
 		if len(links) == 0 && nextPage == 0 {
@@ -103,11 +146,23 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 		}
 		
 		newLinksOnPage := 0
+		belowResumeOnPage := 0
 		for _, link := range links {
+			// Объявления новее until пропускаем, но продолжаем листать выдачу
+			if until != nil && link.ListedAt.After(*until) {
+				continue
+			}
+			if resume != nil && link.ListedAt.Before(resume.Before) {
+				belowResumeOnPage++
+			}
+			if !currentCriteria.Budget.Take() {
+				truncated = true
+				break
+			}
 			link.Source = uc.sourceName // Добавляем источник
 			err = uc.queueRepo.Enqueue(ctx, link, taskID)
 			if err != nil {
-				// Здесь можно решить, что делать: пропустить ссылку, повторить, остановить всё
+				currentCriteria.Budget.Release()
 				pageLogger.Error("Error enqueuing link, skipping", err, port.Fields{"ad_id": link.AdID})
 				continue // Пропускаем эту ссылку, но продолжаем с остальными
 			}
@@ -116,27 +171,24 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 			if link.ListedAt.After(latestAdTimeOnCurrentRun) { // Обновляем самое свежее время
 				latestAdTimeOnCurrentRun = link.ListedAt
 			}
-
-			//FOR DEBUG
-			if newLinksFoundOverall >= debugLinkLimit {
-				parserLogger.Warn("DEBUG: Link limit reached. Stopping fetch process.", port.Fields{
-					"limit": debugLinkLimit,
-					"total_found": newLinksFoundOverall,
-				})
-				stopFetching = true // Устанавливаем флаг для выхода из внешнего цикла
-				break // Выходим из внутреннего цикла `for _, link := ...`
-			}
+			lastProcessedAdTime = link.ListedAt
 		}
 
-		//FOR DEBUG
-		if stopFetching {
-			break 
+		// При продолжении страницы выше точки продолжения уже обработаны, их лимит страниц не считает,
+		// иначе поиск не дойдет до недосмотренного участка
+		if resume == nil || len(links) == 0 || belowResumeOnPage > 0 {
+			limitedPages++
 		}
 
 		if newLinksOnPage > 0 {
 			pageLogger.Debug("Enqueued new links from page", port.Fields{"count": newLinksOnPage})
 		}
 
+		if truncated {
+			parserLogger.Info("Task link limit reached. Stopping fetch process.", port.Fields{"total_found": newLinksFoundOverall})
+			break
+		}
+
 		if nextPage == 0 {
 			parserLogger.Debug("No next page. Pagination finished.", nil)
 			break
@@ -144,29 +196,9 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 
 		// log.Printf("Use Case: Fetched %d new links from page. Next page: %d\n", newLinksOnPage, nextPage)
 		currentCriteria.Page = nextPage
-
-		// Опционально: добавить задержку между запросами страниц пагинации здесь, если
-		// fetcherRepo не управляет этим сам (хотя Colly управляет).
-		// time.Sleep(1 * time.Second)
 	}
 
-	// Обновляем lastRunTime, если были найдены новые ссылки
-	if newLinksFoundOverall > 0 && !latestAdTimeOnCurrentRun.IsZero() {
-		// Устанавливаем время самого нового объявления, которое мы обработали в этом запуске
-		err = uc.lastRunRepo.SetLastRunTimestamp(ctx, parserNameKey, latestAdTimeOnCurrentRun)
-		if err != nil {
-			parserLogger.Error("Error setting last run timestamp", err, port.Fields{"new_timestamp": latestAdTimeOnCurrentRun})
-		} else {
-			parserLogger.Info("Successfully set last run timestamp", port.Fields{"new_timestamp": latestAdTimeOnCurrentRun})
-		}
-	} else if newLinksFoundOverall == 0 && totalPagesProcessed > 0 && !lastRunTime.IsZero() {
-        // Если мы прошлись по страницам, но не нашли НИ ОДНОЙ НОВОЙ ссылки (т.е. все были отсеяны по 'since' в адаптере,
-        // или их просто не было), но при этом lastRunTime не нулевой, то можно его обновить на текущее время,
-        // чтобы показать, что мы проверяли. Но это опционально, можно и не обновлять, если ничего нового не было.
-        currentTime := time.Now().UTC()
-        uc.lastRunRepo.SetLastRunTimestamp(ctx, parserNameKey, currentTime)
-		parserLogger.Info("No new links found, but checked. Updated last run to current time.", port.Fields{"new_timestamp": currentTime})
-    }
+	uc.updateLastRun(ctx, parserLogger, parserNameKey, storedRunTime, resume, latestAdTimeOnCurrentRun, lastProcessedAdTime, newLinksFoundOverall, limits, truncated)
 
 	ucLogger.Info("Finished fetching links", port.Fields{
 		"total_links_enqueued": newLinksFoundOverall,
@@ -174,4 +206,70 @@ func (uc *FetchAndEnqueueLinksUseCase) Execute(ctx context.Context, initialCrite
 	})
 	
 	return newLinksFoundOverall, nil
-}
\ No newline at end of file
+}
+
+// updateLastRun сдвигает курсор parser_last_runs после поиска.
+// Курсор не трогаем, если окно задано явно. Если поиск остановлен лимитом, курсор остается на месте,
+// а недосмотренный участок ниже последней обработанной ссылки запоминается в ResumePoint.
+// Когда участок досмотрен, курсор переходит на самое новое обработанное объявление. Назад курсор не сдвигается
+func (uc *FetchAndEnqueueLinksUseCase) updateLastRun(
+	ctx context.Context,
+	logger port.LoggerPort,
+	parserNameKey string,
+	storedRunTime time.Time,
+	resume *domain.ResumePoint,
+	latestAdTime, lastProcessedAdTime time.Time,
+	linksFound int,
+	limits domain.CrawlLimits,
+	truncated bool,
+) {
+	if limits.HasExplicitWindow() {
+		logger.Info("Last run timestamp left unchanged", port.Fields{"explicit_window": true})
+		return
+	}
+
+	newest := latestAdTime
+	if resume != nil && resume.Newest.After(newest) {
+		newest = resume.Newest
+	}
+
+	if truncated {
+		if linksFound == 0 {
+			logger.Info("Last run timestamp left unchanged", port.Fields{"truncated": true})
+			return
+		}
+		point := &domain.ResumePoint{Before: lastProcessedAdTime, Newest: newest}
+		if err := uc.lastRunRepo.SetResumePoint(ctx, parserNameKey, point); err != nil {
+			logger.Error("Error setting resume point", err, port.Fields{"before": point.Before})
+			return
+		}
+		logger.Info("Search truncated, resume point saved", port.Fields{"before": point.Before, "newest": point.Newest})
+		return
+	}
+
+	var newTimestamp time.Time
+	switch {
+	case (linksFound > 0 || resume != nil) && newest.After(storedRunTime):
+		// время самого нового объявления, которое мы обработали в этом запуске или до остановки лимитом
+		newTimestamp = newest
+	case linksFound == 0 && resume == nil && !storedRunTime.IsZero():
+		// Если мы прошлись по страницам, но не нашли ни одной новой ссылки,
+		// обновляем курсор на текущее время, чтобы показать, что мы проверяли
+		newTimestamp = time.Now().UTC()
+	}
+
+	if !newTimestamp.IsZero() {
+		if err := uc.lastRunRepo.SetLastRunTimestamp(ctx, parserNameKey, newTimestamp); err != nil {
+			logger.Error("Error setting last run timestamp", err, port.Fields{"new_timestamp": newTimestamp})
+			return
+		}
+		logger.Info("Successfully set last run timestamp", port.Fields{"new_timestamp": newTimestamp})
+	}
+
+	// Участок досмотрен: точку продолжения сбрасываем только после сдвига курсора
+	if resume != nil {
+		if err := uc.lastRunRepo.SetResumePoint(ctx, parserNameKey, nil); err != nil {
+			logger.Error("Error clearing resume point", err, nil)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"realt-parser-service/internal/core/port"
 	usecases_port "realt-parser-service/internal/core/port/usecases"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -28,7 +29,7 @@ func NewOrchestrateParsingUseCase(
 	}
 }
 
-func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks []domain.SearchCriteria, taskID uuid.UUID) error {
+func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks []domain.SearchCriteria, taskID uuid.UUID, itemKey string) error {
     
 	baseLogger := contextkeys.LoggerFromContext(ctx)
 	ucLogger := baseLogger.WithFields(port.Fields{
@@ -46,6 +47,7 @@ func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks
         report := &domain.ParsingTasksStats{
 			SearchesCompleted: 0,
 			NewLinksFound: 0,
+			Items: reportedItems(itemKey),
         }
 
 		if err := uc.reporter.ReportResults(ctx, taskID, report); err != nil {
@@ -60,11 +62,11 @@ func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks
    // 2. Запускаем все подзадачи ПАРАЛЛЕЛЬНО и ждем их завершения
 	var wg sync.WaitGroup
     // Создаем канал для сбора статистики из горутин
-    type subTaskResult struct {
-        linksCount int
-        err        error
-    }
-    resultsChan := make(chan subTaskResult, len(internalTasks))
+    resultsChan := make(chan domain.SubtaskStats, len(internalTasks))
+
+	// Счетчики ограничений источника за всю задачу
+	fetchStats := &domain.FetchStats{}
+	ctx = contextkeys.ContextWithFetchStats(ctx, fetchStats)
 
 	for _, task := range internalTasks {
 		wg.Add(1)
@@ -77,8 +79,9 @@ func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks
 			subTaskLogger.Debug("Executing sub-task", nil)
             
             // Execute теперь должен возвращать количество найденных ссылок
+			startedAt := time.Now()
 			newLinksCount, err := uc.fetchLinksUC.Execute(taskCtx, t, taskID)
-			resultsChan <- subTaskResult{linksCount: newLinksCount, err: err}
+			resultsChan <- domain.SubtaskStats{Name: t.Name, LinksFound: newLinksCount, Err: err, Duration: time.Since(startedAt)}
 			if err != nil {
 				subTaskLogger.Error("Sub-task failed", err, nil)
 			} 
@@ -92,24 +95,37 @@ func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks
     // 3. Агрегируем результаты
     totalNewLinksFound := 0
 	successfulSubTasks := 0
+    var lastErr error
+    subtasks := make([]domain.SubtaskStats, 0, len(internalTasks))
 	for result := range resultsChan {
-        if result.err == nil {
+        if result.Err == nil {
             successfulSubTasks++
+        } else {
+            lastErr = result.Err
         }
-        totalNewLinksFound += result.linksCount
+        totalNewLinksFound += result.LinksFound
+        subtasks = append(subtasks, result)
     }
 
 	ucLogger.Info("All sub-tasks completed.", port.Fields{
         "total_subtasks": len(internalTasks),
         "successful_subtasks": successfulSubTasks,
         "total_new_links": totalNewLinksFound,
+        "throttled_requests": fetchStats.Throttled(),
+        "circuit_rejections": fetchStats.CircuitRejections(),
     })
 
 	if successfulSubTasks == 0 && len(internalTasks) > 0 {
-        err := fmt.Errorf("all %d sub-tasks failed", len(internalTasks))
+        // Оборачиваем одну из ошибок, чтобы адаптер мог распознать блокировку источника
+        err := fmt.Errorf("all %d sub-tasks failed: %w", len(internalTasks), lastErr)
         ucLogger.Error("Orchestration failed completely", err, nil)
-        
-        // Мы НЕ отправляем отчет, а возвращаем ошибку, чтобы RabbitMQ сделал retry.
+
+        // Отчет только о подзадачах: поиск не засчитывается, но видно, что именно не работает
+        if reportErr := uc.reporter.ReportResults(ctx, taskID, &domain.ParsingTasksStats{Subtasks: subtasks}); reportErr != nil {
+            ucLogger.Error("Failed to report failed sub-tasks", reportErr, nil)
+        }
+
+        // Возвращаем ошибку, чтобы RabbitMQ сделал retry.
         return err
     }
     
@@ -117,6 +133,10 @@ func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks
 	finalReport := &domain.ParsingTasksStats{
 		SearchesCompleted: 1,
 		NewLinksFound: totalNewLinksFound,
+		ThrottledRequests: fetchStats.Throttled(),
+		CircuitRejections: fetchStats.CircuitRejections(),
+		Items: reportedItems(itemKey),
+		Subtasks: subtasks,
 	}
 
 	 // `useCase` должен предоставить метод для отправки отчета
@@ -129,4 +149,10 @@ func (uc *OrchestrateParsingUseCase) Execute(ctx context.Context, internalTasks
 	return nil
 }
 
-
+// reportedItems - ключ выполненного поиска для отчета. Старые задачи приходят без ключа
+func reportedItems(itemKey string) []string {
+	if itemKey == "" {
+		return nil
+	}
+	return []string{itemKey}
+}
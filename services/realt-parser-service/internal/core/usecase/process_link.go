@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	// "encoding/json"
 	"fmt"
 	// "log"
@@ -22,16 +23,19 @@ import (
 type ProcessLinkUseCase struct {
 	detailsFetcher port.RealtFetcherPort
 	resultQueue    port.ProcessedPropertyQueuePort
+	rawArchive     port.RawArchivePort // nil - архив выключен
 }
 
 // NewProcessLinkUseCase создает новый экземпляр use case.
 func NewProcessLinkUseCase(
 	fetcher port.RealtFetcherPort,
 	queue port.ProcessedPropertyQueuePort,
+	rawArchive port.RawArchivePort,
 ) *ProcessLinkUseCase {
 	return &ProcessLinkUseCase{
 		detailsFetcher: fetcher,
 		resultQueue:    queue,
+		rawArchive:     rawArchive,
 	}
 }
 
@@ -52,6 +56,11 @@ func (uc *ProcessLinkUseCase) Execute(ctx context.Context, linkToParse domain.Pr
 	// 1. Используем порт для парсинга деталей
 	propertyRecord, fetchErr := uc.detailsFetcher.FetchAdDetails(ctx, linkToParse.URL, linkToParse.AdID)
 	if fetchErr != nil {
+		// Ответ, который не удалось смапить, тоже архивируем: после исправления маппера его прогонят заново
+		var mappingErr *domain.MappingError
+		if errors.As(fetchErr, &mappingErr) {
+			uc.archiveRaw(ctx, mappingErr.Raw, ucLogger)
+		}
 		ucLogger.Error("Failed to fetch/parse details", fetchErr, nil)
 		// Ошибка возвращается наверх, чтобы обработчик RabbitMQ мог решить, что делать (requeue/nack)
 		return fmt.Errorf("failed to fetch/parse details for %d: %w", linkToParse.AdID, fetchErr)
@@ -63,6 +72,8 @@ func (uc *ProcessLinkUseCase) Execute(ctx context.Context, linkToParse domain.Pr
 		ucLogger.Debug("Successfully parsed details.", port.Fields{"title": propertyRecord.General.Title})
 	}
 
+	propertyRecord.RawRef = uc.archiveRaw(ctx, propertyRecord.Raw, ucLogger)
+
 	// 2. Используем порт для отправки результата в очередь
 	err := uc.resultQueue.Enqueue(ctx, *propertyRecord, taskID)
 	if err != nil {
@@ -75,6 +86,20 @@ func (uc *ProcessLinkUseCase) Execute(ctx context.Context, linkToParse domain.Pr
 }
 
 
+// archiveRaw сохраняет ответ источника в архив. Ошибка архива не мешает обработке ссылки,
+// событие просто уйдет без ссылки на сырой ответ
+func (uc *ProcessLinkUseCase) archiveRaw(ctx context.Context, raw *domain.RawPayload, logger port.LoggerPort) *domain.RawPayloadRef {
+	if uc.rawArchive == nil || raw == nil {
+		return nil
+	}
+	ref, err := uc.rawArchive.Save(ctx, *raw)
+	if err != nil {
+		logger.Warn("Failed to archive raw payload", port.Fields{"error": err.Error()})
+		return nil
+	}
+	return &ref
+}
+
 // func appendJSONToFile(dir, filename string, data interface{}) error {
 	// 	// 1️⃣ Создаём директорию, если её нет
 	// 	if err := os.MkdirAll(dir, 0755); err != nil {
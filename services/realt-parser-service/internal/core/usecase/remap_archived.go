@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"realt-parser-service/internal/contextkeys"
+	"realt-parser-service/internal/core/domain"
+	"realt-parser-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// errRemapLimitReached останавливает обход архива по достижении лимита
+var errRemapLimitReached = errors.New("remap limit reached")
+
+// RemapArchivedUseCase заново прогоняет текущий маппер по архиву сырых ответов
+// и публикует события в storage-service, как будто объявления только что скачаны
+type RemapArchivedUseCase struct {
+	rawArchive  port.RawArchivePort
+	mapper      port.PayloadMapperPort
+	resultQueue port.ProcessedPropertyQueuePort
+	source      string
+}
+
+func NewRemapArchivedUseCase(
+	rawArchive port.RawArchivePort,
+	mapper port.PayloadMapperPort,
+	queue port.ProcessedPropertyQueuePort,
+	source string,
+) *RemapArchivedUseCase {
+	return &RemapArchivedUseCase{
+		rawArchive:  rawArchive,
+		mapper:      mapper,
+		resultQueue: queue,
+		source:      source,
+	}
+}
+
+func (uc *RemapArchivedUseCase) Execute(ctx context.Context, opts domain.RemapOptions, taskID uuid.UUID) (domain.RemapStats, error) {
+	ucLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"use_case": "RemapArchived",
+		"source":   uc.source,
+		"dry_run":  opts.DryRun,
+	})
+	ucLogger.Info("Remapping archived payloads", port.Fields{"limit": opts.Limit})
+
+	var stats domain.RemapStats
+	err := uc.rawArchive.WalkLatest(ctx, uc.source, func(payload domain.RawPayload, ref domain.RawPayloadRef) error {
+		if opts.Limit > 0 && stats.Scanned >= opts.Limit {
+			return errRemapLimitReached
+		}
+		stats.Scanned++
+
+		record, err := uc.mapper.MapPayload(ctx, payload)
+		if err != nil {
+			// Маппер все еще не справляется с этим ответом, остальные обрабатываем
+			stats.Failed++
+			ucLogger.Warn("Failed to remap archived payload", port.Fields{"ad_id": payload.AdID, "ref": ref.Key, "error": err.Error()})
+			return nil
+		}
+		record.RawRef = &ref
+		stats.Mapped++
+
+		if opts.DryRun {
+			return nil
+		}
+		if err := uc.resultQueue.Enqueue(ctx, *record, taskID); err != nil {
+			return fmt.Errorf("failed to publish remapped record %d: %w", payload.AdID, err)
+		}
+		stats.Published++
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRemapLimitReached) {
+		ucLogger.Error("Remap aborted", err, port.Fields{"scanned": stats.Scanned, "published": stats.Published})
+		return stats, err
+	}
+
+	ucLogger.Info("Remap finished", port.Fields{
+		"scanned":   stats.Scanned,
+		"mapped":    stats.Mapped,
+		"published": stats.Published,
+		"failed":    stats.Failed,
+	})
+	return stats, nil
+}
@@ -12,8 +12,7 @@ require (
 	github.com/lmittmann/tint v1.1.2
 	github.com/mmcloughlin/geohash v0.10.0
 	github.com/rabbitmq/amqp091-go v1.10.0
-	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
-	golang.org/x/text v0.31.0
+	golang.org/x/image v0.25.0
 )
 
 require (
@@ -25,4 +24,5 @@ require (
 	github.com/tinylib/msgp v1.3.0 // indirect
 	golang.org/x/crypto v0.44.0 // indirect
 	golang.org/x/sync v0.18.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
 )
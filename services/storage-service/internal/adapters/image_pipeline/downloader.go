@@ -0,0 +1,55 @@
+package image_pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"storage-service/internal/core/domain"
+	"time"
+)
+
+// HTTPDownloader скачивает фото по прямым ссылкам источников
+type HTTPDownloader struct {
+	httpClient *http.Client
+	maxBytes   int64
+}
+
+// NewHTTPDownloader - конструктор. maxBytes - предел размера файла, больше - ошибка
+func NewHTTPDownloader(timeout time.Duration, maxBytes int64) *HTTPDownloader {
+	return &HTTPDownloader{
+		httpClient: &http.Client{Timeout: timeout},
+		maxBytes:   maxBytes,
+	}
+}
+
+func (d *HTTPDownloader) Download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		// Кривая ссылка из объявления не исправится повтором
+		return nil, fmt.Errorf("%w: %v", domain.ErrImageUnavailable, err)
+	}
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("%w: status %d", domain.ErrImageUnavailable, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("source returned unexpected status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, d.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image body: %w", err)
+	}
+	if int64(len(data)) > d.maxBytes {
+		return nil, fmt.Errorf("%w: larger than %d bytes", domain.ErrImageUnavailable, d.maxBytes)
+	}
+	return data, nil
+}
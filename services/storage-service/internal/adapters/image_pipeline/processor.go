@@ -0,0 +1,101 @@
+package image_pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"storage-service/internal/core/domain"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Processor строит JPEG-миниатюру и перцептивный хеш (dHash) фото
+type Processor struct {
+	thumbnailWidth int
+	maxPixels      int
+}
+
+// NewProcessor - конструктор. Фото уже thumbnailWidth не увеличиваются,
+// фото больше maxPixels (ширина×высота) не декодируются
+func NewProcessor(thumbnailWidth, maxPixels int) *Processor {
+	return &Processor{thumbnailWidth: thumbnailWidth, maxPixels: maxPixels}
+}
+
+func (p *Processor) Process(data []byte) (*domain.ProcessedImage, error) {
+	// Размеры читаются из заголовка без декодирования: файл в сотню килобайт может
+	// объявить картинку, для которой image.Decode выделит гигабайты
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrImageUndecodable, err)
+	}
+	if p.maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > int64(p.maxPixels) {
+		return nil, fmt.Errorf("%w: %dx%d exceeds %d pixels", domain.ErrImageUndecodable, cfg.Width, cfg.Height, p.maxPixels)
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrImageUndecodable, err)
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, fmt.Errorf("%w: empty image", domain.ErrImageUndecodable)
+	}
+
+	thumbnail, err := p.thumbnail(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ProcessedImage{
+		ContentType:          "image/" + format,
+		Width:                bounds.Dx(),
+		Height:               bounds.Dy(),
+		PHash:                dHash(img),
+		Thumbnail:            thumbnail,
+		ThumbnailContentType: "image/jpeg",
+	}, nil
+}
+
+func (p *Processor) thumbnail(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > p.thumbnailWidth {
+		height = height * p.thumbnailWidth / width
+		width = p.thumbnailWidth
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// dHash - разностный хеш: фото сжимается до 9x8 в оттенках серого, каждый бит - ярче ли пиксель соседа справа.
+// Не меняется от пересжатия, масштаба и водяных знаков небольшого размера
+func dHash(img image.Image) uint64 {
+	small := image.NewGray(image.Rect(0, 0, 9, 8))
+	draw.ApproxBiLinear.Scale(small, small.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if small.GrayAt(x, y).Y > small.GrayAt(x+1, y).Y {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExchangeRatesRepository хранит официальные курсы валют по датам
+type ExchangeRatesRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewExchangeRatesRepository(pool *pgxpool.Pool) (*ExchangeRatesRepository, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pgxpool.Pool cannot be nil")
+	}
+	return &ExchangeRatesRepository{pool: pool}, nil
+}
+
+func (r *ExchangeRatesRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":  "ExchangeRatesRepository",
+		"method":     "SaveRates",
+		"rate_count": len(rates),
+	})
+
+	if len(rates) == 0 {
+		return nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, rate := range rates {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO exchange_rates (rate_date, currency, rate_byn, provider)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (currency, rate_date) DO UPDATE SET
+				rate_byn = EXCLUDED.rate_byn,
+				provider = EXCLUDED.provider`,
+			rate.Date, rate.Currency, rate.RateBYN, rate.Provider,
+		)
+		if err != nil {
+			repoLogger.Error("Failed to save exchange rate", err, port.Fields{
+				"currency": rate.Currency,
+				"date":     rate.Date.Format(time.DateOnly),
+			})
+			return fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit exchange rates: %w", err)
+	}
+	repoLogger.Debug("Exchange rates saved", nil)
+	return nil
+}
+
+func (r *ExchangeRatesRepository) GetRateTable(ctx context.Context, date time.Time) (*domain.RateTable, error) {
+	// По каждой валюте - последний курс не позже даты: на выходные НБРБ курс не меняет
+	rows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (currency) currency, rate_byn, rate_date
+		FROM exchange_rates
+		WHERE rate_date <= $1
+		ORDER BY currency, rate_date DESC`,
+		date,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	table := &domain.RateTable{Rates: make(map[string]float64)}
+	for rows.Next() {
+		var currency string
+		var rate float64
+		var rateDate time.Time
+		if err := rows.Scan(&currency, &rate, &rateDate); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		table.Rates[currency] = rate
+		if rateDate.After(table.Date) {
+			table.Date = rateDate
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exchange rates: %w", err)
+	}
+
+	if len(table.Rates) == 0 {
+		return nil, domain.ErrExchangeRatesNotFound
+	}
+	return table, nil
+}
@@ -171,18 +171,8 @@ func (a *FilterRepository) GetPriceRange(ctx context.Context, req domain.FindObj
 	// Получаем базовые WHERE и JOIN от билдера
 	joinClause, whereClause, args := applyFilters(req)
 
-	var priceColumn string
-	switch req.PriceCurrency {
-	case "USD":
-		priceColumn = "gp.price_usd"
-	case "EUR":
-		priceColumn = "gp.price_eur"
-	case "BYN":
-		priceColumn = "gp.price_byn"
-	default:
-		// Валюта по умолчанию, если не указана
-		priceColumn = "gp.price_usd" 
-	}
+	// Валюта по умолчанию, если не указана - USD
+	priceCol := priceColumn("gp.", req.PriceCurrency)
 
 
 	query := fmt.Sprintf(`
@@ -203,7 +193,7 @@ func (a *FilterRepository) GetPriceRange(ctx context.Context, req domain.FindObj
 			latest_visible_objects
 		WHERE
 			rn = 1;
-	`, priceColumn, joinClause, whereClause)
+	`, priceCol, joinClause, whereClause)
 
 	var res domain.RangeResult
 	err := a.pool.QueryRow(ctx, query, args...).Scan(&res.Min, &res.Max)
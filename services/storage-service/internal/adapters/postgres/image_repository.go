@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ImageRepository хранит сведения о локальных копиях фото объявлений
+type ImageRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImageRepository(pool *pgxpool.Pool) (*ImageRepository, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pgxpool.Pool cannot be nil")
+	}
+	return &ImageRepository{pool: pool}, nil
+}
+
+func (r *ImageRepository) FindUnprocessed(ctx context.Context, urls []string) ([]string, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT u.url
+		FROM unnest($1::text[]) AS u(url)
+		WHERE NOT EXISTS (SELECT 1 FROM property_images pi WHERE pi.source_url = u.url)`,
+		urls,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unprocessed images: %w", err)
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("failed to scan image url: %w", err)
+		}
+		result = append(result, url)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate unprocessed images: %w", err)
+	}
+	return result, nil
+}
+
+// nullableString - пустая строка пишется как NULL
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (r *ImageRepository) SaveImage(ctx context.Context, image domain.PropertyImage) error {
+	var width, height, phash interface{}
+	if image.Status == domain.ImageMirrored {
+		// uint64 -> BIGINT с переносом знака: хеш сравнивается побитно, знак не важен
+		width, height, phash = image.Width, image.Height, int64(image.PHash)
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO property_images (id, source_url, status, original_key, thumbnail_key, content_type, width, height, phash, last_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (source_url) DO UPDATE SET
+			status = EXCLUDED.status,
+			original_key = EXCLUDED.original_key,
+			thumbnail_key = EXCLUDED.thumbnail_key,
+			content_type = EXCLUDED.content_type,
+			width = EXCLUDED.width,
+			height = EXCLUDED.height,
+			phash = EXCLUDED.phash,
+			last_error = EXCLUDED.last_error,
+			updated_at = NOW()`,
+		image.ID, image.SourceURL, image.Status,
+		nullableString(image.OriginalKey), nullableString(image.ThumbnailKey), nullableString(image.ContentType),
+		width, height, phash, nullableString(image.LastError),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save image: %w", err)
+	}
+	return nil
+}
+
+func (r *ImageRepository) GetImageByURL(ctx context.Context, url string) (*domain.PropertyImage, error) {
+	var image domain.PropertyImage
+	var originalKey, thumbnailKey, contentType, lastError *string
+	var width, height *int
+	var phash *int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, source_url, status, original_key, thumbnail_key, content_type, width, height, phash, last_error
+		FROM property_images
+		WHERE source_url = $1`,
+		url,
+	).Scan(&image.ID, &image.SourceURL, &image.Status, &originalKey, &thumbnailKey, &contentType, &width, &height, &phash, &lastError)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrImageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	if originalKey != nil {
+		image.OriginalKey = *originalKey
+	}
+	if thumbnailKey != nil {
+		image.ThumbnailKey = *thumbnailKey
+	}
+	if contentType != nil {
+		image.ContentType = *contentType
+	}
+	if lastError != nil {
+		image.LastError = *lastError
+	}
+	if width != nil && height != nil {
+		image.Width, image.Height = *width, *height
+	}
+	if phash != nil {
+		image.PHash = uint64(*phash)
+	}
+	return &image, nil
+}
+
+func (r *ImageRepository) GetPropertyImageURL(ctx context.Context, propertyID uuid.UUID, index int) (string, error) {
+	// Массивы PostgreSQL нумеруются с единицы; за пределами массива - NULL
+	var url *string
+	err := r.pool.QueryRow(ctx, `SELECT images[$2] FROM general_properties WHERE id = $1`, propertyID, index+1).Scan(&url)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrPropertyNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get property image url: %w", err)
+	}
+	if url == nil || *url == "" {
+		return "", domain.ErrImageNotFound
+	}
+	return *url, nil
+}
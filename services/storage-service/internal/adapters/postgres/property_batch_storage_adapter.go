@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"fmt"
 	"storage-service/internal/contextkeys"
@@ -9,10 +10,38 @@ import (
 	"storage-service/internal/core/port"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
-// BatchSave сохраняет пачку записей, используя протокол COPY для максимальной производительности
+// relistWindowDays - сколько дней после архивации новое объявление того же объекта считается перевыставлением
+const relistWindowDays = 180
+
+// BatchSave сохраняет пачку записей. Ошибки, вызванные самими данными, оборачиваются в domain.ErrInvalidRecord,
+// чтобы вызывающий код мог отличить плохую запись от недоступности БД
 func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain.RealEstateRecord) (*domain.BatchSaveStats, error) {
+	stats, err := a.batchSave(ctx, records)
+	if err != nil && isDataError(err) {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidRecord, err)
+	}
+	return stats, err
+}
+
+// isDataError - ошибка из-за содержимого записей: классы SQLSTATE 21 (cardinality violation,
+// например дубль ключа внутри одной пачки), 22 (data exception) и 23 (integrity constraint violation)
+func isDataError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || len(pgErr.Code) < 2 {
+		return false
+	}
+	switch pgErr.Code[:2] {
+	case "21", "22", "23":
+		return true
+	}
+	return false
+}
+
+// batchSave сохраняет пачку записей, используя протокол COPY для максимальной производительности
+func (a *PostgresStorageAdapter) batchSave(ctx context.Context, records []domain.RealEstateRecord) (*domain.BatchSaveStats, error) {
 
 	logger := contextkeys.LoggerFromContext(ctx)
 	repoLogger := logger.WithFields(port.Fields{
@@ -70,7 +99,8 @@ func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain
 					ON gp.source = vals.source AND gp.source_ad_id = vals.source_ad_id
 			)
 			UPDATE general_properties gp
-				SET status = 'archived', updated_at = NOW()
+				SET status = 'archived', updated_at = NOW(),
+					archived_at = CASE WHEN od.status != 'archived' THEN NOW() ELSE gp.archived_at END
 				FROM old_data od
 				WHERE gp.id = od.id
 				RETURNING 
@@ -87,6 +117,21 @@ func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain
 		formattedSQL := fmt.Sprintf(sql, placeholders)
 		flatArgs := flatten(keys)
 
+		// Смену статуса пишем в историю до UPDATE, пока видно старое значение
+		historySQL := fmt.Sprintf(`
+			INSERT INTO property_changes (property_id, changed_at, changes)
+			SELECT gp.id, NOW(), jsonb_build_object('status', jsonb_build_object('old', gp.status, 'new', 'archived'))
+			FROM general_properties gp
+			JOIN (VALUES %s) AS vals(source, source_ad_id)
+				ON gp.source = vals.source AND gp.source_ad_id = vals.source_ad_id
+			WHERE gp.status != 'archived'
+			ON CONFLICT (property_id, changed_at) DO UPDATE SET changes = property_changes.changes || EXCLUDED.changes
+		`, placeholders)
+		if _, err := tx.Exec(ctx, historySQL, flatArgs...); err != nil {
+			repoLogger.Error("Failed to record archive status changes", err, nil)
+			return nil, fmt.Errorf("failed to record archive status changes: %w", err)
+		}
+
 		repoLogger.Debug("Executing batch archive.", nil)
 		
 		rows, err := tx.Query(ctx, formattedSQL, flatArgs...)
@@ -318,6 +363,7 @@ func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain
 				dbGeneral.Coordinates, dbGeneral.CityOrDistrict, dbGeneral.Region,
 				dbGeneral.PriceBYN, dbGeneral.PriceUSD, dbGeneral.PriceEUR, dbGeneral.Address, dbGeneral.IsAgency, dbGeneral.SellerName,
 				dbGeneral.SellerDetails,
+				dbGeneral.OriginalPrice, dbGeneral.OriginalCurrency, dbGeneral.RatesDate,
 				masterID,         
 				isSourceDuplicate, 
 				dbGeneral.Status,
@@ -329,7 +375,7 @@ func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain
 		// массовая запись в БД (с использованием TEMP TABLE, в которой поле coordinates с типом TEXT)
 		repoLogger.Debug("Creating temp table for general properties.", nil)
 		_, err = tx.Exec(ctx, `
-			CREATE TEMP TABLE temp_general_properties (LIKE general_properties) ON COMMIT DROP;
+			CREATE TEMP TABLE temp_general_properties (LIKE general_properties INCLUDING DEFAULTS) ON COMMIT DROP;
 		`)
 		if err != nil {
 			repoLogger.Error("Failed to create temp table", err, nil)
@@ -351,6 +397,7 @@ func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain
 			"currency", "images", "list_time", "description", "title", "deal_type",
 			"coordinates", "city_or_district", "region", "price_byn", "price_usd", "price_eur",
 			"address", "is_agency", "seller_name", "seller_details",
+			"original_price", "original_currency", "rates_date",
 			"master_object_id", "is_source_duplicate", "status",
 		}
 
@@ -368,41 +415,105 @@ func (a *PostgresStorageAdapter) BatchSave(ctx context.Context, records []domain
 		}
 
 
+		// Сравниваем с текущими строками до слияния: ON CONFLICT DO UPDATE старых значений не вернет
+		changedCount, err := recordChanges(ctx, tx, "temp_general_properties", "general_properties",
+			"cur.source = incoming.source AND cur.source_ad_id = incoming.source_ad_id", "id", trackedGeneralColumns)
+		if err != nil {
+			repoLogger.Error("Failed to record general properties changes", err, nil)
+			return nil, err
+		}
+		repoLogger.Debug("Recorded general properties changes.", port.Fields{"changed_properties": changedCount})
+
+		// Продавцы: создаем недостающих и проставляем seller_id во временной таблице до слияния
+		repoLogger.Debug("Upserting sellers.", nil)
+		_, err = tx.Exec(ctx, `
+			INSERT INTO sellers (seller_key, name, is_agency)
+			SELECT seller_key(source, seller_name, seller_details), MAX(seller_name), BOOL_OR(is_agency)
+			FROM temp_general_properties
+			WHERE seller_key(source, seller_name, seller_details) IS NOT NULL
+			GROUP BY 1
+			ON CONFLICT (seller_key) DO UPDATE SET
+				name = EXCLUDED.name,
+				is_agency = sellers.is_agency OR EXCLUDED.is_agency,
+				updated_at = NOW()
+		`)
+		if err != nil {
+			repoLogger.Error("Failed to upsert sellers", err, nil)
+			return nil, fmt.Errorf("failed to upsert sellers: %w", err)
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE temp_general_properties t
+			SET seller_id = s.id
+			FROM sellers s
+			WHERE s.seller_key = seller_key(t.source, t.seller_name, t.seller_details)
+		`)
+		if err != nil {
+			repoLogger.Error("Failed to link properties to sellers", err, nil)
+			return nil, fmt.Errorf("failed to link properties to sellers: %w", err)
+		}
+
 		// INSERT ... ON CONFLICT из временной таблицы в основную
 		repoLogger.Debug("Merging data from temp table into main table.", nil)
 		finalIDMap := make(map[string]uuid.UUID) // key: "source|source_ad_id", value: final_id
 
-		rows, err = tx.Query(ctx, `
+		rows, err = tx.Query(ctx, fmt.Sprintf(`
 			INSERT INTO general_properties (
 				id, source, source_ad_id, created_at, updated_at, category, ad_link, sale_type,
 				currency, images, list_time, description, title, deal_type,
 				coordinates, city_or_district, region, price_byn, price_usd, price_eur, address, is_agency,
 				seller_name, seller_details,
-				master_object_id, is_source_duplicate, status
+				original_price, original_currency, rates_date,
+				master_object_id, is_source_duplicate, status,
+				first_seen_at, last_seen_active_at, relisted_from_id, seller_id
 			)
 			SELECT
-				id, source, source_ad_id, created_at, updated_at, category, ad_link, sale_type,
-				currency, images, list_time, description, title, deal_type,
-				coordinates::geography, -- Преобразуем TEXT в GEOGRAPHY
-				city_or_district, region, price_byn, price_usd, price_eur, address,
-				is_agency, seller_name, seller_details,
-				master_object_id, is_source_duplicate, status
-			FROM temp_general_properties
+				t.id, t.source, t.source_ad_id, t.created_at, t.updated_at, t.category, t.ad_link, t.sale_type,
+				t.currency, t.images, t.list_time, t.description, t.title, t.deal_type,
+				t.coordinates::geography, -- Преобразуем TEXT в GEOGRAPHY
+				t.city_or_district, t.region, t.price_byn, t.price_usd, t.price_eur, t.address,
+				t.is_agency, t.seller_name, t.seller_details,
+				t.original_price, t.original_currency, t.rates_date,
+				t.master_object_id, t.is_source_duplicate, t.status,
+				NOW(), CASE WHEN t.status = 'active' THEN NOW() END, relist.id, t.seller_id
+			FROM temp_general_properties t
+			-- Перевыставление: тот же объект, источник и тип сделки уже был в архиве под другим source_ad_id.
+			-- При конфликте relisted_from_id не обновляется, так что значение важно только для новых строк
+			LEFT JOIN LATERAL (
+				SELECT prev.id
+				FROM general_properties prev
+				WHERE prev.master_object_id = t.master_object_id
+				  AND prev.source = t.source
+				  AND prev.deal_type = t.deal_type
+				  AND prev.source_ad_id != t.source_ad_id
+				  AND prev.status = 'archived'
+				  AND prev.archived_at > NOW() - make_interval(days => %d)
+				ORDER BY prev.archived_at DESC
+				LIMIT 1
+			) relist ON TRUE
 			ON CONFLICT (source, source_ad_id) DO UPDATE SET
 				updated_at = EXCLUDED.updated_at,
 				status = EXCLUDED.status,
 
+				last_seen_active_at = CASE WHEN EXCLUDED.status = 'active' THEN NOW() ELSE general_properties.last_seen_active_at END,
+				archived_at = CASE WHEN EXCLUDED.status = 'archived' THEN general_properties.archived_at END,
+				reactivation_count = general_properties.reactivation_count +
+					CASE WHEN general_properties.status = 'archived' AND EXCLUDED.status = 'active' THEN 1 ELSE 0 END,
+
 				list_time = EXCLUDED.list_time, 
 				price_byn = EXCLUDED.price_byn, 
 				price_usd = EXCLUDED.price_usd, 
 				price_eur = EXCLUDED.price_eur, 
+				original_price = EXCLUDED.original_price,
+				original_currency = EXCLUDED.original_currency,
+				rates_date = EXCLUDED.rates_date,
 				description = EXCLUDED.description, 
 				title = EXCLUDED.title, 
 				images = EXCLUDED.images,
 				
-				is_source_duplicate = EXCLUDED.is_source_duplicate
+				is_source_duplicate = EXCLUDED.is_source_duplicate,
+				seller_id = EXCLUDED.seller_id
 			RETURNING id, source, source_ad_id, (xmax = 0) AS inserted, status; -- Возвращаем id для связи с деталями
-		`)
+		`, relistWindowDays))
 		if err != nil {
 			repoLogger.Error("Failed to merge from temp table", err, nil)
 			return nil, fmt.Errorf("failed to merge from temp_general_properties: %w", err)
@@ -632,6 +743,10 @@ func (a *PostgresStorageAdapter) batchSaveApartmentDetails(ctx context.Context,
 		return fmt.Errorf("failed to copy to temp_apartments: %w", err)
 	}
 
+	if err := recordDetailChanges(ctx, tx, "temp_apartments", "apartments", trackedApartmentColumns); err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(ctx, `
 		INSERT INTO apartments SELECT * FROM temp_apartments
 		ON CONFLICT (property_id) DO UPDATE SET
@@ -684,6 +799,10 @@ func (a *PostgresStorageAdapter) batchSaveHouseDetails(ctx context.Context, tx p
 		return fmt.Errorf("failed to copy to temp_houses: %w", err)
 	}
 
+	if err := recordDetailChanges(ctx, tx, "temp_houses", "houses", trackedHouseColumns); err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(ctx, `
 		INSERT INTO houses SELECT * FROM temp_houses
 		ON CONFLICT (property_id) DO UPDATE SET
@@ -738,6 +857,10 @@ func (a *PostgresStorageAdapter) batchSaveCommercialDetails(ctx context.Context,
 		return fmt.Errorf("failed to copy to temp_commercial: %w", err)
 	}
 
+	if err := recordDetailChanges(ctx, tx, "temp_commercial", "commercial", trackedCommercialColumns); err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(ctx, `
 		INSERT INTO commercial SELECT * FROM temp_commercial
 		ON CONFLICT (property_id) DO UPDATE SET
@@ -60,6 +60,7 @@ func (a *PostgresStorageAdapter) FindWithFilters(ctx context.Context, filters do
 			SELECT 
 				gp.id, gp.source, gp.source_ad_id, gp.updated_at, gp.category, gp.deal_type, gp.ad_link, 
 				gp.title, gp.address, gp.price_byn, gp.price_usd, gp.price_eur, gp.currency, gp.images, gp.status, gp.master_object_id,
+				` + lifecycleColumns("gp.") + `,
 				ROW_NUMBER() OVER(PARTITION BY gp.master_object_id ORDER BY gp.updated_at DESC) as rn
 			FROM general_properties gp `)
 	dataQuery.WriteString(joinClause) // Добавляем JOIN
@@ -67,11 +68,12 @@ func (a *PostgresStorageAdapter) FindWithFilters(ctx context.Context, filters do
 	dataQuery.WriteString(whereClause) // Добавляем WHERE
 	dataQuery.WriteString(`)
 		SELECT id, source, source_ad_id, updated_at, category, deal_type, ad_link, 
-			   title, address, price_byn, price_usd, price_eur, currency, images, status, master_object_id
+			   title, address, price_byn, price_usd, price_eur, currency, images, status, master_object_id,
+			   first_seen_at, last_seen_active_at, archived_at, reactivation_count, relisted_from_id, days_on_market
 		FROM filtered_ranked_properties
 		WHERE rn = 1
-		ORDER BY updated_at DESC, id ASC
 	`)
+	dataQuery.WriteString(orderClause(filters))
 
 	limitOffsetArgs := append(args, limit, offset)
 	limitOffsetQuery := fmt.Sprintf("%s LIMIT $%d OFFSET $%d", dataQuery.String(), len(args)+1, len(args)+2)
@@ -86,11 +88,12 @@ func (a *PostgresStorageAdapter) FindWithFilters(ctx context.Context, filters do
 	objects := make([]domain.GeneralPropertyInfo, 0, limit)
 	for rows.Next() {
 		var obj domain.GeneralPropertyInfo
-		if err := rows.Scan(
+		dest := []interface{}{
 			&obj.ID, &obj.Source, &obj.SourceAdID, &obj.UpdatedAt, &obj.Category, &obj.DealType,
 			&obj.AdLink, &obj.Title, &obj.Address, &obj.PriceBYN, &obj.PriceUSD, &obj.PriceEUR,
 			&obj.Currency, &obj.Images, &obj.Status, &obj.MasterObjectID,
-		); err != nil {
+		}
+		if err := rows.Scan(append(dest, lifecycleScanTargets(&obj)...)...); err != nil {
 			return nil, fmt.Errorf("failed to scan object: %w", err)
 		}
 		objects = append(objects, obj)
@@ -134,6 +137,7 @@ func (a *PostgresStorageAdapter) FindBestByMasterIDs(ctx context.Context, master
             SELECT
                 id, source, source_ad_id, updated_at, category, deal_type, ad_link, title,
 				address, price_byn, price_usd, price_eur, currency, images, status, master_object_id,
+				` + lifecycleColumns("") + `,
                 ROW_NUMBER() OVER(
                     PARTITION BY master_object_id
                     ORDER BY
@@ -147,7 +151,8 @@ func (a *PostgresStorageAdapter) FindBestByMasterIDs(ctx context.Context, master
                 master_object_id = ANY($1)
         )
         SELECT id, source, source_ad_id, updated_at, category, deal_type, ad_link, 
-		title, address, price_byn, price_usd, price_eur, currency, images, status, master_object_id
+		title, address, price_byn, price_usd, price_eur, currency, images, status, master_object_id,
+		first_seen_at, last_seen_active_at, archived_at, reactivation_count, relisted_from_id, days_on_market
         FROM ranked_objects
         WHERE rn = 1`
 
@@ -162,9 +167,10 @@ func (a *PostgresStorageAdapter) FindBestByMasterIDs(ctx context.Context, master
 	for rows.Next() {
 		var obj domain.GeneralPropertyInfo
 
-		if err := rows.Scan(&obj.ID, &obj.Source, &obj.SourceAdID, &obj.UpdatedAt, &obj.Category, &obj.DealType,
+		dest := []interface{}{&obj.ID, &obj.Source, &obj.SourceAdID, &obj.UpdatedAt, &obj.Category, &obj.DealType,
 			&obj.AdLink, &obj.Title, &obj.Address, &obj.PriceBYN, &obj.PriceUSD, &obj.PriceEUR,
-			&obj.Currency, &obj.Images, &obj.Status, &obj.MasterObjectID); err != nil {
+			&obj.Currency, &obj.Images, &obj.Status, &obj.MasterObjectID}
+		if err := rows.Scan(append(dest, lifecycleScanTargets(&obj)...)...); err != nil {
 
 			repoLogger.Error("Failed to scan best object row", err, nil)
 			return nil, fmt.Errorf("failed to scan best object: %w", err)
@@ -198,17 +204,18 @@ func (a *PostgresStorageAdapter) GetPropertyDetails(ctx context.Context, propert
 	repoLogger.Debug("Querying for main property.", nil)
 	mainQuery := `SELECT master_object_id, id, source, source_ad_id, updated_at, created_at, category, ad_link, sale_type, currency, images, list_time,
 						 description, title, deal_type, city_or_district, region, price_byn, price_usd, price_eur, address, is_agency, seller_name, seller_details,
-	                     status
+	                     status, seller_id, ` + lifecycleColumns("") + `
 	              FROM general_properties WHERE id = $1`
 
 	// Сканируем в структуру
-	err := a.pool.QueryRow(ctx, mainQuery, propertyID).Scan(
+	mainDest := []interface{}{
 		&result.MainProperty.MasterObjectID, &result.MainProperty.ID, &result.MainProperty.Source, &result.MainProperty.SourceAdID, &result.MainProperty.UpdatedAt,
 		&result.MainProperty.CreatedAt, &result.MainProperty.Category, &result.MainProperty.AdLink, &result.MainProperty.SaleType, &result.MainProperty.Currency,
 		&result.MainProperty.Images, &result.MainProperty.ListTime, &result.MainProperty.Description, &result.MainProperty.Title, &result.MainProperty.DealType,
 		&result.MainProperty.CityOrDistrict, &result.MainProperty.Region, &result.MainProperty.PriceBYN, &result.MainProperty.PriceUSD, &result.MainProperty.PriceEUR,
-		&result.MainProperty.Address, &result.MainProperty.IsAgency, &result.MainProperty.SellerName, &result.MainProperty.SellerDetails, &result.MainProperty.Status, 
-	)
+		&result.MainProperty.Address, &result.MainProperty.IsAgency, &result.MainProperty.SellerName, &result.MainProperty.SellerDetails, &result.MainProperty.Status, &result.MainProperty.SellerID,
+	}
+	err := a.pool.QueryRow(ctx, mainQuery, propertyID).Scan(append(mainDest, lifecycleScanTargets(&result.MainProperty)...)...)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			repoLogger.Warn("Main property not found.", nil)
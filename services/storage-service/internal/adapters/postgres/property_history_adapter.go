@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Отслеживаемые колонки: изменения только в них попадают в property_changes.
+// Служебные поля (updated_at, is_source_duplicate) и сырые parameters не отслеживаются.
+// Цена отслеживается в валюте объявления: пересчитанные price_byn/price_usd/price_eur меняются вместе с курсами
+var (
+	trackedGeneralColumns = []string{
+		"status", "list_time", "original_price", "original_currency", "title", "description", "images",
+	}
+	trackedApartmentColumns = []string{
+		"rooms_amount", "floor_number", "building_floors", "total_area", "living_space_area", "kitchen_area",
+		"year_built", "wall_material", "repair_state", "bathroom_type", "balcony_type", "price_per_square_meter", "is_new_condition",
+	}
+	trackedHouseColumns = []string{
+		"total_area", "plot_area", "wall_material", "year_built", "living_space_area", "building_floors", "rooms_amount",
+		"kitchen_area", "electricity", "water", "heating", "sewage", "gaz", "roof_material", "house_type", "is_new_condition",
+	}
+	trackedCommercialColumns = []string{
+		"is_new_condition", "property_type", "floor_number", "building_floors", "total_area", "commercial_improvements",
+		"commercial_repair", "price_per_square_meter", "rooms_range", "commercial_building_location", "commercial_rent_type",
+	}
+)
+
+// recordChanges пишет в property_changes отличия новых строк (newTable) от текущих (curTable).
+// Вызывается перед слиянием временной таблицы с основной, пока в основной еще старые значения.
+// join - условие соединения incoming и cur, idColumn - колонка cur с id из general_properties
+func recordChanges(ctx context.Context, tx pgx.Tx, newTable, curTable, join, idColumn string, columns []string) (int64, error) {
+	values := make([]string, len(columns))
+	for i, col := range columns {
+		values[i] = fmt.Sprintf("('%s', to_jsonb(cur.%s), to_jsonb(incoming.%s))", col, col, col)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO property_changes (property_id, changed_at, changes)
+		SELECT cur.%s, NOW(), jsonb_object_agg(c.field, jsonb_build_object('old', c.old_value, 'new', c.new_value))
+		FROM %s incoming
+		JOIN %s cur ON %s
+		CROSS JOIN LATERAL (VALUES %s) AS c(field, old_value, new_value)
+		WHERE c.old_value IS DISTINCT FROM c.new_value
+		GROUP BY cur.%s
+		ON CONFLICT (property_id, changed_at) DO UPDATE SET changes = property_changes.changes || EXCLUDED.changes
+	`, idColumn, newTable, curTable, join, strings.Join(values, ", "), idColumn)
+
+	cmdTag, err := tx.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record changes of %s: %w", curTable, err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// recordDetailChanges - recordChanges для таблиц деталей, которые связаны с general_properties по property_id
+func recordDetailChanges(ctx context.Context, tx pgx.Tx, tempTable, table string, columns []string) error {
+	_, err := recordChanges(ctx, tx, tempTable, table, "cur.property_id = incoming.property_id", "property_id", columns)
+	return err
+}
+
+// GetPropertyHistory возвращает историю изменений объявления, от новых к старым
+func (a *PostgresStorageAdapter) GetPropertyHistory(ctx context.Context, propertyID uuid.UUID, limit, offset int) (*domain.PropertyHistory, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":   "PostgresStorageAdapter",
+		"method":      "GetPropertyHistory",
+		"property_id": propertyID.String(),
+	})
+
+	var exists bool
+	if err := a.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM general_properties WHERE id = $1)`, propertyID).Scan(&exists); err != nil {
+		repoLogger.Error("Failed to check property existence", err, nil)
+		return nil, fmt.Errorf("failed to check property existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrPropertyNotFound
+	}
+
+	history := &domain.PropertyHistory{PropertyID: propertyID, Changes: []domain.PropertyChange{}}
+
+	if err := a.pool.QueryRow(ctx, `SELECT COUNT(*) FROM property_changes WHERE property_id = $1`, propertyID).Scan(&history.TotalCount); err != nil {
+		repoLogger.Error("Failed to count property changes", err, nil)
+		return nil, fmt.Errorf("failed to count property changes: %w", err)
+	}
+
+	rows, err := a.pool.Query(ctx, `
+		SELECT changed_at, changes
+		FROM property_changes
+		WHERE property_id = $1
+		ORDER BY changed_at DESC
+		LIMIT $2 OFFSET $3`,
+		propertyID, limit, offset,
+	)
+	if err != nil {
+		repoLogger.Error("Failed to query property changes", err, nil)
+		return nil, fmt.Errorf("failed to query property changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var changedAt time.Time
+		var raw []byte
+		if err := rows.Scan(&changedAt, &raw); err != nil {
+			return nil, fmt.Errorf("failed to scan property change: %w", err)
+		}
+
+		var fields map[string]struct {
+			Old interface{} `json:"old"`
+			New interface{} `json:"new"`
+		}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to decode property change: %w", err)
+		}
+
+		change := domain.PropertyChange{ChangedAt: changedAt, Fields: make(map[string]domain.FieldChange, len(fields))}
+		for name, f := range fields {
+			change.Fields[name] = domain.FieldChange{Old: f.Old, New: f.New}
+		}
+		history.Changes = append(history.Changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate property changes: %w", err)
+	}
+
+	repoLogger.Debug("Property history loaded", port.Fields{"total": history.TotalCount, "returned": len(history.Changes)})
+	return history, nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetSellerProfile возвращает продавца со статистикой по всем его объявлениям
+func (a *PostgresStorageAdapter) GetSellerProfile(ctx context.Context, sellerID uuid.UUID) (*domain.SellerProfile, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "PostgresStorageAdapter",
+		"method":    "GetSellerProfile",
+		"seller_id": sellerID.String(),
+	})
+
+	// Дубликаты с других источников не отбрасываем: это разные объявления того же продавца
+	query := `
+		SELECT s.id, s.name, s.is_agency, split_part(s.seller_key, ':', 1), s.created_at, s.updated_at,
+			COUNT(gp.id) FILTER (WHERE gp.status = 'active'),
+			COUNT(gp.id),
+			AVG(EXTRACT(EPOCH FROM COALESCE(gp.archived_at, NOW()) - gp.first_seen_at) / 86400)
+		FROM sellers s
+		LEFT JOIN general_properties gp ON gp.seller_id = s.id
+		WHERE s.id = $1
+		GROUP BY s.id`
+
+	var profile domain.SellerProfile
+	err := a.pool.QueryRow(ctx, query, sellerID).Scan(
+		&profile.ID, &profile.Name, &profile.IsAgency, &profile.IdentifiedBy, &profile.CreatedAt, &profile.UpdatedAt,
+		&profile.ActiveListings, &profile.TotalListings, &profile.AvgDaysOnMarket,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrSellerNotFound
+	}
+	if err != nil {
+		repoLogger.Error("Failed to get seller profile", err, port.Fields{"query": query})
+		return nil, fmt.Errorf("failed to get seller profile: %w", err)
+	}
+
+	repoLogger.Debug("Seller profile loaded", port.Fields{"total_listings": profile.TotalListings})
+	return &profile, nil
+}
+
+// FindSellerListings возвращает объявления продавца, от свежих к старым
+func (a *PostgresStorageAdapter) FindSellerListings(ctx context.Context, sellerID uuid.UUID, status string, limit, offset int) (*domain.PaginatedResult, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "PostgresStorageAdapter",
+		"method":    "FindSellerListings",
+		"seller_id": sellerID.String(),
+		"status":    status,
+	})
+
+	var exists bool
+	if err := a.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM sellers WHERE id = $1)`, sellerID).Scan(&exists); err != nil {
+		repoLogger.Error("Failed to check seller existence", err, nil)
+		return nil, fmt.Errorf("failed to check seller existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrSellerNotFound
+	}
+
+	// $2 = '' - все статусы
+	whereClause := `WHERE gp.seller_id = $1 AND ($2 = '' OR gp.status = $2)`
+
+	var totalCount int
+	if err := a.pool.QueryRow(ctx, "SELECT COUNT(*) FROM general_properties gp "+whereClause, sellerID, status).Scan(&totalCount); err != nil {
+		repoLogger.Error("Failed to count seller listings", err, nil)
+		return nil, fmt.Errorf("failed to count seller listings: %w", err)
+	}
+
+	query := `
+		SELECT gp.id, gp.source, gp.source_ad_id, gp.updated_at, gp.category, gp.deal_type, gp.ad_link,
+			gp.title, gp.address, gp.price_byn, gp.price_usd, gp.price_eur, gp.currency, gp.images, gp.status, gp.master_object_id,
+			` + lifecycleColumns("gp.") + `
+		FROM general_properties gp
+		` + whereClause + `
+		ORDER BY gp.first_seen_at DESC, gp.id ASC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := a.pool.Query(ctx, query, sellerID, status, limit, offset)
+	if err != nil {
+		repoLogger.Error("Failed to query seller listings", err, port.Fields{"query": query})
+		return nil, fmt.Errorf("failed to query seller listings: %w", err)
+	}
+	defer rows.Close()
+
+	objects := make([]domain.GeneralPropertyInfo, 0, limit)
+	for rows.Next() {
+		var obj domain.GeneralPropertyInfo
+		dest := []interface{}{
+			&obj.ID, &obj.Source, &obj.SourceAdID, &obj.UpdatedAt, &obj.Category, &obj.DealType,
+			&obj.AdLink, &obj.Title, &obj.Address, &obj.PriceBYN, &obj.PriceUSD, &obj.PriceEUR,
+			&obj.Currency, &obj.Images, &obj.Status, &obj.MasterObjectID,
+		}
+		if err := rows.Scan(append(dest, lifecycleScanTargets(&obj)...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan seller listing: %w", err)
+		}
+		obj.SellerID = &sellerID
+		objects = append(objects, obj)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate seller listings: %w", err)
+	}
+
+	repoLogger.Debug("Seller listings loaded", port.Fields{"total": totalCount, "returned": len(objects)})
+	return &domain.PaginatedResult{
+		Objects:      objects,
+		TotalCount:   totalCount,
+		CurrentPage:  offset/limit + 1,
+		ItemsPerPage: limit,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Характеристики для сравнения из таблицы деталей категории. У коммерции нет комнат и года постройки
+const similarityDetailsJoin = `
+	LEFT JOIN apartments a ON a.property_id = gp.id
+	LEFT JOIN houses h ON h.property_id = gp.id
+	LEFT JOIN commercial c ON c.property_id = gp.id`
+
+const similarityDetailsColumns = `
+	COALESCE(a.total_area, h.total_area, c.total_area) AS total_area,
+	COALESCE(a.rooms_amount, h.rooms_amount) AS rooms_amount,
+	COALESCE(a.year_built, h.year_built) AS year_built`
+
+// Точка (0, 0), которую пишут источники без координат
+const nullIslandPoint = `ST_SetSRID(ST_MakePoint(0, 0), 4326)`
+
+func (a *PostgresStorageAdapter) GetSimilarityProfile(ctx context.Context, propertyID uuid.UUID) (*domain.SimilarityProfile, error) {
+	query := `
+		SELECT gp.id, gp.master_object_id, gp.category, gp.deal_type, gp.price_usd, ` + similarityDetailsColumns + `
+		FROM general_properties gp ` + similarityDetailsJoin + `
+		WHERE gp.id = $1`
+
+	var p domain.SimilarityProfile
+	err := a.pool.QueryRow(ctx, query, propertyID).Scan(
+		&p.PropertyID, &p.MasterObjectID, &p.Category, &p.DealType, &p.PriceUSD, &p.TotalArea, &p.Rooms, &p.YearBuilt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPropertyNotFound
+		}
+		return nil, fmt.Errorf("failed to get similarity profile: %w", err)
+	}
+	return &p, nil
+}
+
+func (a *PostgresStorageAdapter) FindSimilarCandidates(ctx context.Context, source domain.SimilarityProfile, radiusMeters, priceTolerance float64, limit int) ([]domain.SimilarCandidate, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":   "PostgresStorageAdapter",
+		"method":      "FindSimilarCandidates",
+		"property_id": source.PropertyID,
+	})
+
+	// Без цены у исходного объявления ограничивать кандидатов по цене не от чего
+	var priceMin, priceMax *float64
+	if priceTolerance > 0 && source.PriceUSD > 0 {
+		lo, hi := source.PriceUSD*(1-priceTolerance), source.PriceUSD*(1+priceTolerance)
+		priceMin, priceMax = &lo, &hi
+	}
+
+	// Из каждой master-группы берется одно объявление - так же, как в best-by-master-ids.
+	// ST_DWithin по geography использует GiST-индекс по coordinates.
+	// Объявления без координат хранятся в точке (0, 0): у такого источника кандидатов нет,
+	// а такие кандидаты иначе оказались бы "рядом" друг с другом
+	query := `
+		WITH source AS (
+			SELECT coordinates FROM general_properties
+			WHERE id = $1 AND NOT ST_Equals(coordinates::geometry, ` + nullIslandPoint + `)
+		), ranked AS (
+			SELECT
+				gp.id, gp.source, gp.source_ad_id, gp.updated_at, gp.category, gp.deal_type, gp.ad_link, gp.title,
+				gp.address, gp.price_byn, gp.price_usd, gp.price_eur, gp.currency, gp.images, gp.status, gp.master_object_id,
+				` + lifecycleColumns("gp.") + `,
+				` + similarityDetailsColumns + `,
+				ST_Distance(gp.coordinates, s.coordinates) AS distance_m,
+				ROW_NUMBER() OVER (
+					PARTITION BY gp.master_object_id
+					ORDER BY gp.is_source_duplicate ASC, gp.updated_at DESC
+				) AS rn
+			FROM general_properties gp
+			CROSS JOIN source s ` + similarityDetailsJoin + `
+			WHERE gp.status = 'active'
+			  AND gp.category = $2
+			  AND gp.deal_type = $3
+			  AND gp.master_object_id <> $4
+			  AND ST_DWithin(gp.coordinates, s.coordinates, $5)
+			  AND NOT ST_Equals(gp.coordinates::geometry, ` + nullIslandPoint + `)
+			  AND ($6::numeric IS NULL OR gp.price_usd BETWEEN $6 AND $7)
+		)
+		SELECT id, source, source_ad_id, updated_at, category, deal_type, ad_link, title,
+			address, price_byn, price_usd, price_eur, currency, images, status, master_object_id,
+			first_seen_at, last_seen_active_at, archived_at, reactivation_count, relisted_from_id, days_on_market,
+			total_area, rooms_amount, year_built, distance_m
+		FROM ranked
+		WHERE rn = 1
+		ORDER BY distance_m ASC
+		LIMIT $8`
+
+	rows, err := a.pool.Query(ctx, query, source.PropertyID, source.Category, source.DealType, source.MasterObjectID,
+		radiusMeters, priceMin, priceMax, limit)
+	if err != nil {
+		repoLogger.Error("Failed to query similar candidates", err, nil)
+		return nil, fmt.Errorf("failed to find similar candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]domain.SimilarCandidate, 0, limit)
+	for rows.Next() {
+		var c domain.SimilarCandidate
+		obj := &c.Listing
+		dest := []interface{}{
+			&obj.ID, &obj.Source, &obj.SourceAdID, &obj.UpdatedAt, &obj.Category, &obj.DealType,
+			&obj.AdLink, &obj.Title, &obj.Address, &obj.PriceBYN, &obj.PriceUSD, &obj.PriceEUR,
+			&obj.Currency, &obj.Images, &obj.Status, &obj.MasterObjectID,
+		}
+		dest = append(dest, lifecycleScanTargets(obj)...)
+		dest = append(dest, &c.Profile.TotalArea, &c.Profile.Rooms, &c.Profile.YearBuilt, &c.DistanceMeters)
+		if err := rows.Scan(dest...); err != nil {
+			repoLogger.Error("Failed to scan similar candidate row", err, nil)
+			return nil, fmt.Errorf("failed to scan similar candidate: %w", err)
+		}
+
+		c.Profile.PropertyID = obj.ID
+		c.Profile.MasterObjectID = obj.MasterObjectID
+		c.Profile.Category = obj.Category
+		c.Profile.DealType = obj.DealType
+		c.Profile.PriceUSD = obj.PriceUSD
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		repoLogger.Error("Error during similar candidates rows iteration", err, nil)
+		return nil, err
+	}
+
+	repoLogger.Debug("Similar candidates found", port.Fields{"count": len(candidates)})
+	return candidates, nil
+}
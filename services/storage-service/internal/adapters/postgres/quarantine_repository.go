@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// QuarantineRepository хранит записи, не прошедшие правила качества данных
+type QuarantineRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewQuarantineRepository(pool *pgxpool.Pool) (*QuarantineRepository, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pgxpool.Pool cannot be nil")
+	}
+	return &QuarantineRepository{pool: pool}, nil
+}
+
+// quarantinePayload - содержимое колонки record
+type quarantinePayload struct {
+	General domain.GeneralProperty `json:"general"`
+	Details json.RawMessage        `json:"details"`
+}
+
+// newDetailsForCategory - пустые детали нужного типа для разбора колонки record
+func newDetailsForCategory(category string) interface{} {
+	switch category {
+	case "apartment":
+		return &domain.Apartment{}
+	case "house":
+		return &domain.House{}
+	case "commercial":
+		return &domain.Commercial{}
+	case "garage_and_parking":
+		return &domain.GarageAndParking{}
+	case "room":
+		return &domain.Room{}
+	case "plot":
+		return &domain.Plot{}
+	case "new_building":
+		return &domain.NewBuilding{}
+	}
+	return nil
+}
+
+func encodeQuarantinedRecord(record domain.RealEstateRecord) ([]byte, error) {
+	return json.Marshal(struct {
+		General domain.GeneralProperty `json:"general"`
+		Details interface{}            `json:"details"`
+	}{record.General, record.Details})
+}
+
+func decodeQuarantinedRecord(raw []byte) (domain.RealEstateRecord, error) {
+	var payload quarantinePayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return domain.RealEstateRecord{}, err
+	}
+	record := domain.RealEstateRecord{General: payload.General}
+	// Coordinates в JSON не пишется: собираем из широты и долготы так же, как при разборе события
+	record.General.Coordinates = fmt.Sprintf("SRID=4326;POINT(%f %f)", payload.General.Longitude, payload.General.Latitude)
+
+	if details := newDetailsForCategory(payload.General.Category); details != nil && len(payload.Details) > 0 && string(payload.Details) != "null" {
+		if err := json.Unmarshal(payload.Details, details); err != nil {
+			return domain.RealEstateRecord{}, err
+		}
+		record.Details = details
+	}
+	return record, nil
+}
+
+// nullableTaskID - uuid.Nil пишется как NULL
+func nullableTaskID(taskID uuid.UUID) interface{} {
+	if taskID == uuid.Nil {
+		return nil
+	}
+	return taskID
+}
+
+func (r *QuarantineRepository) Quarantine(ctx context.Context, records []domain.QuarantinedRecord) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":    "QuarantineRepository",
+		"method":       "Quarantine",
+		"record_count": len(records),
+	})
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, q := range records {
+		recordJSON, err := encodeQuarantinedRecord(q.Record)
+		if err != nil {
+			return fmt.Errorf("failed to encode quarantined record: %w", err)
+		}
+		violationsJSON, err := json.Marshal(q.Violations)
+		if err != nil {
+			return fmt.Errorf("failed to encode violations: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO quarantined_records (source, source_ad_id, category, task_id, record, violations)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (source, source_ad_id) WHERE status = 'pending' DO UPDATE SET
+				category = EXCLUDED.category,
+				task_id = EXCLUDED.task_id,
+				record = EXCLUDED.record,
+				violations = EXCLUDED.violations,
+				updated_at = NOW()`,
+			q.Record.General.Source, q.Record.General.SourceAdID, q.Record.General.Category,
+			nullableTaskID(q.TaskID), recordJSON, violationsJSON,
+		)
+		if err != nil {
+			repoLogger.Error("Failed to quarantine record", err, port.Fields{
+				"source": q.Record.General.Source,
+				"ad_id":  q.Record.General.SourceAdID,
+			})
+			return fmt.Errorf("failed to quarantine record: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit quarantine: %w", err)
+	}
+	repoLogger.Info("Records quarantined", nil)
+	return nil
+}
+
+const quarantineColumns = `id, task_id, record, violations, status, created_at, updated_at, resolved_at`
+
+func scanQuarantinedRecord(row pgx.Row) (*domain.QuarantinedRecord, error) {
+	var q domain.QuarantinedRecord
+	var taskID *uuid.UUID
+	var recordJSON, violationsJSON []byte
+	if err := row.Scan(&q.ID, &taskID, &recordJSON, &violationsJSON, &q.Status, &q.CreatedAt, &q.UpdatedAt, &q.ResolvedAt); err != nil {
+		return nil, err
+	}
+	if taskID != nil {
+		q.TaskID = *taskID
+	}
+
+	record, err := decodeQuarantinedRecord(recordJSON)
+	if err != nil {
+		return nil, fmt.Errorf("corrupted quarantined record %s: %w", q.ID, err)
+	}
+	q.Record = record
+	if err := json.Unmarshal(violationsJSON, &q.Violations); err != nil {
+		return nil, fmt.Errorf("corrupted violations of quarantined record %s: %w", q.ID, err)
+	}
+	return &q, nil
+}
+
+func (r *QuarantineRepository) ListQuarantined(ctx context.Context, filter domain.QuarantineFilter, limit, offset int) (*domain.PaginatedQuarantine, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "QuarantineRepository",
+		"method":    "ListQuarantined",
+	})
+
+	// Пустой параметр - без фильтра по полю
+	whereClause := `
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR source = $2)
+		  AND ($3 = '' OR category = $3)
+		  AND ($4 = '' OR violations @> jsonb_build_array(jsonb_build_object('rule', $4::text)))`
+	args := []interface{}{filter.Status, filter.Source, filter.Category, filter.Rule}
+
+	result := &domain.PaginatedQuarantine{Records: []domain.QuarantinedRecord{}}
+	if err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM quarantined_records"+whereClause, args...).Scan(&result.TotalCount); err != nil {
+		repoLogger.Error("Failed to count quarantined records", err, nil)
+		return nil, fmt.Errorf("failed to count quarantined records: %w", err)
+	}
+
+	query := "SELECT " + quarantineColumns + " FROM quarantined_records" + whereClause + `
+		ORDER BY created_at DESC, id ASC
+		LIMIT $5 OFFSET $6`
+	rows, err := r.pool.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		repoLogger.Error("Failed to query quarantined records", err, nil)
+		return nil, fmt.Errorf("failed to query quarantined records: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		q, err := scanQuarantinedRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quarantined record: %w", err)
+		}
+		result.Records = append(result.Records, *q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate quarantined records: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *QuarantineRepository) GetQuarantined(ctx context.Context, id uuid.UUID) (*domain.QuarantinedRecord, error) {
+	q, err := scanQuarantinedRecord(r.pool.QueryRow(ctx, "SELECT "+quarantineColumns+" FROM quarantined_records WHERE id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrQuarantinedRecordNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quarantined record: %w", err)
+	}
+	return q, nil
+}
+
+func (r *QuarantineRepository) UpdateQuarantined(ctx context.Context, id uuid.UUID, record domain.RealEstateRecord, violations []domain.QualityViolation) error {
+	recordJSON, err := encodeQuarantinedRecord(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode quarantined record: %w", err)
+	}
+	if violations == nil {
+		violations = []domain.QualityViolation{}
+	}
+	violationsJSON, err := json.Marshal(violations)
+	if err != nil {
+		return fmt.Errorf("failed to encode violations: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE quarantined_records
+		SET record = $2, violations = $3, updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'`,
+		id, recordJSON, violationsJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update quarantined record: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.notPendingError(ctx, id)
+	}
+	return nil
+}
+
+func (r *QuarantineRepository) ResolveQuarantined(ctx context.Context, id uuid.UUID, status string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE quarantined_records
+		SET status = $2, updated_at = $3, resolved_at = $3
+		WHERE id = $1 AND status = 'pending'`,
+		id, status, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve quarantined record: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.notPendingError(ctx, id)
+	}
+	return nil
+}
+
+// notPendingError различает "записи нет" и "запись уже разобрана", когда UPDATE ничего не затронул
+func (r *QuarantineRepository) notPendingError(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM quarantined_records WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check quarantined record existence: %w", err)
+	}
+	if !exists {
+		return domain.ErrQuarantinedRecordNotFound
+	}
+	return domain.ErrQuarantineNotPending
+}
@@ -84,13 +84,38 @@ func applyFilters(filters domain.FindObjectsFilters) (string, string, []interfac
 		qb.addCondition("%s = $%d", "gp.deal_type", filters.DealType)
 	}
 
+	// Список показывает только активные объявления, поэтому срок экспозиции считается от first_seen_at до NOW()
+	if filters.DaysOnMarketMin != nil {
+		qb.addCondition("%s <= NOW() - make_interval(days => $%d)", "gp.first_seen_at", *filters.DaysOnMarketMin)
+	}
+	if filters.DaysOnMarketMax != nil {
+		// days_on_market <= max, пока не прошло max+1 полных суток
+		qb.addCondition("%s > NOW() - make_interval(days => $%d)", "gp.first_seen_at", *filters.DaysOnMarketMax+1)
+	}
+	if filters.IsRelisted != nil {
+		if *filters.IsRelisted {
+			qb.conditions = append(qb.conditions, "gp.relisted_from_id IS NOT NULL")
+		} else {
+			qb.conditions = append(qb.conditions, "gp.relisted_from_id IS NULL")
+		}
+	}
+
+	if filters.PrivateSellersOnly {
+		qb.addCondition(`EXISTS (
+			SELECT 1 FROM sellers s
+			WHERE s.id = %s AND NOT s.is_agency
+			  AND (SELECT COUNT(*) FROM general_properties sp WHERE sp.seller_id = s.id AND sp.status = 'active') <= $%d
+		)`, "gp.seller_id", domain.PrivateSellerMaxActiveListings)
+	}
+
+	if len(filters.ExcludeMasterIDs) > 0 {
+		qb.addCondition("%s <> ALL($%d::uuid[])", "gp.master_object_id", filters.ExcludeMasterIDs)
+	}
+
+	// Цены всех источников пересчитаны по одним курсам, так что фильтр по любой валюте сравним
 	switch filters.PriceCurrency {
-	case "BYN":
-		qb.AddFloatFilter("gp.price_byn", filters.PriceMin, filters.PriceMax)
-	case "EUR":
-		qb.AddFloatFilter("gp.price_eur", filters.PriceMin, filters.PriceMax)
-	case "USD":
-		qb.AddFloatFilter("gp.price_usd", filters.PriceMin, filters.PriceMax)
+	case domain.CurrencyBYN, domain.CurrencyEUR, domain.CurrencyUSD:
+		qb.AddFloatFilter(priceColumn("gp.", filters.PriceCurrency), filters.PriceMin, filters.PriceMax)
 	}
 	
 	// Специфичные фильтры, требующие JOIN
@@ -208,3 +233,47 @@ func applyFilters(filters domain.FindObjectsFilters) (string, string, []interfac
 	return qb.build()
 }
 
+
+// priceColumn - колонка цены в валюте. prefix - алиас таблицы с точкой или пустая строка, по умолчанию USD
+func priceColumn(prefix, currency string) string {
+	switch currency {
+	case domain.CurrencyBYN:
+		return prefix + "price_byn"
+	case domain.CurrencyEUR:
+		return prefix + "price_eur"
+	default:
+		return prefix + "price_usd"
+	}
+}
+
+// orderClause - ORDER BY для выдачи FindWithFilters по колонкам CTE
+func orderClause(filters domain.FindObjectsFilters) string {
+	switch filters.SortBy {
+	case domain.SortByDaysOnMarketAsc:
+		return "ORDER BY first_seen_at DESC, id ASC"
+	case domain.SortByDaysOnMarketDesc:
+		return "ORDER BY first_seen_at ASC, id ASC"
+	case domain.SortByPriceAsc:
+		return "ORDER BY " + priceColumn("", filters.PriceCurrency) + " ASC NULLS LAST, id ASC"
+	case domain.SortByPriceDesc:
+		return "ORDER BY " + priceColumn("", filters.PriceCurrency) + " DESC NULLS LAST, id ASC"
+	default:
+		return "ORDER BY updated_at DESC, id ASC"
+	}
+}
+
+// lifecycleColumns - колонки жизненного цикла для SELECT. prefix - алиас таблицы с точкой или пустая строка
+func lifecycleColumns(prefix string) string {
+	return fmt.Sprintf(
+		"%[1]sfirst_seen_at, %[1]slast_seen_active_at, %[1]sarchived_at, %[1]sreactivation_count, %[1]srelisted_from_id, "+
+			"EXTRACT(DAY FROM COALESCE(%[1]sarchived_at, NOW()) - %[1]sfirst_seen_at)::int AS days_on_market",
+		prefix,
+	)
+}
+
+// lifecycleScanTargets - приемники для колонок lifecycleColumns в том же порядке
+func lifecycleScanTargets(obj *domain.GeneralPropertyInfo) []interface{} {
+	return []interface{}{
+		&obj.FirstSeenAt, &obj.LastSeenActiveAt, &obj.ArchivedAt, &obj.ReactivationCount, &obj.RelistedFromID, &obj.DaysOnMarket,
+	}
+}
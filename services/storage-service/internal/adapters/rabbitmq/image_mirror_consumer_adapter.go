@@ -0,0 +1,84 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/port"
+	usecases_port "storage-service/internal/core/port/usecases_port"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ImageMirrorConsumerAdapter - входящий адаптер очереди копирования фото
+type ImageMirrorConsumerAdapter struct {
+	consumer rabbitmq_consumer.Consumer
+	useCase  usecases_port.MirrorImagesPort
+	logger   port.LoggerPort
+}
+
+func NewImageMirrorConsumerAdapter(
+	cfg rabbitmq_consumer.ConsumerConfig,
+	useCase usecases_port.MirrorImagesPort,
+	logger port.LoggerPort,
+	connManager *rabbitmq_common.ConnectionManager,
+) (*ImageMirrorConsumerAdapter, error) {
+	adapter := &ImageMirrorConsumerAdapter{useCase: useCase, logger: logger}
+
+	pkgLogger := logger.WithFields(port.Fields{"component": "rabbitmq_distributing_consumer", "consumer_tag": cfg.ConsumerTag})
+	cfg.Logger = NewPkgLoggerBridge(pkgLogger)
+
+	consumer, err := rabbitmq_consumer.NewDistributingConsumer(cfg, adapter.messageHandler, connManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RabbitMQ consumer for image mirroring: %w", err)
+	}
+	adapter.consumer = consumer
+	return adapter, nil
+}
+
+func (a *ImageMirrorConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) error {
+	traceID, _ := d.Headers["x-trace-id"].(string)
+	if traceID == "" {
+		traceID = uuid.New().String()
+	}
+	msgLogger := a.logger.WithFields(port.Fields{
+		"trace_id":     traceID,
+		"delivery_tag": d.DeliveryTag,
+		"adapter_name": "ImageMirrorConsumerAdapter",
+	})
+
+	eventType, _ := d.Headers[schemas.HeaderEventType].(string)
+	eventVersion, _ := d.Headers[schemas.HeaderEventVersion].(string)
+	if err := schemas.Validate(eventType, eventVersion, d.Body); err != nil {
+		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
+		return rabbitmq_consumer.NewPermanentError(err)
+	}
+
+	var event types.ImageMirrorEventV1
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		return rabbitmq_consumer.NewPermanentError(fmt.Errorf("failed to unmarshal image mirror event: %w", err))
+	}
+
+	handlerLogger := msgLogger.WithFields(port.Fields{"source": event.Source})
+	ctx = contextkeys.ContextWithLogger(ctx, handlerLogger)
+	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
+
+	// Ошибка use case - временная (сеть, хранилище): сообщение уйдет на повтор
+	return a.useCase.Execute(ctx, event.Urls)
+}
+
+// Start реализует EventListenerPort
+func (a *ImageMirrorConsumerAdapter) Start(ctx context.Context) error {
+	return a.consumer.StartConsuming(ctx)
+}
+
+// Close реализует EventListenerPort
+func (a *ImageMirrorConsumerAdapter) Close() error {
+	return a.consumer.Close()
+}
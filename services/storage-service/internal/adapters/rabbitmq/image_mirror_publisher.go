@@ -0,0 +1,65 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
+	"storage-service/internal/contextkeys"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ImageMirrorPublisherAdapter ставит фото в очередь на копирование
+type ImageMirrorPublisherAdapter struct {
+	producer   *rabbitmq_producer.Publisher
+	routingKey string
+}
+
+func NewImageMirrorPublisherAdapter(producer *rabbitmq_producer.Publisher, routingKey string) (*ImageMirrorPublisherAdapter, error) {
+	if producer == nil {
+		return nil, fmt.Errorf("rabbitmq adapter: producer cannot be nil")
+	}
+	if routingKey == "" {
+		return nil, fmt.Errorf("rabbitmq adapter: routingKey cannot be empty")
+	}
+	return &ImageMirrorPublisherAdapter{
+		producer:   producer,
+		routingKey: routingKey,
+	}, nil
+}
+
+func (a *ImageMirrorPublisherAdapter) RequestMirror(ctx context.Context, source string, urls []string) error {
+	body, err := schemas.Marshal(types.ImageMirrorEventV1EventType, types.ImageMirrorEventV1Version, types.ImageMirrorEventV1{
+		Source:      source,
+		Urls:        urls,
+		RequestedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("rabbitmq adapter: failed to marshal image mirror request: %w", err)
+	}
+
+	msg := amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         body,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.ImageMirrorEventV1EventType,
+			schemas.HeaderEventVersion: types.ImageMirrorEventV1Version,
+		},
+	}
+	if traceID := contextkeys.TraceIDFromContext(ctx); traceID != "" {
+		msg.Headers["x-trace-id"] = traceID
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := a.producer.Publish(publishCtx, a.routingKey, msg); err != nil {
+		return fmt.Errorf("rabbitmq adapter: failed to publish image mirror request: %w", err)
+	}
+	return nil
+}
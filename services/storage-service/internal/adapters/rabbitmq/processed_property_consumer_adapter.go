@@ -3,15 +3,16 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"storage-service/internal/contextkeys"
-	"storage-service/internal/contracts"
 	"storage-service/internal/core/domain"
 	"storage-service/internal/core/port"
 	usecases_port "storage-service/internal/core/port/usecases_port"
 
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
+	"real-estate-system/schemas"
 	"time"
 
 	"github.com/google/uuid"
@@ -112,8 +113,15 @@ func NewProcessedPropertyConsumerAdapter(
 	return adapter, nil
 }
 
+// indexedRecord - запись вместе с индексом доставки, из которой она получена
+type indexedRecord struct {
+	index  int
+	record domain.RealEstateRecord
+}
+
 // batchMessageHandler - обработчик, который принимает срез сообщений.
-func (a *ProcessedPropertyConsumerAdapter) batchMessageHandler(deliveries []amqp.Delivery) error {
+// Возвращает итог по каждому сообщению, чтобы одна плохая запись не блокировала всю пачку
+func (a *ProcessedPropertyConsumerAdapter) batchMessageHandler(deliveries []amqp.Delivery) []error {
 
 	if len(deliveries) == 0 {
 		return nil // Пустая пачка, ничего не делаем
@@ -142,23 +150,24 @@ func (a *ProcessedPropertyConsumerAdapter) batchMessageHandler(deliveries []amqp
 
 	batchLogger.Info("Received batch of messages to process.", nil)
 
-	recordsByTask := make(map[uuid.UUID][]domain.RealEstateRecord)
+	outcomes := make([]error, len(deliveries))
+	recordsByTask := make(map[uuid.UUID][]indexedRecord)
 
-	// Разбираем все сообщения в пачке
-	for _, d := range deliveries {
+	// Разбираем все сообщения в пачке. Неразобранные сразу помечаем, остальные сохраняем
+	for i, d := range deliveries {
 		record, taskID, err := a.unmarshalRecord(d, batchLogger)
 		if err != nil {
-			// Если хотя бы одно сообщение плохое, возвращаем ошибку, чтобы вся пачка вернулась в очередь (и в итоге попала в DLX)
-			return err
+			outcomes[i] = err
+			continue
 		}
 		if record != nil {
-			recordsByTask[taskID] = append(recordsByTask[taskID], *record)
+			recordsByTask[taskID] = append(recordsByTask[taskID], indexedRecord{index: i, record: *record})
 		}
 	}
 
 	if len(recordsByTask) == 0 {
 		batchLogger.Info("No valid records in batch to save.", nil)
-		return nil
+		return outcomes
 	}
 
 	// вызываем BatchSave для каждой группы задач
@@ -166,17 +175,49 @@ func (a *ProcessedPropertyConsumerAdapter) batchMessageHandler(deliveries []amqp
 		taskLogger := batchLogger.WithFields(port.Fields{"task_id": taskID.String()})
 		taskLogger.Info("Calling BatchSave for records from task...", port.Fields{"record_count": len(records)})
 
-		// Передаем taskID в Use Case
-		if err := a.useCase.BatchSave(ctx, records, taskID); err != nil {
-			taskLogger.Error("BatchSave failed, the entire batch will be requeued.", err, nil)
-			// Если хотя бы один объект не сохранился, возвращаем ошибку, чтобы весь батч обработался снова
-			return err
+		a.saveBisecting(contextkeys.ContextWithLogger(ctx, taskLogger), taskLogger, records, taskID, outcomes)
+	}
+
+	batchLogger.Info("Batch processed.", nil)
+	return outcomes
+}
+
+// saveBisecting сохраняет группу записей. Если БД отвергла данные (а не просто недоступна),
+// группа делится пополам, пока не останутся одиночные плохие записи - только они уходят в DLX,
+// остальные сохраняются
+func (a *ProcessedPropertyConsumerAdapter) saveBisecting(ctx context.Context, logger port.LoggerPort, records []indexedRecord, taskID uuid.UUID, outcomes []error) {
+	batch := make([]domain.RealEstateRecord, len(records))
+	for i, r := range records {
+		batch[i] = r.record
+	}
+
+	err := a.useCase.BatchSave(ctx, batch, taskID)
+	if err == nil {
+		return
+	}
+
+	if !errors.Is(err, domain.ErrInvalidRecord) {
+		// Временная ошибка (соединение, таймаут): делить бесполезно, повторяем всю группу
+		logger.Error("BatchSave failed, records will be retried.", err, port.Fields{"record_count": len(records)})
+		for _, r := range records {
+			outcomes[r.index] = err
 		}
+		return
 	}
 
-	batchLogger.Info("Batch processed successfully.", nil)
-	return nil
+	if len(records) == 1 {
+		logger.Error("Record rejected by storage, sending to DLX.", err, port.Fields{
+			"source": records[0].record.General.Source,
+			"ad_id":  records[0].record.General.SourceAdID,
+		})
+		outcomes[records[0].index] = rabbitmq_consumer.NewPermanentError(err)
+		return
+	}
 
+	logger.Warn("BatchSave rejected records, bisecting batch.", port.Fields{"record_count": len(records), "error": err.Error()})
+	mid := len(records) / 2
+	a.saveBisecting(ctx, logger, records[:mid], taskID, outcomes)
+	a.saveBisecting(ctx, logger, records[mid:], taskID, outcomes)
 }
 
 // unmarshalRecord - функция для разбора сообщения
@@ -188,17 +229,17 @@ func (a *ProcessedPropertyConsumerAdapter) unmarshalRecord(d amqp.Delivery, pare
 	})
 
 	// Валидация по схеме
-	eventType, _ := d.Headers["event-type"].(string)
-	eventVersion, _ := d.Headers["event-version"].(string)
-	if err := contracts.ValidateEvent(eventType, eventVersion, d.Body); err != nil {
+	eventType, _ := d.Headers[schemas.HeaderEventType].(string)
+	eventVersion, _ := d.Headers[schemas.HeaderEventVersion].(string)
+	if err := schemas.Validate(eventType, eventVersion, d.Body); err != nil {
 		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
-		return nil, uuid.Nil, err
+		return nil, uuid.Nil, rabbitmq_consumer.NewPermanentError(err)
 	}
 
 	// Десериализация в DTO
 	var dto IncomingEventDTO
 	if err := json.Unmarshal(d.Body, &dto); err != nil {
-		return nil, uuid.Nil, fmt.Errorf("failed to unmarshal incoming event DTO: %w", err)
+		return nil, uuid.Nil, rabbitmq_consumer.NewPermanentError(fmt.Errorf("failed to unmarshal incoming event DTO: %w", err))
 	}
 
 	// трансляция в домен
@@ -213,7 +254,7 @@ func (a *ProcessedPropertyConsumerAdapter) unmarshalRecord(d amqp.Delivery, pare
 		details, err := unmarshaler.UnmarshalDetails(dto.Details)
 		if err != nil {
 			parentLogger.Error("Error unmarshalling details", err, port.Fields{"details_type": dto.DetailsType})
-			return nil, uuid.Nil, err
+			return nil, uuid.Nil, rabbitmq_consumer.NewPermanentError(err)
 		}
 		record.Details = details
 	}
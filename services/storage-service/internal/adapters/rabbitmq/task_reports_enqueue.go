@@ -2,9 +2,10 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"real-estate-system/schemas"
+	"real-estate-system/schemas/types"
 	"storage-service/internal/contextkeys"
 	"storage-service/internal/core/domain"
 	"storage-service/internal/core/port"
@@ -18,6 +19,45 @@ import (
 type TaskResultDTO struct {
 	TaskID  uuid.UUID      `json:"task_id"`
 	Results map[string]int `json:"results"`
+	Items   []string       `json:"items,omitempty"`
+
+	Subtasks []SubtaskProgressDTO `json:"subtasks,omitempty"`
+}
+
+// SubtaskProgressDTO - сохранение пачки одного источника для разбивки прогресса задачи (task-result 1.2)
+type SubtaskProgressDTO struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	Processed  int    `json:"processed"`
+	Errors     int    `json:"errors,omitempty"` // записи, отложенные в карантин
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// saveSubtaskName - подзадача сохранения, общая для всех пачек одного источника
+const saveSubtaskName = "save"
+
+func toSubtaskProgress(stats *domain.BatchSaveStats) []SubtaskProgressDTO {
+	bySource := make(map[string]*SubtaskProgressDTO)
+	get := func(source string) *SubtaskProgressDTO {
+		if sub, ok := bySource[source]; ok {
+			return sub
+		}
+		sub := &SubtaskProgressDTO{Name: saveSubtaskName, Source: source, DurationMs: stats.Duration.Milliseconds()}
+		bySource[source] = sub
+		return sub
+	}
+	for source, count := range stats.ProcessedBySource {
+		get(source).Processed = count
+	}
+	for source, count := range stats.QuarantinedBySource {
+		get(source).Errors = count
+	}
+
+	subtasks := make([]SubtaskProgressDTO, 0, len(bySource))
+	for _, sub := range bySource {
+		subtasks = append(subtasks, *sub)
+	}
+	return subtasks
 }
 
 type TaskReporterAdapter struct {
@@ -53,18 +93,28 @@ func (a *TaskReporterAdapter) ReportResults(ctx context.Context, taskID uuid.UUI
 			"created":         stats.Created,
 			"updated":         stats.Updated,
 			"archived":        stats.Archived,
+			"quarantined":     stats.Quarantined,
 			"total_processed": stats.Created + stats.Updated + stats.Archived,
 		},
+		Items:    stats.ReportedItems,
+		Subtasks: toSubtaskProgress(stats),
 	}
 
-	body, _ := json.Marshal(dto)
+	body, err := schemas.Marshal(types.TaskResultEventV1EventType, types.TaskResultEventV1Version, dto)
+	if err != nil {
+		adapterLogger.Error("Failed to marshal report for task", err, nil)
+		return fmt.Errorf("rabbitmq adapter: failed to marshal report for task %s: %w", taskID, err)
+	}
 
 	msg := amqp.Publishing{
 		ContentType:  "application/json",
 		Body:         body,
 		DeliveryMode: amqp.Persistent, // Для сохранения сообщений при перезапуске брокера
 		Timestamp:    time.Now(),
-		Headers:      make(amqp.Table),
+		Headers: amqp.Table{
+			schemas.HeaderEventType:    types.TaskResultEventV1EventType,
+			schemas.HeaderEventVersion: types.TaskResultEventV1Version,
+		},
 	}
 
 	traceID := contextkeys.TraceIDFromContext(ctx)
@@ -76,7 +126,7 @@ func (a *TaskReporterAdapter) ReportResults(ctx context.Context, taskID uuid.UUI
 	defer cancel()
 
 	adapterLogger.Debug("Publishing batch save report for task", port.Fields{"stats": dto.Results})
-	err := a.producer.Publish(publishCtx, a.routingKey, msg)
+	err = a.producer.Publish(publishCtx, a.routingKey, msg)
 	if err != nil {
 		adapterLogger.Error("Failed to publish report", err, nil)
 		return fmt.Errorf("rabbitmq adapter: failed to publish report for task %s: %w", taskID, err)
@@ -0,0 +1,56 @@
+package rates_provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"storage-service/internal/core/domain"
+	"time"
+)
+
+// FileProvider берет курсы из JSON-файла, например выгрузки истории НБРБ:
+// [{"date": "2025-01-15", "currency": "USD", "rate_byn": 3.2782}, ...]
+// Файл перечитывается при каждом запросе, так что его можно дополнять без перезапуска
+type FileProvider struct {
+	path string
+}
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	if path == "" {
+		return nil, fmt.Errorf("exchange rates file path cannot be empty")
+	}
+	return &FileProvider{path: path}, nil
+}
+
+type fileRate struct {
+	Date     string  `json:"date"`
+	Currency string  `json:"currency"`
+	RateBYN  float64 `json:"rate_byn"`
+}
+
+func (p *FileProvider) FetchRates(ctx context.Context, date time.Time) ([]domain.ExchangeRate, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates file '%s': %w", p.path, err)
+	}
+	var fileRates []fileRate
+	if err := json.Unmarshal(data, &fileRates); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates file '%s': %w", p.path, err)
+	}
+
+	day := date.Format(time.DateOnly)
+	rated := make(map[string]bool, len(domain.RatedCurrencies))
+	for _, currency := range domain.RatedCurrencies {
+		rated[currency] = true
+	}
+
+	var rates []domain.ExchangeRate
+	for _, r := range fileRates {
+		if r.Date != day || !rated[r.Currency] || r.RateBYN <= 0 {
+			continue
+		}
+		rates = append(rates, domain.ExchangeRate{Date: date, Currency: r.Currency, RateBYN: r.RateBYN, Provider: "file"})
+	}
+	return rates, nil
+}
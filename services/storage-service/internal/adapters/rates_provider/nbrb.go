@@ -0,0 +1,89 @@
+package rates_provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"storage-service/internal/core/domain"
+	"time"
+)
+
+// DefaultNBRBURL - публичное API Национального банка
+const DefaultNBRBURL = "https://api.nbrb.by"
+
+// NBRBProvider загружает официальные курсы из API НБРБ
+type NBRBProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewNBRBProvider - конструктор. Пустой baseURL - DefaultNBRBURL
+func NewNBRBProvider(baseURL string, timeout time.Duration) *NBRBProvider {
+	if baseURL == "" {
+		baseURL = DefaultNBRBURL
+	}
+	return &NBRBProvider{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// nbrbRate - элемент ответа /exrates/rates. Курс дается за Cur_Scale единиц валюты
+type nbrbRate struct {
+	Abbreviation string  `json:"Cur_Abbreviation"`
+	Scale        int     `json:"Cur_Scale"`
+	OfficialRate float64 `json:"Cur_OfficialRate"`
+}
+
+func (p *NBRBProvider) FetchRates(ctx context.Context, date time.Time) ([]domain.ExchangeRate, error) {
+	query := url.Values{}
+	query.Set("ondate", date.Format(time.DateOnly))
+	query.Set("periodicity", "0") // ежедневные курсы
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/exrates/rates?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request NBRB rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// На дату, для которой курсы еще не установлены, НБРБ отвечает 404
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("NBRB returned unexpected status code: %d", resp.StatusCode)
+	}
+
+	var body []nbrbRate
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode NBRB response: %w", err)
+	}
+
+	byCurrency := make(map[string]nbrbRate, len(body))
+	for _, r := range body {
+		byCurrency[r.Abbreviation] = r
+	}
+
+	rates := make([]domain.ExchangeRate, 0, len(domain.RatedCurrencies))
+	for _, currency := range domain.RatedCurrencies {
+		r, ok := byCurrency[currency]
+		if !ok || r.Scale <= 0 || r.OfficialRate <= 0 {
+			continue
+		}
+		rates = append(rates, domain.ExchangeRate{
+			Date:     date,
+			Currency: currency,
+			RateBYN:  r.OfficialRate / float64(r.Scale),
+			Provider: "nbrb",
+		})
+	}
+	return rates, nil
+}
@@ -0,0 +1,28 @@
+package rates_provider
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+	"time"
+)
+
+// stubRatesBYN - правдоподобные постоянные курсы для локального запуска без доступа к НБРБ
+var stubRatesBYN = map[string]float64{
+	domain.CurrencyUSD: 3.25,
+	domain.CurrencyEUR: 3.55,
+}
+
+// StubProvider отдает одни и те же курсы на любую дату
+type StubProvider struct{}
+
+func NewStubProvider() *StubProvider {
+	return &StubProvider{}
+}
+
+func (p *StubProvider) FetchRates(ctx context.Context, date time.Time) ([]domain.ExchangeRate, error) {
+	rates := make([]domain.ExchangeRate, 0, len(domain.RatedCurrencies))
+	for _, currency := range domain.RatedCurrencies {
+		rates = append(rates, domain.ExchangeRate{Date: date, Currency: currency, RateBYN: stubRatesBYN[currency], Provider: "stub"})
+	}
+	return rates, nil
+}
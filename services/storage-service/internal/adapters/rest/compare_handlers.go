@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"storage-service/internal/core/port/usecases_port"
+)
+
+type CompareHandler struct {
+	compareObjectsUC usecases_port.CompareObjectsUseCase
+}
+
+func NewCompareHandler(compareObjectsUC usecases_port.CompareObjectsUseCase) *CompareHandler {
+	return &CompareHandler{compareObjectsUC: compareObjectsUC}
+}
+
+// CompareObjects обрабатывает POST /api/v1/objects/compare
+func (h *CompareHandler) CompareObjects(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	var req CompareObjectsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("Invalid request body", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"handler":    "CompareObjects",
+		"ids_amount": len(req.MasterIDs),
+	})
+	handlerLogger.Debug("Processing request to compare objects", nil)
+
+	comparison, err := h.compareObjectsUC.Execute(r.Context(), req.MasterIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidComparison) {
+			WriteJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		handlerLogger.Error("Use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to compare objects")
+		return
+	}
+
+	response := CompareObjectsResponse{
+		Objects:    make([]ComparedObjectResponse, len(comparison.Objects)),
+		Attributes: make([]ComparisonAttributeResponse, len(comparison.Attributes)),
+		MissingIDs: comparison.MissingIDs,
+	}
+	for i, obj := range comparison.Objects {
+		offers := make([]DuplicatesInfoResponse, len(obj.RelatedOffers))
+		for j, offer := range obj.RelatedOffers {
+			offers[j] = DuplicatesInfoResponse{
+				ID:                offer.ID.String(),
+				Source:            offer.Source,
+				AdLink:            offer.AdLink,
+				IsSourceDuplicate: offer.IsSourceDuplicate,
+				DealType:          offer.DealType,
+			}
+		}
+		response.Objects[i] = ComparedObjectResponse{
+			MasterObjectID:         obj.Main.MasterObjectID,
+			ID:                     obj.Main.ID.String(),
+			Title:                  obj.Main.Title,
+			Images:                 obj.Main.Images,
+			Source:                 obj.Main.Source,
+			AdLink:                 obj.Main.AdLink,
+			Category:               obj.Main.Category,
+			DealType:               obj.Main.DealType,
+			Status:                 obj.Main.Status,
+			PriceUSD:               obj.Main.PriceUSD,
+			PriceBYN:               obj.Main.PriceBYN,
+			PricePerSquareMeterUSD: obj.PricePerSquareMeterUSD,
+			PricePerSquareMeterBYN: obj.PricePerSquareMeterBYN,
+			Details:                obj.Details,
+			RelatedOffers:          offers,
+		}
+	}
+	for i, attr := range comparison.Attributes {
+		response.Attributes[i] = ComparisonAttributeResponse{
+			Key:     attr.Key,
+			Group:   attr.Group,
+			Values:  attr.Values,
+			Differs: attr.Differs,
+		}
+	}
+
+	handlerLogger.Info("Successfully compared objects", port.Fields{
+		"compared": len(comparison.Objects),
+		"missing":  len(comparison.MissingIDs),
+	})
+	RespondWithJSON(w, http.StatusOK, response)
+}
@@ -26,6 +26,10 @@ type ObjectCardResponse struct {
     DealType string    `json:"deal_type"`
 
     MasterObjectID	string    `json:"master_object_id"`
+
+    FirstSeenAt  time.Time `json:"first_seen_at"`
+    DaysOnMarket int       `json:"days_on_market"`
+    IsRelisted   bool      `json:"is_relisted"`
 }
 
 type ObjectGeneralInfoResponse struct {
@@ -54,6 +58,16 @@ type ObjectGeneralInfoResponse struct {
     SellerName string  `json:"seller_name"`     
     SellerDetails interface{} `json:"seller_details"`  
     Status   string    `json:"status"`
+
+    FirstSeenAt       time.Time  `json:"first_seen_at"`
+    LastSeenActiveAt  *time.Time `json:"last_seen_active_at"`
+    ArchivedAt        *time.Time `json:"archived_at"`
+    ReactivationCount int        `json:"reactivation_count"`
+    DaysOnMarket      int        `json:"days_on_market"`
+    IsRelisted        bool       `json:"is_relisted"`
+    RelistedFromID    *string    `json:"relisted_from_id"`
+
+    SellerID *string `json:"seller_id"`
 }
 
 // PaginatedObjectsResponse - DTO для ответа со списком и пагинацией
@@ -80,6 +94,11 @@ type ObjectDetailsResponse struct {
 }
 
 
+// SearchObjectsRequest - тело POST /objects/search, фильтры передаются в query-параметрах как у GET /objects
+type SearchObjectsRequest struct {
+    ExcludeMasterIDs []string `json:"exclude_master_ids"`
+}
+
 type GetByMasterIDsRequest struct {
     MasterIDs []string `json:"master_ids"`
 }
@@ -113,4 +132,124 @@ type StatsByCategoryResponse struct {
 	SystemName    string `json:"system_name"`
 	ActiveCount   int64  `json:"active_count"`
 	ArchivedCount int64  `json:"archived_count"`
-}
\ No newline at end of file
+}
+// PropertyHistoryResponse - ответ GET /objects/{objectID}/history
+type PropertyHistoryResponse struct {
+	PropertyID string                   `json:"property_id"`
+	Total      int                      `json:"total"`
+	Page       int                      `json:"page"`
+	PerPage    int                      `json:"per_page"`
+	Changes    []PropertyChangeResponse `json:"changes"`
+}
+
+type PropertyChangeResponse struct {
+	ChangedAt time.Time                      `json:"changed_at"`
+	Fields    map[string]FieldChangeResponse `json:"fields"`
+}
+
+type FieldChangeResponse struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// SellerProfileResponse - ответ GET /sellers/{sellerID}
+type SellerProfileResponse struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	IsAgency        bool      `json:"is_agency"`
+	IsPrivate       bool      `json:"is_private"`
+	IdentifiedBy    string    `json:"identified_by"`
+	FirstSeenAt     time.Time `json:"first_seen_at"`
+	ActiveListings  int       `json:"active_listings"`
+	TotalListings   int       `json:"total_listings"`
+	AvgDaysOnMarket *float64  `json:"avg_days_on_market"`
+}
+
+// QuarantinedRecordPayload - сама запись в карантине в том виде, в каком ее можно исправить через PATCH
+type QuarantinedRecordPayload struct {
+	General interface{} `json:"general"`
+	Details interface{} `json:"details"`
+}
+
+type QuarantinedRecordResponse struct {
+	ID         string                   `json:"id"`
+	TaskID     *string                  `json:"task_id"`
+	Source     string                   `json:"source"`
+	SourceAdID int64                    `json:"source_ad_id"`
+	Category   string                   `json:"category"`
+	Status     string                   `json:"status"`
+	Violations interface{}              `json:"violations"` // [{"rule": ..., "message": ...}]
+	Record     QuarantinedRecordPayload `json:"record"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	ResolvedAt *time.Time               `json:"resolved_at"`
+}
+
+type PaginatedQuarantineResponse struct {
+	Records []QuarantinedRecordResponse `json:"records"`
+	Total   int                         `json:"total"`
+	Page    int                         `json:"page"`
+	PerPage int                         `json:"per_page"`
+}
+
+// CompareObjectsRequest - тело POST /objects/compare
+type CompareObjectsRequest struct {
+	MasterIDs []string `json:"master_ids"`
+}
+
+// ComparedObjectResponse - столбец таблицы сравнения
+type ComparedObjectResponse struct {
+	MasterObjectID         string                   `json:"master_object_id"`
+	ID                     string                   `json:"id"`
+	Title                  string                   `json:"title"`
+	Images                 []string                 `json:"images"`
+	Source                 string                   `json:"source"`
+	AdLink                 string                   `json:"ad_link"`
+	Category               string                   `json:"category"`
+	DealType               string                   `json:"deal_type"`
+	Status                 string                   `json:"status"`
+	PriceUSD               float64                  `json:"price_usd"`
+	PriceBYN               float64                  `json:"price_byn"`
+	PricePerSquareMeterUSD *float64                 `json:"price_per_square_meter_usd"`
+	PricePerSquareMeterBYN *float64                 `json:"price_per_square_meter_byn"`
+	Details                interface{}              `json:"details"`
+	RelatedOffers          []DuplicatesInfoResponse `json:"related_offers"`
+}
+
+// ComparisonAttributeResponse - строка таблицы сравнения, values в порядке objects
+type ComparisonAttributeResponse struct {
+	Key     string        `json:"key"`
+	Group   string        `json:"group"`
+	Values  []interface{} `json:"values"`
+	Differs bool          `json:"differs"`
+}
+
+type CompareObjectsResponse struct {
+	Objects    []ComparedObjectResponse      `json:"objects"`
+	Attributes []ComparisonAttributeResponse `json:"attributes"`
+	MissingIDs []string                      `json:"missing_ids"`
+}
+
+// SimilarityFactorResponse - пояснение к оценке похожести
+type SimilarityFactorResponse struct {
+	Factor string  `json:"factor"`
+	Weight float64 `json:"weight"`
+	Score  float64 `json:"score"`
+	Note   string  `json:"note"`
+}
+
+// SimilarListingResponse - карточка похожего объекта с оценкой 0..1
+type SimilarListingResponse struct {
+	ObjectCardResponse
+	Score       float64                    `json:"score"`
+	DistanceKm  float64                    `json:"distance_km"`
+	Explanation []SimilarityFactorResponse `json:"explanation"`
+}
+
+type SimilarListingsResponse struct {
+	ObjectID   string                   `json:"object_id"`
+	Category   string                   `json:"category"`
+	DealType   string                   `json:"deal_type"`
+	Candidates int                      `json:"candidates"`
+	Data       []SimilarListingResponse `json:"data"`
+}
@@ -82,6 +82,12 @@ func (h *FilterHandler) GetFilterOptions(w http.ResponseWriter, r *http.Request)
         CommercialLocation: parseStringSlice(query, "commercialBuildingLocations"),
         CommercialRoomsMin: parseInt(query, "roomsMin"),
         CommercialRoomsMax: parseInt(query, "roomsMax"),
+
+		// жизненный цикл
+		DaysOnMarketMin: parseInt(query, "daysOnMarketMin"),
+		DaysOnMarketMax: parseInt(query, "daysOnMarketMax"),
+		IsRelisted:      parseBool(query, "isRelisted"),
+		PrivateSellersOnly: query.Get("privateSellersOnly") == "true",
 	}
     
 
@@ -2,7 +2,10 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"storage-service/internal/contextkeys"
 	"storage-service/internal/core/domain"
 	"storage-service/internal/core/port"
@@ -16,26 +19,63 @@ type GetInfoHandler struct {
 	findObjectsUC      usecases_port.FindObjectsUseCase
 	getObjectDetailsUC usecases_port.GetObjectDetailsUseCase
 	getBestObjectsUC   usecases_port.GetBestObjectsByMasterIDsUseCase
+	getHistoryUC       usecases_port.GetPropertyHistoryUseCase
 }
 
 
 func NewGetInfoHandler(findObjectsUC usecases_port.FindObjectsUseCase, 
 	getObjectDetailsUC usecases_port.GetObjectDetailsUseCase,
-	getBestObjectsUC   usecases_port.GetBestObjectsByMasterIDsUseCase) *GetInfoHandler {
+	getBestObjectsUC   usecases_port.GetBestObjectsByMasterIDsUseCase,
+	getHistoryUC       usecases_port.GetPropertyHistoryUseCase) *GetInfoHandler {
 		return &GetInfoHandler{
 			findObjectsUC: findObjectsUC,
 			getObjectDetailsUC: getObjectDetailsUC,
 			getBestObjectsUC:  getBestObjectsUC,
+			getHistoryUC:      getHistoryUC,
 		}
 }
 
 // FindObjects обрабатывает GET /api/v1/objects
 func (h *GetInfoHandler) FindObjects(w http.ResponseWriter, r *http.Request) {
+	filters, page, perPage := parseFindObjectsQuery(r.URL.Query())
+	h.findObjects(w, r, "FindObjects", filters, page, perPage)
+}
+
+// SearchObjects обрабатывает POST /api/v1/objects/search - тот же поиск, что и GET /objects
+// (фильтры в query-параметрах), но с исключением объектов из тела. Список может быть длинным
+// для URL, поэтому его передает api-gateway в теле запроса
+func (h *GetInfoHandler) SearchObjects(w http.ResponseWriter, r *http.Request) {
 	logger := contextkeys.LoggerFromContext(r.Context())
 
-	// Парсим query-параметры
-	query := r.URL.Query()
+	var req SearchObjectsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("Invalid request body", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.ExcludeMasterIDs) > domain.MaxExcludedMasterIDs {
+		logger.Warn("Too many excluded master IDs", port.Fields{"count": len(req.ExcludeMasterIDs), "max": domain.MaxExcludedMasterIDs})
+		WriteJSONError(w, http.StatusBadRequest, fmt.Sprintf("Too many excluded IDs, max %d", domain.MaxExcludedMasterIDs))
+		return
+	}
 
+	filters, page, perPage := parseFindObjectsQuery(r.URL.Query())
+	filters.ExcludeMasterIDs = make([]uuid.UUID, 0, len(req.ExcludeMasterIDs))
+	for _, raw := range req.ExcludeMasterIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			logger.Warn("Invalid excluded master ID", port.Fields{"provided_id": raw})
+			WriteJSONError(w, http.StatusBadRequest, "Invalid exclude_master_ids format")
+			return
+		}
+		filters.ExcludeMasterIDs = append(filters.ExcludeMasterIDs, id)
+	}
+
+	h.findObjects(w, r, "SearchObjects", filters, page, perPage)
+}
+
+// parseFindObjectsQuery разбирает фильтры и пагинацию поиска объектов
+func parseFindObjectsQuery(query url.Values) (domain.FindObjectsFilters, int, int) {
 	// Парсим пагинацию
 	page, _ := strconv.Atoi(query.Get("page"))
 	if page < 1 {
@@ -45,8 +85,6 @@ func (h *GetInfoHandler) FindObjects(w http.ResponseWriter, r *http.Request) {
 	if perPage < 1 || perPage > 100 {
 		perPage = 20
 	}
-	limit := perPage
-	offset := (page - 1) * perPage
 
 	// Собираем фильтры с помощью хелперов
 	filters := domain.FindObjectsFilters{
@@ -100,13 +138,30 @@ func (h *GetInfoHandler) FindObjects(w http.ResponseWriter, r *http.Request) {
         CommercialLocation: parseStringSlice(query, "commercialBuildingLocations"),
         CommercialRoomsMin: parseInt(query, "roomsMin"),
         CommercialRoomsMax: parseInt(query, "roomsMax"),
+
+		// жизненный цикл
+		DaysOnMarketMin: parseInt(query, "daysOnMarketMin"),
+		DaysOnMarketMax: parseInt(query, "daysOnMarketMax"),
+		IsRelisted:      parseBool(query, "isRelisted"),
+		PrivateSellersOnly: query.Get("privateSellersOnly") == "true",
+		SortBy:          parseString(query, "sortBy"),
 	}
 
+	return filters, page, perPage
+}
+
+func (h *GetInfoHandler) findObjects(w http.ResponseWriter, r *http.Request, handlerName string, filters domain.FindObjectsFilters, page, perPage int) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	limit := perPage
+	offset := (page - 1) * perPage
+
 	handlerLogger := logger.WithFields(port.Fields{
-		"handler": "FindObjects",
+		"handler": handlerName,
 		"page":    page,
 		"per_page": perPage,
-		"filters": filters, 
+		"filters": filters.WithoutExclusions(),
+		"excluded_count": len(filters.ExcludeMasterIDs),
 	})
 	handlerLogger.Debug("Processing request to find objects", nil)
 
@@ -143,6 +198,9 @@ func (h *GetInfoHandler) FindObjects(w http.ResponseWriter, r *http.Request) {
 			MasterObjectID: obj.MasterObjectID,
 			Category: obj.Category,
 			DealType: obj.DealType,
+			FirstSeenAt:  obj.FirstSeenAt,
+			DaysOnMarket: obj.DaysOnMarket,
+			IsRelisted:   obj.IsRelisted(),
 		}
 	}
 
@@ -203,6 +261,20 @@ func (h *GetInfoHandler) GetObjectDetails(w http.ResponseWriter, r *http.Request
 		SellerName: detailsView.MainProperty.SellerName,
 		SellerDetails: detailsView.MainProperty.SellerDetails,
 		Status:   detailsView.MainProperty.Status,		
+		FirstSeenAt: detailsView.MainProperty.FirstSeenAt,
+		LastSeenActiveAt: detailsView.MainProperty.LastSeenActiveAt,
+		ArchivedAt: detailsView.MainProperty.ArchivedAt,
+		ReactivationCount: detailsView.MainProperty.ReactivationCount,
+		DaysOnMarket: detailsView.MainProperty.DaysOnMarket,
+		IsRelisted: detailsView.MainProperty.IsRelisted(),
+	}
+	if detailsView.MainProperty.RelistedFromID != nil {
+		relistedFrom := detailsView.MainProperty.RelistedFromID.String()
+		generalResponse.RelistedFromID = &relistedFrom
+	}
+	if detailsView.MainProperty.SellerID != nil {
+		sellerID := detailsView.MainProperty.SellerID.String()
+		generalResponse.SellerID = &sellerID
 	}
 
 	// Маппим детали и связанные предложения
@@ -226,6 +298,64 @@ func (h *GetInfoHandler) GetObjectDetails(w http.ResponseWriter, r *http.Request
 	RespondWithJSON(w, http.StatusOK, response)
 }
 
+// GetObjectHistory обрабатывает GET /api/v1/objects/{objectID}/history
+func (h *GetInfoHandler) GetObjectHistory(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	objectIDStr := chi.URLParam(r, "objectID")
+	objectID, err := uuid.Parse(objectIDStr)
+	if err != nil {
+		logger.Warn("Invalid object ID format", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid object ID format")
+		return
+	}
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("perPage"))
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"handler":   "GetObjectHistory",
+		"object_id": objectIDStr,
+	})
+	handlerLogger.Debug("Processing request to get object history", nil)
+
+	history, err := h.getHistoryUC.Execute(r.Context(), objectID, perPage, (page-1)*perPage)
+	if err != nil {
+		if errors.Is(err, domain.ErrPropertyNotFound) {
+			WriteJSONError(w, http.StatusNotFound, "Object not found")
+			return
+		}
+		handlerLogger.Error("Use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := PropertyHistoryResponse{
+		PropertyID: history.PropertyID.String(),
+		Total:      history.TotalCount,
+		Page:       page,
+		PerPage:    perPage,
+		Changes:    make([]PropertyChangeResponse, len(history.Changes)),
+	}
+	for i, change := range history.Changes {
+		fields := make(map[string]FieldChangeResponse, len(change.Fields))
+		for name, field := range change.Fields {
+			fields[name] = FieldChangeResponse{Old: field.Old, New: field.New}
+		}
+		response.Changes[i] = PropertyChangeResponse{ChangedAt: change.ChangedAt, Fields: fields}
+	}
+
+	handlerLogger.Info("Successfully found object history", port.Fields{"changes_on_page": len(response.Changes)})
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
 // для избранного
 func (h *GetInfoHandler) GetBestByMasterIDs(w http.ResponseWriter, r *http.Request) {
 	logger := contextkeys.LoggerFromContext(r.Context())
@@ -273,6 +403,9 @@ func (h *GetInfoHandler) GetBestByMasterIDs(w http.ResponseWriter, r *http.Reque
 			MasterObjectID: obj.MasterObjectID,
 			Category: obj.Category,
 			DealType: obj.DealType,
+			FirstSeenAt:  obj.FirstSeenAt,
+			DaysOnMarket: obj.DaysOnMarket,
+			IsRelisted:   obj.IsRelisted(),
 		}
 	}
 
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"storage-service/internal/core/port/usecases_port"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type ImageHandler struct {
+	getPropertyImageUC usecases_port.GetPropertyImageUseCase
+}
+
+func NewImageHandler(getPropertyImageUC usecases_port.GetPropertyImageUseCase) *ImageHandler {
+	return &ImageHandler{getPropertyImageUC: getPropertyImageUC}
+}
+
+// GetObjectImage обрабатывает GET /api/v1/objects/{objectID}/images/{index}?size=thumb.
+// Отдает локальную копию фото, а без нее перенаправляет на ссылку источника
+func (h *ImageHandler) GetObjectImage(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	objectID, err := uuid.Parse(chi.URLParam(r, "objectID"))
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid object ID format")
+		return
+	}
+	index, err := strconv.Atoi(chi.URLParam(r, "index"))
+	if err != nil || index < 0 {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid image index")
+		return
+	}
+	size := r.URL.Query().Get("size")
+	if size == "" {
+		size = domain.ImageSizeOriginal
+	}
+	if size != domain.ImageSizeOriginal && size != domain.ImageSizeThumbnail {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid 'size' parameter, expected 'original' or 'thumb'")
+		return
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"handler":   "GetObjectImage",
+		"object_id": objectID.String(),
+		"index":     index,
+	})
+
+	content, err := h.getPropertyImageUC.Execute(r.Context(), objectID, index, size)
+	if err != nil {
+		if errors.Is(err, domain.ErrPropertyNotFound) || errors.Is(err, domain.ErrImageNotFound) {
+			WriteJSONError(w, http.StatusNotFound, "Image not found")
+			return
+		}
+		handlerLogger.Error("Use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to get image")
+		return
+	}
+
+	if content.Body == nil {
+		http.Redirect(w, r, content.FallbackURL, http.StatusFound)
+		return
+	}
+	defer content.Body.Close()
+
+	// Номер указывает на фото, пока объявление не обновят, поэтому кешируем ненадолго
+	w.Header().Set("Content-Type", content.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content.Body); err != nil {
+		handlerLogger.Warn("Failed to stream image", port.Fields{"error": err.Error()})
+	}
+}
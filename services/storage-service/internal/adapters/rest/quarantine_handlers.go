@@ -0,0 +1,226 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"storage-service/internal/core/port/usecases_port"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// QuarantineHandler - админское API карантина записей, не прошедших правила качества
+type QuarantineHandler struct {
+	quarantineUC usecases_port.QuarantineAdminUseCase
+}
+
+func NewQuarantineHandler(quarantineUC usecases_port.QuarantineAdminUseCase) *QuarantineHandler {
+	return &QuarantineHandler{quarantineUC: quarantineUC}
+}
+
+func toQuarantinedRecordResponse(q domain.QuarantinedRecord) QuarantinedRecordResponse {
+	violations := q.Violations
+	if violations == nil {
+		violations = []domain.QualityViolation{}
+	}
+	resp := QuarantinedRecordResponse{
+		ID:         q.ID.String(),
+		Source:     q.Record.General.Source,
+		SourceAdID: q.Record.General.SourceAdID,
+		Category:   q.Record.General.Category,
+		Status:     q.Status,
+		Violations: violations,
+		Record: QuarantinedRecordPayload{
+			General: q.Record.General,
+			Details: q.Record.Details,
+		},
+		CreatedAt:  q.CreatedAt,
+		UpdatedAt:  q.UpdatedAt,
+		ResolvedAt: q.ResolvedAt,
+	}
+	if q.TaskID != uuid.Nil {
+		taskID := q.TaskID.String()
+		resp.TaskID = &taskID
+	}
+	return resp
+}
+
+// writeQuarantineError переводит ошибки карантина в HTTP-статусы
+func writeQuarantineError(w http.ResponseWriter, logger port.LoggerPort, err error) {
+	switch {
+	case errors.Is(err, domain.ErrQuarantinedRecordNotFound):
+		WriteJSONError(w, http.StatusNotFound, "Quarantined record not found")
+	case errors.Is(err, domain.ErrQuarantineNotPending):
+		WriteJSONError(w, http.StatusConflict, "Quarantined record is already resolved")
+	case errors.Is(err, domain.ErrQualityViolations):
+		WriteJSONError(w, http.StatusConflict, "Record still violates data quality rules; fix it or release with force=true")
+	default:
+		logger.Error("Quarantine use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+func parseQuarantineID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "recordID"))
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid quarantined record ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// List обрабатывает GET /api/v1/quarantine?status=&source=&category=&rule=
+func (h *QuarantineHandler) List(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("perPage"))
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	filter := domain.QuarantineFilter{
+		Status:   parseString(query, "status"),
+		Source:   parseString(query, "source"),
+		Category: parseString(query, "category"),
+		Rule:     parseString(query, "rule"),
+	}
+	if filter.Status == "" {
+		filter.Status = domain.QuarantinePending
+	} else if filter.Status == "all" {
+		filter.Status = ""
+	}
+
+	result, err := h.quarantineUC.List(r.Context(), filter, perPage, (page-1)*perPage)
+	if err != nil {
+		writeQuarantineError(w, logger, err)
+		return
+	}
+
+	response := PaginatedQuarantineResponse{
+		Total:   result.TotalCount,
+		Page:    page,
+		PerPage: perPage,
+		Records: make([]QuarantinedRecordResponse, len(result.Records)),
+	}
+	for i, q := range result.Records {
+		response.Records[i] = toQuarantinedRecordResponse(q)
+	}
+	RespondWithJSON(w, http.StatusOK, response)
+}
+
+// Get обрабатывает GET /api/v1/quarantine/{recordID}
+func (h *QuarantineHandler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+	id, ok := parseQuarantineID(w, r)
+	if !ok {
+		return
+	}
+
+	q, err := h.quarantineUC.Get(r.Context(), id)
+	if err != nil {
+		writeQuarantineError(w, logger, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, toQuarantinedRecordResponse(*q))
+}
+
+// Fix обрабатывает PATCH /api/v1/quarantine/{recordID}.
+// Тело - {"general": {...}, "details": {...}}; переданные поля накладываются на сохраненную запись
+func (h *QuarantineHandler) Fix(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+	id, ok := parseQuarantineID(w, r)
+	if !ok {
+		return
+	}
+
+	var patch struct {
+		General json.RawMessage `json:"general"`
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	q, err := h.quarantineUC.Get(r.Context(), id)
+	if err != nil {
+		writeQuarantineError(w, logger, err)
+		return
+	}
+
+	record := q.Record
+	original := record.General
+	if len(patch.General) > 0 {
+		if err := json.Unmarshal(patch.General, &record.General); err != nil {
+			WriteJSONError(w, http.StatusBadRequest, "Invalid general fields: "+err.Error())
+			return
+		}
+	}
+	if len(patch.Details) > 0 {
+		if record.Details == nil {
+			WriteJSONError(w, http.StatusBadRequest, "Record has no details to patch")
+			return
+		}
+		if err := json.Unmarshal(patch.Details, record.Details); err != nil {
+			WriteJSONError(w, http.StatusBadRequest, "Invalid details fields: "+err.Error())
+			return
+		}
+	}
+	// Ключ объявления и категория определяют, куда запись попадет при выпуске
+	if record.General.Source != original.Source || record.General.SourceAdID != original.SourceAdID ||
+		record.General.Category != original.Category {
+		WriteJSONError(w, http.StatusBadRequest, "source, source_ad_id and category cannot be changed")
+		return
+	}
+
+	violations, err := h.quarantineUC.Fix(r.Context(), id, record)
+	if err != nil {
+		writeQuarantineError(w, logger, err)
+		return
+	}
+
+	q.Record = record
+	q.Violations = violations
+	RespondWithJSON(w, http.StatusOK, toQuarantinedRecordResponse(*q))
+}
+
+// Release обрабатывает POST /api/v1/quarantine/{recordID}/release?force=true
+func (h *QuarantineHandler) Release(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+	id, ok := parseQuarantineID(w, r)
+	if !ok {
+		return
+	}
+	force := r.URL.Query().Get("force") == "true"
+
+	if err := h.quarantineUC.Release(r.Context(), id, force); err != nil {
+		writeQuarantineError(w, logger, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, map[string]string{"status": domain.QuarantineReleased})
+}
+
+// Discard обрабатывает POST /api/v1/quarantine/{recordID}/discard
+func (h *QuarantineHandler) Discard(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+	id, ok := parseQuarantineID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.quarantineUC.Discard(r.Context(), id); err != nil {
+		writeQuarantineError(w, logger, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, map[string]string{"status": domain.QuarantineDiscarded})
+}
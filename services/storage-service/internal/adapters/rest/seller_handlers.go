@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"storage-service/internal/core/port/usecases_port"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type SellerHandler struct {
+	getSellerProfileUC  usecases_port.GetSellerProfileUseCase
+	getSellerListingsUC usecases_port.GetSellerListingsUseCase
+}
+
+func NewSellerHandler(getSellerProfileUC usecases_port.GetSellerProfileUseCase,
+	getSellerListingsUC usecases_port.GetSellerListingsUseCase) *SellerHandler {
+	return &SellerHandler{
+		getSellerProfileUC:  getSellerProfileUC,
+		getSellerListingsUC: getSellerListingsUC,
+	}
+}
+
+// GetSellerProfile обрабатывает GET /api/v1/sellers/{sellerID}
+func (h *SellerHandler) GetSellerProfile(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	sellerID, err := uuid.Parse(chi.URLParam(r, "sellerID"))
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid seller ID format")
+		return
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"handler":   "GetSellerProfile",
+		"seller_id": sellerID.String(),
+	})
+
+	profile, err := h.getSellerProfileUC.Execute(r.Context(), sellerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSellerNotFound) {
+			WriteJSONError(w, http.StatusNotFound, "Seller not found")
+			return
+		}
+		handlerLogger.Error("Use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to get seller")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, SellerProfileResponse{
+		ID:              profile.ID.String(),
+		Name:            profile.Name,
+		IsAgency:        profile.IsAgency,
+		IsPrivate:       profile.IsPrivate(),
+		IdentifiedBy:    profile.IdentifiedBy,
+		FirstSeenAt:     profile.CreatedAt,
+		ActiveListings:  profile.ActiveListings,
+		TotalListings:   profile.TotalListings,
+		AvgDaysOnMarket: profile.AvgDaysOnMarket,
+	})
+}
+
+// GetSellerListings обрабатывает GET /api/v1/sellers/{sellerID}/objects?status=active|archived
+func (h *SellerHandler) GetSellerListings(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	sellerID, err := uuid.Parse(chi.URLParam(r, "sellerID"))
+	if err != nil {
+		WriteJSONError(w, http.StatusBadRequest, "Invalid seller ID format")
+		return
+	}
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("perPage"))
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"handler":   "GetSellerListings",
+		"seller_id": sellerID.String(),
+	})
+
+	result, err := h.getSellerListingsUC.Execute(r.Context(), sellerID, query.Get("status"), perPage, (page-1)*perPage)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidSellerListingsStatus):
+			WriteJSONError(w, http.StatusBadRequest, "status must be 'active' or 'archived'")
+		case errors.Is(err, domain.ErrSellerNotFound):
+			WriteJSONError(w, http.StatusNotFound, "Seller not found")
+		default:
+			handlerLogger.Error("Use case failed", err, nil)
+			WriteJSONError(w, http.StatusInternalServerError, "Failed to get seller listings")
+		}
+		return
+	}
+
+	response := PaginatedObjectsResponse{
+		Total:   result.TotalCount,
+		Page:    result.CurrentPage,
+		PerPage: result.ItemsPerPage,
+		Data:    make([]ObjectCardResponse, len(result.Objects)),
+	}
+	for i, obj := range result.Objects {
+		response.Data[i] = ObjectCardResponse{
+			ID:             obj.ID.String(),
+			Title:          obj.Title,
+			PriceUSD:       obj.PriceUSD,
+			PriceBYN:       obj.PriceBYN,
+			Images:         obj.Images,
+			Address:        obj.Address,
+			Status:         obj.Status,
+			MasterObjectID: obj.MasterObjectID,
+			Category:       obj.Category,
+			DealType:       obj.DealType,
+			FirstSeenAt:    obj.FirstSeenAt,
+			DaysOnMarket:   obj.DaysOnMarket,
+			IsRelisted:     obj.IsRelisted(),
+		}
+	}
+
+	RespondWithJSON(w, http.StatusOK, response)
+}
@@ -18,6 +18,11 @@ func NewServer(port string,
     actualiztion_handlers *ActualiztionObjectsHandler, 
     get_info_handlers *GetInfoHandler,
     filters_handlers *FilterHandler,
+    seller_handlers *SellerHandler,
+    quarantine_handlers *QuarantineHandler,
+    image_handlers *ImageHandler,
+    compare_handlers *CompareHandler,
+    similar_handlers *SimilarHandler,
     baseLogger core_port.LoggerPort) *Server {
 
     r := chi.NewRouter()
@@ -31,10 +36,27 @@ func NewServer(port string,
         r.Get("/object", actualiztion_handlers.GetObjectsByMasterID)
 
         r.Post("/objects/best-by-master-ids", get_info_handlers.GetBestByMasterIDs)
+        // таблица сравнения нескольких объектов (например, из избранного)
+        r.Post("/objects/compare", compare_handlers.CompareObjects)
 
         // роуты для пользователей
         r.Get("/objects", get_info_handlers.FindObjects)
+        // поиск с исключением скрытых/просмотренных объектов пользователя (вызывает api-gateway)
+        r.Post("/objects/search", get_info_handlers.SearchObjects)
         r.Get("/objects/{objectID}", get_info_handlers.GetObjectDetails)
+        r.Get("/objects/{objectID}/history", get_info_handlers.GetObjectHistory)
+        r.Get("/objects/{objectID}/similar", similar_handlers.GetSimilarListings)
+        r.Get("/objects/{objectID}/images/{index}", image_handlers.GetObjectImage)
+
+        r.Get("/sellers/{sellerID}", seller_handlers.GetSellerProfile)
+        r.Get("/sellers/{sellerID}/objects", seller_handlers.GetSellerListings)
+
+        // админские роуты карантина
+        r.Get("/quarantine", quarantine_handlers.List)
+        r.Get("/quarantine/{recordID}", quarantine_handlers.Get)
+        r.Patch("/quarantine/{recordID}", quarantine_handlers.Fix)
+        r.Post("/quarantine/{recordID}/release", quarantine_handlers.Release)
+        r.Post("/quarantine/{recordID}/discard", quarantine_handlers.Discard)
 
         r.Get("/filters/options", filters_handlers.GetFilterOptions)
         r.Get("/dictionaries", filters_handlers.GetDictionaries)
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"storage-service/internal/core/port/usecases_port"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type SimilarHandler struct {
+	getSimilarListingsUC usecases_port.GetSimilarListingsUseCase
+}
+
+func NewSimilarHandler(getSimilarListingsUC usecases_port.GetSimilarListingsUseCase) *SimilarHandler {
+	return &SimilarHandler{getSimilarListingsUC: getSimilarListingsUC}
+}
+
+// GetSimilarListings обрабатывает GET /api/v1/objects/{objectID}/similar?limit=
+func (h *SimilarHandler) GetSimilarListings(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context())
+
+	objectID, err := uuid.Parse(chi.URLParam(r, "objectID"))
+	if err != nil {
+		logger.Warn("Invalid object ID format", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid object ID format")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{
+		"handler":   "GetSimilarListings",
+		"object_id": objectID.String(),
+		"limit":     limit,
+	})
+	handlerLogger.Debug("Processing request to find similar listings", nil)
+
+	result, err := h.getSimilarListingsUC.Execute(r.Context(), objectID, limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrPropertyNotFound) {
+			WriteJSONError(w, http.StatusNotFound, "Object not found")
+			return
+		}
+		handlerLogger.Error("Use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to find similar listings")
+		return
+	}
+
+	response := SimilarListingsResponse{
+		ObjectID:   result.Source.PropertyID.String(),
+		Category:   result.Source.Category,
+		DealType:   result.Source.DealType,
+		Candidates: result.Candidates,
+		Data:       make([]SimilarListingResponse, len(result.Listings)),
+	}
+	for i, item := range result.Listings {
+		obj := item.Listing
+		explanation := make([]SimilarityFactorResponse, len(item.Factors))
+		for j, f := range item.Factors {
+			explanation[j] = SimilarityFactorResponse{Factor: f.Factor, Weight: f.Weight, Score: f.Score, Note: f.Note}
+		}
+		response.Data[i] = SimilarListingResponse{
+			ObjectCardResponse: ObjectCardResponse{
+				ID:             obj.ID.String(),
+				Title:          obj.Title,
+				PriceUSD:       obj.PriceUSD,
+				PriceBYN:       obj.PriceBYN,
+				Images:         obj.Images,
+				Address:        obj.Address,
+				Status:         obj.Status,
+				MasterObjectID: obj.MasterObjectID,
+				Category:       obj.Category,
+				DealType:       obj.DealType,
+				FirstSeenAt:    obj.FirstSeenAt,
+				DaysOnMarket:   obj.DaysOnMarket,
+				IsRelisted:     obj.IsRelisted(),
+			},
+			Score:       item.Score,
+			DistanceKm:  math.Round(item.DistanceMeters/100) / 10,
+			Explanation: explanation,
+		}
+	}
+
+	handlerLogger.Info("Successfully found similar listings", port.Fields{"count": len(result.Listings)})
+	RespondWithJSON(w, http.StatusOK, response)
+}
@@ -90,6 +90,18 @@ func parseInt(q url.Values, key string) *int {
 	return nil
 }
 
+// parseBool извлекает bool параметр. Пустое или некорректное значение - nil
+func parseBool(q url.Values, key string) *bool {
+	strVal := q.Get(key)
+	if strVal == "" {
+		return nil
+	}
+	if val, err := strconv.ParseBool(strVal); err == nil {
+		return &val
+	}
+	return nil
+}
+
 // parseIntSlice извлекает срез int, разделенных запятыми
 func parseIntSlice(q url.Values, key string) []int {
 	strVal := q.Get(key)
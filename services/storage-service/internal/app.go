@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -10,11 +11,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"storage-service/internal/adapters/image_pipeline"
 	logger_adapter "storage-service/internal/adapters/logger"
 	postgres_adapter "storage-service/internal/adapters/postgres"
+	"storage-service/internal/adapters/rates_provider"
 	"storage-service/internal/adapters/rest"
 	"storage-service/internal/configs"
 
+	"real-estate-system/pkg/blobstore"
 	fluentlogger "real-estate-system/pkg/fluent_logger"
 	"real-estate-system/pkg/postgres"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
@@ -22,10 +26,13 @@ import (
 	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
 	rabbitmq_adapter "storage-service/internal/adapters/rabbitmq"
 	"storage-service/internal/constants"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
 	"storage-service/internal/core/port"
 	"storage-service/internal/core/usecase"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/fluent/fluent-logger-golang/fluent"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -40,7 +47,10 @@ type App struct {
 	logger       port.LoggerPort
 
 	processedPropEventsListener port.EventListenerPort
+	imageMirrorListener         port.EventListenerPort // nil, если копирование фото выключено
 	tasksResultsProducer        *rabbitmq_producer.Publisher
+
+	refreshRatesUseCase *usecase.RefreshExchangeRatesUseCase
 }
 
 // NewApp создает новый экземпляр приложения.
@@ -121,8 +131,58 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to create postgres filter repository: %w", err)
 	}
 
+	quarantineRepository, err := postgres_adapter.NewQuarantineRepository(dbPool)
+	if err != nil {
+		appLogger.Error("Failed to create postgres quarantine repository", err, nil)
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to create postgres quarantine repository: %w", err)
+	}
+
+	exchangeRatesRepository, err := postgres_adapter.NewExchangeRatesRepository(dbPool)
+	if err != nil {
+		appLogger.Error("Failed to create postgres exchange rates repository", err, nil)
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to create postgres exchange rates repository: %w", err)
+	}
+
+	imageRepository, err := postgres_adapter.NewImageRepository(dbPool)
+	if err != nil {
+		appLogger.Error("Failed to create postgres image repository", err, nil)
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to create postgres image repository: %w", err)
+	}
+
 	appLogger.Debug("Postgres storage adapters initialized.", nil)
 
+	blobStore, err := blobstore.NewFSStore(appConfig.Images.BlobDir)
+	if err != nil {
+		appLogger.Error("Failed to create image blob store", err, port.Fields{"dir": appConfig.Images.BlobDir})
+		dbPool.Close()
+		return nil, err
+	}
+
+	ratesProvider, err := newRatesProvider(appConfig.Rates)
+	if err != nil {
+		appLogger.Error("Failed to create exchange rates provider", err, port.Fields{"provider": appConfig.Rates.Provider})
+		dbPool.Close()
+		return nil, err
+	}
+	appLogger.Debug("Exchange rates provider initialized.", port.Fields{"provider": appConfig.Rates.Provider})
+
+	qualityRules, err := loadQualityRules(appConfig.Quality.RulesFile)
+	if err != nil {
+		appLogger.Error("Failed to load data quality rules", err, port.Fields{"file": appConfig.Quality.RulesFile})
+		dbPool.Close()
+		return nil, err
+	}
+
+	similarityRules, err := loadSimilarityRules(appConfig.Similarity.RulesFile)
+	if err != nil {
+		appLogger.Error("Failed to load similarity rules", err, port.Fields{"file": appConfig.Similarity.RulesFile})
+		dbPool.Close()
+		return nil, err
+	}
+
 	producerLogger := baseLogger.WithFields(port.Fields{"component": "rabbitmq_producer"})
 	pkgLoggerBridge := rabbitmq_adapter.NewPkgLoggerBridge(producerLogger)
 
@@ -154,10 +214,18 @@ func NewApp() (*App, error) {
 	appLogger.Debug("RabbitMQ Event Producer initialized.", nil)
 
 	tasksResultsQueueAdapter, _ := rabbitmq_adapter.NewTaskReporterAdapter(eventProducer, constants.RoutingKeyTaskResults)
+
+	var imageMirrorQueue port.ImageMirrorQueuePort
+	if appConfig.Images.MirrorEnabled {
+		imageMirrorQueue, _ = rabbitmq_adapter.NewImageMirrorPublisherAdapter(eventProducer, constants.RoutingKeyImageMirror)
+	}
 	appLogger.Debug("All outgoing adapters initialized.", nil)
 
 	// инициализация use cases
-	savePropertyUseCase := usecase.NewSavePropertyUseCase(postgresStorageAdapter, tasksResultsQueueAdapter)
+	savePropertyUseCase := usecase.NewSavePropertyUseCase(postgresStorageAdapter, tasksResultsQueueAdapter, quarantineRepository, qualityRules, exchangeRatesRepository,
+		imageRepository, imageMirrorQueue)
+	refreshExchangeRatesUseCase := usecase.NewRefreshExchangeRatesUseCase(ratesProvider, exchangeRatesRepository)
+	quarantineAdminUseCase := usecase.NewQuarantineAdminUseCase(quarantineRepository, postgresStorageAdapter, qualityRules)
 	getActiveObjectsUseCase := usecase.NewGetActiveObjectsUseCase(postgresStorageAdapter)
 	getArchivedObjectsUseCase := usecase.NewGetArchivedObjectsUseCase(postgresStorageAdapter)
 	getObjectByIDUseCase := usecase.NewGetObjectsByIDUseCase(postgresStorageAdapter)
@@ -165,7 +233,13 @@ func NewApp() (*App, error) {
 
 	findObjectsUseCase := usecase.NewFindObjectsUseCase(postgresStorageAdapter)
 	getObjectDetailsUseCase := usecase.NewGetObjectDetailsUseCase(postgresStorageAdapter)
+	getPropertyHistoryUseCase := usecase.NewGetPropertyHistoryUseCase(postgresStorageAdapter)
 	getBestObjectsByMasterIDsUseCase := usecase.NewGetBestObjectsByMasterIDsUseCase(postgresStorageAdapter)
+	compareObjectsUseCase := usecase.NewCompareObjectsUseCase(postgresStorageAdapter)
+	getSimilarListingsUseCase := usecase.NewGetSimilarListingsUseCase(postgresStorageAdapter, similarityRules, appConfig.Similarity.CandidatesLimit)
+	getSellerProfileUseCase := usecase.NewGetSellerProfileUseCase(postgresStorageAdapter)
+	getSellerListingsUseCase := usecase.NewGetSellerListingsUseCase(postgresStorageAdapter)
+	getPropertyImageUseCase := usecase.NewGetPropertyImageUseCase(imageRepository, blobStore)
 
 	getFilterOptionsUseCase := usecase.NewGetFilterOptionsUseCase(filterRepository)
 	getDictionariesUseCase := usecase.NewGetDictionariesUseCase(filterRepository)
@@ -197,9 +271,7 @@ func NewApp() (*App, error) {
 		FinalDLXExchange:   constants.FinalDLXExchange,
 		FinalDLQ:           constants.FinalDLQ,
 		FinalDLQRoutingKey: constants.FinalDLQRoutingKey,
-
-		MaxRetries: 3,
-	}
+	}.WithRetryTiers(constants.ProcessedPropertiesRetryDelays)
 	processedPropListener, err := rabbitmq_adapter.NewProcessedPropertyConsumerAdapter(processedConsumerCfg, savePropertyUseCase, baseLogger, connManager)
 	if err != nil {
 		appLogger.Error("Failed to create Processed Property listener", err, nil)
@@ -208,12 +280,49 @@ func NewApp() (*App, error) {
 	}
 	appLogger.Debug("Processed Property Events Listener initialized.", nil)
 
+	var imageMirrorListener port.EventListenerPort
+	if appConfig.Images.MirrorEnabled {
+		mirrorImagesUseCase := usecase.NewMirrorImagesUseCase(imageRepository, blobStore,
+			image_pipeline.NewHTTPDownloader(appConfig.Images.DownloadTimeout, appConfig.Images.MaxImageBytes),
+			image_pipeline.NewProcessor(appConfig.Images.ThumbnailWidth, appConfig.Images.MaxImagePixels))
+
+		imageConsumerCfg := rabbitmq_consumer.ConsumerConfig{
+			Config:              rabbitmq_common.Config{URL: appConfig.RabbitMQ.URL},
+			QueueName:           constants.QueueImageMirror,
+			DurableQueue:        true,
+			ExchangeNameForBind: constants.MainExchange,
+			RoutingKeyForBind:   constants.RoutingKeyImageMirror,
+			PrefetchCount:       appConfig.Images.Workers,
+			WorkerPoolSize:      appConfig.Images.Workers,
+			ConsumerTag:         "image-mirror-adapter",
+			DeclareQueue:        true,
+
+			EnableRetryMechanism: true,
+
+			FinalDLXExchange:   constants.ImageMirrorFinalDLXExchange,
+			FinalDLQ:           constants.ImageMirrorFinalDLQ,
+			FinalDLQRoutingKey: constants.ImageMirrorFinalDLQRoutingKey,
+		}.WithRetryTiers(constants.ImageMirrorRetryDelays)
+		imageMirrorListener, err = rabbitmq_adapter.NewImageMirrorConsumerAdapter(imageConsumerCfg, mirrorImagesUseCase, baseLogger, connManager)
+		if err != nil {
+			appLogger.Error("Failed to create Image Mirror listener", err, nil)
+			dbPool.Close()
+			return nil, err
+		}
+		appLogger.Debug("Image Mirror Listener initialized.", nil)
+	}
+
 	// REST API Server
 	apiActualizationHandlers := rest.NewActualizationHandlers(getActiveObjectsUseCase, getArchivedObjectsUseCase, getObjectByIDUseCase, getActualizationStatsUseCase)
-	apiGetInfoHandlers := rest.NewGetInfoHandler(findObjectsUseCase, getObjectDetailsUseCase, getBestObjectsByMasterIDsUseCase)
+	apiGetInfoHandlers := rest.NewGetInfoHandler(findObjectsUseCase, getObjectDetailsUseCase, getBestObjectsByMasterIDsUseCase, getPropertyHistoryUseCase)
 	filtersHandlers := rest.NewFilterHandler(getFilterOptionsUseCase, getDictionariesUseCase)
+	sellerHandlers := rest.NewSellerHandler(getSellerProfileUseCase, getSellerListingsUseCase)
+	quarantineHandlers := rest.NewQuarantineHandler(quarantineAdminUseCase)
+	imageHandlers := rest.NewImageHandler(getPropertyImageUseCase)
+	compareHandlers := rest.NewCompareHandler(compareObjectsUseCase)
+	similarHandlers := rest.NewSimilarHandler(getSimilarListingsUseCase)
 
-	apiServer := rest.NewServer(appConfig.Rest.PORT, apiActualizationHandlers, apiGetInfoHandlers, filtersHandlers, baseLogger)
+	apiServer := rest.NewServer(appConfig.Rest.PORT, apiActualizationHandlers, apiGetInfoHandlers, filtersHandlers, sellerHandlers, quarantineHandlers, imageHandlers, compareHandlers, similarHandlers, baseLogger)
 	appLogger.Debug("REST API server configured.", nil)
 
 	// Собираем приложение
@@ -222,7 +331,9 @@ func NewApp() (*App, error) {
 		dbPool:                      dbPool,
 		apiServer:                   apiServer,
 		processedPropEventsListener: processedPropListener,
+		imageMirrorListener:         imageMirrorListener,
 		tasksResultsProducer:        eventProducer,
+		refreshRatesUseCase:         refreshExchangeRatesUseCase,
 
 		fluentClient: fluentClient,
 		logger:       appLogger,
@@ -261,6 +372,12 @@ func (a *App) Run() error {
 			}
 		}
 
+		if a.imageMirrorListener != nil {
+			if err := a.imageMirrorListener.Close(); err != nil {
+				a.logger.Error("Error closing image mirror listener", err, nil)
+			}
+		}
+
 		if a.tasksResultsProducer != nil {
 			if err := a.tasksResultsProducer.Close(); err != nil {
 				a.logger.Error("Error closing event producer", err, nil)
@@ -304,6 +421,17 @@ func (a *App) Run() error {
 	wg.Add(1)
 	go startListener("Processed Property Events Listener", a.processedPropEventsListener)
 
+	if a.imageMirrorListener != nil {
+		wg.Add(1)
+		go startListener("Image Mirror Listener", a.imageMirrorListener)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.runRatesRefresher(appCtx)
+	}()
+
 	go func() {
 		a.logger.Debug("Starting HTTP server...", port.Fields{"port": a.config.Rest.PORT})
 		if err := a.apiServer.Start(); err != nil && err != http.ErrServerClosed {
@@ -347,4 +475,77 @@ func parseLogLevel(levelStr string) slog.Level {
 		log.Printf("Warning: Unknown log level '%s'. Defaulting to 'info'.", levelStr)
 		return slog.LevelInfo
 	}
-}
\ No newline at end of file
+}
+
+// runRatesRefresher догружает курсы за последние дни при старте и затем обновляет курсы на сегодня по расписанию.
+// Ошибки провайдера не останавливают сервис: цены пересчитываются по последним загруженным курсам
+func (a *App) runRatesRefresher(ctx context.Context) {
+	refresherLogger := a.logger.WithFields(port.Fields{"component": "rates_refresher"})
+	ctx = contextkeys.ContextWithLogger(ctx, refresherLogger)
+
+	refresh := func(from time.Time) {
+		if _, err := a.refreshRatesUseCase.Execute(ctx, from, time.Now()); err != nil && ctx.Err() == nil {
+			refresherLogger.Error("Failed to refresh exchange rates", err, nil)
+		}
+	}
+
+	refresh(time.Now().AddDate(0, 0, -a.config.Rates.BackfillDays))
+
+	ticker := time.NewTicker(a.config.Rates.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			refresherLogger.Info("Rates refresher stopped.", nil)
+			return
+		case <-ticker.C:
+			refresh(time.Now())
+		}
+	}
+}
+
+// newRatesProvider выбирает провайдер курсов по конфигурации
+func newRatesProvider(cfg configs.RatesConfig) (port.ExchangeRatesProviderPort, error) {
+	switch cfg.Provider {
+	case "file":
+		return rates_provider.NewFileProvider(cfg.File)
+	case "stub":
+		return rates_provider.NewStubProvider(), nil
+	default:
+		return rates_provider.NewNBRBProvider(cfg.NBRBURL, cfg.RequestTimeout), nil
+	}
+}
+
+// loadQualityRules читает правила качества данных из JSON-файла поверх встроенных.
+// Категория из файла заменяет встроенную целиком
+func loadQualityRules(path string) (domain.QualityRules, error) {
+	rules := domain.DefaultQualityRules()
+	if path == "" {
+		return rules, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read quality rules file '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse quality rules file '%s': %w", path, err)
+	}
+	return rules, nil
+}
+
+// loadSimilarityRules читает веса рекомендаций похожих объектов из JSON-файла поверх встроенных.
+// Категория из файла заменяет встроенную целиком
+func loadSimilarityRules(path string) (domain.SimilarityRules, error) {
+	rules := domain.DefaultSimilarityRules()
+	if path == "" {
+		return rules, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return rules, fmt.Errorf("failed to read similarity rules file '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("failed to parse similarity rules file '%s': %w", path, err)
+	}
+	return rules, nil
+}
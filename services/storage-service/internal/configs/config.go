@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -30,6 +31,38 @@ type FluentBitConfig struct {
 	Level   string `mapstructure:"FLUENTBIT_LOG_LEVEL" default:"info"` // По умолчанию INFO
 }
 
+// QualityConfig - правила качества данных. Пустой RulesFile - встроенные правила
+type QualityConfig struct {
+	RulesFile string
+}
+
+// RatesConfig - загрузка официальных курсов валют для пересчета цен
+type RatesConfig struct {
+	Provider        string // "nbrb", "file" или "stub"
+	File            string // для Provider = "file"
+	NBRBURL         string
+	RequestTimeout  time.Duration
+	RefreshInterval time.Duration
+	BackfillDays    int // за сколько прошедших дней догрузить курсы при старте
+}
+
+// ImagesConfig - локальные копии фото объявлений
+type ImagesConfig struct {
+	MirrorEnabled   bool   // false - фото не копируются, выдаются только уже скопированные
+	BlobDir         string // каталог хранилища файлов
+	ThumbnailWidth  int
+	DownloadTimeout time.Duration
+	MaxImageBytes   int64
+	MaxImagePixels  int // ширина×высота; больше не декодируем, чтобы маленький файл не занял гигабайты памяти
+	Workers         int // сколько запросов на копирование обрабатывается одновременно
+}
+
+// SimilarityConfig - рекомендации похожих объектов. Пустой RulesFile - встроенные веса по категориям
+type SimilarityConfig struct {
+	RulesFile       string
+	CandidatesLimit int // сколько ближайших кандидатов оценивать на один запрос
+}
+
 type StdoutLogConfig struct {
     Level string `mapstructure:"STDOUT_LOG_LEVEL" default:"debug"` // По умолчанию DEBUG
 }
@@ -42,6 +75,10 @@ type AppConfig struct {
 	Rest		RESTconfig
 	FluentBit	FluentBitConfig
 	StdoutLogger StdoutLogConfig
+	Quality      QualityConfig
+	Rates        RatesConfig
+	Images       ImagesConfig
+	Similarity   SimilarityConfig
 }
 
 // LoadConfig загружает конфигурацию из переменных окружения.
@@ -102,6 +139,46 @@ func LoadConfig(envPath ...string) (*AppConfig, error) {
 
 	cfg.StdoutLogger.Level = getEnvAsString("STDOUT_LOG_LEVEL", "debug")
 
+	cfg.Quality.RulesFile = getEnvAsString("QUALITY_RULES_FILE", "")
+
+	cfg.Rates.Provider = getEnvAsString("RATES_PROVIDER", "")
+	switch cfg.Rates.Provider {
+	case "":
+		cfg.Rates.Provider = "nbrb"
+	case "nbrb", "stub":
+	case "file":
+		cfg.Rates.File = os.Getenv("RATES_FILE")
+		if cfg.Rates.File == "" {
+			return nil, fmt.Errorf("RATES_FILE environment variable is required for RATES_PROVIDER=file")
+		}
+	default:
+		return nil, fmt.Errorf("unknown RATES_PROVIDER '%s', expected nbrb, file or stub", cfg.Rates.Provider)
+	}
+	cfg.Rates.NBRBURL = getEnvAsString("RATES_NBRB_URL", "")
+	cfg.Rates.RequestTimeout = time.Duration(getEnvAsInt("RATES_REQUEST_TIMEOUT_SECONDS", 10)) * time.Second
+	cfg.Rates.RefreshInterval = time.Duration(getEnvAsInt("RATES_REFRESH_INTERVAL_MINUTES", 360)) * time.Minute
+	if cfg.Rates.RefreshInterval <= 0 {
+		return nil, fmt.Errorf("RATES_REFRESH_INTERVAL_MINUTES must be positive")
+	}
+	cfg.Rates.BackfillDays = getEnvAsInt("RATES_BACKFILL_DAYS", 7)
+
+	cfg.Images.MirrorEnabled = getEnvAsBool("IMAGES_MIRROR_ENABLED", true)
+	cfg.Images.BlobDir = getEnvAsString("IMAGES_BLOB_DIR", "")
+	if cfg.Images.BlobDir == "" {
+		cfg.Images.BlobDir = "./data/images"
+	}
+	cfg.Images.ThumbnailWidth = getEnvAsInt("IMAGES_THUMBNAIL_WIDTH", 320)
+	cfg.Images.DownloadTimeout = time.Duration(getEnvAsInt("IMAGES_DOWNLOAD_TIMEOUT_SECONDS", 20)) * time.Second
+	cfg.Images.MaxImageBytes = int64(getEnvAsInt("IMAGES_MAX_SIZE_MB", 15)) << 20
+	cfg.Images.MaxImagePixels = getEnvAsInt("IMAGES_MAX_PIXELS", 50_000_000)
+	cfg.Images.Workers = getEnvAsInt("IMAGES_WORKERS", 4)
+
+	cfg.Similarity.RulesFile = getEnvAsString("SIMILARITY_RULES_FILE", "")
+	cfg.Similarity.CandidatesLimit = getEnvAsInt("SIMILARITY_CANDIDATES_LIMIT", 300)
+	if cfg.Similarity.CandidatesLimit <= 0 {
+		cfg.Similarity.CandidatesLimit = 300
+	}
+
 	return cfg, nil
 }
 
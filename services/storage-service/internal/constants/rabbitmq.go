@@ -1,8 +1,11 @@
 package constants
 
+import "time"
+
 // Имена очередей
 const (
 	QueueProcessedProperties = "processed_properties"
+	QueueImageMirror         = "image_mirror"
 )
 
 // Ключи маршрутизации
@@ -10,6 +13,8 @@ const (
 	RoutingKeyProcessedProperties = "db.properties.save"
     
     RoutingKeyTaskResults          = "notify.task.result"
+
+	RoutingKeyImageMirror = "storage.images.mirror"
 )
 
 
@@ -26,4 +31,16 @@ const (
 	RetryExchange = "shared_retry_exchange"
 	WaitQueue     = "shared_wait_10s"
 	RetryTTL      = 10000 // 10 секунд
-)
\ No newline at end of file
+)
+
+// Уровни ретраев: временные ошибки БД обычно проходят за секунды или минуты
+var ProcessedPropertiesRetryDelays = []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}
+
+const (
+	ImageMirrorFinalDLXExchange   = "image_mirror_final_dlx"
+	ImageMirrorFinalDLQ           = "image_mirror_final_dlq"
+	ImageMirrorFinalDLQRoutingKey = "images.dlq.key"
+)
+
+// Источник может временно не отдавать фото: повторяем реже, чем сохранение в БД
+var ImageMirrorRetryDelays = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +20,14 @@ type BatchSaveStats struct {
 	Created   int // Количество новых записей, которые были вставлены (INSERT)
 	Updated   int // Количество существующих записей, которые были обновлены (UPDATE)
 	Archived  int // Количество записей, которые были переведены в статус "archived"
+	Quarantined int // Количество записей, отложенных в карантин правилами качества данных
+
+	ReportedItems []string // Ключи ссылок задачи ("link:<источник>:<ID объявления>"), по которым пришел результат
+
+	// Разбивка пачки по источникам для прогресса подзадач задачи
+	ProcessedBySource   map[string]int
+	QuarantinedBySource map[string]int
+	Duration            time.Duration
 }
 
 
@@ -27,4 +36,9 @@ type StatsByCategory struct {
 	SystemName    string 
 	ActiveCount   int64  
 	ArchivedCount int64  
-}
\ No newline at end of file
+}
+
+// LinkItemKey - ключ ссылки в задаче. actualization-service строит такой же ключ при отправке ссылки
+func LinkItemKey(source string, adID int64) string {
+	return fmt.Sprintf("link:%s:%d", source, adID)
+}
@@ -0,0 +1,41 @@
+package domain
+
+// Ограничения сравнения: меньше двух объектов сравнивать не с чем, больше MaxComparedObjects не помещается в таблицу
+const (
+	MinComparedObjects = 2
+	MaxComparedObjects = 5
+)
+
+// Группы строк таблицы сравнения
+const (
+	ComparisonGroupGeneral = "general"
+	ComparisonGroupPrice   = "price"
+	ComparisonGroupDetails = "details"
+)
+
+// ComparedObject - один столбец таблицы сравнения: лучшее объявление объекта, его детали и остальные предложения
+type ComparedObject struct {
+	Main          GeneralPropertyInfo
+	Details       interface{}
+	RelatedOffers []DuplicatesInfo
+
+	// Цена за м² по общей площади, nil - нет цены или площади
+	PricePerSquareMeterUSD *float64
+	PricePerSquareMeterBYN *float64
+}
+
+// ComparisonAttribute - строка таблицы сравнения. Values идут в порядке ObjectsComparison.Objects,
+// nil - у объекта нет такого значения (например, площадь участка у квартиры)
+type ComparisonAttribute struct {
+	Key     string
+	Group   string
+	Values  []interface{}
+	Differs bool
+}
+
+// ObjectsComparison - нормализованная таблица сравнения объектов
+type ObjectsComparison struct {
+	Objects    []ComparedObject
+	Attributes []ComparisonAttribute
+	MissingIDs []string // запрошенные master_object_id, которых нет в хранилище
+}
@@ -70,8 +70,39 @@ type FindObjectsFilters struct {
 
     CommercialRoomsMin *int
     CommercialRoomsMax *int
+
+    // жизненный цикл
+    DaysOnMarketMin *int
+    DaysOnMarketMax *int
+    IsRelisted      *bool // nil - без фильтра
+
+    PrivateSellersOnly bool // по профилю продавца, см. SellerProfile.IsPrivate
+
+    // Скрытые и уже просмотренные пользователем объекты. Заполняет только POST /objects/search,
+    // список собирает api-gateway из favorites-service
+    ExcludeMasterIDs []uuid.UUID
+
+    SortBy string // одно из SortBy*, пусто - SortByUpdated
 }
 
+// WithoutExclusions - копия фильтров без списка исключений, чтобы не писать тысячи ID в логи
+func (f FindObjectsFilters) WithoutExclusions() FindObjectsFilters {
+    f.ExcludeMasterIDs = nil
+    return f
+}
+
+// Порядок выдачи FindObjects
+const (
+    SortByUpdated           = "updated"
+    SortByDaysOnMarketAsc   = "days_on_market_asc"  // сначала свежие
+    SortByDaysOnMarketDesc  = "days_on_market_desc" // сначала долго висящие
+    SortByPriceAsc          = "price_asc"  // в валюте PriceCurrency, по умолчанию USD
+    SortByPriceDesc         = "price_desc"
+)
+
+// MaxExcludedMasterIDs - ограничение на размер списка исключений в одном запросе поиска
+const MaxExcludedMasterIDs = 20000
+
 // PaginatedResult - стандартная структура для ответа с пагинацией
 type PaginatedResult struct {
     Objects      []GeneralPropertyInfo // Возвращаем только общую информацию для списка
@@ -111,6 +142,21 @@ type GeneralPropertyInfo struct {
     Status   string 
 
     SellerDetails interface{}
+
+    // жизненный цикл
+    FirstSeenAt       time.Time
+    LastSeenActiveAt  *time.Time
+    ArchivedAt        *time.Time
+    ReactivationCount int
+    RelistedFromID    *uuid.UUID // предыдущее объявление, если это перевыставление
+    DaysOnMarket      int        // от first_seen_at до архивации или до текущего момента
+
+    SellerID *uuid.UUID
+}
+
+// IsRelisted - объявление выставлено заново после архивации предыдущего
+func (p GeneralPropertyInfo) IsRelisted() bool {
+    return p.RelistedFromID != nil
 }
 
 
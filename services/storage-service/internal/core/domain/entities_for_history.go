@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldChange - старое и новое значение одного поля
+type FieldChange struct {
+	Old interface{}
+	New interface{}
+}
+
+// PropertyChange - все поля, изменившиеся при одном сохранении объявления
+type PropertyChange struct {
+	ChangedAt time.Time
+	Fields    map[string]FieldChange // ключ - имя колонки: "price_usd", "total_area"
+}
+
+// PropertyHistory - страница истории изменений, от новых к старым
+type PropertyHistory struct {
+	PropertyID uuid.UUID
+	Changes    []PropertyChange
+	TotalCount int
+}
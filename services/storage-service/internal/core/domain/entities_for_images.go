@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Статусы копий фотографий. failed - фото у источника недоступно или не читается,
+// повторно не загружается, выдается исходная ссылка
+const (
+	ImageMirrored = "mirrored"
+	ImageFailed   = "failed"
+)
+
+// Размеры фото для выдачи
+const (
+	ImageSizeOriginal  = "original"
+	ImageSizeThumbnail = "thumb"
+)
+
+// PropertyImage - локальная копия фото объявления
+type PropertyImage struct {
+	ID           uuid.UUID
+	SourceURL    string
+	Status       string
+	OriginalKey  string // ключи в хранилище файлов
+	ThumbnailKey string
+	ContentType  string
+	Width        int
+	Height       int
+	PHash        uint64 // dHash: у копий одного снимка расстояние Хэмминга между хешами мало
+	LastError    string
+}
+
+// ProcessedImage - результат обработки скачанного фото
+type ProcessedImage struct {
+	ContentType          string
+	Width                int
+	Height               int
+	PHash                uint64
+	Thumbnail            []byte
+	ThumbnailContentType string
+}
+
+// ImageContent - фото для выдачи. Body == nil - копии нет, клиента отправляем на FallbackURL
+type ImageContent struct {
+	Body        io.ReadCloser
+	ContentType string
+	FallbackURL string
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Валюты цен объявлений
+const (
+	CurrencyBYN = "BYN"
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+)
+
+// RatedCurrencies - валюты, курсы которых к BYN загружаются и хранятся
+var RatedCurrencies = []string{CurrencyUSD, CurrencyEUR}
+
+// ExchangeRate - официальный курс: сколько BYN стоит одна единица валюты на дату
+type ExchangeRate struct {
+	Date     time.Time
+	Currency string
+	RateBYN  float64
+	Provider string // "nbrb", "file", "stub"
+}
+
+// RateTable - курсы, действующие на дату: по каждой валюте последний курс не позже нее
+type RateTable struct {
+	Date  time.Time          // самая поздняя дата среди использованных курсов
+	Rates map[string]float64 // валюта -> BYN за единицу
+}
+
+func (t RateTable) rateBYN(currency string) (float64, bool) {
+	if currency == CurrencyBYN {
+		return 1, true
+	}
+	rate, ok := t.Rates[currency]
+	return rate, ok && rate > 0
+}
+
+// Convert пересчитывает сумму через BYN с округлением до копеек. false - нет курса одной из валют
+func (t RateTable) Convert(amount float64, from, to string) (float64, bool) {
+	fromRate, ok := t.rateBYN(from)
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := t.rateBYN(to)
+	if !ok {
+		return 0, false
+	}
+	return math.Round(amount*fromRate/toRate*100) / 100, true
+}
+
+// originalAmount - цена в валюте объявления. Если цены в этой валюте нет
+// (RUB у realt, пустая цена), опорной считается цена в BYN
+func (g GeneralProperty) originalAmount() (float64, string) {
+	switch {
+	case g.Currency == CurrencyUSD && g.PriceUSD > 0:
+		return g.PriceUSD, CurrencyUSD
+	case g.Currency == CurrencyEUR && g.PriceEUR != nil && *g.PriceEUR > 0:
+		return *g.PriceEUR, CurrencyEUR
+	}
+	return g.PriceBYN, CurrencyBYN
+}
+
+// NormalizePrices пересчитывает PriceBYN/PriceUSD/PriceEUR из цены в валюте объявления по курсам одной даты,
+// чтобы цены разных источников были сравнимы. Без курсов (table == nil) цены источника остаются как есть
+func (g *GeneralProperty) NormalizePrices(table *RateTable) {
+	amount, currency := g.originalAmount()
+	g.OriginalPrice, g.OriginalCurrency = &amount, currency
+	g.RatesDate = nil
+
+	if table == nil || amount <= 0 {
+		return
+	}
+	byn, okBYN := table.Convert(amount, currency, CurrencyBYN)
+	usd, okUSD := table.Convert(amount, currency, CurrencyUSD)
+	eur, okEUR := table.Convert(amount, currency, CurrencyEUR)
+	if !okBYN || !okUSD || !okEUR {
+		return
+	}
+
+	g.PriceBYN, g.PriceUSD, g.PriceEUR = byn, usd, &eur
+	date := table.Date
+	g.RatesDate = &date
+}
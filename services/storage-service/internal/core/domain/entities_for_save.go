@@ -16,38 +16,41 @@ type RealEstateRecord struct {
 
 // dbGeneralProperty - это структура для хранения
 type GeneralProperty struct {
-	ID           uuid.UUID 			
-	Source       string    			
-	SourceAdID   int64     			
-	CreatedAt    time.Time 			
-	UpdatedAt    time.Time 			
-
-	Category         string     	
-	AdLink           string     	
-	SaleType 		 string    		
-	Currency         string     	
-	Images           []string   	
-	ListTime         time.Time  	
-	Description     string     		
-	Title          	string     		
-	DealType         string     	
-	Coordinates      string	 		
-	CityOrDistrict   string    		
-	Region           string    		
-	PriceBYN         float64   		
-	PriceUSD         float64   		
-	PriceEUR         *float64   	
-	Address        	string 			
-
-	IsAgency        bool       		
-	SellerName     string 			
-	
-	SellerDetails   json.RawMessage 
-
-	Status 			string			
-
-	Latitude    float64 
-	Longitude   float64 
+	ID               uuid.UUID        `json:"id"`
+	Source           string           `json:"source"`
+	SourceAdID       int64            `json:"source_ad_id"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+
+	Category         string           `json:"category"`
+	AdLink           string           `json:"ad_link"`
+	SaleType         string           `json:"sale_type"`
+	Currency         string           `json:"currency"`
+	Images           []string         `json:"images"`
+	ListTime         time.Time        `json:"list_time"`
+	Description      string           `json:"description"`
+	Title            string           `json:"title"`
+	DealType         string           `json:"deal_type"`
+	Coordinates      string           `json:"-"`
+	CityOrDistrict   string           `json:"city_or_district"`
+	Region           string           `json:"region"`
+	PriceBYN         float64          `json:"price_byn"`
+	PriceUSD         float64          `json:"price_usd"`
+	PriceEUR         *float64         `json:"price_eur"`
+	OriginalPrice    *float64         `json:"original_price"`    // см. NormalizePrices
+	OriginalCurrency string           `json:"original_currency"`
+	RatesDate        *time.Time       `json:"rates_date"`        // nil - цены источника без пересчета
+	Address          string           `json:"address"`
+
+	IsAgency         bool             `json:"is_agency"`
+	SellerName       string           `json:"seller_name"`
+
+	SellerDetails    json.RawMessage  `json:"seller_details"`
+
+	Status           string           `json:"status"`
+
+	Latitude         float64          `json:"latitude"`
+	Longitude        float64          `json:"longitude"`
 }
 
 // dbApartment - структура для таблицы `apartments`
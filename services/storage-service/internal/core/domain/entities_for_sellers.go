@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PrivateSellerMaxActiveListings - сколько активных объявлений может быть у частного продавца.
+// Больше - это уже риелтор без отметки агентства, даже если источник не считает объявления агентскими
+const PrivateSellerMaxActiveListings = 3
+
+// SellerProfile - продавец, собранный по всем его объявлениям
+type SellerProfile struct {
+	ID           uuid.UUID
+	Name         string
+	IsAgency     bool   // хоть одно объявление помечено источником как агентское
+	IdentifiedBy string // по чему склеены объявления: "unp", "account", "phone", "name"
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	ActiveListings  int
+	TotalListings   int
+	AvgDaysOnMarket *float64 // nil, если объявлений нет
+}
+
+// IsPrivate - частный продавец по данным всех его объявлений, а не по флагу отдельного объявления
+func (s SellerProfile) IsPrivate() bool {
+	return !s.IsAgency && s.ActiveListings <= PrivateSellerMaxActiveListings
+}
+
+// Статусы для выборки объявлений продавца. Пусто - все
+const (
+	SellerListingsActive   = "active"
+	SellerListingsArchived = "archived"
+)
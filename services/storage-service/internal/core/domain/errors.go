@@ -0,0 +1,40 @@
+package domain
+
+import "errors"
+
+// ErrInvalidRecord - хранилище отвергло данные записи (нарушение ограничений, некорректное значение).
+// Повтор той же записи не поможет
+var ErrInvalidRecord = errors.New("record rejected by storage")
+
+// ErrPropertyNotFound - объявления с таким id нет
+var ErrPropertyNotFound = errors.New("property not found")
+
+// ErrSellerNotFound - продавца с таким id нет
+var ErrSellerNotFound = errors.New("seller not found")
+
+// ErrInvalidSellerListingsStatus - статус для выборки объявлений продавца не из domain.SellerListings*
+var ErrInvalidSellerListingsStatus = errors.New("invalid seller listings status")
+
+// ErrQuarantinedRecordNotFound - записи карантина с таким id нет
+var ErrQuarantinedRecordNotFound = errors.New("quarantined record not found")
+
+// ErrQuarantineNotPending - запись карантина уже выпущена или отброшена
+var ErrQuarantineNotPending = errors.New("quarantined record is already resolved")
+
+// ErrQualityViolations - запись по-прежнему нарушает правила качества
+var ErrQualityViolations = errors.New("record violates data quality rules")
+
+// ErrExchangeRatesNotFound - нет курсов валют ни на дату, ни раньше
+var ErrExchangeRatesNotFound = errors.New("exchange rates not found")
+
+// ErrImageNotFound - у объявления нет фото с таким номером
+var ErrImageNotFound = errors.New("image not found")
+
+// ErrImageUnavailable - источник больше не отдает фото. Повтор не поможет
+var ErrImageUnavailable = errors.New("image is unavailable at source")
+
+// ErrImageUndecodable - скачанный файл не читается как изображение
+var ErrImageUndecodable = errors.New("image cannot be decoded")
+
+// ErrInvalidComparison - для сравнения передано слишком мало или слишком много объектов
+var ErrInvalidComparison = errors.New("invalid objects comparison request")
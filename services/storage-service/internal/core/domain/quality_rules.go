@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Имена правил качества данных. Их же можно перечислить в DisabledRules
+const (
+	RulePriceTooLow          = "price_too_low"
+	RuleAreaOutOfRange       = "area_out_of_range"
+	RuleFloorAboveBuilding   = "floor_above_building"
+	RuleCoordinatesOutsideBY = "coordinates_outside_belarus"
+)
+
+// Границы Беларуси с небольшим запасом
+const (
+	belarusMinLat = 51.2
+	belarusMaxLat = 56.2
+	belarusMinLon = 23.1
+	belarusMaxLon = 32.8
+)
+
+// HasCoordinates - у объявления есть координаты. Источники без координат оставляют (0, 0)
+func HasCoordinates(lat, lon float64) bool {
+	return lat != 0 || lon != 0
+}
+
+// QualityViolation - нарушенное правило и пояснение для администратора
+type QualityViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// CategoryQualityRules - пороги для одной категории. Нулевое значение порога - проверка выключена
+type CategoryQualityRules struct {
+	MinPriceBYN   map[string]float64 `json:"min_price_byn"` // по deal_type: цена посуточной аренды на порядки ниже цены продажи
+	MinTotalArea  float64            `json:"min_total_area"`
+	MaxTotalArea  float64            `json:"max_total_area"`
+	DisabledRules []string           `json:"disabled_rules"`
+}
+
+// QualityRules - правила по категориям. Категории без своих правил проверяются по Default
+type QualityRules struct {
+	Default    CategoryQualityRules            `json:"default"`
+	Categories map[string]CategoryQualityRules `json:"categories"`
+}
+
+// DefaultQualityRules - правила, которые действуют без файла QUALITY_RULES_FILE
+func DefaultQualityRules() QualityRules {
+	livingMinPrice := map[string]float64{"sale": 1000, "rent": 50, "daily_rent": 10}
+	return QualityRules{
+		Default: CategoryQualityRules{
+			MinPriceBYN: livingMinPrice,
+		},
+		Categories: map[string]CategoryQualityRules{
+			"apartment":  {MinPriceBYN: livingMinPrice, MinTotalArea: 8, MaxTotalArea: 1000},
+			"house":      {MinPriceBYN: livingMinPrice, MinTotalArea: 10, MaxTotalArea: 5000},
+			"commercial": {MinPriceBYN: livingMinPrice, MinTotalArea: 1, MaxTotalArea: 100000},
+		},
+	}
+}
+
+func (q QualityRules) forCategory(category string) CategoryQualityRules {
+	if rules, ok := q.Categories[category]; ok {
+		return rules
+	}
+	return q.Default
+}
+
+// Check возвращает нарушенные правила. Архивные записи не проверяются: они только меняют статус
+func (q QualityRules) Check(record RealEstateRecord) []QualityViolation {
+	general := record.General
+	if general.Status == "archived" {
+		return nil
+	}
+
+	rules := q.forCategory(general.Category)
+	disabled := make(map[string]bool, len(rules.DisabledRules))
+	for _, name := range rules.DisabledRules {
+		disabled[name] = true
+	}
+
+	var violations []QualityViolation
+	add := func(rule, format string, args ...interface{}) {
+		if !disabled[rule] {
+			violations = append(violations, QualityViolation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	// Цена 0 - "договорная": цены нет, а не слишком низкая цена
+	if minPrice := rules.MinPriceBYN[general.DealType]; minPrice > 0 && general.PriceBYN > 0 && general.PriceBYN < minPrice {
+		add(RulePriceTooLow, "price %.2f BYN is below %.2f BYN for %s", general.PriceBYN, minPrice, general.DealType)
+	}
+
+	// (0, 0) - координат нет (так их оставляет Kufar), это не повод для карантина
+	if HasCoordinates(general.Latitude, general.Longitude) && (general.Latitude < belarusMinLat || general.Latitude > belarusMaxLat ||
+		general.Longitude < belarusMinLon || general.Longitude > belarusMaxLon) {
+		add(RuleCoordinatesOutsideBY, "coordinates (%f, %f) are outside Belarus", general.Latitude, general.Longitude)
+	}
+
+	var totalArea *float64
+	var floor, buildingFloors *int8
+	switch d := record.Details.(type) {
+	case *Apartment:
+		totalArea, floor, buildingFloors = d.TotalArea, d.FloorNumber, d.BuildingFloors
+	case *House:
+		totalArea = d.TotalArea
+	case *Commercial:
+		totalArea, floor, buildingFloors = d.TotalArea, d.FloorNumber, d.BuildingFloors
+	}
+
+	if totalArea != nil {
+		if rules.MinTotalArea > 0 && *totalArea < rules.MinTotalArea {
+			add(RuleAreaOutOfRange, "total area %.2f m² is below %.2f m²", *totalArea, rules.MinTotalArea)
+		} else if rules.MaxTotalArea > 0 && *totalArea > rules.MaxTotalArea {
+			add(RuleAreaOutOfRange, "total area %.2f m² is above %.2f m²", *totalArea, rules.MaxTotalArea)
+		}
+	}
+
+	if floor != nil && buildingFloors != nil && *buildingFloors > 0 && *floor > *buildingFloors {
+		add(RuleFloorAboveBuilding, "floor %d is above building floors %d", *floor, *buildingFloors)
+	}
+
+	return violations
+}
+
+// Статусы записей карантина
+const (
+	QuarantinePending   = "pending"
+	QuarantineReleased  = "released"
+	QuarantineDiscarded = "discarded"
+)
+
+// QuarantinedRecord - запись, не прошедшая правила качества, в ожидании решения администратора
+type QuarantinedRecord struct {
+	ID         uuid.UUID
+	TaskID     uuid.UUID // uuid.Nil - запись пришла вне задачи
+	Record     RealEstateRecord
+	Violations []QualityViolation
+	Status     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// QuarantineFilter - выборка записей карантина для администратора. Пустые поля не фильтруют
+type QuarantineFilter struct {
+	Status   string
+	Source   string
+	Category string
+	Rule     string
+}
+
+// PaginatedQuarantine - страница записей карантина
+type PaginatedQuarantine struct {
+	Records    []QuarantinedRecord
+	TotalCount int
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "testing"
+
+func TestQualityRulesCheckCoordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     bool // ожидается нарушение RuleCoordinatesOutsideBY
+	}{
+		{name: "no coordinates", lat: 0, lon: 0, want: false},
+		{name: "Minsk", lat: 53.9, lon: 27.56, want: false},
+		{name: "Moscow", lat: 55.75, lon: 37.62, want: true},
+		{name: "zero latitude only", lat: 0, lon: 27.56, want: true},
+		{name: "zero longitude only", lat: 53.9, lon: 0, want: true},
+	}
+
+	rules := DefaultQualityRules()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := RealEstateRecord{General: GeneralProperty{
+				Category:  "apartment",
+				DealType:  "sale",
+				PriceBYN:  100000,
+				Status:    "active",
+				Latitude:  tt.lat,
+				Longitude: tt.lon,
+			}}
+
+			got := false
+			for _, v := range rules.Check(record) {
+				if v.Rule == RuleCoordinatesOutsideBY {
+					got = true
+				}
+			}
+			if got != tt.want {
+				t.Errorf("coordinates violation for (%v, %v): got %v, want %v", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualityRulesCheckPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		dealType string
+		price    float64
+		want     bool // ожидается нарушение RulePriceTooLow
+	}{
+		{name: "negotiable price", dealType: "sale", price: 0, want: false},
+		{name: "too low for sale", dealType: "sale", price: 500, want: true},
+		{name: "normal sale", dealType: "sale", price: 150000, want: false},
+		{name: "daily rent", dealType: "daily_rent", price: 60, want: false},
+		{name: "too low for rent", dealType: "rent", price: 20, want: true},
+	}
+
+	rules := DefaultQualityRules()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := RealEstateRecord{General: GeneralProperty{
+				Category: "apartment",
+				DealType: tt.dealType,
+				PriceBYN: tt.price,
+				Status:   "active",
+			}}
+
+			got := false
+			for _, v := range rules.Check(record) {
+				if v.Rule == RulePriceTooLow {
+					got = true
+				}
+			}
+			if got != tt.want {
+				t.Errorf("price violation for %.2f BYN (%s): got %v, want %v", tt.price, tt.dealType, got, tt.want)
+			}
+		})
+	}
+}
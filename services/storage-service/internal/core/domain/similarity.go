@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Факторы похожести. Те же имена используются в пояснениях к результату
+const (
+	SimilarityFactorDistance            = "distance"
+	SimilarityFactorPrice               = "price"
+	SimilarityFactorPricePerSquareMeter = "price_per_square_meter"
+	SimilarityFactorRooms               = "rooms"
+	SimilarityFactorArea                = "area"
+	SimilarityFactorYearBuilt           = "year_built"
+)
+
+// SimilarityWeights - вклад факторов в итоговую оценку. Нулевой вес выключает фактор
+type SimilarityWeights struct {
+	Distance            float64 `json:"distance"`
+	Price               float64 `json:"price"`
+	PricePerSquareMeter float64 `json:"price_per_square_meter"`
+	Rooms               float64 `json:"rooms"`
+	Area                float64 `json:"area"`
+	YearBuilt           float64 `json:"year_built"`
+}
+
+// CategorySimilarityRules - веса и допуски для одной категории. Фактор дает 1 при совпадении
+// и линейно падает до 0 на границе допуска
+type CategorySimilarityRules struct {
+	Weights        SimilarityWeights `json:"weights"`
+	MaxDistanceKm  float64           `json:"max_distance_km"` // дальше кандидаты не рассматриваются
+	PriceTolerance float64           `json:"price_tolerance"` // доля цены, кандидаты вне допуска не рассматриваются
+	AreaTolerance  float64           `json:"area_tolerance"`  // доля площади, и для цены за м²
+	RoomsTolerance int               `json:"rooms_tolerance"`
+	YearTolerance  int               `json:"year_tolerance"`
+}
+
+// SimilarityRules - правила по категориям. Категории без своих правил оцениваются по Default
+type SimilarityRules struct {
+	Default    CategorySimilarityRules            `json:"default"`
+	Categories map[string]CategorySimilarityRules `json:"categories"`
+}
+
+// DefaultSimilarityRules - правила, которые действуют без файла SIMILARITY_RULES_FILE
+func DefaultSimilarityRules() SimilarityRules {
+	return SimilarityRules{
+		Default: CategorySimilarityRules{
+			Weights:        SimilarityWeights{Distance: 3, Price: 3, PricePerSquareMeter: 2, Area: 2},
+			MaxDistanceKm:  10,
+			PriceTolerance: 0.3,
+			AreaTolerance:  0.3,
+		},
+		Categories: map[string]CategorySimilarityRules{
+			"apartment": {
+				Weights:        SimilarityWeights{Distance: 3, Price: 3, PricePerSquareMeter: 2, Rooms: 2, Area: 2, YearBuilt: 1},
+				MaxDistanceKm:  5,
+				PriceTolerance: 0.25,
+				AreaTolerance:  0.25,
+				RoomsTolerance: 2,
+				YearTolerance:  20,
+			},
+			// Дома сравнивают по району шире, а цена за м² у них зависит от участка
+			"house": {
+				Weights:        SimilarityWeights{Distance: 3, Price: 3, PricePerSquareMeter: 1, Rooms: 1, Area: 2, YearBuilt: 1},
+				MaxDistanceKm:  20,
+				PriceTolerance: 0.35,
+				AreaTolerance:  0.35,
+				RoomsTolerance: 3,
+				YearTolerance:  25,
+			},
+			"commercial": {
+				Weights:        SimilarityWeights{Distance: 2, Price: 2, PricePerSquareMeter: 3, Area: 3},
+				MaxDistanceKm:  10,
+				PriceTolerance: 0.4,
+				AreaTolerance:  0.4,
+			},
+		},
+	}
+}
+
+// ForCategory - правила категории или Default
+func (s SimilarityRules) ForCategory(category string) CategorySimilarityRules {
+	if rules, ok := s.Categories[category]; ok {
+		return rules
+	}
+	return s.Default
+}
+
+// SimilarityProfile - то, по чему сравниваются объявления. nil - значение не указано в объявлении
+type SimilarityProfile struct {
+	PropertyID     uuid.UUID
+	MasterObjectID string
+	Category       string
+	DealType       string
+	PriceUSD       float64
+	TotalArea      *float64
+	Rooms          *int
+	YearBuilt      *int
+}
+
+// PricePerSquareMeterUSD - nil, если нет цены или площади
+func (p SimilarityProfile) PricePerSquareMeterUSD() *float64 {
+	if p.PriceUSD <= 0 || p.TotalArea == nil || *p.TotalArea <= 0 {
+		return nil
+	}
+	value := p.PriceUSD / *p.TotalArea
+	return &value
+}
+
+// SimilarCandidate - активное объявление-кандидат с расстоянием до исходного объекта
+type SimilarCandidate struct {
+	Profile        SimilarityProfile
+	Listing        GeneralPropertyInfo
+	DistanceMeters float64
+}
+
+// SimilarityFactorScore - пояснение: как фактор повлиял на оценку
+type SimilarityFactorScore struct {
+	Factor string  `json:"factor"`
+	Weight float64 `json:"weight"`
+	Score  float64 `json:"score"` // 0..1
+	Note   string  `json:"note"`
+}
+
+// SimilarListing - рекомендованное объявление с итоговой оценкой 0..1 и пояснениями
+type SimilarListing struct {
+	Listing        GeneralPropertyInfo
+	DistanceMeters float64
+	Score          float64
+	Factors        []SimilarityFactorScore
+}
+
+// SimilarListingsResult - рекомендации для объявления
+type SimilarListingsResult struct {
+	Source     SimilarityProfile
+	Listings   []SimilarListing
+	Candidates int // сколько кандидатов оценивалось
+}
+
+// Score оценивает кандидата. Факторы, для которых нет данных у одной из сторон, пропускаются,
+// а веса остальных перенормируются, чтобы неполные объявления не проигрывали только из-за пропусков
+func (r CategorySimilarityRules) Score(source SimilarityProfile, candidate SimilarCandidate) SimilarListing {
+	target := candidate.Profile
+	factors := make([]SimilarityFactorScore, 0, 6)
+	add := func(factor string, weight, score float64, note string) {
+		if weight <= 0 {
+			return
+		}
+		factors = append(factors, SimilarityFactorScore{Factor: factor, Weight: weight, Score: round2(score), Note: note})
+	}
+
+	distanceKm := candidate.DistanceMeters / 1000
+	if r.MaxDistanceKm > 0 {
+		add(SimilarityFactorDistance, r.Weights.Distance, linearScore(distanceKm, r.MaxDistanceKm),
+			fmt.Sprintf("%.1f km away", distanceKm))
+	}
+
+	if source.PriceUSD > 0 && target.PriceUSD > 0 && r.PriceTolerance > 0 {
+		diff := relativeDiff(target.PriceUSD, source.PriceUSD)
+		add(SimilarityFactorPrice, r.Weights.Price, linearScore(math.Abs(diff), r.PriceTolerance),
+			fmt.Sprintf("price %s", describeRelative(diff)))
+	}
+
+	if sp, tp := source.PricePerSquareMeterUSD(), target.PricePerSquareMeterUSD(); sp != nil && tp != nil && r.AreaTolerance > 0 {
+		diff := relativeDiff(*tp, *sp)
+		add(SimilarityFactorPricePerSquareMeter, r.Weights.PricePerSquareMeter, linearScore(math.Abs(diff), r.AreaTolerance),
+			fmt.Sprintf("%.0f USD/m², %s", *tp, describeRelative(diff)))
+	}
+
+	if source.Rooms != nil && target.Rooms != nil && r.RoomsTolerance > 0 {
+		diff := *target.Rooms - *source.Rooms
+		add(SimilarityFactorRooms, r.Weights.Rooms, linearScore(math.Abs(float64(diff)), float64(r.RoomsTolerance)),
+			fmt.Sprintf("%d rooms (%+d)", *target.Rooms, diff))
+	}
+
+	if source.TotalArea != nil && target.TotalArea != nil && *source.TotalArea > 0 && r.AreaTolerance > 0 {
+		diff := relativeDiff(*target.TotalArea, *source.TotalArea)
+		add(SimilarityFactorArea, r.Weights.Area, linearScore(math.Abs(diff), r.AreaTolerance),
+			fmt.Sprintf("%.1f m², %s", *target.TotalArea, describeRelative(diff)))
+	}
+
+	if source.YearBuilt != nil && target.YearBuilt != nil && r.YearTolerance > 0 {
+		diff := *target.YearBuilt - *source.YearBuilt
+		add(SimilarityFactorYearBuilt, r.Weights.YearBuilt, linearScore(math.Abs(float64(diff)), float64(r.YearTolerance)),
+			fmt.Sprintf("built in %d (%+d years)", *target.YearBuilt, diff))
+	}
+
+	var weighted, totalWeight float64
+	for _, f := range factors {
+		weighted += f.Weight * f.Score
+		totalWeight += f.Weight
+	}
+	score := 0.0
+	if totalWeight > 0 {
+		score = weighted / totalWeight
+	}
+
+	// Самые весомые по вкладу факторы - первыми
+	sort.SliceStable(factors, func(i, j int) bool {
+		return factors[i].Weight*factors[i].Score > factors[j].Weight*factors[j].Score
+	})
+
+	return SimilarListing{
+		Listing:        candidate.Listing,
+		DistanceMeters: candidate.DistanceMeters,
+		Score:          round2(score),
+		Factors:        factors,
+	}
+}
+
+// linearScore - 1 при нулевой разнице, 0 на границе допуска и дальше
+func linearScore(diff, tolerance float64) float64 {
+	if tolerance <= 0 {
+		return 0
+	}
+	return math.Max(0, 1-diff/tolerance)
+}
+
+func relativeDiff(value, base float64) float64 {
+	return (value - base) / base
+}
+
+func describeRelative(diff float64) string {
+	percent := math.Round(diff * 100)
+	switch {
+	case percent > 0:
+		return fmt.Sprintf("%.0f%% higher", percent)
+	case percent < 0:
+		return fmt.Sprintf("%.0f%% lower", -percent)
+	default:
+		return "about the same"
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
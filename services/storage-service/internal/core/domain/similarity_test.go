@@ -0,0 +1,73 @@
+package domain
+
+import "testing"
+
+func TestCategorySimilarityRulesScoreMissingFactors(t *testing.T) {
+	rules := CategorySimilarityRules{
+		Weights:        SimilarityWeights{Distance: 1, Price: 1, Area: 2},
+		MaxDistanceKm:  10,
+		PriceTolerance: 0.5,
+		AreaTolerance:  0.5,
+	}
+	area := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name        string
+		source      SimilarityProfile
+		target      SimilarityProfile
+		distanceM   float64
+		wantScore   float64
+		wantFactors []string
+	}{
+		{
+			name:        "all factors",
+			source:      SimilarityProfile{PriceUSD: 100000, TotalArea: area(50)},
+			target:      SimilarityProfile{PriceUSD: 100000, TotalArea: area(50)},
+			distanceM:   5000, // 0.5
+			wantScore:   0.88, // (1*0.5 + 1*1 + 2*1) / 4
+			wantFactors: []string{SimilarityFactorArea, SimilarityFactorPrice, SimilarityFactorDistance},
+		},
+		{
+			name:        "candidate without area",
+			source:      SimilarityProfile{PriceUSD: 100000, TotalArea: area(50)},
+			target:      SimilarityProfile{PriceUSD: 100000},
+			distanceM:   5000,
+			wantScore:   0.75, // (1*0.5 + 1*1) / 2
+			wantFactors: []string{SimilarityFactorPrice, SimilarityFactorDistance},
+		},
+		{
+			name:        "source without price and area",
+			source:      SimilarityProfile{},
+			target:      SimilarityProfile{PriceUSD: 100000, TotalArea: area(50)},
+			distanceM:   0,
+			wantScore:   1,
+			wantFactors: []string{SimilarityFactorDistance},
+		},
+		{
+			name:        "zero source area",
+			source:      SimilarityProfile{PriceUSD: 100000, TotalArea: area(0)},
+			target:      SimilarityProfile{PriceUSD: 125000, TotalArea: area(50)},
+			distanceM:   0,
+			wantScore:   0.75, // (1*1 + 1*0.5) / 2
+			wantFactors: []string{SimilarityFactorDistance, SimilarityFactorPrice},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules.Score(tt.source, SimilarCandidate{Profile: tt.target, DistanceMeters: tt.distanceM})
+
+			if got.Score != tt.wantScore {
+				t.Errorf("score: got %v, want %v", got.Score, tt.wantScore)
+			}
+			if len(got.Factors) != len(tt.wantFactors) {
+				t.Fatalf("factors: got %v, want %v", got.Factors, tt.wantFactors)
+			}
+			for i, f := range got.Factors {
+				if f.Factor != tt.wantFactors[i] {
+					t.Errorf("factor #%d: got %s, want %s", i, f.Factor, tt.wantFactors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+	"time"
+)
+
+// ExchangeRatesProviderPort - источник официальных курсов: API НБРБ, файл или локальная заглушка
+type ExchangeRatesProviderPort interface {
+	// FetchRates возвращает курсы domain.RatedCurrencies на дату. Пустой ответ - курсов на дату нет
+	FetchRates(ctx context.Context, date time.Time) ([]domain.ExchangeRate, error)
+}
+
+// ExchangeRatesStoragePort - хранилище загруженных курсов
+type ExchangeRatesStoragePort interface {
+	// SaveRates сохраняет курсы, уже загруженные на ту же дату перезаписываются
+	SaveRates(ctx context.Context, rates []domain.ExchangeRate) error
+	// GetRateTable - курсы, действующие на дату. domain.ErrExchangeRatesNotFound, если курсов нет
+	GetRateTable(ctx context.Context, date time.Time) (*domain.RateTable, error)
+}
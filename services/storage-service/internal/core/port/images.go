@@ -0,0 +1,43 @@
+package port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ImageStoragePort - учет локальных копий фото объявлений
+type ImageStoragePort interface {
+	// FindUnprocessed возвращает адреса, для которых еще нет ни копии, ни отметки об ошибке
+	FindUnprocessed(ctx context.Context, urls []string) ([]string, error)
+	// SaveImage сохраняет результат копирования фото, строка с тем же адресом заменяется
+	SaveImage(ctx context.Context, image domain.PropertyImage) error
+	// GetImageByURL возвращает domain.ErrImageNotFound, если фото еще не обрабатывалось
+	GetImageByURL(ctx context.Context, url string) (*domain.PropertyImage, error)
+	// GetPropertyImageURL - адрес фото объявления по номеру с нуля.
+	// domain.ErrPropertyNotFound, если объявления нет, domain.ErrImageNotFound, если номера нет
+	GetPropertyImageURL(ctx context.Context, propertyID uuid.UUID, index int) (string, error)
+}
+
+// BlobStorePort - хранилище файлов фото. Ключи - относительные пути вида "originals/<id>".
+// Реализуется blobstore.FSStore из pkg
+type BlobStorePort interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// ImageDownloaderPort скачивает фото у источника. domain.ErrImageUnavailable, если источник его больше не отдает
+type ImageDownloaderPort interface {
+	Download(ctx context.Context, url string) ([]byte, error)
+}
+
+// ImageProcessorPort строит миниатюру и перцептивный хеш. domain.ErrImageUndecodable для нечитаемых файлов
+type ImageProcessorPort interface {
+	Process(data []byte) (*domain.ProcessedImage, error)
+}
+
+// ImageMirrorQueuePort ставит фото в очередь на копирование
+type ImageMirrorQueuePort interface {
+	RequestMirror(ctx context.Context, source string, urls []string) error
+}
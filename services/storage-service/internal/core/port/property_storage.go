@@ -20,4 +20,12 @@ type PropertyStoragePort interface {
 	FindWithFilters(ctx context.Context, filters domain.FindObjectsFilters, limit, offset int) (*domain.PaginatedResult, error)
     GetPropertyDetails(ctx context.Context, propertyID uuid.UUID) (*domain.PropertyDetailsView, error)
 	FindBestByMasterIDs(ctx context.Context, masterIDs []string) ([]domain.GeneralPropertyInfo, error)
+
+	// GetPropertyHistory - история изменений объявления. domain.ErrPropertyNotFound, если объявления нет
+	GetPropertyHistory(ctx context.Context, propertyID uuid.UUID, limit, offset int) (*domain.PropertyHistory, error)
+
+	// GetSellerProfile - продавец со статистикой по объявлениям. domain.ErrSellerNotFound, если продавца нет
+	GetSellerProfile(ctx context.Context, sellerID uuid.UUID) (*domain.SellerProfile, error)
+	// FindSellerListings - объявления продавца, status - domain.SellerListings* или пусто
+	FindSellerListings(ctx context.Context, sellerID uuid.UUID, status string, limit, offset int) (*domain.PaginatedResult, error)
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// QuarantineStoragePort - хранилище записей, не прошедших правила качества данных
+type QuarantineStoragePort interface {
+	// Quarantine сохраняет записи. Ожидающая запись того же объявления заменяется новой
+	Quarantine(ctx context.Context, records []domain.QuarantinedRecord) error
+
+	ListQuarantined(ctx context.Context, filter domain.QuarantineFilter, limit, offset int) (*domain.PaginatedQuarantine, error)
+	// GetQuarantined возвращает domain.ErrQuarantinedRecordNotFound, если записи нет
+	GetQuarantined(ctx context.Context, id uuid.UUID) (*domain.QuarantinedRecord, error)
+
+	// UpdateQuarantined заменяет данные ожидающей записи после исправления
+	UpdateQuarantined(ctx context.Context, id uuid.UUID, record domain.RealEstateRecord, violations []domain.QualityViolation) error
+	// ResolveQuarantined переводит ожидающую запись в released или discarded.
+	// domain.ErrQuarantineNotPending, если запись уже разобрана
+	ResolveQuarantined(ctx context.Context, id uuid.UUID, status string) error
+}
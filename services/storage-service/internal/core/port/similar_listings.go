@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SimilarListingsPort - выборка кандидатов для рекомендаций похожих объектов
+type SimilarListingsPort interface {
+	// GetSimilarityProfile - характеристики объявления. domain.ErrPropertyNotFound, если объявления нет
+	GetSimilarityProfile(ctx context.Context, propertyID uuid.UUID) (*domain.SimilarityProfile, error)
+	// FindSimilarCandidates - лучшие активные объявления других master-объектов той же категории и типа сделки
+	// в радиусе radiusMeters и с ценой в допуске priceTolerance (0 - без ограничения), ближайшие первыми
+	FindSimilarCandidates(ctx context.Context, source domain.SimilarityProfile, radiusMeters, priceTolerance float64, limit int) ([]domain.SimilarCandidate, error)
+}
@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+)
+
+type CompareObjectsUseCase interface {
+	Execute(ctx context.Context, masterIDs []string) (*domain.ObjectsComparison, error)
+}
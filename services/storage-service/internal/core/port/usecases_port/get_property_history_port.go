@@ -0,0 +1,12 @@
+package usecases_port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type GetPropertyHistoryUseCase interface {
+	Execute(ctx context.Context, propertyID uuid.UUID, limit, offset int) (*domain.PropertyHistory, error)
+}
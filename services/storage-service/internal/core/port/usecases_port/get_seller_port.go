@@ -0,0 +1,16 @@
+package usecases_port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type GetSellerProfileUseCase interface {
+	Execute(ctx context.Context, sellerID uuid.UUID) (*domain.SellerProfile, error)
+}
+
+type GetSellerListingsUseCase interface {
+	Execute(ctx context.Context, sellerID uuid.UUID, status string, limit, offset int) (*domain.PaginatedResult, error)
+}
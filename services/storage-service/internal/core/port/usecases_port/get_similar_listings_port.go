@@ -0,0 +1,12 @@
+package usecases_port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type GetSimilarListingsUseCase interface {
+	Execute(ctx context.Context, propertyID uuid.UUID, limit int) (*domain.SimilarListingsResult, error)
+}
@@ -0,0 +1,16 @@
+package usecases_port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type MirrorImagesPort interface {
+	Execute(ctx context.Context, urls []string) error
+}
+
+type GetPropertyImageUseCase interface {
+	Execute(ctx context.Context, propertyID uuid.UUID, index int, size string) (*domain.ImageContent, error)
+}
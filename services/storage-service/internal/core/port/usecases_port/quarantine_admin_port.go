@@ -0,0 +1,19 @@
+package usecases_port
+
+import (
+	"context"
+	"storage-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// QuarantineAdminUseCase - разбор карантина администратором
+type QuarantineAdminUseCase interface {
+	List(ctx context.Context, filter domain.QuarantineFilter, limit, offset int) (*domain.PaginatedQuarantine, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.QuarantinedRecord, error)
+	// Fix сохраняет исправленную запись и возвращает оставшиеся нарушения
+	Fix(ctx context.Context, id uuid.UUID, record domain.RealEstateRecord) ([]domain.QualityViolation, error)
+	// Release сохраняет запись в основное хранилище. Без force запись с нарушениями не выпускается
+	Release(ctx context.Context, id uuid.UUID, force bool) error
+	Discard(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type CompareObjectsUseCase struct {
+	storage port.PropertyStoragePort
+}
+
+func NewCompareObjectsUseCase(storage port.PropertyStoragePort) *CompareObjectsUseCase {
+	return &CompareObjectsUseCase{storage: storage}
+}
+
+// Execute строит таблицу сравнения для master-объектов в порядке запроса.
+// Для каждого берется то же лучшее объявление, что и в best-by-master-ids
+func (uc *CompareObjectsUseCase) Execute(ctx context.Context, masterIDs []string) (*domain.ObjectsComparison, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":   "CompareObjects",
+		"ids_amount": len(masterIDs),
+	})
+
+	ids, err := normalizeMasterIDs(masterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	best, err := uc.storage.FindBestByMasterIDs(ctx, ids)
+	if err != nil {
+		ucLogger.Error("Storage returned an error", err, nil)
+		return nil, err
+	}
+	bestByMasterID := make(map[string]domain.GeneralPropertyInfo, len(best))
+	for _, obj := range best {
+		bestByMasterID[obj.MasterObjectID] = obj
+	}
+
+	result := &domain.ObjectsComparison{
+		Objects:    make([]domain.ComparedObject, 0, len(ids)),
+		MissingIDs: make([]string, 0),
+	}
+	for _, id := range ids {
+		obj, ok := bestByMasterID[id]
+		if !ok {
+			result.MissingIDs = append(result.MissingIDs, id)
+			continue
+		}
+
+		view, err := uc.storage.GetPropertyDetails(ctx, obj.ID)
+		if err != nil {
+			ucLogger.Error("Failed to get object details", err, port.Fields{"master_object_id": id})
+			return nil, err
+		}
+		result.Objects = append(result.Objects, newComparedObject(view))
+	}
+
+	result.Attributes = buildComparisonAttributes(result.Objects)
+
+	ucLogger.Info("Use case finished successfully", port.Fields{
+		"compared": len(result.Objects),
+		"missing":  len(result.MissingIDs),
+	})
+	return result, nil
+}
+
+// normalizeMasterIDs проверяет формат и убирает повторы, сохраняя порядок
+func normalizeMasterIDs(masterIDs []string) ([]string, error) {
+	ids := make([]string, 0, len(masterIDs))
+	seen := make(map[string]bool, len(masterIDs))
+	for _, raw := range masterIDs {
+		parsed, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid master id %q", domain.ErrInvalidComparison, raw)
+		}
+		id := parsed.String()
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < domain.MinComparedObjects || len(ids) > domain.MaxComparedObjects {
+		return nil, fmt.Errorf("%w: expected from %d to %d distinct objects, got %d",
+			domain.ErrInvalidComparison, domain.MinComparedObjects, domain.MaxComparedObjects, len(ids))
+	}
+	return ids, nil
+}
+
+func newComparedObject(view *domain.PropertyDetailsView) domain.ComparedObject {
+	obj := domain.ComparedObject{
+		Main:          view.MainProperty,
+		Details:       view.Details,
+		RelatedOffers: view.RelatedOffers,
+	}
+	if area := totalArea(view.Details); area > 0 {
+		obj.PricePerSquareMeterUSD = pricePerSquareMeter(view.MainProperty.PriceUSD, area)
+		obj.PricePerSquareMeterBYN = pricePerSquareMeter(view.MainProperty.PriceBYN, area)
+	}
+	return obj
+}
+
+func totalArea(details interface{}) float64 {
+	var area *float64
+	switch d := details.(type) {
+	case *domain.Apartment:
+		area = d.TotalArea
+	case *domain.House:
+		area = d.TotalArea
+	case *domain.Commercial:
+		area = d.TotalArea
+	}
+	if area == nil {
+		return 0
+	}
+	return *area
+}
+
+func pricePerSquareMeter(price, area float64) *float64 {
+	if price <= 0 {
+		return nil
+	}
+	value := math.Round(price/area*100) / 100
+	return &value
+}
+
+// buildComparisonAttributes раскладывает объекты по строкам: сначала общие поля и цены,
+// затем поля деталей категории в порядке их объявления
+func buildComparisonAttributes(objects []domain.ComparedObject) []domain.ComparisonAttribute {
+	general := []struct {
+		key   string
+		group string
+		value func(o domain.ComparedObject) interface{}
+	}{
+		{"category", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.Category }},
+		{"deal_type", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.DealType }},
+		{"status", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.Status }},
+		{"region", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.Region }},
+		{"city_or_district", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.CityOrDistrict }},
+		{"address", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.Address }},
+		{"is_agency", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.IsAgency }},
+		{"days_on_market", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.DaysOnMarket }},
+		{"is_relisted", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return o.Main.IsRelisted() }},
+		{"offers_count", domain.ComparisonGroupGeneral, func(o domain.ComparedObject) interface{} { return len(o.RelatedOffers) + 1 }},
+		{"price_usd", domain.ComparisonGroupPrice, func(o domain.ComparedObject) interface{} { return o.Main.PriceUSD }},
+		{"price_byn", domain.ComparisonGroupPrice, func(o domain.ComparedObject) interface{} { return o.Main.PriceBYN }},
+		{"price_per_square_meter_usd", domain.ComparisonGroupPrice, func(o domain.ComparedObject) interface{} { return derefFloat(o.PricePerSquareMeterUSD) }},
+		{"price_per_square_meter_byn", domain.ComparisonGroupPrice, func(o domain.ComparedObject) interface{} { return derefFloat(o.PricePerSquareMeterBYN) }},
+	}
+
+	attributes := make([]domain.ComparisonAttribute, 0, len(general))
+	for _, field := range general {
+		values := make([]interface{}, len(objects))
+		for i, o := range objects {
+			values[i] = field.value(o)
+		}
+		attributes = append(attributes, newComparisonAttribute(field.key, field.group, values))
+	}
+
+	// Детали у объектов разных категорий разные: строка есть, если поле есть хотя бы у одного объекта
+	detailValues := make([]map[string]interface{}, len(objects))
+	var detailKeys []string
+	seenKeys := make(map[string]bool)
+	for i, o := range objects {
+		fields := detailFields(o.Details)
+		detailValues[i] = make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			detailValues[i][f.key] = f.value
+			if !seenKeys[f.key] {
+				seenKeys[f.key] = true
+				detailKeys = append(detailKeys, f.key)
+			}
+		}
+	}
+	for _, key := range detailKeys {
+		values := make([]interface{}, len(objects))
+		for i := range objects {
+			values[i] = detailValues[i][key]
+		}
+		attributes = append(attributes, newComparisonAttribute(key, domain.ComparisonGroupDetails, values))
+	}
+
+	return attributes
+}
+
+func newComparisonAttribute(key, group string, values []interface{}) domain.ComparisonAttribute {
+	differs := false
+	for _, v := range values {
+		if !reflect.DeepEqual(v, values[0]) {
+			differs = true
+			break
+		}
+	}
+	return domain.ComparisonAttribute{Key: key, Group: group, Values: values, Differs: differs}
+}
+
+type detailField struct {
+	key   string
+	value interface{}
+}
+
+// detailFields читает поля деталей по их json-тегам. Указатели разыменовываются, пустые значения
+// превращаются в nil, чтобы "не указано" у разных объектов считалось одинаковым.
+// parameters - сырые параметры источника, в сравнение не попадают
+func detailFields(details interface{}) []detailField {
+	v := reflect.ValueOf(details)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	fields := make([]detailField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" || key == "parameters" {
+			continue
+		}
+
+		var value interface{}
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Ptr:
+			if !fv.IsNil() {
+				value = fv.Elem().Interface()
+			}
+		case reflect.Slice:
+			if fv.Len() > 0 {
+				value = fv.Interface()
+			}
+		default:
+			value = fv.Interface()
+		}
+		fields = append(fields, detailField{key: key, value: value})
+	}
+	return fields
+}
+
+func derefFloat(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -22,7 +22,8 @@ func (uc *FindObjectsUseCase) Execute(ctx context.Context, filters domain.FindOb
     logger := contextkeys.LoggerFromContext(ctx)
     ucLogger := logger.WithFields(port.Fields{
         "use_case": "FindObjects",
-        "filters":  filters,
+        "filters":  filters.WithoutExclusions(),
+        "excluded_count": len(filters.ExcludeMasterIDs),
         "limit":    limit,
         "offset":   offset,
     })
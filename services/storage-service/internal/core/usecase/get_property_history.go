@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+type GetPropertyHistoryUseCase struct {
+	storage port.PropertyStoragePort
+}
+
+func NewGetPropertyHistoryUseCase(storage port.PropertyStoragePort) *GetPropertyHistoryUseCase {
+	return &GetPropertyHistoryUseCase{storage: storage}
+}
+
+func (uc *GetPropertyHistoryUseCase) Execute(ctx context.Context, propertyID uuid.UUID, limit, offset int) (*domain.PropertyHistory, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":  "GetPropertyHistory",
+		"object_id": propertyID.String(),
+	})
+
+	ucLogger.Info("Use case started", nil)
+
+	history, err := uc.storage.GetPropertyHistory(ctx, propertyID, limit, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrPropertyNotFound) {
+			ucLogger.Warn("Property not found", nil)
+		} else {
+			ucLogger.Error("Storage returned an error", err, nil)
+		}
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"total_changes": history.TotalCount})
+	return history, nil
+}
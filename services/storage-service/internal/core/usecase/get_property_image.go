@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// GetPropertyImageUseCase отдает локальную копию фото объявления,
+// а если копии нет - исходную ссылку источника
+type GetPropertyImageUseCase struct {
+	images port.ImageStoragePort
+	blobs  port.BlobStorePort
+}
+
+func NewGetPropertyImageUseCase(images port.ImageStoragePort, blobs port.BlobStorePort) *GetPropertyImageUseCase {
+	return &GetPropertyImageUseCase{images: images, blobs: blobs}
+}
+
+func (uc *GetPropertyImageUseCase) Execute(ctx context.Context, propertyID uuid.UUID, index int, size string) (*domain.ImageContent, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":    "GetPropertyImage",
+		"property_id": propertyID.String(),
+		"index":       index,
+	})
+
+	url, err := uc.images.GetPropertyImageURL(ctx, propertyID, index)
+	if err != nil {
+		return nil, err
+	}
+	fallback := &domain.ImageContent{FallbackURL: url}
+
+	image, err := uc.images.GetImageByURL(ctx, url)
+	if errors.Is(err, domain.ErrImageNotFound) {
+		return fallback, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if image.Status != domain.ImageMirrored {
+		return fallback, nil
+	}
+
+	key, contentType := image.OriginalKey, image.ContentType
+	if size == domain.ImageSizeThumbnail {
+		key, contentType = image.ThumbnailKey, "image/jpeg"
+	}
+	data, err := uc.blobs.Get(ctx, key)
+	if err != nil {
+		// Файл потерян (например, том пересоздан) - отдаем ссылку источника, а не ошибку
+		ucLogger.Warn("Mirrored image file is unavailable", port.Fields{"key": key, "error": err.Error()})
+		return fallback, nil
+	}
+	return &domain.ImageContent{Body: io.NopCloser(bytes.NewReader(data)), ContentType: contentType, FallbackURL: url}, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+type GetSellerProfileUseCase struct {
+	storage port.PropertyStoragePort
+}
+
+func NewGetSellerProfileUseCase(storage port.PropertyStoragePort) *GetSellerProfileUseCase {
+	return &GetSellerProfileUseCase{storage: storage}
+}
+
+func (uc *GetSellerProfileUseCase) Execute(ctx context.Context, sellerID uuid.UUID) (*domain.SellerProfile, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":  "GetSellerProfile",
+		"seller_id": sellerID.String(),
+	})
+
+	profile, err := uc.storage.GetSellerProfile(ctx, sellerID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSellerNotFound) {
+			ucLogger.Error("Storage returned an error", err, nil)
+		}
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"total_listings": profile.TotalListings})
+	return profile, nil
+}
+
+type GetSellerListingsUseCase struct {
+	storage port.PropertyStoragePort
+}
+
+func NewGetSellerListingsUseCase(storage port.PropertyStoragePort) *GetSellerListingsUseCase {
+	return &GetSellerListingsUseCase{storage: storage}
+}
+
+func (uc *GetSellerListingsUseCase) Execute(ctx context.Context, sellerID uuid.UUID, status string, limit, offset int) (*domain.PaginatedResult, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":  "GetSellerListings",
+		"seller_id": sellerID.String(),
+		"status":    status,
+	})
+
+	switch status {
+	case "", domain.SellerListingsActive, domain.SellerListingsArchived:
+	default:
+		return nil, fmt.Errorf("%w: unknown status '%s'", domain.ErrInvalidSellerListingsStatus, status)
+	}
+
+	result, err := uc.storage.FindSellerListings(ctx, sellerID, status, limit, offset)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSellerNotFound) {
+			ucLogger.Error("Storage returned an error", err, nil)
+		}
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"total": result.TotalCount})
+	return result, nil
+}
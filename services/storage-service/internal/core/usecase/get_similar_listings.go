@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+type GetSimilarListingsUseCase struct {
+	storage         port.SimilarListingsPort
+	rules           domain.SimilarityRules
+	candidatesLimit int // сколько ближайших кандидатов оценивать
+}
+
+func NewGetSimilarListingsUseCase(storage port.SimilarListingsPort, rules domain.SimilarityRules, candidatesLimit int) *GetSimilarListingsUseCase {
+	return &GetSimilarListingsUseCase{storage: storage, rules: rules, candidatesLimit: candidatesLimit}
+}
+
+func (uc *GetSimilarListingsUseCase) Execute(ctx context.Context, propertyID uuid.UUID, limit int) (*domain.SimilarListingsResult, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":    "GetSimilarListings",
+		"property_id": propertyID.String(),
+	})
+
+	source, err := uc.storage.GetSimilarityProfile(ctx, propertyID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPropertyNotFound) {
+			ucLogger.Error("Storage returned an error", err, nil)
+		}
+		return nil, err
+	}
+
+	rules := uc.rules.ForCategory(source.Category)
+	candidates, err := uc.storage.FindSimilarCandidates(ctx, *source, rules.MaxDistanceKm*1000, rules.PriceTolerance, uc.candidatesLimit)
+	if err != nil {
+		ucLogger.Error("Failed to find similar candidates", err, nil)
+		return nil, err
+	}
+
+	listings := make([]domain.SimilarListing, 0, len(candidates))
+	for _, candidate := range candidates {
+		listings = append(listings, rules.Score(*source, candidate))
+	}
+	// При равной оценке ближайший выше: кандидаты уже отсортированы по расстоянию
+	sort.SliceStable(listings, func(i, j int) bool { return listings[i].Score > listings[j].Score })
+	if len(listings) > limit {
+		listings = listings[:limit]
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{
+		"category":   source.Category,
+		"candidates": len(candidates),
+		"returned":   len(listings),
+	})
+	return &domain.SimilarListingsResult{Source: *source, Listings: listings, Candidates: len(candidates)}, nil
+}
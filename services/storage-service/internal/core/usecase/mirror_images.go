@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// MirrorImagesUseCase копирует фото объявлений в локальное хранилище:
+// оригинал, миниатюра и перцептивный хеш для поиска дубликатов
+type MirrorImagesUseCase struct {
+	images     port.ImageStoragePort
+	blobs      port.BlobStorePort
+	downloader port.ImageDownloaderPort
+	processor  port.ImageProcessorPort
+}
+
+func NewMirrorImagesUseCase(images port.ImageStoragePort, blobs port.BlobStorePort,
+	downloader port.ImageDownloaderPort, processor port.ImageProcessorPort) *MirrorImagesUseCase {
+	return &MirrorImagesUseCase{
+		images:     images,
+		blobs:      blobs,
+		downloader: downloader,
+		processor:  processor,
+	}
+}
+
+// Execute копирует еще не обработанные фото. Недоступные и нечитаемые фото отмечаются как failed.
+// Временные ошибки возвращаются после обработки остальных фото: при повторе скопированные уже пропускаются
+func (uc *MirrorImagesUseCase) Execute(ctx context.Context, urls []string) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":  "MirrorImages",
+		"url_count": len(urls),
+	})
+
+	pending, err := uc.images.FindUnprocessed(ctx, urls)
+	if err != nil {
+		ucLogger.Error("Failed to find unprocessed images", err, nil)
+		return err
+	}
+
+	var lastErr error
+	mirrored, failed := 0, 0
+	for _, url := range pending {
+		image, err := uc.mirror(ctx, url)
+		switch {
+		case errors.Is(err, domain.ErrImageUnavailable), errors.Is(err, domain.ErrImageUndecodable):
+			ucLogger.Warn("Image cannot be mirrored, keeping source link", port.Fields{"url": url, "error": err.Error()})
+			image = domain.PropertyImage{ID: uuid.New(), SourceURL: url, Status: domain.ImageFailed, LastError: err.Error()}
+			failed++
+		case err != nil:
+			ucLogger.Error("Failed to mirror image", err, port.Fields{"url": url})
+			lastErr = err
+			continue
+		default:
+			mirrored++
+		}
+
+		if err := uc.images.SaveImage(ctx, image); err != nil {
+			ucLogger.Error("Failed to save image", err, port.Fields{"url": url})
+			lastErr = err
+		}
+	}
+
+	ucLogger.Info("Use case finished", port.Fields{
+		"pending":  len(pending),
+		"mirrored": mirrored,
+		"failed":   failed,
+	})
+	if lastErr != nil {
+		return fmt.Errorf("failed to mirror some images: %w", lastErr)
+	}
+	return nil
+}
+
+func (uc *MirrorImagesUseCase) mirror(ctx context.Context, url string) (domain.PropertyImage, error) {
+	data, err := uc.downloader.Download(ctx, url)
+	if err != nil {
+		return domain.PropertyImage{}, err
+	}
+	processed, err := uc.processor.Process(data)
+	if err != nil {
+		return domain.PropertyImage{}, err
+	}
+
+	image := domain.PropertyImage{
+		ID:          uuid.New(),
+		SourceURL:   url,
+		Status:      domain.ImageMirrored,
+		ContentType: processed.ContentType,
+		Width:       processed.Width,
+		Height:      processed.Height,
+		PHash:       processed.PHash,
+	}
+	image.OriginalKey = "originals/" + image.ID.String()
+	image.ThumbnailKey = "thumbnails/" + image.ID.String() + ".jpg"
+
+	if err := uc.blobs.Put(ctx, image.OriginalKey, data); err != nil {
+		return domain.PropertyImage{}, err
+	}
+	if err := uc.blobs.Put(ctx, image.ThumbnailKey, processed.Thumbnail); err != nil {
+		return domain.PropertyImage{}, err
+	}
+	return image, nil
+}
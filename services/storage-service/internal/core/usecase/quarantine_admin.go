@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/domain"
+	"storage-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuarantineAdminUseCase - просмотр, исправление и выпуск записей из карантина
+type QuarantineAdminUseCase struct {
+	quarantine port.QuarantineStoragePort
+	storage    port.PropertyStoragePort
+	rules      domain.QualityRules
+}
+
+func NewQuarantineAdminUseCase(quarantine port.QuarantineStoragePort, storage port.PropertyStoragePort, rules domain.QualityRules) *QuarantineAdminUseCase {
+	return &QuarantineAdminUseCase{
+		quarantine: quarantine,
+		storage:    storage,
+		rules:      rules,
+	}
+}
+
+func (uc *QuarantineAdminUseCase) List(ctx context.Context, filter domain.QuarantineFilter, limit, offset int) (*domain.PaginatedQuarantine, error) {
+	return uc.quarantine.ListQuarantined(ctx, filter, limit, offset)
+}
+
+func (uc *QuarantineAdminUseCase) Get(ctx context.Context, id uuid.UUID) (*domain.QuarantinedRecord, error) {
+	return uc.quarantine.GetQuarantined(ctx, id)
+}
+
+func (uc *QuarantineAdminUseCase) Fix(ctx context.Context, id uuid.UUID, record domain.RealEstateRecord) ([]domain.QualityViolation, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":      "QuarantineFix",
+		"quarantine_id": id.String(),
+	})
+
+	violations := uc.rules.Check(record)
+	if err := uc.quarantine.UpdateQuarantined(ctx, id, record, violations); err != nil {
+		return nil, err
+	}
+
+	ucLogger.Info("Quarantined record fixed", port.Fields{"remaining_violations": len(violations)})
+	return violations, nil
+}
+
+func (uc *QuarantineAdminUseCase) Release(ctx context.Context, id uuid.UUID, force bool) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":      "QuarantineRelease",
+		"quarantine_id": id.String(),
+		"force":         force,
+	})
+
+	q, err := uc.quarantine.GetQuarantined(ctx, id)
+	if err != nil {
+		return err
+	}
+	if q.Status != domain.QuarantinePending {
+		return domain.ErrQuarantineNotPending
+	}
+
+	// Правила могли поменяться с момента попадания в карантин, поэтому проверяем заново
+	if violations := uc.rules.Check(q.Record); len(violations) > 0 && !force {
+		return fmt.Errorf("%w: %d rule(s) still violated", domain.ErrQualityViolations, len(violations))
+	}
+
+	record := q.Record
+	record.General.UpdatedAt = time.Now()
+	stats, err := uc.storage.BatchSave(ctx, []domain.RealEstateRecord{record})
+	if err != nil {
+		ucLogger.Error("Failed to save released record", err, nil)
+		return fmt.Errorf("failed to save released record: %w", err)
+	}
+
+	if err := uc.quarantine.ResolveQuarantined(ctx, id, domain.QuarantineReleased); err != nil {
+		return err
+	}
+
+	ucLogger.Info("Quarantined record released", port.Fields{"created": stats.Created, "updated": stats.Updated})
+	return nil
+}
+
+func (uc *QuarantineAdminUseCase) Discard(ctx context.Context, id uuid.UUID) error {
+	if err := uc.quarantine.ResolveQuarantined(ctx, id, domain.QuarantineDiscarded); err != nil {
+		return err
+	}
+	contextkeys.LoggerFromContext(ctx).Info("Quarantined record discarded", port.Fields{"quarantine_id": id.String()})
+	return nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"storage-service/internal/contextkeys"
+	"storage-service/internal/core/port"
+	"time"
+)
+
+// RefreshExchangeRatesUseCase загружает официальные курсы у провайдера и сохраняет их по датам
+type RefreshExchangeRatesUseCase struct {
+	provider port.ExchangeRatesProviderPort
+	storage  port.ExchangeRatesStoragePort
+}
+
+func NewRefreshExchangeRatesUseCase(provider port.ExchangeRatesProviderPort, storage port.ExchangeRatesStoragePort) *RefreshExchangeRatesUseCase {
+	return &RefreshExchangeRatesUseCase{provider: provider, storage: storage}
+}
+
+// Execute загружает курсы за дни с from по to включительно и возвращает число сохраненных курсов.
+// День, на который у провайдера курсов нет, пропускается
+func (uc *RefreshExchangeRatesUseCase) Execute(ctx context.Context, from, to time.Time) (int, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case": "RefreshExchangeRates",
+		"from":     from.Format(time.DateOnly),
+		"to":       to.Format(time.DateOnly),
+	})
+
+	saved := 0
+	for day := truncateToDay(from); !day.After(truncateToDay(to)); day = day.AddDate(0, 0, 1) {
+		rates, err := uc.provider.FetchRates(ctx, day)
+		if err != nil {
+			ucLogger.Error("Provider returned an error", err, port.Fields{"date": day.Format(time.DateOnly)})
+			return saved, fmt.Errorf("failed to fetch exchange rates on %s: %w", day.Format(time.DateOnly), err)
+		}
+		if len(rates) == 0 {
+			ucLogger.Debug("No exchange rates on date", port.Fields{"date": day.Format(time.DateOnly)})
+			continue
+		}
+		if err := uc.storage.SaveRates(ctx, rates); err != nil {
+			return saved, fmt.Errorf("failed to save exchange rates on %s: %w", day.Format(time.DateOnly), err)
+		}
+		saved += len(rates)
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"saved_rates": saved})
+	return saved, nil
+}
+
+// truncateToDay - полночь того же календарного дня в UTC: курсы хранятся по датам
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
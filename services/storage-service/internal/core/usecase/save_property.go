@@ -7,6 +7,7 @@ import (
 	"storage-service/internal/contextkeys"
 	"storage-service/internal/core/domain"
 	"storage-service/internal/core/port"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,14 +16,85 @@ import (
 type SavePropertyUseCase struct {
 	storage port.PropertyStoragePort
 	reporter port.TaskReporterPort
+	quarantine port.QuarantineStoragePort
+	rules domain.QualityRules
+	rates port.ExchangeRatesStoragePort
+	images port.ImageStoragePort
+	mirrorQueue port.ImageMirrorQueuePort // nil - копирование фото выключено
 }
 
+// imageMirrorChunkSize - сколько адресов фото в одном запросе на копирование
+const imageMirrorChunkSize = 50
+
 // NewSavePropertyUseCase создает новый экземпляр use case
-func NewSavePropertyUseCase(storage port.PropertyStoragePort, reporter port.TaskReporterPort) *SavePropertyUseCase {
+func NewSavePropertyUseCase(storage port.PropertyStoragePort, reporter port.TaskReporterPort,
+	quarantine port.QuarantineStoragePort, rules domain.QualityRules, rates port.ExchangeRatesStoragePort,
+	images port.ImageStoragePort, mirrorQueue port.ImageMirrorQueuePort) *SavePropertyUseCase {
 	return &SavePropertyUseCase{
 		storage: storage,
 		reporter: reporter,
+		quarantine: quarantine,
+		rules: rules,
+		rates: rates,
+		images: images,
+		mirrorQueue: mirrorQueue,
+	}
+}
+
+// requestImageMirror ставит в очередь на копирование еще не обработанные фото активных объявлений.
+// Ошибки только логируются: записи уже сохранены, а без копии фото выдаются по ссылке источника
+func (uc *SavePropertyUseCase) requestImageMirror(ctx context.Context, records []domain.RealEstateRecord, ucLogger port.LoggerPort) {
+	if uc.mirrorQueue == nil {
+		return
+	}
+
+	urlsBySource := make(map[string][]string)
+	for _, rec := range records {
+		if rec.General.Status == "active" {
+			urlsBySource[rec.General.Source] = append(urlsBySource[rec.General.Source], rec.General.Images...)
+		}
+	}
+
+	for source, urls := range urlsBySource {
+		pending, err := uc.images.FindUnprocessed(ctx, urls)
+		if err != nil {
+			ucLogger.Error("Failed to find images to mirror", err, port.Fields{"source": source})
+			continue
+		}
+		for start := 0; start < len(pending); start += imageMirrorChunkSize {
+			end := min(start+imageMirrorChunkSize, len(pending))
+			if err := uc.mirrorQueue.RequestMirror(ctx, source, pending[start:end]); err != nil {
+				ucLogger.Error("Failed to request image mirroring", err, port.Fields{"source": source, "url_count": end - start})
+			}
+		}
+	}
+}
+
+// normalizePrices пересчитывает цены записей по курсам на сегодня. Правила качества проверяют уже пересчитанные цены.
+// Без курсов записи сохраняются с ценами источника: прием объявлений не должен вставать из-за курсов
+func (uc *SavePropertyUseCase) normalizePrices(ctx context.Context, records []domain.RealEstateRecord, ucLogger port.LoggerPort) {
+	table, err := uc.rates.GetRateTable(ctx, time.Now())
+	if err != nil {
+		ucLogger.Warn("Exchange rates unavailable, keeping source prices", port.Fields{"error": err.Error()})
+		table = nil
+	}
+	for i := range records {
+		records[i].General.NormalizePrices(table)
+	}
+}
+
+// splitByQuality отделяет записи, нарушающие правила качества данных
+func (uc *SavePropertyUseCase) splitByQuality(records []domain.RealEstateRecord, taskID uuid.UUID) ([]domain.RealEstateRecord, []domain.QuarantinedRecord) {
+	passed := make([]domain.RealEstateRecord, 0, len(records))
+	var quarantined []domain.QuarantinedRecord
+	for _, rec := range records {
+		if violations := uc.rules.Check(rec); len(violations) > 0 {
+			quarantined = append(quarantined, domain.QuarantinedRecord{TaskID: taskID, Record: rec, Violations: violations})
+			continue
+		}
+		passed = append(passed, rec)
 	}
+	return passed, quarantined
 }
 
 // Execute выполняет основную логику: сохраняет запись, используя порт хранилища
@@ -37,11 +109,22 @@ func (uc *SavePropertyUseCase) Save(ctx context.Context, record domain.RealEstat
 	
 	ucLogger.Info("Use case started: attempting to save single record", nil)
 
+	records := []domain.RealEstateRecord{record}
+	uc.normalizePrices(ctx, records, ucLogger)
+	record = records[0]
+
+	if violations := uc.rules.Check(record); len(violations) > 0 {
+		ucLogger.Warn("Record violates data quality rules, quarantining", port.Fields{"violations": violations})
+		return uc.quarantine.Quarantine(ctx, []domain.QuarantinedRecord{{Record: record, Violations: violations}})
+	}
+
 	if err := uc.storage.Save(ctx, record); err != nil {
 		ucLogger.Error("Storage returned an error during save", err, nil)
 		return fmt.Errorf("failed to save property record from source %s: %w", record.General.Source, err)
 	}
 
+	uc.requestImageMirror(ctx, records, ucLogger)
+
 	ucLogger.Info("Use case finished: successfully saved single record", nil)
 	return nil
 }
@@ -55,15 +138,46 @@ func (uc *SavePropertyUseCase) BatchSave(ctx context.Context, records []domain.R
 	})
 	
 	ucLogger.Info("Use case started: attempting to batch save records", nil)
+	startedAt := time.Now()
 
-	stats, err := uc.storage.BatchSave(ctx, records)
-    if err != nil {
-		ucLogger.Error("Storage returned an error during batch save", err, nil)
-        return fmt.Errorf("failed to save %d property records: %w", len(records), err)
-    }
+	uc.normalizePrices(ctx, records, ucLogger)
+	passed, quarantined := uc.splitByQuality(records, taskID)
+	if len(quarantined) > 0 {
+		// Карантин пишем первым: если он недоступен, вся пачка уйдет на повтор, и записи не потеряются
+		if err := uc.quarantine.Quarantine(ctx, quarantined); err != nil {
+			ucLogger.Error("Failed to quarantine records", err, port.Fields{"quarantined": len(quarantined)})
+			return fmt.Errorf("failed to quarantine %d property records: %w", len(quarantined), err)
+		}
+		ucLogger.Warn("Records quarantined by data quality rules", port.Fields{"quarantined": len(quarantined)})
+	}
+
+	stats := &domain.BatchSaveStats{}
+	if len(passed) > 0 {
+		var err error
+		stats, err = uc.storage.BatchSave(ctx, passed)
+		if err != nil {
+			ucLogger.Error("Storage returned an error during batch save", err, nil)
+			return fmt.Errorf("failed to save %d property records: %w", len(passed), err)
+		}
+	}
+	stats.Quarantined = len(quarantined)
+	for _, record := range records {
+		stats.ReportedItems = append(stats.ReportedItems, domain.LinkItemKey(record.General.Source, record.General.SourceAdID))
+	}
+	stats.ProcessedBySource = make(map[string]int)
+	for _, record := range passed {
+		stats.ProcessedBySource[record.General.Source]++
+	}
+	stats.QuarantinedBySource = make(map[string]int)
+	for _, q := range quarantined {
+		stats.QuarantinedBySource[q.Record.General.Source]++
+	}
+	stats.Duration = time.Since(startedAt)
+	uc.requestImageMirror(ctx, passed, ucLogger)
 
-	// 2. Если статистика не пустая, отправляем отчет
-    if stats != nil && (stats.Created > 0 || stats.Updated > 0 || stats.Archived > 0) {
+	// 2. Если статистика не пустая, отправляем отчет.
+	// Нулевой task_id - повторная публикация из архива парсера вне какой-либо задачи, отчитываться некому
+    if taskID != uuid.Nil && stats != nil && (stats.Created > 0 || stats.Updated > 0 || stats.Archived > 0 || stats.Quarantined > 0) {
         if err := uc.reporter.ReportResults(ctx, taskID, stats); err != nil {
             // Логируем ошибку, но не возвращаем ее, т.к. основная операция (сохранение) прошла успешно
             // Это предотвратит повторную обработку уже сохраненных данных
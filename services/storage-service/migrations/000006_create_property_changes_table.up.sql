@@ -0,0 +1,11 @@
+-- История изменений объявлений: одна строка на одно сохранение, в котором поменялись отслеживаемые поля
+CREATE TABLE IF NOT EXISTS property_changes (
+    id          BIGSERIAL PRIMARY KEY,
+    property_id UUID NOT NULL REFERENCES general_properties(id) ON DELETE CASCADE,
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    changes     JSONB NOT NULL, -- {"price_usd": {"old": 50000, "new": 48000}, ...}
+
+    -- general_properties и таблица деталей пишутся в одной транзакции с одним NOW(),
+    -- поэтому их изменения сливаются в одну строку
+    UNIQUE (property_id, changed_at)
+);
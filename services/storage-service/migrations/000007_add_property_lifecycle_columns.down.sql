@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_general_properties_relisted_from;
+DROP INDEX IF EXISTS idx_general_properties_first_seen_at;
+
+ALTER TABLE general_properties
+    DROP COLUMN IF EXISTS relisted_from_id,
+    DROP COLUMN IF EXISTS reactivation_count,
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS last_seen_active_at,
+    DROP COLUMN IF EXISTS first_seen_at;
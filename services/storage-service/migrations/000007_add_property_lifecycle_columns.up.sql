@@ -0,0 +1,21 @@
+-- Жизненный цикл объявления: когда впервые увидели, когда последний раз было активным, когда ушло в архив
+ALTER TABLE general_properties
+    ADD COLUMN first_seen_at       TIMESTAMPTZ,
+    ADD COLUMN last_seen_active_at TIMESTAMPTZ,
+    ADD COLUMN archived_at         TIMESTAMPTZ,
+    ADD COLUMN reactivation_count  INTEGER NOT NULL DEFAULT 0,
+    -- архивное объявление того же master_object, которое было выставлено заново под новым source_ad_id
+    ADD COLUMN relisted_from_id    UUID REFERENCES general_properties(id) ON DELETE SET NULL;
+
+-- Для уже сохраненных объявлений точнее created_at/updated_at данных нет
+UPDATE general_properties SET
+    first_seen_at = created_at,
+    last_seen_active_at = CASE WHEN status = 'active' THEN updated_at END,
+    archived_at = CASE WHEN status = 'archived' THEN updated_at END;
+
+ALTER TABLE general_properties
+    ALTER COLUMN first_seen_at SET DEFAULT NOW(),
+    ALTER COLUMN first_seen_at SET NOT NULL;
+
+CREATE INDEX idx_general_properties_first_seen_at ON general_properties(first_seen_at);
+CREATE INDEX idx_general_properties_relisted_from ON general_properties(relisted_from_id) WHERE relisted_from_id IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_general_properties_seller_id;
+
+ALTER TABLE general_properties DROP COLUMN IF EXISTS seller_id;
+
+DROP TABLE IF EXISTS sellers;
+
+DROP FUNCTION IF EXISTS seller_key(TEXT, TEXT, JSONB);
@@ -0,0 +1,46 @@
+-- Ключ продавца по seller_details и имени, от более надежного к менее надежному:
+--   unp:<УНП>                 - юрлицо/ИП, общий для всех источников
+--   account:<источник>:<id>   - аккаунт на площадке (kufar кладет account_id в seller_details)
+--   phone:<цифры>             - первый контактный телефон (realt), общий для всех источников
+--   name:<источник>:<имя>     - последний вариант, только внутри источника
+-- NULL - продавца определить не по чему
+CREATE OR REPLACE FUNCTION seller_key(p_source TEXT, p_seller_name TEXT, p_details JSONB)
+RETURNS TEXT
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT COALESCE(
+        'unp:' || NULLIF(NULLIF(btrim(COALESCE(p_details->>'unp', p_details->'agency'->>'unp')), ''), '0'),
+        'account:' || p_source || ':' || NULLIF(btrim(p_details->>'account_id'), ''),
+        'phone:' || NULLIF(regexp_replace(COALESCE(p_details->'contactPhones'->>0, ''), '\D', '', 'g'), ''),
+        'name:' || p_source || ':' || NULLIF(lower(btrim(p_seller_name)), '')
+    )
+$$;
+
+CREATE TABLE IF NOT EXISTS sellers (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seller_key  TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL,
+    -- TRUE, если хоть одно объявление продавца помечено источником как агентское
+    is_agency   BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE general_properties
+    ADD COLUMN seller_id UUID REFERENCES sellers(id) ON DELETE SET NULL;
+
+-- Заполняем продавцов по уже сохраненным объявлениям
+INSERT INTO sellers (seller_key, name, is_agency)
+SELECT seller_key(source, seller_name, seller_details), MAX(seller_name), BOOL_OR(is_agency)
+FROM general_properties
+WHERE seller_key(source, seller_name, seller_details) IS NOT NULL
+GROUP BY 1
+ON CONFLICT (seller_key) DO NOTHING;
+
+UPDATE general_properties gp
+SET seller_id = s.id
+FROM sellers s
+WHERE s.seller_key = seller_key(gp.source, gp.seller_name, gp.seller_details);
+
+CREATE INDEX idx_general_properties_seller_id ON general_properties(seller_id);
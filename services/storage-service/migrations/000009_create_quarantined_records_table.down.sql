@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_quarantined_records_status_created;
+DROP INDEX IF EXISTS idx_quarantined_records_pending_key;
+
+DROP TABLE IF EXISTS quarantined_records;
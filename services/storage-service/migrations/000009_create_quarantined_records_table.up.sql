@@ -0,0 +1,20 @@
+-- Записи, не прошедшие правила качества данных. В general_properties не попадают,
+-- пока администратор не исправит или не выпустит их
+CREATE TABLE IF NOT EXISTS quarantined_records (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source       VARCHAR(50) NOT NULL,
+    source_ad_id BIGINT NOT NULL,
+    category     VARCHAR(255) NOT NULL,
+    task_id      UUID,
+    record       JSONB NOT NULL, -- {"general": {...}, "details": {...}}
+    violations   JSONB NOT NULL, -- [{"rule": "price_too_low", "message": "..."}]
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending | released | discarded
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at  TIMESTAMPTZ
+);
+
+-- Повторно пришедшее объявление заменяет ожидающую запись, а не копит дубли
+CREATE UNIQUE INDEX idx_quarantined_records_pending_key
+    ON quarantined_records(source, source_ad_id) WHERE status = 'pending';
+CREATE INDEX idx_quarantined_records_status_created ON quarantined_records(status, created_at DESC);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_general_properties_price_eur;
+DROP INDEX IF EXISTS idx_general_properties_price_byn;
+DROP INDEX IF EXISTS idx_general_properties_price_usd;
+
+ALTER TABLE general_properties
+    DROP COLUMN IF EXISTS rates_date,
+    DROP COLUMN IF EXISTS original_currency,
+    DROP COLUMN IF EXISTS original_price;
+
+DROP TABLE IF EXISTS exchange_rates;
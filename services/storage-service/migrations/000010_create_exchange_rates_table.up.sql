@@ -0,0 +1,30 @@
+-- Официальные курсы НБРБ: сколько BYN стоит одна единица валюты на дату
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    rate_date   DATE NOT NULL,
+    currency    VARCHAR(3) NOT NULL,
+    rate_byn    NUMERIC(14, 6) NOT NULL CHECK (rate_byn > 0),
+    provider    VARCHAR(16) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (currency, rate_date)
+);
+
+-- Цена в валюте объявления, из которой пересчитаны price_byn/price_usd/price_eur, и дата курсов пересчета.
+-- rates_date NULL - цены взяты из источника как есть
+ALTER TABLE general_properties
+    ADD COLUMN original_price NUMERIC(14, 2),
+    ADD COLUMN original_currency VARCHAR(3),
+    ADD COLUMN rates_date DATE;
+
+UPDATE general_properties
+SET original_currency = CASE
+        WHEN currency = 'USD' AND price_usd > 0 THEN 'USD'
+        WHEN currency = 'EUR' AND price_eur > 0 THEN 'EUR'
+        ELSE 'BYN'
+    END;
+
+UPDATE general_properties
+SET original_price = CASE original_currency WHEN 'USD' THEN price_usd WHEN 'EUR' THEN price_eur ELSE price_byn END;
+
+CREATE INDEX idx_general_properties_price_usd ON general_properties(price_usd);
+CREATE INDEX idx_general_properties_price_byn ON general_properties(price_byn);
+CREATE INDEX idx_general_properties_price_eur ON general_properties(price_eur);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_property_images_phash;
+
+DROP TABLE IF EXISTS property_images;
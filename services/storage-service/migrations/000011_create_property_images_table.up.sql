@@ -0,0 +1,18 @@
+-- Локальные копии фотографий объявлений. Одна строка на адрес фото у источника:
+-- general_properties.images хранит эти адреса, одно фото может встречаться в нескольких объявлениях
+CREATE TABLE IF NOT EXISTS property_images (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_url     TEXT NOT NULL UNIQUE,
+    status         VARCHAR(16) NOT NULL, -- mirrored | failed
+    original_key   TEXT,                 -- ключи в хранилище файлов, только для mirrored
+    thumbnail_key  TEXT,
+    content_type   VARCHAR(64),
+    width          INT,
+    height         INT,
+    phash          BIGINT,               -- dHash 64 бита: близкие по расстоянию Хэмминга фото - вероятные дубликаты
+    last_error     TEXT,                 -- причина для failed
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_property_images_phash ON property_images(phash) WHERE phash IS NOT NULL;
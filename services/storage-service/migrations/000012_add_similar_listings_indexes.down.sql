@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_general_properties_category_deal_active;
+DROP INDEX IF EXISTS idx_general_properties_coordinates;
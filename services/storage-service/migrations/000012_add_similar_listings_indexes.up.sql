@@ -0,0 +1,5 @@
+-- Поиск похожих объектов: кандидаты отбираются по радиусу вокруг объекта
+-- среди активных объявлений той же категории и типа сделки
+CREATE INDEX IF NOT EXISTS idx_general_properties_coordinates ON general_properties USING GIST (coordinates);
+CREATE INDEX IF NOT EXISTS idx_general_properties_category_deal_active
+    ON general_properties (category, deal_type) WHERE status = 'active';
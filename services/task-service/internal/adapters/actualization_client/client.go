@@ -0,0 +1,82 @@
+package actualization_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+)
+
+// Client - клиент для actualization-service, который выполняет задачи
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type dispatchTaskRequest struct {
+	Type            string            `json:"type"`
+	Params          domain.TaskParams `json:"params"`
+	CreatedByUserID string            `json:"created_by_user_id"`
+	Items           []domain.TaskItem `json:"items,omitempty"`
+}
+
+// Dispatch просит actualization-service запустить работу по уже созданной задаче
+func (c *Client) Dispatch(ctx context.Context, task *domain.Task, items []domain.TaskItem) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	clientLogger := logger.WithFields(port.Fields{
+		"component": "ActualizationApiClient",
+		"method":    "Dispatch",
+		"task_id":   task.ID.String(),
+	})
+
+	reqBody, err := json.Marshal(dispatchTaskRequest{
+		Type:            task.Type,
+		Params:          task.Params,
+		CreatedByUserID: task.CreatedByUserID.String(),
+		Items:           items,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispatch request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/internal/tasks/%s/dispatch", c.baseURL, task.ID.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if traceID := contextkeys.TraceIDFromContext(ctx); traceID != "" {
+		req.Header.Set("X-Trace-ID", traceID)
+	}
+
+	clientLogger.Debug("Sending request to dispatch task", port.Fields{"url": url, "item_count": len(items)})
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		clientLogger.Error("Failed to perform request to actualization-service", err, nil)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		err := fmt.Errorf("actualization service returned non-success status code %d: %s", resp.StatusCode, string(bodyBytes))
+		clientLogger.Error("Received error response from actualization-service", err, port.Fields{"status_code": resp.StatusCode})
+		return err
+	}
+
+	clientLogger.Info("Task dispatched", nil)
+	return nil
+}
@@ -5,12 +5,75 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
 	"task-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
 )
 
-// clientChannel - это канал, через который мы будем отправлять события одному конкретному клиенту (браузеру)
-type clientChannel chan []byte
+// Message - готовое SSE-сообщение. ID равен 0, если событие не удалось сохранить в журнал
+type Message struct {
+	ID   int64
+	Data []byte
+}
+
+// Client - одно SSE-соединение (одна открытая вкладка) со своими счетчиками
+type Client struct {
+	userID      string
+	connectedAt time.Time
+	messages    chan Message
+
+	// overflow закрывается, когда буфер клиента переполнен. Соединение после этого закрывается,
+	// и браузер переподключается с Last-Event-ID, получая пропущенное из журнала
+	overflow     chan struct{}
+	overflowOnce sync.Once
+
+	sent      atomic.Int64
+	dropped   atomic.Int64
+	maxQueued atomic.Int64
+}
+
+// Messages - канал событий для этого клиента
+func (c *Client) Messages() <-chan Message { return c.messages }
+
+// Overflow - закрывается, если клиент не успевает читать события
+func (c *Client) Overflow() <-chan struct{} { return c.overflow }
+
+// MarkSent учитывает сообщение, записанное в соединение
+func (c *Client) MarkSent() { c.sent.Add(1) }
+
+// ClientStats - метрики одного соединения для админского API
+type ClientStats struct {
+	UserID      string    `json:"user_id"`
+	ConnectedAt time.Time `json:"connected_at"`
+	Sent        int64     `json:"sent"`
+	Dropped     int64     `json:"dropped"`
+	Queued      int       `json:"queued"`     // сообщений в буфере прямо сейчас
+	MaxQueued   int64     `json:"max_queued"` // максимальное заполнение буфера за время соединения
+	BufferSize  int       `json:"buffer_size"`
+}
+
+func (c *Client) stats() ClientStats {
+	return ClientStats{
+		UserID:      c.userID,
+		ConnectedAt: c.connectedAt,
+		Sent:        c.sent.Load(),
+		Dropped:     c.dropped.Load(),
+		Queued:      len(c.messages),
+		MaxQueued:   c.maxQueued.Load(),
+		BufferSize:  cap(c.messages),
+	}
+}
+
+// Config - настройки нотификатора
+type Config struct {
+	ClientBuffer      int           // размер буфера одного соединения
+	ReplayLimit       int           // сколько событий максимум досылать по Last-Event-ID
+	HeartbeatInterval time.Duration // период комментариев-пингов в открытом соединении
+}
 
 // структура для передачи в канал
 type eventWithContext struct {
@@ -18,29 +81,37 @@ type eventWithContext struct {
 	event port.TaskEvent
 }
 
-// SSENotifier - это реализация NotifierPort
+// SSENotifier - это реализация NotifierPort.
+// Событие сохраняется в журнал (получает ID), затем через fanout попадает на все реплики,
+// и каждая отдает его своим подключенным клиентам
 type SSENotifier struct {
 	// clients хранит активные подключения. Ключ - ID пользователя,
-	// значение - срез каналов (один пользователь может открыть несколько вкладок)
-	clients map[string][]clientChannel
+	// значение - срез клиентов (один пользователь может открыть несколько вкладок)
+	clients map[string][]*Client
 	// mu - мьютекс для защиты clients от одновременного доступа из разных горутин
 	mu sync.RWMutex
 
 	// eventChan - внутренний канал, в который Use Cases будут бросать события
 	eventChan chan eventWithContext
 
-	logger    port.LoggerPort
-}
+	eventLog port.TaskEventLogPort
+	fanout   port.TaskEventFanoutPort // nil - одна реплика, события отдаются только своим клиентам
+	cfg      Config
 
+	logger port.LoggerPort
+}
 
 // NewSSENotifier создает и запускает новый нотификатор
-func NewSSENotifier(baseLogger port.LoggerPort) *SSENotifier {
+func NewSSENotifier(baseLogger port.LoggerPort, eventLog port.TaskEventLogPort, fanout port.TaskEventFanoutPort, cfg Config) *SSENotifier {
 
 	notifierLogger := baseLogger.WithFields(port.Fields{"component": "SSENotifier"})
 
 	notifier := &SSENotifier{
-		clients:   make(map[string][]clientChannel),
+		clients:   make(map[string][]*Client),
 		eventChan: make(chan eventWithContext, 100), // Буферизованный канал
+		eventLog:  eventLog,
+		fanout:    fanout,
+		cfg:       cfg,
 		logger:    notifierLogger,
 	}
 
@@ -50,11 +121,14 @@ func NewSSENotifier(baseLogger port.LoggerPort) *SSENotifier {
 	return notifier
 }
 
+// HeartbeatInterval - период пингов для хендлера подписки
+func (n *SSENotifier) HeartbeatInterval() time.Duration { return n.cfg.HeartbeatInterval }
+
 // dispatcher - работает в фоне и никогда не завершается
 func (n *SSENotifier) dispatcher() {
 	n.logger.Debug("Notifier dispatcher started.", nil)
 	for {
-		
+
 		// Блокируемся, пока не придет новое событие из Use Case
 		eventPackage := <-n.eventChan
 
@@ -70,7 +144,7 @@ func (n *SSENotifier) dispatcher() {
 			"event_type": event.Type,
 			"task_id":    event.Data.ID.String(),
 		})
-		
+
 		eventLogger.Info("Processing new event.", nil)
 
 		// Маршалим событие в JSON
@@ -79,34 +153,76 @@ func (n *SSENotifier) dispatcher() {
 			eventLogger.Error("Failed to marshal event", err, nil)
 			continue
 		}
-		
-		// Форматируем для SSE
-		sseMessage := []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, string(eventBytes)))
-
-		// Получаем ID пользователя, которому адресовано событие
-		userID := event.Data.CreatedByUserID.String()
-
-		// Блокируем clients для безопасного чтения
-		n.mu.RLock()
-		
-		// Находим все активные соединения для этого пользователя
-		if clientChannels, found := n.clients[userID]; found {
-			eventLogger.Debug("Dispatching event to clients", port.Fields{"user_id": userID, "channels_count": len(clientChannels)})
-			// Отправляем сообщение в каждый канал (в каждую открытую вкладку)
-			for _, ch := range clientChannels {
-				// Используем select с default, чтобы не заблокироваться,
-				// если канал клиента переполнен или закрыт
-				select {
-				case ch <- sseMessage:
-				default:
-					eventLogger.Warn("Client channel is full or closed, skipping.", port.Fields{"user_id": userID})
-				}
-			}
+
+		record := domain.TaskEventRecord{
+			UserID:  event.Data.CreatedByUserID,
+			Type:    event.Type,
+			Payload: eventBytes,
+		}
+
+		// Без ID событие все равно доставляется, но после переподключения его уже не получить
+		if saved, err := n.eventLog.AppendEvent(ctx, record); err != nil {
+			eventLogger.Warn("Failed to save event to log, sending without id", port.Fields{"error": err.Error()})
 		} else {
-			eventLogger.Debug("No active clients for user, event dropped.", port.Fields{"user_id": userID})
+			record = saved
+		}
+
+		if n.fanout != nil {
+			err := n.fanout.PublishEvent(ctx, record)
+			if err == nil {
+				// Своим клиентам событие придет тем же путем, что и остальным репликам
+				continue
+			}
+			eventLogger.Warn("Failed to publish event to fanout, delivering to local clients only", port.Fields{"error": err.Error()})
+		}
+
+		n.Deliver(ctx, record)
+	}
+}
+
+// Deliver отдает событие подключенным к этой реплике клиентам адресата (реализация TaskEventReceiverPort)
+func (n *SSENotifier) Deliver(ctx context.Context, event domain.TaskEventRecord) {
+	eventLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component":  "SSENotifier.Deliver",
+		"event_type": event.Type,
+		"event_id":   event.ID,
+	})
+
+	message := Message{ID: event.ID, Data: formatEvent(event)}
+
+	// Получаем ID пользователя, которому адресовано событие
+	userID := event.UserID.String()
+
+	// Блокируем clients для безопасного чтения
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	// Находим все активные соединения для этого пользователя
+	clients, found := n.clients[userID]
+	if !found {
+		eventLogger.Debug("No active clients for user on this replica.", port.Fields{"user_id": userID})
+		return
+	}
+
+	eventLogger.Debug("Dispatching event to clients", port.Fields{"user_id": userID, "channels_count": len(clients)})
+	// Отправляем сообщение каждому клиенту (в каждую открытую вкладку)
+	for _, c := range clients {
+		// Используем select с default, чтобы не заблокироваться, если клиент не успевает читать
+		select {
+		case c.messages <- message:
+			if queued := int64(len(c.messages)); queued > c.maxQueued.Load() {
+				c.maxQueued.Store(queued)
+			}
+		default:
+			c.dropped.Add(1)
+			c.overflowOnce.Do(func() {
+				close(c.overflow)
+				eventLogger.Warn("Client buffer is full, closing connection to force resume", port.Fields{
+					"user_id":     userID,
+					"buffer_size": cap(c.messages),
+				})
+			})
 		}
-		
-		n.mu.RUnlock()
 	}
 }
 
@@ -114,55 +230,115 @@ func (n *SSENotifier) dispatcher() {
 // Use Cases вызывают этот метод. Он просто отправляет событие во внутренний канал
 func (n *SSENotifier) Notify(ctx context.Context, event port.TaskEvent) {
 	eventPackage := eventWithContext{
-		ctx:   ctx,
+		// Контекст HTTP-запроса отменяется сразу после ответа, а событие сохраняется и публикуется
+		// позже в диспетчере. Отмену отбрасываем, логгер и trace_id остаются
+		ctx:   context.WithoutCancel(ctx),
 		event: event,
 	}
 
-	// Отправка в канал неблокирующая, если есть место в буфере
+	// Блокируется, если буфер канала заполнен
 	n.eventChan <- eventPackage
 }
 
+// Replay возвращает события пользователя после lastEventID из журнала.
+// resync = true, если часть событий уже удалена из журнала или их слишком много:
+// клиенту нужно заново загрузить состояние целиком
+func (n *SSENotifier) Replay(ctx context.Context, userID uuid.UUID, lastEventID int64) (messages []Message, resync bool, err error) {
+	oldestID, err := n.eventLog.OldestEventID(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	if oldestID == 0 || oldestID > lastEventID+1 {
+		return nil, true, nil
+	}
+
+	events, err := n.eventLog.FindEventsAfter(ctx, userID, lastEventID, n.cfg.ReplayLimit+1)
+	if err != nil {
+		return nil, true, err
+	}
+	if len(events) > n.cfg.ReplayLimit {
+		return nil, true, nil
+	}
+
+	messages = make([]Message, 0, len(events))
+	for _, e := range events {
+		messages = append(messages, Message{ID: e.ID, Data: formatEvent(e)})
+	}
+	return messages, false, nil
+}
+
+// formatEvent форматирует событие для SSE. id задается только для сохраненных в журнале событий
+func formatEvent(event domain.TaskEventRecord) []byte {
+	if event.ID > 0 {
+		return []byte(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, string(event.Payload)))
+	}
+	return []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, string(event.Payload)))
+}
+
 // AddClient добавляет нового клиента (новое SSE-соединение)
 // Этот метод вызывается из HTTP-хендлера
-func (n *SSENotifier) AddClient(userID string) clientChannel {
+func (n *SSENotifier) AddClient(userID string) *Client {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	ch := make(clientChannel, 100) // Канал для одного клиента
-	n.clients[userID] = append(n.clients[userID], ch)
+	c := &Client{
+		userID:      userID,
+		connectedAt: time.Now(),
+		messages:    make(chan Message, n.cfg.ClientBuffer),
+		overflow:    make(chan struct{}),
+	}
+	n.clients[userID] = append(n.clients[userID], c)
 
 	n.logger.Info("Client connected for user", port.Fields{
-		"user_id":         userID,
+		"user_id":                    userID,
 		"total_connections_for_user": len(n.clients[userID]),
 	})
-	
-	return ch
+
+	return c
 }
 
-// RemoveClient удаляет канал клиента при отключении
+// RemoveClient удаляет клиента при отключении
 // Этот метод будет вызывается из HTTP-хендлера, когда клиент закрывает соединение
-func (n *SSENotifier) RemoveClient(userID string, ch clientChannel) {
+func (n *SSENotifier) RemoveClient(client *Client) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	if channels, found := n.clients[userID]; found {
-		newChannels := make([]clientChannel, 0)
-		for _, c := range channels {
-			// Сравниваем указатели на каналы, чтобы найти и удалить нужный
-			if c != ch {
-				newChannels = append(newChannels, c)
+	userID := client.userID
+	stats := client.stats()
+	statsFields := port.Fields{"sent": stats.Sent, "dropped": stats.Dropped, "max_queued": stats.MaxQueued}
+
+	if clients, found := n.clients[userID]; found {
+		remaining := make([]*Client, 0, len(clients))
+		for _, c := range clients {
+			if c != client {
+				remaining = append(remaining, c)
 			}
 		}
 
-		if len(newChannels) == 0 {
+		if len(remaining) == 0 {
 			delete(n.clients, userID)
-			n.logger.Debug("Last client disconnected for user. User removed.", port.Fields{"user_id": userID})
+			n.logger.Debug("Last client disconnected for user. User removed.", port.Fields{"user_id": userID, "stats": statsFields})
 		} else {
-			n.clients[userID] = newChannels
+			n.clients[userID] = remaining
 			n.logger.Info("Client disconnected for user.", port.Fields{
-				"user_id":             userID,
-				"remaining_connections": len(newChannels),
+				"user_id":               userID,
+				"remaining_connections": len(remaining),
+				"stats":                 statsFields,
 			})
 		}
 	}
-}
\ No newline at end of file
+}
+
+// Stats - метрики всех соединений этой реплики
+func (n *SSENotifier) Stats() []ClientStats {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	stats := make([]ClientStats, 0)
+	for _, clients := range n.clients {
+		for _, c := range clients {
+			stats = append(stats, c.stats())
+		}
+	}
+	return stats
+}
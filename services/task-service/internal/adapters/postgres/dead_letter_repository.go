@@ -0,0 +1,297 @@
+package postgres_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const deadLetterColumns = `
+	id, source_queue, original_queue, original_exchange, original_routing_key, failure_reason, task_id,
+	content_type, body, headers, death_history, status, replay_count, received_at, last_replayed_at, discarded_at
+`
+
+// PostgresDeadLetterRepository - реализация DeadLetterRepositoryPort для PostgreSQL
+type PostgresDeadLetterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresDeadLetterRepository - конструктор
+func NewPostgresDeadLetterRepository(pool *pgxpool.Pool) (*PostgresDeadLetterRepository, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pgxpool.Pool cannot be nil")
+	}
+	return &PostgresDeadLetterRepository{pool: pool}, nil
+}
+
+// Save сохраняет новое сообщение из DLQ
+func (r *PostgresDeadLetterRepository) Save(ctx context.Context, letter *domain.DeadLetter) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":      "PostgresDeadLetterRepository",
+		"method":         "Save",
+		"dead_letter_id": letter.ID.String(),
+	})
+
+	repoLogger.Debug("Saving dead letter in DB", nil)
+
+	headersJSON, err := json.Marshal(letter.Headers)
+	if err != nil {
+		repoLogger.Error("Failed to marshal headers", err, nil)
+		return fmt.Errorf("failed to marshal dead letter headers: %w", err)
+	}
+	historyJSON, err := json.Marshal(letter.DeathHistory)
+	if err != nil {
+		repoLogger.Error("Failed to marshal death history", err, nil)
+		return fmt.Errorf("failed to marshal dead letter death history: %w", err)
+	}
+
+	query := `
+		INSERT INTO dead_letters (
+			id, source_queue, original_queue, original_exchange, original_routing_key, failure_reason, task_id,
+			content_type, body, headers, death_history, status, replay_count, received_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err = r.pool.Exec(ctx, query,
+		letter.ID,
+		letter.SourceQueue,
+		letter.OriginalQueue,
+		letter.OriginalExchange,
+		letter.OriginalRoutingKey,
+		letter.FailureReason,
+		letter.TaskID,
+		letter.ContentType,
+		letter.Body,
+		headersJSON,
+		historyJSON,
+		letter.Status,
+		letter.ReplayCount,
+		letter.ReceivedAt,
+	)
+	if err != nil {
+		repoLogger.Error("Failed to save dead letter", err, port.Fields{"query": query})
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+
+	repoLogger.Debug("Dead letter saved successfully", nil)
+	return nil
+}
+
+// FindByID находит одно сообщение по ID
+func (r *PostgresDeadLetterRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":      "PostgresDeadLetterRepository",
+		"method":         "FindByID",
+		"dead_letter_id": id.String(),
+	})
+
+	repoLogger.Debug("Finding dead letter by ID.", nil)
+
+	query := `SELECT ` + deadLetterColumns + ` FROM dead_letters WHERE id = $1`
+
+	letter, err := scanDeadLetter(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			repoLogger.Warn("Dead letter not found.", nil)
+			return nil, domain.ErrDeadLetterNotFound
+		}
+		repoLogger.Error("Failed to find dead letter by ID", err, port.Fields{"query": query})
+		return nil, fmt.Errorf("failed to find dead letter by id: %w", err)
+	}
+
+	return letter, nil
+}
+
+// FindAll находит сообщения по фильтру с пагинацией, свежие сверху
+func (r *PostgresDeadLetterRepository) FindAll(ctx context.Context, filter domain.DeadLetterFilter, limit, offset int) ([]domain.DeadLetter, int64, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "PostgresDeadLetterRepository",
+		"method":    "FindAll",
+		"limit":     limit,
+		"offset":    offset,
+	})
+
+	whereClause, args := buildDeadLetterWhere(filter)
+
+	var totalCount int64
+	countQuery := "SELECT COUNT(*) FROM dead_letters " + whereClause
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		repoLogger.Error("Failed to count dead letters", err, port.Fields{"query": countQuery})
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	if totalCount == 0 {
+		return []domain.DeadLetter{}, 0, nil
+	}
+
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM dead_letters
+		%s
+		ORDER BY received_at DESC
+		LIMIT $%d OFFSET $%d
+	`, deadLetterColumns, whereClause, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.pool.Query(ctx, dataQuery, args...)
+	if err != nil {
+		repoLogger.Error("Failed to query dead letters", err, port.Fields{"query": dataQuery})
+		return nil, 0, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := make([]domain.DeadLetter, 0, limit)
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			repoLogger.Error("Failed to scan dead letter row", err, nil)
+			return nil, 0, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		letters = append(letters, *letter)
+	}
+
+	if err := rows.Err(); err != nil {
+		repoLogger.Error("Error during dead letters iteration", err, nil)
+		return nil, 0, fmt.Errorf("error during dead letters iteration: %w", err)
+	}
+
+	return letters, totalCount, nil
+}
+
+// MarkReplayed увеличивает счетчик повторов и переводит сообщение в статус replayed
+func (r *PostgresDeadLetterRepository) MarkReplayed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE dead_letters
+		SET status = $2, replay_count = replay_count + 1, last_replayed_at = NOW()
+		WHERE id = $1
+	`
+	return r.updateStatus(ctx, "MarkReplayed", query, id, domain.DeadLetterStatusReplayed)
+}
+
+// MarkDiscarded переводит сообщение в статус discarded
+func (r *PostgresDeadLetterRepository) MarkDiscarded(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE dead_letters
+		SET status = $2, discarded_at = NOW()
+		WHERE id = $1
+	`
+	return r.updateStatus(ctx, "MarkDiscarded", query, id, domain.DeadLetterStatusDiscarded)
+}
+
+func (r *PostgresDeadLetterRepository) updateStatus(ctx context.Context, method, query string, id uuid.UUID, status domain.DeadLetterStatus) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":      "PostgresDeadLetterRepository",
+		"method":         method,
+		"dead_letter_id": id.String(),
+	})
+
+	cmdTag, err := r.pool.Exec(ctx, query, id, status)
+	if err != nil {
+		repoLogger.Error("Failed to update dead letter status", err, port.Fields{"query": query})
+		return fmt.Errorf("failed to update dead letter status: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		repoLogger.Warn("Update failed: dead letter not found", nil)
+		return domain.ErrDeadLetterNotFound
+	}
+
+	repoLogger.Debug("Dead letter status updated", port.Fields{"status": status})
+	return nil
+}
+
+// buildDeadLetterWhere собирает WHERE по заданным полям фильтра
+func buildDeadLetterWhere(filter domain.DeadLetterFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.SourceQueue != "" {
+		add("source_queue = $%d", filter.SourceQueue)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.TaskID != nil {
+		add("task_id = $%d", *filter.TaskID)
+	}
+	if filter.Reason != "" {
+		add("failure_reason ILIKE $%d", "%"+filter.Reason+"%")
+	}
+	if filter.From != nil {
+		add("received_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("received_at <= $%d", *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanDeadLetter(row pgx.Row) (*domain.DeadLetter, error) {
+	var letter domain.DeadLetter
+	var originalQueue, originalExchange, originalRoutingKey, failureReason, contentType *string
+	var headersJSON, historyJSON []byte
+
+	err := row.Scan(
+		&letter.ID,
+		&letter.SourceQueue,
+		&originalQueue,
+		&originalExchange,
+		&originalRoutingKey,
+		&failureReason,
+		&letter.TaskID,
+		&contentType,
+		&letter.Body,
+		&headersJSON,
+		&historyJSON,
+		&letter.Status,
+		&letter.ReplayCount,
+		&letter.ReceivedAt,
+		&letter.LastReplayedAt,
+		&letter.DiscardedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	letter.OriginalQueue = derefString(originalQueue)
+	letter.OriginalExchange = derefString(originalExchange)
+	letter.OriginalRoutingKey = derefString(originalRoutingKey)
+	letter.FailureReason = derefString(failureReason)
+	letter.ContentType = derefString(contentType)
+
+	if err := json.Unmarshal(headersJSON, &letter.Headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dead letter headers: %w", err)
+	}
+	if err := json.Unmarshal(historyJSON, &letter.DeathHistory); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dead letter death history: %w", err)
+	}
+
+	return &letter, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
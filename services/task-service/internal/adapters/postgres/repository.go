@@ -8,6 +8,7 @@ import (
 	"task-service/internal/contextkeys"
 	"task-service/internal/core/domain" 
 	"task-service/internal/core/port"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -27,6 +28,34 @@ func NewPostgresTaskRepository(pool *pgxpool.Pool) (*PostgresTaskRepository, err
 	return &PostgresTaskRepository{pool: pool}, nil
 }
 
+// taskColumns - колонки задачи в порядке, который ожидает scanTask
+const taskColumns = `id, name, type, status, result_summary, created_at, started_at, finished_at, created_by_user_id,
+	last_progress_at, params, parent_task_id, restart_mode`
+
+// scanTask читает строку с колонками taskColumns
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	var task domain.Task
+	var summaryJSON, paramsJSON []byte
+	var restartMode *string
+	if err := row.Scan(
+		&task.ID, &task.Name, &task.Type, &task.Status, &summaryJSON, &task.CreatedAt,
+		&task.StartedAt, &task.FinishedAt, &task.CreatedByUserID,
+		&task.LastProgressAt, &paramsJSON, &task.ParentTaskID, &restartMode,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(summaryJSON, &task.ResultSummary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result summary: %w", err)
+	}
+	if err := json.Unmarshal(paramsJSON, &task.Params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task params: %w", err)
+	}
+	if restartMode != nil {
+		task.RestartMode = domain.RestartMode(*restartMode)
+	}
+	return &task, nil
+}
+
 // Create создает новую задачу в БД
 func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task) error {
 	logger := contextkeys.LoggerFromContext(ctx)
@@ -40,13 +69,23 @@ func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task)
 	repoLogger.Debug("Creating new task in DB", nil)
 
 	query := `
-		INSERT INTO tasks (id, name, type, status, created_at, created_by_user_id, result_summary)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO tasks (id, name, type, status, created_at, created_by_user_id, result_summary, last_progress_at,
+			params, parent_task_id, restart_mode)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))
 	`
 	// Инициализируем result_summary пустым JSON-объектом '{}'
 	summaryJSON, _ := json.Marshal(task.ResultSummary)
+	params := task.Params
+	if params == nil {
+		params = domain.TaskParams{}
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		repoLogger.Error("Failed to marshal task params", err, nil)
+		return fmt.Errorf("failed to marshal task params: %w", err)
+	}
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err = r.pool.Exec(ctx, query,
 		task.ID,
 		task.Name,
 		task.Type,
@@ -54,6 +93,10 @@ func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task)
 		task.CreatedAt,
 		task.CreatedByUserID,
 		summaryJSON,
+		task.LastProgressAt,
+		paramsJSON,
+		task.ParentTaskID,
+		string(task.RestartMode),
 	)
 	if err != nil {
 		repoLogger.Error("Failed to create task", err, port.Fields{"query": query})
@@ -88,25 +131,37 @@ func (r *PostgresTaskRepository) Update(ctx context.Context, task *domain.Task)
 			type = $3,
 			status = $4,
 			started_at = $5,
-			finished_at = $6
-		WHERE id = $1
+			finished_at = $6,
+			last_progress_at = NOW()
+		WHERE id = $1 AND status NOT IN ('completed', 'failed', 'timed_out')
+		RETURNING last_progress_at
 	`
-	cmdTag, err := r.pool.Exec(ctx, query,
+	// Смена статуса - тоже прогресс: сторож отсчитывает бездействие от нее.
+	// Условие на статус не дает позднему событию вернуть к жизни уже завершенную (или остановленную сторожем) задачу
+	err := r.pool.QueryRow(ctx, query,
 		task.ID,
 		task.Name,
 		task.Type,
 		task.Status,
 		task.StartedAt,
 		task.FinishedAt,
-	)
+	).Scan(&task.LastProgressAt)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			var exists bool
+			if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)`, task.ID).Scan(&exists); err != nil {
+				return fmt.Errorf("failed to check task existence: %w", err)
+			}
+			if exists {
+				repoLogger.Warn("Update skipped: task is already finished", nil)
+				return domain.ErrTaskFinished
+			}
+			repoLogger.Warn("Update failed: task not found", nil)
+			return domain.ErrTaskNotFound
+		}
 		repoLogger.Error("Failed to update task", err, port.Fields{"query": query})
 		return fmt.Errorf("failed to update task: %w", err)
 	}
-	if cmdTag.RowsAffected() == 0 {
-		repoLogger.Warn("Update failed: task not found", nil)
-		return domain.ErrTaskNotFound
-	}
 
 	repoLogger.Debug("Task updated successfully", nil)
 	return nil
@@ -123,25 +178,9 @@ func (r *PostgresTaskRepository) FindByID(ctx context.Context, taskID uuid.UUID)
 
 	repoLogger.Debug("Finding task by ID.", nil)
 
-	query := `
-		SELECT id, name, type, status, result_summary, created_at, started_at, finished_at, created_by_user_id
-		FROM tasks
-		WHERE id = $1
-	`
-	var task domain.Task
-	var summaryJSON []byte
-
-	err := r.pool.QueryRow(ctx, query, taskID).Scan(
-		&task.ID,
-		&task.Name,
-		&task.Type,
-		&task.Status,
-		&summaryJSON,
-		&task.CreatedAt,
-		&task.StartedAt,
-		&task.FinishedAt,
-		&task.CreatedByUserID,
-	)
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1`
+
+	task, err := scanTask(r.pool.QueryRow(ctx, query, taskID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			repoLogger.Warn("Task not found.", nil)
@@ -151,13 +190,8 @@ func (r *PostgresTaskRepository) FindByID(ctx context.Context, taskID uuid.UUID)
 		return nil, fmt.Errorf("failed to find task by id: %w", err)
 	}
 
-	if err := json.Unmarshal(summaryJSON, &task.ResultSummary); err != nil {
-		repoLogger.Error("Failed to unmarshal result summary from DB", err, nil)
-		return nil, fmt.Errorf("failed to unmarshal result summary: %w", err)
-	}
-
 	repoLogger.Debug("Task found successfully.", nil)
-	return &task, nil
+	return task, nil
 }
 
 
@@ -194,7 +228,7 @@ func (r *PostgresTaskRepository) FindAll(ctx context.Context, createdByUserID uu
 
     // Запрос на получение данных
     dataQuery := `
-        SELECT id, name, type, status, result_summary, created_at, started_at, finished_at, created_by_user_id
+        SELECT ` + taskColumns + `
         FROM tasks
         WHERE created_by_user_id = $1
         ORDER BY created_at DESC
@@ -209,20 +243,12 @@ func (r *PostgresTaskRepository) FindAll(ctx context.Context, createdByUserID uu
 
     tasks := make([]domain.Task, 0, limit)
     for rows.Next() {
-        var task domain.Task
-        var summaryJSON []byte
-        if err := rows.Scan(
-            &task.ID, &task.Name, &task.Type, &task.Status, &summaryJSON, &task.CreatedAt,
-            &task.StartedAt, &task.FinishedAt, &task.CreatedByUserID,
-        ); err != nil {
+        task, err := scanTask(rows)
+        if err != nil {
 			repoLogger.Error("Failed to scan task row", err, nil)
             return nil, 0, fmt.Errorf("failed to scan task: %w", err)
         }
-        if err := json.Unmarshal(summaryJSON, &task.ResultSummary); err != nil {
-			repoLogger.Error("Failed to unmarshal task summary", err, nil)
-            return nil, 0, fmt.Errorf("failed to unmarshal task summary: %w", err)
-        }
-        tasks = append(tasks, task)
+        tasks = append(tasks, *task)
     }
     
     if err := rows.Err(); err != nil {
@@ -285,18 +311,13 @@ func (r *PostgresTaskRepository) IncrementSummary(ctx context.Context, taskID uu
         )
         -- Обновляем и возвращаем
         UPDATE tasks
-        SET result_summary = (SELECT final_summary FROM new_summary)
+        SET result_summary = (SELECT final_summary FROM new_summary),
+            last_progress_at = NOW()
         WHERE id = $1
-        RETURNING id, name, type, status, result_summary, created_at, started_at, finished_at, created_by_user_id;
+        RETURNING ` + taskColumns + `;
     `
-    
-    var task domain.Task
-	var summaryJSON []byte
 
-	err = r.pool.QueryRow(ctx, query, taskID, resultsJSON).Scan(
-		&task.ID, &task.Name, &task.Type, &task.Status, &summaryJSON,
-		&task.CreatedAt, &task.StartedAt, &task.FinishedAt, &task.CreatedByUserID,
-	)
+	task, err := scanTask(r.pool.QueryRow(ctx, query, taskID, resultsJSON))
     if err != nil {
         if errors.Is(err, pgx.ErrNoRows) {
 			repoLogger.Warn("Task not found.", nil)
@@ -305,17 +326,96 @@ func (r *PostgresTaskRepository) IncrementSummary(ctx context.Context, taskID uu
 		repoLogger.Error("failed to increment summary and return task", err, nil)
         return nil, fmt.Errorf("failed to increment summary and return task: %w", err)
     }
-    
-    if err := json.Unmarshal(summaryJSON, &task.ResultSummary); err != nil {
-		repoLogger.Error("failed to unmarshal updated result summary", err, nil)
-        return nil, fmt.Errorf("failed to unmarshal updated result summary: %w", err)
-    }
 
-	return &task, nil
+	return task, nil
 }
 
 
 
+// FindStale возвращает незавершенные задачи, не продвигавшиеся дольше окна своего типа.
+// Окна передаются массивами, поэтому в выборку не попадают задачи, которым еще рано по таймауту
+func (r *PostgresTaskRepository) FindStale(ctx context.Context, timeouts domain.TaskTimeouts, now time.Time, limit int) ([]domain.Task, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "PostgresTaskRepository",
+		"method":    "FindStale",
+		"now":       now,
+	})
+
+	types := make([]string, 0, len(timeouts.ByType))
+	windows := make([]float64, 0, len(timeouts.ByType))
+	for taskType, window := range timeouts.ByType {
+		types = append(types, taskType)
+		windows = append(windows, window.Seconds())
+	}
+
+	query := `
+		WITH windows (window_type, window_seconds) AS (
+			SELECT * FROM unnest($1::text[], $2::float8[])
+		)
+		SELECT ` + taskColumns + `
+		FROM tasks
+		LEFT JOIN windows ON window_type = type
+		WHERE status IN ('pending', 'running')
+		  AND COALESCE(window_seconds, $3::float8) > 0
+		  AND last_progress_at < $4::timestamptz - make_interval(secs => COALESCE(window_seconds, $3::float8))
+		ORDER BY last_progress_at ASC
+		LIMIT $5
+	`
+	rows, err := r.pool.Query(ctx, query, types, windows, timeouts.Default.Seconds(), now, limit)
+	if err != nil {
+		repoLogger.Error("Failed to query stale tasks", err, port.Fields{"query": query})
+		return nil, fmt.Errorf("failed to query stale tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stale task: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during stale tasks iteration: %w", err)
+	}
+	return tasks, nil
+}
+
+// MarkTimedOut останавливает задачу, если она не продвинулась с момента before.
+// Условие проверяется в том же UPDATE, поэтому результат, пришедший одновременно со сторожем, не теряется
+func (r *PostgresTaskRepository) MarkTimedOut(ctx context.Context, taskID uuid.UUID, before time.Time, reason domain.ResultSummary) (*domain.Task, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "PostgresTaskRepository",
+		"method":    "MarkTimedOut",
+		"task_id":   taskID.String(),
+	})
+
+	reasonJSON, err := json.Marshal(reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal timeout reason: %w", err)
+	}
+
+	query := `
+		UPDATE tasks
+		SET status = $2,
+			finished_at = NOW(),
+			result_summary = COALESCE(result_summary, '{}'::jsonb) || $3::jsonb
+		WHERE id = $1 AND status IN ('pending', 'running') AND last_progress_at < $4
+		RETURNING ` + taskColumns
+	task, err := scanTask(r.pool.QueryRow(ctx, query, taskID, domain.StatusTimedOut, reasonJSON, before))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTaskNotStale
+		}
+		repoLogger.Error("Failed to mark task as timed out", err, nil)
+		return nil, fmt.Errorf("failed to mark task as timed out: %w", err)
+	}
+	return task, nil
+}
+
 
 
 // IncrementSummary атомарно обновляет числовые значения в JSONB поле result_summary.
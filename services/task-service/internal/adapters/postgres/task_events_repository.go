@@ -0,0 +1,104 @@
+package postgres_adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AppendEvent сохраняет событие в журнал. ID выдает последовательность, поэтому он общий для всех реплик.
+// Вставки сериализуются advisory-блокировкой до коммита: иначе меньший ID мог бы закоммититься
+// после большего, уже отправленного клиенту, и повторная досылка по Last-Event-ID его бы пропустила
+func (r *PostgresTaskRepository) AppendEvent(ctx context.Context, event domain.TaskEventRecord) (domain.TaskEventRecord, error) {
+	repoLogger := contextkeys.LoggerFromContext(ctx).WithFields(port.Fields{
+		"component":  "PostgresTaskRepository",
+		"method":     "AppendEvent",
+		"event_type": event.Type,
+	})
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		repoLogger.Error("Failed to begin transaction", err, nil)
+		return event, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('task_events'))`); err != nil {
+		repoLogger.Error("Failed to lock task events log", err, nil)
+		return event, fmt.Errorf("failed to lock task events log: %w", err)
+	}
+
+	query := `
+		INSERT INTO task_events (user_id, event_type, payload)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, query, event.UserID, event.Type, []byte(event.Payload)).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		repoLogger.Error("Failed to append task event", err, nil)
+		return event, fmt.Errorf("failed to append task event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		repoLogger.Error("Failed to commit task event", err, nil)
+		return event, fmt.Errorf("failed to commit task event: %w", err)
+	}
+	return event, nil
+}
+
+func (r *PostgresTaskRepository) FindEventsAfter(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.TaskEventRecord, error) {
+	query := `
+		SELECT id, user_id, event_type, payload, created_at
+		FROM task_events
+		WHERE user_id = $1 AND id > $2
+		ORDER BY id ASC
+		LIMIT $3
+	`
+	rows, err := r.pool.Query(ctx, query, userID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.TaskEventRecord
+	for rows.Next() {
+		var e domain.TaskEventRecord
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Type, &payload, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task event: %w", err)
+		}
+		e.Payload = payload
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during task events iteration: %w", err)
+	}
+	return events, nil
+}
+
+func (r *PostgresTaskRepository) OldestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, `SELECT id FROM task_events ORDER BY id ASC LIMIT 1`).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query oldest task event: %w", err)
+	}
+	return id, nil
+}
+
+func (r *PostgresTaskRepository) DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM task_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old task events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,107 @@
+package postgres_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// RegisterItems сохраняет единицы работы одной командой: ключи и payload передаются массивами
+func (r *PostgresTaskRepository) RegisterItems(ctx context.Context, taskID uuid.UUID, items []domain.TaskItem) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":  "PostgresTaskRepository",
+		"method":     "RegisterItems",
+		"task_id":    taskID.String(),
+		"item_count": len(items),
+	})
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(items))
+	payloads := make([]string, len(items))
+	for i, item := range items {
+		payload := item.Payload
+		if payload == nil {
+			payload = map[string]interface{}{}
+		}
+		payloadJSON, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload of item %s: %w", item.Key, err)
+		}
+		keys[i] = item.Key
+		payloads[i] = string(payloadJSON)
+	}
+
+	query := `
+		INSERT INTO task_items (task_id, item_key, payload)
+		SELECT $1, item.key, item.payload::jsonb
+		FROM unnest($2::text[], $3::text[]) AS item(key, payload)
+		ON CONFLICT (task_id, item_key) DO NOTHING
+	`
+	if _, err := r.pool.Exec(ctx, query, taskID, keys, payloads); err != nil {
+		repoLogger.Error("Failed to register task items", err, nil)
+		return fmt.Errorf("failed to register task items: %w", err)
+	}
+
+	repoLogger.Debug("Task items registered", nil)
+	return nil
+}
+
+func (r *PostgresTaskRepository) MarkItemsReported(ctx context.Context, taskID uuid.UUID, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE task_items
+		SET reported_at = NOW()
+		WHERE task_id = $1 AND item_key = ANY($2) AND reported_at IS NULL
+	`
+	if _, err := r.pool.Exec(ctx, query, taskID, keys); err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to mark task items as reported", err, port.Fields{
+			"component": "PostgresTaskRepository",
+			"task_id":   taskID.String(),
+		})
+		return fmt.Errorf("failed to mark task items as reported: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresTaskRepository) FindUnreportedItems(ctx context.Context, taskID uuid.UUID) ([]domain.TaskItem, error) {
+	query := `
+		SELECT item_key, payload
+		FROM task_items
+		WHERE task_id = $1 AND reported_at IS NULL
+		ORDER BY dispatched_at ASC, item_key ASC
+	`
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreported task items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []domain.TaskItem
+	for rows.Next() {
+		var item domain.TaskItem
+		var payloadJSON []byte
+		if err := rows.Scan(&item.Key, &payloadJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan task item: %w", err)
+		}
+		if err := json.Unmarshal(payloadJSON, &item.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload of item %s: %w", item.Key, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during task items iteration: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,211 @@
+package postgres_adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+)
+
+// taskSortExpressions - SQL-выражения для полей сортировки. Значения не приходят от клиента напрямую
+var taskSortExpressions = map[domain.TaskSortField]string{
+	domain.TaskSortCreatedAt:  "created_at",
+	domain.TaskSortFinishedAt: "finished_at",
+	domain.TaskSortDuration:   "(finished_at - COALESCE(started_at, created_at))",
+	domain.TaskSortType:       "type",
+	domain.TaskSortStatus:     "status",
+}
+
+// Search ищет задачи всех пользователей по фильтру
+func (r *PostgresTaskRepository) Search(ctx context.Context, filter domain.TaskFilter, sort domain.TaskSort, limit, offset int) ([]domain.Task, int64, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component": "PostgresTaskRepository",
+		"method":    "Search",
+		"limit":     limit,
+		"offset":    offset,
+	})
+
+	whereClause, args := buildTaskWhere(filter, true)
+
+	var totalCount int64
+	countQuery := "SELECT COUNT(*) FROM tasks " + whereClause
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		repoLogger.Error("Failed to count tasks", err, port.Fields{"query": countQuery})
+		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	if totalCount == 0 {
+		return []domain.Task{}, 0, nil
+	}
+
+	orderBy, ok := taskSortExpressions[sort.Field]
+	if !ok {
+		orderBy = taskSortExpressions[domain.TaskSortCreatedAt]
+	}
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks
+		%s
+		ORDER BY %s %s NULLS LAST, created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, taskColumns, whereClause, orderBy, direction, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.pool.Query(ctx, dataQuery, args...)
+	if err != nil {
+		repoLogger.Error("Failed to query tasks", err, port.Fields{"query": dataQuery})
+		return nil, 0, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.Task, 0, limit)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			repoLogger.Error("Failed to scan task row", err, nil)
+			return nil, 0, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	if err := rows.Err(); err != nil {
+		repoLogger.Error("Error during tasks iteration", err, nil)
+		return nil, 0, fmt.Errorf("error during tasks iteration: %w", err)
+	}
+
+	return tasks, totalCount, nil
+}
+
+// Stats считает сводку по типам задач одним запросом
+func (r *PostgresTaskRepository) Stats(ctx context.Context, filter domain.TaskFilter) ([]domain.TaskTypeStats, error) {
+	whereClause, args := buildTaskWhere(filter, false)
+
+	query := `
+		SELECT
+			type,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'completed'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			COUNT(*) FILTER (WHERE status = 'timed_out'),
+			COUNT(*) FILTER (WHERE status IN ('pending', 'running')),
+			COALESCE(AVG(EXTRACT(EPOCH FROM finished_at - COALESCE(started_at, created_at)))
+				FILTER (WHERE status = 'completed' AND finished_at IS NOT NULL), 0)
+		FROM tasks
+		` + whereClause + `
+		GROUP BY type
+		ORDER BY type
+	`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to query task stats", err, port.Fields{
+			"component": "PostgresTaskRepository",
+			"method":    "Stats",
+		})
+		return nil, fmt.Errorf("failed to query task stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []domain.TaskTypeStats{}
+	for rows.Next() {
+		var s domain.TaskTypeStats
+		if err := rows.Scan(&s.Type, &s.Total, &s.Completed, &s.Failed, &s.TimedOut, &s.Active, &s.AvgDurationSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan task stats: %w", err)
+		}
+		if finished := s.Completed + s.Failed + s.TimedOut; finished > 0 {
+			s.SuccessRate = float64(s.Completed) / float64(finished)
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during task stats iteration: %w", err)
+	}
+	return stats, nil
+}
+
+// RemoveFinishedBefore убирает пачку старых завершенных задач одной командой. Единицы работы и подзадачи
+// удаляются каскадно, у повторов ссылка на исходную задачу обнуляется
+func (r *PostgresTaskRepository) RemoveFinishedBefore(ctx context.Context, before time.Time, limit int, mode domain.RetentionMode) (int, error) {
+	finalStatuses := []string{string(domain.StatusCompleted), string(domain.StatusFailed), string(domain.StatusTimedOut)}
+
+	archiveStep := ""
+	if mode == domain.RetentionArchive {
+		archiveStep = `,
+		archived AS (
+			INSERT INTO tasks_archive (id, type, status, created_by_user_id, created_at, finished_at, data)
+			SELECT t.id, t.type, t.status, t.created_by_user_id, t.created_at, t.finished_at,
+				jsonb_build_object(
+					'task', to_jsonb(t),
+					'subtasks', COALESCE((SELECT jsonb_agg(to_jsonb(s) - 'task_id') FROM task_subtasks s WHERE s.task_id = t.id), '[]'::jsonb)
+				)
+			FROM tasks t
+			JOIN doomed d ON d.id = t.id
+			ON CONFLICT (id) DO NOTHING
+		)`
+	}
+
+	query := `
+		WITH doomed AS (
+			SELECT id
+			FROM tasks
+			WHERE status = ANY($3) AND finished_at < $1
+			ORDER BY finished_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)` + archiveStep + `
+		DELETE FROM tasks t
+		USING doomed d
+		WHERE t.id = d.id
+	`
+	tag, err := r.pool.Exec(ctx, query, before, limit, finalStatuses)
+	if err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Failed to remove finished tasks", err, port.Fields{
+			"component": "PostgresTaskRepository",
+			"method":    "RemoveFinishedBefore",
+			"mode":      mode,
+		})
+		return 0, fmt.Errorf("failed to remove finished tasks: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func buildTaskWhere(filter domain.TaskFilter, withStatus bool) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Type != "" {
+		add("type = $%d", filter.Type)
+	}
+	if withStatus && filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.CreatedByUserID != nil {
+		add("created_by_user_id = $%d", *filter.CreatedByUserID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at <= $%d", *filter.To)
+	}
+	if filter.TargetObjectID != "" {
+		add("params->>'object_id' = $%d", filter.TargetObjectID)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,91 @@
+package postgres_adapter
+
+import (
+	"context"
+	"fmt"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const subtaskColumns = `source, name, links_found, processed, errors, duration_ms, COALESCE(last_error, ''), updated_at`
+
+// AddSubtaskProgress прибавляет счетчики одной командой: поля отчета передаются массивами
+func (r *PostgresTaskRepository) AddSubtaskProgress(ctx context.Context, taskID uuid.UUID, progress []domain.TaskSubtask) ([]domain.TaskSubtask, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	repoLogger := logger.WithFields(port.Fields{
+		"component":     "PostgresTaskRepository",
+		"method":        "AddSubtaskProgress",
+		"task_id":       taskID.String(),
+		"subtask_count": len(progress),
+	})
+
+	if len(progress) == 0 {
+		return nil, nil
+	}
+
+	n := len(progress)
+	sources, names, lastErrors := make([]string, n), make([]string, n), make([]string, n)
+	linksFound, processed, errorCounts := make([]int32, n), make([]int32, n), make([]int32, n)
+	durations := make([]int64, n)
+	for i, p := range progress {
+		sources[i] = p.Source
+		names[i] = p.Name
+		linksFound[i] = int32(p.LinksFound)
+		processed[i] = int32(p.Processed)
+		errorCounts[i] = int32(p.Errors)
+		durations[i] = p.DurationMs
+		lastErrors[i] = p.LastError
+	}
+
+	// Последняя ошибка перезаписывается только непустой: успешный отчет не стирает причину прошлого сбоя
+	query := `
+		INSERT INTO task_subtasks (task_id, source, name, links_found, processed, errors, duration_ms, last_error)
+		SELECT $1, p.source, p.name, p.links_found, p.processed, p.errors, p.duration_ms, NULLIF(p.last_error, '')
+		FROM unnest($2::text[], $3::text[], $4::int[], $5::int[], $6::int[], $7::bigint[], $8::text[])
+			AS p(source, name, links_found, processed, errors, duration_ms, last_error)
+		ON CONFLICT (task_id, source, name) DO UPDATE SET
+			links_found = task_subtasks.links_found + EXCLUDED.links_found,
+			processed = task_subtasks.processed + EXCLUDED.processed,
+			errors = task_subtasks.errors + EXCLUDED.errors,
+			duration_ms = task_subtasks.duration_ms + EXCLUDED.duration_ms,
+			last_error = COALESCE(EXCLUDED.last_error, task_subtasks.last_error),
+			updated_at = NOW()
+		RETURNING ` + subtaskColumns
+
+	rows, err := r.pool.Query(ctx, query, taskID, sources, names, linksFound, processed, errorCounts, durations, lastErrors)
+	if err != nil {
+		repoLogger.Error("Failed to add subtask progress", err, nil)
+		return nil, fmt.Errorf("failed to add subtask progress: %w", err)
+	}
+	return collectSubtasks(rows)
+}
+
+func (r *PostgresTaskRepository) FindSubtasks(ctx context.Context, taskID uuid.UUID) ([]domain.TaskSubtask, error) {
+	query := `SELECT ` + subtaskColumns + ` FROM task_subtasks WHERE task_id = $1 ORDER BY source ASC, name ASC`
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task subtasks: %w", err)
+	}
+	return collectSubtasks(rows)
+}
+
+func collectSubtasks(rows pgx.Rows) ([]domain.TaskSubtask, error) {
+	defer rows.Close()
+
+	var subtasks []domain.TaskSubtask
+	for rows.Next() {
+		var s domain.TaskSubtask
+		if err := rows.Scan(&s.Source, &s.Name, &s.LinksFound, &s.Processed, &s.Errors, &s.DurationMs, &s.LastError, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task subtask: %w", err)
+		}
+		subtasks = append(subtasks, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during task subtasks iteration: %w", err)
+	}
+	return subtasks, nil
+}
@@ -3,35 +3,39 @@ package rabbitmq_adapter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
 	"task-service/internal/contextkeys"
 	"task-service/internal/core/domain"
 	"task-service/internal/core/port"
 	"task-service/internal/core/port/usecases_port"
+	"time"
 
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type genericMessage struct {
-	TaskID  uuid.UUID      `json:"task_id"`
+	TaskID uuid.UUID `json:"task_id"`
 }
 
 type DLQConsumerAdapter struct {
-	consumer rabbitmq_consumer.Consumer 
-	useCase  usecases_port.UpdateTaskStatusUseCasePort
-	logger   port.LoggerPort
+	consumer  rabbitmq_consumer.Consumer
+	queueName string
+	captureUC usecases_port.CaptureDeadLetterUseCasePort
+	useCase   usecases_port.UpdateTaskStatusUseCasePort
+	logger    port.LoggerPort
 }
 
-
 func NewDLQConsumerAdapter(
 	cfg rabbitmq_consumer.ConsumerConfig,
-	useCase  usecases_port.UpdateTaskStatusUseCasePort,
+	captureUC usecases_port.CaptureDeadLetterUseCasePort,
+	useCase usecases_port.UpdateTaskStatusUseCasePort,
 	logger port.LoggerPort,
 	connManager *rabbitmq_common.ConnectionManager,
 ) (*DLQConsumerAdapter, error) {
-	adapter := &DLQConsumerAdapter{useCase: useCase, logger: logger}
+	adapter := &DLQConsumerAdapter{queueName: cfg.QueueName, captureUC: captureUC, useCase: useCase, logger: logger}
 
 	// Создаем логгер для pkg-уровня с контекстом нашего компонента
 	pkgLogger := logger.WithFields(port.Fields{"component": "rabbitmq_distributing_consumer", "consumer_tag": cfg.ConsumerTag})
@@ -45,8 +49,8 @@ func NewDLQConsumerAdapter(
 	return adapter, nil
 }
 
-func (a *DLQConsumerAdapter) messageHandler(d amqp.Delivery) error {
-	
+func (a *DLQConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) error {
+
 	traceID, ok := d.Headers["x-trace-id"].(string)
 	if !ok || traceID == "" {
 		traceID = uuid.New().String()
@@ -56,57 +60,150 @@ func (a *DLQConsumerAdapter) messageHandler(d amqp.Delivery) error {
 	msgLogger := a.logger.WithFields(port.Fields{
 		"trace_id":     traceID,
 		"delivery_tag": d.DeliveryTag,
-		"queue":        d.RoutingKey, 
+		"queue":        a.queueName,
 		"exchange":     d.Exchange,
 	})
 
-	var deathInfo interface{}
-	if d.Headers != nil {
-		if di, ok := d.Headers["x-death"]; ok {
-			deathInfo = di
-		}
-	}
+	letter := toDeadLetter(a.queueName, d)
 
 	msgLogger.Error(
-		"Processing dead letter from DLQ", 
-		nil, 
+		"Processing dead letter from DLQ",
+		nil,
 		port.Fields{
-			"body_as_string": string(d.Body), // Тело сообщения как строка
-			"headers":        d.Headers,      // Все заголовки
-			"x_death_info":   deathInfo,      // Информация о причине смерти
+			"dead_letter_id":       letter.ID.String(),
+			"failure_reason":       letter.FailureReason,
+			"original_exchange":    letter.OriginalExchange,
+			"original_routing_key": letter.OriginalRoutingKey,
 		},
 	)
 
 	// логер и trace_id в контекст
-	ctx := context.Background()
 	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
+	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
 
 	var msg genericMessage
 	if err := json.Unmarshal(d.Body, &msg); err != nil {
-		msgLogger.Error("Failed to unmarshal genericMessage, rejecting message.", err, nil)
-		return nil 
+		// Тело не разобрали, но сообщение все равно сохраняем, чтобы его можно было изучить
+		msgLogger.Warn("Failed to unmarshal genericMessage, saving dead letter without task_id", port.Fields{"error": err.Error()})
+	} else if msg.TaskID != uuid.Nil {
+		letter.TaskID = &msg.TaskID
+	}
+
+	if err := a.captureUC.Execute(ctx, letter); err != nil {
+		msgLogger.Error("Failed to save dead letter, message will be nacked.", err, nil)
+		return err
+	}
+
+	if letter.TaskID == nil {
+		return nil
 	}
 
 	handlerLogger := msgLogger.WithFields(port.Fields{
-		"task_id": msg.TaskID.String(),
+		"task_id": letter.TaskID.String(),
 	})
 	// Обновляем контекст с более детальным логгером
 	ctx = contextkeys.ContextWithLogger(ctx, handlerLogger)
 
 	handlerLogger.Debug("Processing failed task", nil)
 
-	if _, err := a.useCase.Execute(ctx, msg.TaskID, domain.StatusFailed); err != nil {
-		handlerLogger.Error("Failed to process failed task, message will be nacked for retry.", err, nil)
-		return err // Возвращаем ошибку, чтобы RabbitMQ попробовал снова
+	if _, err := a.useCase.Execute(ctx, *letter.TaskID, domain.StatusFailed); err != nil {
+		if errors.Is(err, domain.ErrTaskFinished) {
+			handlerLogger.Debug("Task is already finished, leaving its status as is", nil)
+			return nil
+		}
+		// Само сообщение уже сохранено, повтор привел бы к дублю в dead_letters
+		handlerLogger.Error("Failed to mark task as failed", err, nil)
+		return nil
 	}
 
 	handlerLogger.Debug("Successfully processed failed result.", nil)
 	return nil
 }
 
+// toDeadLetter переводит доставку из финальной DLQ в доменную модель
+func toDeadLetter(queueName string, d amqp.Delivery) *domain.DeadLetter {
+	letter := &domain.DeadLetter{
+		ID:          uuid.New(),
+		SourceQueue: queueName,
+		ContentType: d.ContentType,
+		Body:        d.Body,
+		Headers:     make(map[string]interface{}, len(d.Headers)),
+		Status:      domain.DeadLetterStatusNew,
+		ReceivedAt:  time.Now().UTC(),
+	}
+	for k, v := range d.Headers {
+		letter.Headers[k] = v
+	}
+
+	letter.FailureReason, _ = d.Headers[rabbitmq_consumer.HeaderFailureReason].(string)
+	letter.OriginalExchange, _ = d.Headers[rabbitmq_consumer.HeaderOriginalExchange].(string)
+	letter.OriginalRoutingKey, _ = d.Headers[rabbitmq_consumer.HeaderOriginalRoutingKey].(string)
+	letter.OriginalQueue, _ = d.Headers[rabbitmq_consumer.HeaderOriginalQueue].(string)
+
+	letter.DeathHistory = parseXDeath(d.Headers)
+
+	// Сообщения, опубликованные до появления заголовков x-original-*, восстанавливаем по x-death
+	if len(letter.DeathHistory) > 0 {
+		// Нас интересует запись основной очереди (reason=rejected), а не wait-очереди ретраев (reason=expired)
+		origin := letter.DeathHistory[len(letter.DeathHistory)-1]
+		for _, record := range letter.DeathHistory {
+			if record.Reason == "rejected" {
+				origin = record
+				break
+			}
+		}
+		if letter.OriginalQueue == "" {
+			letter.OriginalQueue = origin.Queue
+		}
+		if letter.OriginalExchange == "" && letter.OriginalRoutingKey == "" {
+			letter.OriginalExchange = origin.Exchange
+			if len(origin.RoutingKeys) > 0 {
+				letter.OriginalRoutingKey = origin.RoutingKeys[0]
+			}
+		}
+		if letter.FailureReason == "" {
+			letter.FailureReason = origin.Reason
+		}
+	}
+
+	return letter
+}
+
+// parseXDeath разбирает заголовок x-death. Самая свежая запись идет первой
+func parseXDeath(headers amqp.Table) []domain.DeathRecord {
+	deaths, ok := headers["x-death"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	records := make([]domain.DeathRecord, 0, len(deaths))
+	for _, death := range deaths {
+		tbl, ok := death.(amqp.Table)
+		if !ok {
+			continue
+		}
+		var record domain.DeathRecord
+		record.Queue, _ = tbl["queue"].(string)
+		record.Reason, _ = tbl["reason"].(string)
+		record.Exchange, _ = tbl["exchange"].(string)
+		record.Count, _ = tbl["count"].(int64)
+		if t, ok := tbl["time"].(time.Time); ok {
+			record.Time = &t
+		}
+		if keys, ok := tbl["routing-keys"].([]interface{}); ok {
+			for _, k := range keys {
+				if s, ok := k.(string); ok {
+					record.RoutingKeys = append(record.RoutingKeys, s)
+				}
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
 
 func (a *DLQConsumerAdapter) Start(ctx context.Context) error {
 	return a.consumer.StartConsuming(ctx)
 }
 
-func (a *DLQConsumerAdapter) Close() error { return a.consumer.Close() }
\ No newline at end of file
+func (a *DLQConsumerAdapter) Close() error { return a.consumer.Close() }
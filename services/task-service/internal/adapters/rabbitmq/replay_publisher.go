@@ -0,0 +1,136 @@
+package rabbitmq_adapter
+
+import (
+	"context"
+	"fmt"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"sync"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// HeaderReplayedFrom - заголовок с ID сохраненного сообщения, из которого сделан повтор
+const HeaderReplayedFrom = "x-replayed-from"
+
+// RabbitMQReplayAdapter реализует MessageReplayerPort.
+// Издатели создаются лениво, по одному на каждый исходный обменник
+type RabbitMQReplayAdapter struct {
+	url         string
+	connManager *rabbitmq_common.ConnectionManager
+	logger      port.LoggerPort
+
+	mu         sync.Mutex
+	publishers map[string]*rabbitmq_producer.Publisher
+}
+
+// NewRabbitMQReplayAdapter - конструктор
+func NewRabbitMQReplayAdapter(url string, connManager *rabbitmq_common.ConnectionManager, logger port.LoggerPort) (*RabbitMQReplayAdapter, error) {
+	if connManager == nil {
+		return nil, fmt.Errorf("rabbitmq adapter: connection manager cannot be nil")
+	}
+	return &RabbitMQReplayAdapter{
+		url:         url,
+		connManager: connManager,
+		logger:      logger,
+		publishers:  make(map[string]*rabbitmq_producer.Publisher),
+	}, nil
+}
+
+// Replay публикует тело сообщения в исходный обменник с исходным ключом маршрутизации.
+// x-death и служебные заголовки DLQ не переносятся, поэтому счетчик ретраев начинается заново
+func (a *RabbitMQReplayAdapter) Replay(ctx context.Context, letter *domain.DeadLetter) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	adapterLogger := logger.WithFields(port.Fields{
+		"component":      "RabbitMQReplayAdapter",
+		"dead_letter_id": letter.ID.String(),
+		"exchange":       letter.OriginalExchange,
+		"routing_key":    letter.OriginalRoutingKey,
+	})
+
+	publisher, err := a.publisherFor(letter.OriginalExchange)
+	if err != nil {
+		adapterLogger.Error("Failed to get publisher for exchange", err, nil)
+		return err
+	}
+
+	headers := amqp.Table{}
+	for k, v := range letter.Headers {
+		// Восстанавливаем только строковые прикладные заголовки (trace id, тип и версия события и т.п.)
+		s, ok := v.(string)
+		if !ok || isDeadLetterHeader(k) {
+			continue
+		}
+		headers[k] = s
+	}
+	headers[HeaderReplayedFrom] = letter.ID.String()
+
+	err = publisher.Publish(ctx, letter.OriginalRoutingKey, amqp.Publishing{
+		ContentType:  letter.ContentType,
+		Body:         letter.Body,
+		Headers:      headers,
+		Timestamp:    time.Now(),
+		DeliveryMode: amqp.Persistent,
+	})
+	if err != nil {
+		adapterLogger.Error("Failed to republish dead letter", err, nil)
+		return fmt.Errorf("failed to republish dead letter %s: %w", letter.ID, err)
+	}
+
+	adapterLogger.Debug("Dead letter republished", nil)
+	return nil
+}
+
+func (a *RabbitMQReplayAdapter) publisherFor(exchange string) (*rabbitmq_producer.Publisher, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if p, ok := a.publishers[exchange]; ok {
+		return p, nil
+	}
+
+	pkgLogger := a.logger.WithFields(port.Fields{"component": "rabbitmq_replay_publisher", "exchange": exchange})
+	p, err := rabbitmq_producer.NewPublisher(rabbitmq_producer.PublisherConfig{
+		Config:                   rabbitmq_common.Config{URL: a.url},
+		ExchangeName:             exchange,
+		DeclareExchangeIfMissing: false, // Обменник уже объявлен сервисом-владельцем
+		Logger:                   NewPkgLoggerBridge(pkgLogger),
+	}, a.connManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay publisher for exchange '%s': %w", exchange, err)
+	}
+	a.publishers[exchange] = p
+	return p, nil
+}
+
+// Close закрывает всех созданных издателей
+func (a *RabbitMQReplayAdapter) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var firstErr error
+	for exchange, p := range a.publishers {
+		if err := p.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(a.publishers, exchange)
+	}
+	return firstErr
+}
+
+func isDeadLetterHeader(key string) bool {
+	switch key {
+	case "x-death", "x-first-death-exchange", "x-first-death-queue", "x-first-death-reason",
+		"x-last-death-exchange", "x-last-death-queue", "x-last-death-reason",
+		rabbitmq_consumer.HeaderFailureReason, rabbitmq_consumer.HeaderOriginalExchange,
+		rabbitmq_consumer.HeaderOriginalRoutingKey, rabbitmq_consumer.HeaderOriginalQueue,
+		HeaderReplayedFrom:
+		return true
+	}
+	return false
+}
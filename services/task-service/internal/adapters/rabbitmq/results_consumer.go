@@ -8,6 +8,7 @@ import (
 	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
 	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
 	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
 	"task-service/internal/core/port"
 	"task-service/internal/core/port/usecases_port"
 
@@ -19,6 +20,36 @@ import (
 type TaskResultDTO struct {
 	TaskID  uuid.UUID      `json:"task_id"`
 	Results map[string]int `json:"results"`
+	Items   []string       `json:"items,omitempty"`
+
+	Subtasks []SubtaskProgressDTO `json:"subtasks,omitempty"`
+}
+
+// SubtaskProgressDTO - прирост счетчиков одной подзадачи
+type SubtaskProgressDTO struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	LinksFound int    `json:"links_found"`
+	Processed  int    `json:"processed"`
+	Errors     int    `json:"errors"`
+	DurationMs int64  `json:"duration_ms"`
+	LastError  string `json:"last_error"`
+}
+
+func (dto TaskResultDTO) toDomainSubtasks() []domain.TaskSubtask {
+	subtasks := make([]domain.TaskSubtask, 0, len(dto.Subtasks))
+	for _, s := range dto.Subtasks {
+		subtasks = append(subtasks, domain.TaskSubtask{
+			Source:     s.Source,
+			Name:       s.Name,
+			LinksFound: s.LinksFound,
+			Processed:  s.Processed,
+			Errors:     s.Errors,
+			DurationMs: s.DurationMs,
+			LastError:  s.LastError,
+		})
+	}
+	return subtasks
 }
 
 // ResultsConsumerAdapter - консьюмер для результатов задач
@@ -50,7 +81,7 @@ func NewResultsConsumerAdapter(
 }
 
 // messageHandler - обработчик одного сообщения
-func (a *ResultsConsumerAdapter) messageHandler(d amqp.Delivery) error {
+func (a *ResultsConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) error {
 	
 	traceID, ok := d.Headers["x-trace-id"].(string)
 	if !ok || traceID == "" {
@@ -64,9 +95,13 @@ func (a *ResultsConsumerAdapter) messageHandler(d amqp.Delivery) error {
 	})
 
 	// логер и trace_id в контекст
-	ctx := context.Background()
 	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
 
+	if err := validateEvent(d); err != nil {
+		msgLogger.Error("Message failed schema validation. Rejecting.", err, nil)
+		return err
+	}
+
 	var dto TaskResultDTO
 	if err := json.Unmarshal(d.Body, &dto); err != nil {
 		msgLogger.Error("Failed to unmarshal task result DTO, rejecting message.", err, nil)
@@ -82,7 +117,7 @@ func (a *ResultsConsumerAdapter) messageHandler(d amqp.Delivery) error {
 	handlerLogger.Debug("Processing task result.", port.Fields{"results": dto.Results})
 
 	// Вызываем Use Case для инкрементации счетчиков
-	if err := a.useCase.Execute(ctx, dto.TaskID, dto.Results); err != nil {
+	if err := a.useCase.Execute(ctx, dto.TaskID, dto.Results, dto.Items, dto.toDomainSubtasks()); err != nil {
 		handlerLogger.Error("Failed to process task result, message will be nacked for retry.", err, nil)
 		return err // Возвращаем ошибку, чтобы RabbitMQ попробовал снова
 	}
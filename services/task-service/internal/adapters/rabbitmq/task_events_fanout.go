@@ -0,0 +1,115 @@
+package rabbitmq_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_common"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_consumer"
+	"real-estate-system/pkg/rabbitmq/rabbitmq_producer"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// TaskEventsPublisherAdapter реализует TaskEventFanoutPort: событие уходит в fanout-обменник,
+// откуда его получают все реплики, включая отправившую
+type TaskEventsPublisherAdapter struct {
+	publisher *rabbitmq_producer.Publisher
+}
+
+// NewTaskEventsPublisherAdapter - конструктор. Обменник объявляется, если его еще нет
+func NewTaskEventsPublisherAdapter(url, exchange string, connManager *rabbitmq_common.ConnectionManager, logger port.LoggerPort) (*TaskEventsPublisherAdapter, error) {
+	pkgLogger := logger.WithFields(port.Fields{"component": "rabbitmq_task_events_publisher", "exchange": exchange})
+	publisher, err := rabbitmq_producer.NewPublisher(rabbitmq_producer.PublisherConfig{
+		Config:                   rabbitmq_common.Config{URL: url},
+		ExchangeName:             exchange,
+		ExchangeType:             "fanout",
+		DurableExchange:          true,
+		DeclareExchangeIfMissing: true,
+		Logger:                   NewPkgLoggerBridge(pkgLogger),
+	}, connManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task events publisher: %w", err)
+	}
+	return &TaskEventsPublisherAdapter{publisher: publisher}, nil
+}
+
+func (a *TaskEventsPublisherAdapter) PublishEvent(ctx context.Context, event domain.TaskEventRecord) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task event: %w", err)
+	}
+
+	headers := amqp.Table{}
+	if traceID := contextkeys.TraceIDFromContext(ctx); traceID != "" {
+		headers["x-trace-id"] = traceID
+	}
+
+	// События не переживают перезапуск брокера: пропущенное клиент доберет из журнала по Last-Event-ID
+	return a.publisher.Publish(ctx, "", amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         body,
+		Headers:      headers,
+		Timestamp:    time.Now(),
+		DeliveryMode: amqp.Transient,
+	})
+}
+
+func (a *TaskEventsPublisherAdapter) Close() error { return a.publisher.Close() }
+
+// TaskEventsConsumerAdapter получает события из fanout-обменника в собственную
+// временную очередь реплики и передает их локальным SSE-клиентам
+type TaskEventsConsumerAdapter struct {
+	consumer rabbitmq_consumer.Consumer
+	receiver port.TaskEventReceiverPort
+	logger   port.LoggerPort
+}
+
+// NewTaskEventsConsumerAdapter - конструктор
+func NewTaskEventsConsumerAdapter(
+	cfg rabbitmq_consumer.ConsumerConfig,
+	receiver port.TaskEventReceiverPort,
+	logger port.LoggerPort,
+	connManager *rabbitmq_common.ConnectionManager,
+) (*TaskEventsConsumerAdapter, error) {
+	adapter := &TaskEventsConsumerAdapter{receiver: receiver, logger: logger}
+
+	pkgLogger := logger.WithFields(port.Fields{"component": "rabbitmq_distributing_consumer", "consumer_tag": cfg.ConsumerTag})
+	cfg.Logger = NewPkgLoggerBridge(pkgLogger)
+
+	consumer, err := rabbitmq_consumer.NewDistributingConsumer(cfg, adapter.messageHandler, connManager)
+	if err != nil {
+		return nil, err
+	}
+	adapter.consumer = consumer
+	return adapter, nil
+}
+
+func (a *TaskEventsConsumerAdapter) messageHandler(ctx context.Context, d amqp.Delivery) error {
+	traceID, ok := d.Headers["x-trace-id"].(string)
+	if !ok || traceID == "" {
+		traceID = uuid.New().String()
+	}
+	msgLogger := a.logger.WithFields(port.Fields{"trace_id": traceID, "delivery_tag": d.DeliveryTag})
+	ctx = contextkeys.ContextWithTraceID(ctx, traceID)
+	ctx = contextkeys.ContextWithLogger(ctx, msgLogger)
+
+	var event domain.TaskEventRecord
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		msgLogger.Error("Failed to unmarshal task event, dropping message.", err, nil)
+		return nil // Повтор не поможет
+	}
+
+	a.receiver.Deliver(ctx, event)
+	return nil
+}
+
+func (a *TaskEventsConsumerAdapter) Start(ctx context.Context) error {
+	return a.consumer.StartConsuming(ctx)
+}
+func (a *TaskEventsConsumerAdapter) Close() error { return a.consumer.Close() }
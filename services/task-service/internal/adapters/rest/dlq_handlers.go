@@ -0,0 +1,258 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"task-service/internal/core/port/usecases_port"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultReplayBatchLimit = 100
+	maxReplayBatchLimit     = 1000
+)
+
+// DLQHandler - обработчики админского API для сообщений из финальных DLQ
+type DLQHandler struct {
+	getListUC usecases_port.GetDeadLettersListUseCasePort
+	getByIdUC usecases_port.GetDeadLetterByIdUseCasePort
+	replayUC  usecases_port.ReplayDeadLettersUseCasePort
+	discardUC usecases_port.DiscardDeadLetterUseCasePort
+}
+
+// NewDLQHandler - конструктор
+func NewDLQHandler(
+	getListUC usecases_port.GetDeadLettersListUseCasePort,
+	getByIdUC usecases_port.GetDeadLetterByIdUseCasePort,
+	replayUC usecases_port.ReplayDeadLettersUseCasePort,
+	discardUC usecases_port.DiscardDeadLetterUseCasePort,
+) *DLQHandler {
+	return &DLQHandler{
+		getListUC: getListUC,
+		getByIdUC: getByIdUC,
+		replayUC:  replayUC,
+		discardUC: discardUC,
+	}
+}
+
+// GetDeadLetters - GET /api/v1/dlq/messages?queue=&status=&task_id=&reason=&from=&to=&page=&perPage=
+func (h *DLQHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetDeadLetters"})
+
+	query := r.URL.Query()
+
+	filter := domain.DeadLetterFilter{
+		SourceQueue: query.Get("queue"),
+		Status:      domain.DeadLetterStatus(query.Get("status")),
+		Reason:      query.Get("reason"),
+	}
+	if taskIDStr := query.Get("task_id"); taskIDStr != "" {
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			logger.Warn("Invalid 'task_id' format", port.Fields{"provided_id": taskIDStr})
+			WriteJSONError(w, http.StatusBadRequest, "Invalid 'task_id' format")
+			return
+		}
+		filter.TaskID = &taskID
+	}
+	var err error
+	if filter.From, err = parseTimeParam(query.Get("from")); err != nil {
+		logger.Warn("Invalid 'from' format", port.Fields{"value": query.Get("from")})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid 'from' format, RFC3339 expected")
+		return
+	}
+	if filter.To, err = parseTimeParam(query.Get("to")); err != nil {
+		logger.Warn("Invalid 'to' format", port.Fields{"value": query.Get("to")})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid 'to' format, RFC3339 expected")
+		return
+	}
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("perPage"))
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	limit := perPage
+	offset := (page - 1) * perPage
+
+	handlerLogger := logger.WithFields(port.Fields{"filter": filter, "limit": limit, "offset": offset})
+	handlerLogger.Info("Processing request to get dead letters", nil)
+
+	letters, totalCount, err := h.getListUC.Execute(r.Context(), filter, limit, offset)
+	if err != nil {
+		handlerLogger.Error("GetDeadLettersList use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to retrieve dead letters")
+		return
+	}
+
+	responses := make([]DeadLetterResponse, len(letters))
+	for i := range letters {
+		responses[i] = toDeadLetterResponse(&letters[i], false)
+	}
+
+	RespondWithJSON(w, http.StatusOK, PaginatedDeadLettersResponse{
+		Data:    responses,
+		Total:   totalCount,
+		Page:    page,
+		PerPage: limit,
+	})
+}
+
+// GetDeadLetterByID - GET /api/v1/dlq/messages/{messageID}
+func (h *DLQHandler) GetDeadLetterByID(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetDeadLetterByID"})
+
+	id, ok := parseDeadLetterID(w, r, logger)
+	if !ok {
+		return
+	}
+
+	letter, err := h.getByIdUC.Execute(r.Context(), id)
+	if err != nil {
+		writeDeadLetterError(w, logger, "GetDeadLetterById", err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, toDeadLetterResponse(letter, true))
+}
+
+// ReplayDeadLetter - POST /api/v1/dlq/messages/{messageID}/replay
+func (h *DLQHandler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ReplayDeadLetter"})
+
+	id, ok := parseDeadLetterID(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger.Info("Processing request to replay dead letter", port.Fields{"dead_letter_id": id.String()})
+
+	letter, err := h.replayUC.ReplayOne(r.Context(), id)
+	if err != nil {
+		writeDeadLetterError(w, logger, "ReplayDeadLetter", err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, toDeadLetterResponse(letter, false))
+}
+
+// ReplayDeadLetters - POST /api/v1/dlq/messages/replay
+func (h *DLQHandler) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "ReplayDeadLetters"})
+
+	var req ReplayDeadLettersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("Failed to decode replay request body", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	filter := domain.DeadLetterFilter{
+		SourceQueue: req.Queue,
+		Status:      req.Status,
+		Reason:      req.Reason,
+	}
+	if filter.Status == "" {
+		filter.Status = domain.DeadLetterStatusNew
+	}
+	if filter.Status == domain.DeadLetterStatusDiscarded {
+		WriteJSONError(w, http.StatusBadRequest, "Discarded messages cannot be replayed")
+		return
+	}
+	if req.TaskID != "" {
+		taskID, err := uuid.Parse(req.TaskID)
+		if err != nil {
+			logger.Warn("Invalid 'task_id' format", port.Fields{"provided_id": req.TaskID})
+			WriteJSONError(w, http.StatusBadRequest, "Invalid 'task_id' format")
+			return
+		}
+		filter.TaskID = &taskID
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultReplayBatchLimit
+	}
+	if limit > maxReplayBatchLimit {
+		limit = maxReplayBatchLimit
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{"filter": filter, "limit": limit})
+	handlerLogger.Info("Processing request to replay dead letters batch", nil)
+
+	result, err := h.replayUC.ReplayBatch(r.Context(), filter, limit)
+	if err != nil {
+		handlerLogger.Error("ReplayDeadLetters use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to replay dead letters")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, result)
+}
+
+// DiscardDeadLetter - POST /api/v1/dlq/messages/{messageID}/discard
+func (h *DLQHandler) DiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "DiscardDeadLetter"})
+
+	id, ok := parseDeadLetterID(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger.Info("Processing request to discard dead letter", port.Fields{"dead_letter_id": id.String()})
+
+	letter, err := h.discardUC.Execute(r.Context(), id)
+	if err != nil {
+		writeDeadLetterError(w, logger, "DiscardDeadLetter", err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, toDeadLetterResponse(letter, false))
+}
+
+// parseTimeParam разбирает необязательный параметр даты в формате RFC3339
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func parseDeadLetterID(w http.ResponseWriter, r *http.Request, logger port.LoggerPort) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "messageID"))
+	if err != nil {
+		logger.Warn("Invalid message ID format in URL", port.Fields{"provided_id": chi.URLParam(r, "messageID")})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid message ID in URL")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeDeadLetterError(w http.ResponseWriter, logger port.LoggerPort, useCase string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDeadLetterNotFound):
+		logger.Warn("Dead letter not found", nil)
+		WriteJSONError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrDeadLetterDiscarded), errors.Is(err, domain.ErrDeadLetterNoRoute):
+		logger.Warn("Dead letter cannot be replayed", port.Fields{"reason": err.Error()})
+		WriteJSONError(w, http.StatusConflict, err.Error())
+	default:
+		logger.Error(useCase+" use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to process dead letter")
+	}
+}
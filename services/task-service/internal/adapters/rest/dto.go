@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"encoding/json"
+	"task-service/internal/adapters/notifier"
 	"task-service/internal/core/domain"
 	"time"
 )
@@ -9,11 +11,18 @@ type CreateTaskRequest struct {
 	Name            string `json:"name"`
 	Type            string `json:"type"`
 	CreatedByUserID string `json:"created_by_user_id"`
-	ObjectID		string `json:"object_id,omitempty"`
+	ObjectID        string `json:"object_id,omitempty"`
+	// Params - входные параметры, по которым задачу можно запустить повторно
+	Params domain.TaskParams `json:"params,omitempty"`
+}
+
+// RegisterTaskItemsRequest - единицы работы, отправленные исполнителем по задаче
+type RegisterTaskItemsRequest struct {
+	Items []domain.TaskItem `json:"items"`
 }
 
 type UpdateTaskRequest struct {
-	Status        domain.TaskStatus `json:"status"`
+	Status domain.TaskStatus `json:"status"`
 	// ResultSummary *json.RawMessage  `json:"result_summary"`
 }
 
@@ -28,6 +37,11 @@ type TaskResponse struct {
 	StartedAt       *string              `json:"started_at,omitempty"`
 	FinishedAt      *string              `json:"finished_at,omitempty"`
 	CreatedByUserID string               `json:"created_by_user_id"`
+	LastProgressAt  string               `json:"last_progress_at"`
+	Params          domain.TaskParams    `json:"params"`
+	ParentTaskID    *string              `json:"parent_task_id,omitempty"`
+	RestartMode     domain.RestartMode   `json:"restart_mode,omitempty"`
+	Subtasks        []domain.TaskSubtask `json:"subtasks,omitempty"`
 }
 
 // PaginatedTasksResponse - DTO для ответа со списком задач
@@ -38,6 +52,15 @@ type PaginatedTasksResponse struct {
 	PerPage int            `json:"perPage"`
 }
 
+// TaskStatsResponse - DTO для сводки по типам задач
+type TaskStatsResponse struct {
+	Data []domain.TaskTypeStats `json:"data"`
+}
+
+// SSEClientsResponse - DTO с метриками SSE-соединений
+type SSEClientsResponse struct {
+	Data []notifier.ClientStats `json:"data"`
+}
 
 // toTaskResponse - маппер из доменной модели в DTO
 func toTaskResponse(task *domain.Task) TaskResponse {
@@ -49,6 +72,14 @@ func toTaskResponse(task *domain.Task) TaskResponse {
 		ResultSummary:   task.ResultSummary,
 		CreatedAt:       task.CreatedAt.Format(time.RFC3339),
 		CreatedByUserID: task.CreatedByUserID.String(),
+		LastProgressAt:  task.LastProgressAt.Format(time.RFC3339),
+		Params:          task.Params,
+		RestartMode:     task.RestartMode,
+		Subtasks:        task.Subtasks,
+	}
+	if task.ParentTaskID != nil {
+		parentTaskID := task.ParentTaskID.String()
+		resp.ParentTaskID = &parentTaskID
 	}
 	if task.StartedAt != nil {
 		startedAt := task.StartedAt.Format(time.RFC3339)
@@ -59,4 +90,82 @@ func toTaskResponse(task *domain.Task) TaskResponse {
 		resp.FinishedAt = &finishedAt
 	}
 	return resp
-}
\ No newline at end of file
+}
+
+// DeadLetterResponse - DTO для сообщения из DLQ
+type DeadLetterResponse struct {
+	ID                 string                  `json:"id"`
+	SourceQueue        string                  `json:"source_queue"`
+	OriginalQueue      string                  `json:"original_queue,omitempty"`
+	OriginalExchange   string                  `json:"original_exchange"`
+	OriginalRoutingKey string                  `json:"original_routing_key"`
+	FailureReason      string                  `json:"failure_reason"`
+	TaskID             *string                 `json:"task_id,omitempty"`
+	ContentType        string                  `json:"content_type,omitempty"`
+	Body               json.RawMessage         `json:"body,omitempty"`     // Тело как JSON, если оно валидно
+	BodyRaw            string                  `json:"body_raw,omitempty"` // Иначе как строка
+	Headers            map[string]interface{}  `json:"headers,omitempty"`
+	DeathHistory       []domain.DeathRecord    `json:"death_history"`
+	Status             domain.DeadLetterStatus `json:"status"`
+	ReplayCount        int                     `json:"replay_count"`
+	ReceivedAt         string                  `json:"received_at"`
+	LastReplayedAt     *string                 `json:"last_replayed_at,omitempty"`
+	DiscardedAt        *string                 `json:"discarded_at,omitempty"`
+}
+
+// PaginatedDeadLettersResponse - DTO для списка сообщений из DLQ
+type PaginatedDeadLettersResponse struct {
+	Data    []DeadLetterResponse `json:"data"`
+	Total   int64                `json:"total"`
+	Page    int                  `json:"page"`
+	PerPage int                  `json:"perPage"`
+}
+
+// ReplayDeadLettersRequest - фильтр для пакетного повтора.
+// По умолчанию повторяются только сообщения в статусе "new"
+type ReplayDeadLettersRequest struct {
+	Queue  string                  `json:"queue"`
+	Status domain.DeadLetterStatus `json:"status"`
+	TaskID string                  `json:"task_id"`
+	Reason string                  `json:"reason"`
+	Limit  int                     `json:"limit"`
+}
+
+// toDeadLetterResponse - маппер из доменной модели в DTO.
+// withPayload=false используется для списков, чтобы не тащить тела всех сообщений
+func toDeadLetterResponse(letter *domain.DeadLetter, withPayload bool) DeadLetterResponse {
+	resp := DeadLetterResponse{
+		ID:                 letter.ID.String(),
+		SourceQueue:        letter.SourceQueue,
+		OriginalQueue:      letter.OriginalQueue,
+		OriginalExchange:   letter.OriginalExchange,
+		OriginalRoutingKey: letter.OriginalRoutingKey,
+		FailureReason:      letter.FailureReason,
+		ContentType:        letter.ContentType,
+		DeathHistory:       letter.DeathHistory,
+		Status:             letter.Status,
+		ReplayCount:        letter.ReplayCount,
+		ReceivedAt:         letter.ReceivedAt.Format(time.RFC3339),
+	}
+	if letter.TaskID != nil {
+		taskID := letter.TaskID.String()
+		resp.TaskID = &taskID
+	}
+	if letter.LastReplayedAt != nil {
+		replayedAt := letter.LastReplayedAt.Format(time.RFC3339)
+		resp.LastReplayedAt = &replayedAt
+	}
+	if letter.DiscardedAt != nil {
+		discardedAt := letter.DiscardedAt.Format(time.RFC3339)
+		resp.DiscardedAt = &discardedAt
+	}
+	if withPayload {
+		resp.Headers = letter.Headers
+		if json.Valid(letter.Body) {
+			resp.Body = json.RawMessage(letter.Body)
+		} else {
+			resp.BodyRaw = string(letter.Body)
+		}
+	}
+	return resp
+}
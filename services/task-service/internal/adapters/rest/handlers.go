@@ -80,12 +80,15 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	})
 	handlerLogger.Info("Processing request to create task", nil)
 
-	var task *domain.Task
-	if req.Type == "ACTUALIZE_BY_ID" {
-		task, err = h.createTaskUC.Execute(r.Context(), req.Name, req.Type, userID, req.ObjectID)
-	} else {
-		task, err = h.createTaskUC.Execute(r.Context(), req.Name, req.Type, userID)
+	params := req.Params
+	if req.Type == "ACTUALIZE_BY_ID" && req.ObjectID != "" {
+		if params == nil {
+			params = domain.TaskParams{}
+		}
+		params["object_id"] = req.ObjectID
 	}
+
+	task, err := h.createTaskUC.Execute(r.Context(), req.Name, req.Type, userID, params)
 	
 	if err != nil {
 		handlerLogger.Error("CreateTask use case failed", err, nil)
@@ -137,6 +140,10 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
             WriteJSONError(w, http.StatusNotFound, err.Error())
             return
         }
+		if errors.Is(err, domain.ErrTaskFinished) {
+			WriteJSONError(w, http.StatusConflict, err.Error())
+			return
+		}
 		
 		handlerLogger.Error("UpdateTask use case failed", err, nil)
 		WriteJSONError(w, http.StatusInternalServerError, "Failed to update task")
@@ -242,6 +249,7 @@ func (h *TaskHandler) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 
 
 // SubscribeToTasks - обработчик для GET /api/v1/tasks/subscribe
+// При переподключении с Last-Event-ID (или ?lastEventId= после перезагрузки страницы) сначала досылаются пропущенные события
 func (h *TaskHandler) SubscribeToTasks(w http.ResponseWriter, r *http.Request) {
 	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "SubscribeToTasks"})
 
@@ -252,8 +260,11 @@ func (h *TaskHandler) SubscribeToTasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	lastEventID := parseLastEventID(r)
+
 	handlerLogger := logger.WithFields(port.Fields{
-		"user_id": userID,
+		"user_id":       userID,
+		"last_event_id": lastEventID,
 	})
 	handlerLogger.Info("New client subscribing to SSE events", nil)
 
@@ -262,29 +273,62 @@ func (h *TaskHandler) SubscribeToTasks(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	
-	clientChan := h.notifier.AddClient(userID.String())
-	defer h.notifier.RemoveClient(userID.String(), clientChan)
+	// Клиент регистрируется до чтения журнала, чтобы не потерять события между выборкой и подпиской
+	client := h.notifier.AddClient(userID.String())
+	defer h.notifier.RemoveClient(client)
 
 	// Отправляем ping для подтверждения установки соединения
 	fmt.Fprintf(w, "event: connected\ndata: {}\n\n")
+
+	// События с ID не больше этого уже отправлены из журнала и в живом потоке пропускаются
+	var replayedUpTo int64
+	if lastEventID > 0 {
+		messages, resync, err := h.notifier.Replay(r.Context(), userID, lastEventID)
+		if err != nil {
+			handlerLogger.Error("Failed to replay missed events, asking client to resync", err, nil)
+		}
+		if resync {
+			// Часть событий уже не восстановить: клиент должен заново загрузить список задач
+			fmt.Fprintf(w, "event: resync\ndata: {}\n\n")
+		}
+		for _, msg := range messages {
+			if _, err := w.Write(msg.Data); err != nil {
+				handlerLogger.Error("Error writing replayed event to client, closing SSE connection", err, nil)
+				return
+			}
+			client.MarkSent()
+			replayedUpTo = msg.ID
+		}
+		handlerLogger.Info("Replayed missed events", port.Fields{"count": len(messages), "resync": resync})
+	}
 	if f, ok := w.(http.Flusher); ok { f.Flush() }
 
-	// Отправляем пустой комментарий каждые 15 секунд
-	ticker := time.NewTicker(15 * time.Second)
+	// Отправляем пустой комментарий, чтобы прокси не закрывали простаивающее соединение
+	ticker := time.NewTicker(h.notifier.HeartbeatInterval())
     defer ticker.Stop()
 
 	for {
 		select {
-		case data := <-clientChan:
-			if _, err := fmt.Fprintf(w, "%s", data); err != nil {
+		case msg := <-client.Messages():
+			if msg.ID != 0 && msg.ID <= replayedUpTo {
+				continue
+			}
+			if _, err := w.Write(msg.Data); err != nil {
 				handlerLogger.Error("Error writing to client, closing SSE connection", err, nil)
 				return
 			}
 			if f, ok := w.(http.Flusher); ok {
 				f.Flush()
 			}
+			client.MarkSent()
 			handlerLogger.Info("Sent SSE event to client", nil)
 
+		case <-client.Overflow():
+			// Клиент не успевал читать, часть событий отброшена. Закрываем соединение:
+			// браузер переподключится с Last-Event-ID и получит пропущенное из журнала
+			handlerLogger.Warn("Client is too slow, closing SSE connection to resume from event log", nil)
+			return
+
 		case <-ticker.C:
             // В спецификации SSE строки, начинающиеся с двоеточия (:), считаются комментариями
             // Браузер их получает, канал остается активным, но JS-код (onmessage) их игнорирует
@@ -299,4 +343,23 @@ func (h *TaskHandler) SubscribeToTasks(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-}
\ No newline at end of file
+}
+
+// GetSSEClients - GET /api/v1/tasks/sse/clients - метрики SSE-соединений этой реплики
+func (h *TaskHandler) GetSSEClients(w http.ResponseWriter, r *http.Request) {
+	RespondWithJSON(w, http.StatusOK, SSEClientsResponse{Data: h.notifier.Stats()})
+}
+
+// parseLastEventID берет ID последнего полученного события из заголовка Last-Event-ID,
+// который браузер отправляет при автоматическом переподключении, или из параметра lastEventId
+func parseLastEventID(r *http.Request) int64 {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("lastEventId")
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
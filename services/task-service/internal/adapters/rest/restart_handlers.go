@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"task-service/internal/core/port/usecases_port"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// RestartHandler - единицы работы задач и повтор завершенных задач
+type RestartHandler struct {
+	registerItemsUC usecases_port.RegisterTaskItemsUseCasePort
+	restartUC       usecases_port.RestartTaskUseCasePort
+}
+
+// NewRestartHandler - конструктор
+func NewRestartHandler(
+	registerItemsUC usecases_port.RegisterTaskItemsUseCasePort,
+	restartUC usecases_port.RestartTaskUseCasePort,
+) *RestartHandler {
+	return &RestartHandler{
+		registerItemsUC: registerItemsUC,
+		restartUC:       restartUC,
+	}
+}
+
+// RegisterItems - POST /api/v1/tasks/{taskID}/items, вызывается сервисом-исполнителем
+func (h *RestartHandler) RegisterItems(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "RegisterTaskItems"})
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "taskID"))
+	if err != nil {
+		logger.Warn("Invalid task ID format in URL", port.Fields{"provided_id": chi.URLParam(r, "taskID")})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid task ID in URL")
+		return
+	}
+
+	var req RegisterTaskItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("Failed to decode register items request body", port.Fields{"error": err.Error()})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	for _, item := range req.Items {
+		if item.Key == "" {
+			WriteJSONError(w, http.StatusBadRequest, "Field 'key' is required for every item")
+			return
+		}
+	}
+
+	if err := h.registerItemsUC.Execute(r.Context(), taskID, req.Items); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			WriteJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		logger.Error("RegisterTaskItems use case failed", err, port.Fields{"task_id": taskID.String()})
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to register task items")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RerunTask - POST /api/v1/tasks/{taskID}/rerun
+func (h *RestartHandler) RerunTask(w http.ResponseWriter, r *http.Request) {
+	h.restart(w, r, domain.RestartRerun)
+}
+
+// ResumeTask - POST /api/v1/tasks/{taskID}/resume
+func (h *RestartHandler) ResumeTask(w http.ResponseWriter, r *http.Request) {
+	h.restart(w, r, domain.RestartResume)
+}
+
+func (h *RestartHandler) restart(w http.ResponseWriter, r *http.Request, mode domain.RestartMode) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "RestartTask", "mode": mode})
+
+	userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+	if !ok {
+		logger.Error("Invalid or missing user ID in context", nil, nil)
+		WriteJSONError(w, http.StatusUnauthorized, "User ID not found in context")
+		return
+	}
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "taskID"))
+	if err != nil {
+		logger.Warn("Invalid task ID format in URL", port.Fields{"provided_id": chi.URLParam(r, "taskID")})
+		WriteJSONError(w, http.StatusBadRequest, "Invalid task ID in URL")
+		return
+	}
+
+	handlerLogger := logger.WithFields(port.Fields{"parent_task_id": taskID.String(), "user_id": userID.String()})
+	handlerLogger.Info("Processing request to restart task", nil)
+
+	task, err := h.restartUC.Execute(r.Context(), taskID, mode, userID)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrTaskNotFound):
+		WriteJSONError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, domain.ErrTaskNotFinished), errors.Is(err, domain.ErrNothingToResume):
+		WriteJSONError(w, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, domain.ErrRestartNotSupported):
+		WriteJSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	default:
+		handlerLogger.Error("RestartTask use case failed", err, nil)
+		WriteJSONError(w, http.StatusBadGateway, "Failed to start restarted task")
+		return
+	}
+
+	handlerLogger.Info("Task restarted", port.Fields{"task_id": task.ID.String()})
+	RespondWithJSON(w, http.StatusAccepted, toTaskResponse(task))
+}
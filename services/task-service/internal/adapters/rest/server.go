@@ -18,7 +18,7 @@ type Server struct {
 }
 
 
-func NewServer(port string, handlers *TaskHandler, baseLogger core_port.LoggerPort) *Server {
+func NewServer(port string, handlers *TaskHandler, dlqHandlers *DLQHandler, restartHandlers *RestartHandler, searchHandlers *TaskSearchHandler, baseLogger core_port.LoggerPort) *Server {
 	r := chi.NewRouter()
 
 
@@ -31,6 +31,7 @@ func NewServer(port string, handlers *TaskHandler, baseLogger core_port.LoggerPo
 		// для других сервисов (без проверки userID)
 		r.Post("/", handlers.CreateTask)
 		r.Put("/{taskID}", handlers.UpdateTask)
+		r.Post("/{taskID}/items", restartHandlers.RegisterItems)
 
 		// эндпоинты для пользователей (через API Gateway)
 		r.Group(func(r chi.Router) {
@@ -42,11 +43,35 @@ func NewServer(port string, handlers *TaskHandler, baseLogger core_port.LoggerPo
 			// GET /api/v1/tasks/subscribe - подписаться на обновления своих задач
 			r.Get("/subscribe", handlers.SubscribeToTasks)
 			
+			// GET /api/v1/tasks/sse/clients - метрики SSE-соединений этой реплики (буферы, отброшенные события)
+			r.Get("/sse/clients", handlers.GetSSEClients)
+
+			// GET /api/v1/tasks/search - поиск по задачам всех пользователей (только для админов, проверяется на API Gateway)
+			r.Get("/search", searchHandlers.SearchTasks)
+			// GET /api/v1/tasks/stats - доля успешных и средняя длительность по типам задач
+			r.Get("/stats", searchHandlers.GetTaskStats)
+			
 			// GET /api/v1/tasks/{taskID} - получить детали задачи
 			r.Get("/{taskID}", handlers.GetTaskByID)
+
+			// POST /api/v1/tasks/{taskID}/rerun - запустить завершенную задачу заново с теми же параметрами
+			r.Post("/{taskID}/rerun", restartHandlers.RerunTask)
+			// POST /api/v1/tasks/{taskID}/resume - повторить только то, по чему не пришли результаты
+			r.Post("/{taskID}/resume", restartHandlers.ResumeTask)
 		})
 	})
 
+	// Админское API для финальных DLQ (доступ проверяется на API Gateway)
+	r.Route("/api/v1/dlq/messages", func(r chi.Router) {
+		r.Use(AuthMiddleware)
+
+		r.Get("/", dlqHandlers.GetDeadLetters)
+		r.Post("/replay", dlqHandlers.ReplayDeadLetters)
+		r.Get("/{messageID}", dlqHandlers.GetDeadLetterByID)
+		r.Post("/{messageID}/replay", dlqHandlers.ReplayDeadLetter)
+		r.Post("/{messageID}/discard", dlqHandlers.DiscardDeadLetter)
+	})
+
 	srv := &http.Server{
 		Addr:         ":" + port,
 		Handler:      r,
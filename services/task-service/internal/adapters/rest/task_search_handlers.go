@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"task-service/internal/core/port/usecases_port"
+
+	"github.com/google/uuid"
+)
+
+// TaskSearchHandler - админский поиск задач всех пользователей и сводка по ним
+type TaskSearchHandler struct {
+	searchUC usecases_port.SearchTasksUseCasePort
+	statsUC  usecases_port.GetTaskStatsUseCasePort
+}
+
+// NewTaskSearchHandler - конструктор
+func NewTaskSearchHandler(
+	searchUC usecases_port.SearchTasksUseCasePort,
+	statsUC usecases_port.GetTaskStatsUseCasePort,
+) *TaskSearchHandler {
+	return &TaskSearchHandler{
+		searchUC: searchUC,
+		statsUC:  statsUC,
+	}
+}
+
+// SearchTasks - GET /api/v1/tasks/search?type=&status=&created_by=&from=&to=&target_object_id=&sort=&order=&page=&perPage=
+func (h *TaskSearchHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "SearchTasks"})
+
+	query := r.URL.Query()
+
+	filter, errMsg := parseTaskFilter(query.Get)
+	if errMsg != "" {
+		logger.Warn("Invalid task search filter", port.Fields{"reason": errMsg})
+		WriteJSONError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	sort := domain.TaskSort{Field: domain.TaskSortCreatedAt, Desc: true}
+	if field := query.Get("sort"); field != "" {
+		sort.Field = domain.TaskSortField(field)
+		if !sort.Field.IsValid() {
+			WriteJSONError(w, http.StatusBadRequest, "Invalid 'sort', expected one of: created_at, finished_at, duration, type, status")
+			return
+		}
+	}
+	switch query.Get("order") {
+	case "", "desc":
+	case "asc":
+		sort.Desc = false
+	default:
+		WriteJSONError(w, http.StatusBadRequest, "Invalid 'order', expected 'asc' or 'desc'")
+		return
+	}
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("perPage"))
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	limit := perPage
+	offset := (page - 1) * perPage
+
+	handlerLogger := logger.WithFields(port.Fields{"filter": filter, "sort": sort, "limit": limit, "offset": offset})
+	handlerLogger.Info("Processing request to search tasks", nil)
+
+	tasks, totalCount, err := h.searchUC.Execute(r.Context(), filter, sort, limit, offset)
+	if err != nil {
+		handlerLogger.Error("SearchTasks use case failed", err, nil)
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to search tasks")
+		return
+	}
+
+	taskResponses := make([]TaskResponse, len(tasks))
+	for i := range tasks {
+		taskResponses[i] = toTaskResponse(&tasks[i])
+	}
+
+	RespondWithJSON(w, http.StatusOK, PaginatedTasksResponse{
+		Data:    taskResponses,
+		Total:   totalCount,
+		Page:    page,
+		PerPage: limit,
+	})
+}
+
+// GetTaskStats - GET /api/v1/tasks/stats?type=&created_by=&from=&to=&target_object_id=
+func (h *TaskSearchHandler) GetTaskStats(w http.ResponseWriter, r *http.Request) {
+	logger := contextkeys.LoggerFromContext(r.Context()).WithFields(port.Fields{"handler": "GetTaskStats"})
+
+	filter, errMsg := parseTaskFilter(r.URL.Query().Get)
+	if errMsg != "" {
+		logger.Warn("Invalid task stats filter", port.Fields{"reason": errMsg})
+		WriteJSONError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	stats, err := h.statsUC.Execute(r.Context(), filter)
+	if err != nil {
+		logger.Error("GetTaskStats use case failed", err, port.Fields{"filter": filter})
+		WriteJSONError(w, http.StatusInternalServerError, "Failed to retrieve task stats")
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, TaskStatsResponse{Data: stats})
+}
+
+// parseTaskFilter разбирает общие для поиска и сводки параметры. Возвращает текст ошибки для клиента
+func parseTaskFilter(get func(string) string) (domain.TaskFilter, string) {
+	filter := domain.TaskFilter{
+		Type:           get("type"),
+		Status:         domain.TaskStatus(get("status")),
+		TargetObjectID: get("target_object_id"),
+	}
+	if createdBy := get("created_by"); createdBy != "" {
+		userID, err := uuid.Parse(createdBy)
+		if err != nil {
+			return filter, "Invalid 'created_by' format"
+		}
+		filter.CreatedByUserID = &userID
+	}
+	var err error
+	if filter.From, err = parseTimeParam(get("from")); err != nil {
+		return filter, "Invalid 'from' format, RFC3339 expected"
+	}
+	if filter.To, err = parseTimeParam(get("to")); err != nil {
+		return filter, "Invalid 'to' format, RFC3339 expected"
+	}
+	return filter, ""
+}
@@ -15,6 +15,8 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
+	"task-service/internal/adapters/actualization_client"
 	logger_adapter "task-service/internal/adapters/logger"
 	"task-service/internal/adapters/notifier"
 	postgres_adapter "task-service/internal/adapters/postgres"
@@ -22,7 +24,10 @@ import (
 	"task-service/internal/adapters/rest"
 	"task-service/internal/configs"
 	"task-service/internal/constants"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
 	"task-service/internal/core/port"
+	"task-service/internal/core/port/usecases_port"
 	"task-service/internal/core/usecase"
 
 	"github.com/fluent/fluent-logger-golang/fluent"
@@ -35,6 +40,12 @@ type App struct {
 	apiServer                      *rest.Server
 	resultsListener                port.EventListenerPort
 	dlqListeners                   []port.EventListenerPort
+	replayAdapter                  *rabbitmq_adapter.RabbitMQReplayAdapter
+	eventsListener                 port.EventListenerPort
+	eventsPublisher                *rabbitmq_adapter.TaskEventsPublisherAdapter
+	eventLog                       port.TaskEventLogPort
+	timeoutStaleTasksUseCase       usecases_port.TimeoutStaleTasksUseCasePort
+	applyTaskRetentionUseCase      usecases_port.ApplyTaskRetentionUseCasePort
 
 	logger       port.LoggerPort
 	fluentClient *fluent.Fluent
@@ -119,21 +130,71 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to create postgres storage adapter: %w", err)
 	}
 
-	sseNotifier := notifier.NewSSENotifier(baseLogger)
+	deadLetterRepo, err := postgres_adapter.NewPostgresDeadLetterRepository(dbPool)
+	if err != nil {
+		appLogger.Error("Failed to create postgres dead letter repository", err, nil)
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to create postgres dead letter repository: %w", err)
+	}
+
+	replayAdapter, err := rabbitmq_adapter.NewRabbitMQReplayAdapter(appConfig.RabbitMQ.URL, connManager, baseLogger)
+	if err != nil {
+		appLogger.Error("Failed to create replay adapter", err, nil)
+		dbPool.Close()
+		return nil, fmt.Errorf("failed to create replay adapter: %w", err)
+	}
+
+	// Без fanout события получают только клиенты, подключенные к этой же реплике
+	var eventsFanout port.TaskEventFanoutPort
+	var eventsPublisher *rabbitmq_adapter.TaskEventsPublisherAdapter
+	if appConfig.SSE.FanoutEnabled {
+		eventsPublisher, err = rabbitmq_adapter.NewTaskEventsPublisherAdapter(appConfig.RabbitMQ.URL, constants.TaskEventsFanoutExchange, connManager, baseLogger)
+		if err != nil {
+			appLogger.Error("Failed to create task events publisher", err, nil)
+			dbPool.Close()
+			return nil, fmt.Errorf("failed to create task events publisher: %w", err)
+		}
+		eventsFanout = eventsPublisher
+	}
+
+	sseNotifier := notifier.NewSSENotifier(baseLogger, taskRepo, eventsFanout, notifier.Config{
+		ClientBuffer:      appConfig.SSE.ClientBuffer,
+		ReplayLimit:       appConfig.SSE.ReplayLimit,
+		HeartbeatInterval: appConfig.SSE.HeartbeatInterval,
+	})
 	appLogger.Debug("SSE Notifier initialized.", nil)
 
 	// инициализация use cases
 	createTaskUC := usecase.NewCreateTaskUseCase(taskRepo, sseNotifier)
 	updateTaskUC := usecase.NewUpdateTaskStatusUseCase(taskRepo, sseNotifier)
-	getTaskByIdUC := usecase.NewGetTaskByIdUseCase(taskRepo)
+	getTaskByIdUC := usecase.NewGetTaskByIdUseCase(taskRepo, taskRepo)
 	getTasksUC := usecase.NewGetTasksListUseCase(taskRepo)
-	processResultUC := usecase.NewProcessTaskResultUseCase(taskRepo, sseNotifier)
+	processResultUC := usecase.NewProcessTaskResultUseCase(taskRepo, taskRepo, taskRepo, sseNotifier)
 	// completeTaskUC := usecase.NewCompleteTaskUseCase(taskRepo, sseNotifier)
+	captureDeadLetterUC := usecase.NewCaptureDeadLetterUseCase(deadLetterRepo)
+	getDeadLettersUC := usecase.NewGetDeadLettersListUseCase(deadLetterRepo)
+	getDeadLetterByIdUC := usecase.NewGetDeadLetterByIdUseCase(deadLetterRepo)
+	replayDeadLettersUC := usecase.NewReplayDeadLettersUseCase(deadLetterRepo, replayAdapter)
+	discardDeadLetterUC := usecase.NewDiscardDeadLetterUseCase(deadLetterRepo)
+	timeoutStaleTasksUC := usecase.NewTimeoutStaleTasksUseCase(taskRepo, sseNotifier, domain.TaskTimeouts{
+		Default: appConfig.Watchdog.DefaultTimeout,
+		ByType:  appConfig.Watchdog.TimeoutsByType,
+	})
+	actualizationClient := actualization_client.NewClient(appConfig.Actualization.URL, appConfig.Actualization.Timeout)
+	registerTaskItemsUC := usecase.NewRegisterTaskItemsUseCase(taskRepo, taskRepo)
+	restartTaskUC := usecase.NewRestartTaskUseCase(taskRepo, taskRepo, actualizationClient, sseNotifier)
+	searchTasksUC := usecase.NewSearchTasksUseCase(taskRepo)
+	getTaskStatsUC := usecase.NewGetTaskStatsUseCase(taskRepo)
+	applyTaskRetentionUC := usecase.NewApplyTaskRetentionUseCase(taskRepo, appConfig.Retention.MaxAge,
+		domain.RetentionMode(appConfig.Retention.Mode), appConfig.Retention.BatchSize)
 	appLogger.Debug("All use cases initialized.", nil)
 
 	// REST API Server
 	apiHandlers := rest.NewTaskHandler(createTaskUC, updateTaskUC, getTaskByIdUC, getTasksUC, processResultUC, sseNotifier)
-	apiServer := rest.NewServer(appConfig.Rest.PORT, apiHandlers, baseLogger)
+	dlqHandlers := rest.NewDLQHandler(getDeadLettersUC, getDeadLetterByIdUC, replayDeadLettersUC, discardDeadLetterUC)
+	restartHandlers := rest.NewRestartHandler(registerTaskItemsUC, restartTaskUC)
+	searchHandlers := rest.NewTaskSearchHandler(searchTasksUC, getTaskStatsUC)
+	apiServer := rest.NewServer(appConfig.Rest.PORT, apiHandlers, dlqHandlers, restartHandlers, searchHandlers, baseLogger)
 	appLogger.Debug("REST API server configured.", nil)
 
 	// RabbitMQ Consumer для результатов
@@ -143,6 +204,8 @@ func NewApp() (*App, error) {
 		RoutingKeyForBind:   constants.RoutingKeyTaskResults,
 		ExchangeNameForBind: constants.MainExchange,
 		PrefetchCount:       5,
+		WorkerPoolSize:      5,
+		HandlerTimeout:      30 * time.Second,
 		DurableQueue:        true,
 		ConsumerTag:         "task-results-processor-adapter",
 		DeclareQueue:        true,
@@ -160,9 +223,7 @@ func NewApp() (*App, error) {
 		FinalDLXExchange:   constants.FinalDLXExchange,
 		FinalDLQ:           constants.FinalDLQ,
 		FinalDLQRoutingKey: constants.FinalDLQRoutingKey,
-
-		MaxRetries: 3,
-	}
+	}.WithRetryTiers(constants.TaskResultsRetryDelays)
 
 	resultsListener, err := rabbitmq_adapter.NewResultsConsumerAdapter(consumerCfg, processResultUC, baseLogger, connManager)
 	if err != nil {
@@ -192,7 +253,8 @@ func NewApp() (*App, error) {
 		// новый экземпляр DLQ адаптера
 		dlqListener, err := rabbitmq_adapter.NewDLQConsumerAdapter(
 			dlqConsumerCfg,
-			updateTaskUC, 
+			captureDeadLetterUC,
+			updateTaskUC,
 			baseLogger,
 			connManager,
 		)
@@ -205,6 +267,31 @@ func NewApp() (*App, error) {
 		appLogger.Debug("DLQ listener created", port.Fields{"queue_name": queueName})
 	}
 
+	var eventsListener port.EventListenerPort
+	if appConfig.SSE.FanoutEnabled {
+		hostname, _ := os.Hostname()
+		// Временная очередь на каждую реплику: имя выдает брокер, очередь удаляется вместе с соединением
+		eventsConsumerCfg := rabbitmq_consumer.ConsumerConfig{
+			Config:                 rabbitmq_common.Config{URL: appConfig.RabbitMQ.URL},
+			DeclareQueue:           true,
+			ExclusiveQueue:         true,
+			AutoDeleteQueue:        true,
+			ExchangeNameForBind:    constants.TaskEventsFanoutExchange,
+			DeclareExchangeForBind: true,
+			ExchangeTypeForBind:    "fanout",
+			DurableExchangeForBind: true,
+			PrefetchCount:          50,
+			WorkerPoolSize:         1, // порядок событий важен
+			ConsumerTag:            fmt.Sprintf("task-events-sse-%s-%d", hostname, os.Getpid()),
+		}
+		eventsListener, err = rabbitmq_adapter.NewTaskEventsConsumerAdapter(eventsConsumerCfg, sseNotifier, baseLogger, connManager)
+		if err != nil {
+			appLogger.Error("Failed to create task events consumer", err, nil)
+			dbPool.Close()
+			return nil, fmt.Errorf("failed to create task events consumer: %w", err)
+		}
+	}
+
 	appLogger.Debug("All RabbitMQ listeners initialized.", nil)
 
 	// Собираем приложение
@@ -214,6 +301,12 @@ func NewApp() (*App, error) {
 		apiServer:                      apiServer,
 		resultsListener:                resultsListener,
 		dlqListeners:    				dlqListeners,
+		replayAdapter:                  replayAdapter,
+		eventsListener:                 eventsListener,
+		eventsPublisher:                eventsPublisher,
+		eventLog:                       taskRepo,
+		timeoutStaleTasksUseCase:       timeoutStaleTasksUC,
+		applyTaskRetentionUseCase:      applyTaskRetentionUC,
 		logger:                         appLogger,
 		fluentClient:                   fluentClient,
 	}
@@ -260,6 +353,23 @@ func (a *App) Run() error {
 			}
 		}
 
+		if a.eventsListener != nil {
+			if err := a.eventsListener.Close(); err != nil {
+				a.logger.Error("Error closing task events listener", err, nil)
+			}
+		}
+		if a.eventsPublisher != nil {
+			if err := a.eventsPublisher.Close(); err != nil {
+				a.logger.Error("Error closing task events publisher", err, nil)
+			}
+		}
+
+		if a.replayAdapter != nil {
+			if err := a.replayAdapter.Close(); err != nil {
+				a.logger.Error("Error closing replay adapter", err, nil)
+			}
+		}
+
 		if a.dbPool != nil {
 			a.dbPool.Close()
 			a.logger.Debug("PostgreSQL pool closed.", nil)
@@ -306,6 +416,32 @@ func (a *App) Run() error {
 		go startListener("DLQ Listener", listener)
 	}
 
+	if a.eventsListener != nil {
+		wg.Add(1)
+		go startListener("Task Events Fanout Listener", a.eventsListener)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.runEventLogCleanup(appCtx)
+	}()
+
+	if a.config.Watchdog.Enabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.runWatchdog(appCtx)
+		}()
+	}
+	if a.config.Retention.Enabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.runRetention(appCtx)
+		}()
+	}
+
 	// Ожидание сигнала на завершение или ошибки от одного из компонентов
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -326,6 +462,75 @@ func (a *App) Run() error {
 	return nil
 }
 
+// runWatchdog периодически останавливает задачи, которые дольше окна бездействия не получали результатов
+func (a *App) runWatchdog(ctx context.Context) {
+	watchdogLogger := a.logger.WithFields(port.Fields{"component": "task_watchdog"})
+	ctx = contextkeys.ContextWithLogger(ctx, watchdogLogger)
+	watchdogLogger.Debug("Task watchdog started", port.Fields{"interval": a.config.Watchdog.CheckInterval.String()})
+
+	ticker := time.NewTicker(a.config.Watchdog.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			watchdogLogger.Debug("Task watchdog stopped", nil)
+			return
+		case <-ticker.C:
+			if _, err := a.timeoutStaleTasksUseCase.Execute(ctx); err != nil && ctx.Err() == nil {
+				watchdogLogger.Error("Failed to time out stale tasks", err, nil)
+			}
+		}
+	}
+}
+
+// runRetention периодически архивирует или удаляет завершенные задачи старше срока хранения
+func (a *App) runRetention(ctx context.Context) {
+	retentionLogger := a.logger.WithFields(port.Fields{"component": "task_retention"})
+	ctx = contextkeys.ContextWithLogger(ctx, retentionLogger)
+	retentionLogger.Debug("Task retention started", port.Fields{
+		"interval": a.config.Retention.CheckInterval.String(),
+		"max_age":  a.config.Retention.MaxAge.String(),
+		"mode":     a.config.Retention.Mode,
+	})
+
+	ticker := time.NewTicker(a.config.Retention.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			retentionLogger.Debug("Task retention stopped", nil)
+			return
+		case <-ticker.C:
+			if _, err := a.applyTaskRetentionUseCase.Execute(ctx); err != nil && ctx.Err() == nil {
+				retentionLogger.Error("Failed to apply task retention", err, nil)
+			}
+		}
+	}
+}
+
+// runEventLogCleanup периодически удаляет из журнала SSE-событий записи старше SSE_EVENT_LOG_TTL_MINUTES
+func (a *App) runEventLogCleanup(ctx context.Context) {
+	cleanupLogger := a.logger.WithFields(port.Fields{"component": "task_event_log_cleanup"})
+
+	ticker := time.NewTicker(a.config.SSE.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := a.eventLog.DeleteEventsBefore(ctx, time.Now().Add(-a.config.SSE.EventLogTTL))
+			if err != nil && ctx.Err() == nil {
+				cleanupLogger.Error("Failed to delete old task events", err, nil)
+				continue
+			}
+			if deleted > 0 {
+				cleanupLogger.Debug("Old task events deleted", port.Fields{"deleted": deleted})
+			}
+		}
+	}
+}
+
 func parseLogLevel(levelStr string) slog.Level {
 	switch strings.ToLower(levelStr) {
 	case "debug":
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -21,6 +23,12 @@ type RESTconfig struct {
 	PORT string
 }
 
+// ActualizationClientConfig - actualization-service, который запускает повторы задач
+type ActualizationClientConfig struct {
+	URL     string
+	Timeout time.Duration
+}
+
 type FluentBitConfig struct {
 	Host string
 	Port int
@@ -28,6 +36,33 @@ type FluentBitConfig struct {
 	Level   string `mapstructure:"FLUENTBIT_LOG_LEVEL" default:"info"` // По умолчанию INFO
 }
 
+// WatchdogConfig - остановка задач, которые долго не продвигаются
+type WatchdogConfig struct {
+	Enabled        bool
+	CheckInterval  time.Duration
+	DefaultTimeout time.Duration            // 0 - задачи без своего окна не останавливаются
+	TimeoutsByType map[string]time.Duration // окна по типам задач, перекрывают DefaultTimeout
+}
+
+// RetentionConfig - очистка старых завершенных задач
+type RetentionConfig struct {
+	Enabled       bool
+	CheckInterval time.Duration
+	MaxAge        time.Duration
+	Mode          string // "archive" - перенести в tasks_archive, "delete" - удалить
+	BatchSize     int
+}
+
+// SSEConfig - доставка событий подписчикам
+type SSEConfig struct {
+	FanoutEnabled     bool          // рассылка событий между репликами через RabbitMQ
+	ClientBuffer      int           // размер буфера одного соединения
+	ReplayLimit       int           // сколько пропущенных событий максимум досылать по Last-Event-ID
+	HeartbeatInterval time.Duration
+	EventLogTTL       time.Duration // сколько хранить журнал событий
+	CleanupInterval   time.Duration
+}
+
 type StdoutLogConfig struct {
     Level string `mapstructure:"STDOUT_LOG_LEVEL" default:"debug"` // По умолчанию DEBUG
 }
@@ -40,6 +75,10 @@ type AppConfig struct {
 	Rest		RESTconfig
 	FluentBit	FluentBitConfig
 	StdoutLogger StdoutLogConfig
+	Watchdog     WatchdogConfig
+	Retention    RetentionConfig
+	SSE          SSEConfig
+	Actualization ActualizationClientConfig
 }
 
 // LoadConfig загружает конфигурацию из переменных окружения.
@@ -75,6 +114,12 @@ func LoadConfig(envPath ...string) (*AppConfig, error) {
 		cfg.Rest.PORT = "8083"
 	}
 
+	cfg.Actualization.URL = os.Getenv("ACTUALIZATION_SERVICE_URL")
+	if cfg.Actualization.URL == "" {
+		cfg.Actualization.URL = "http://localhost:8084"
+	}
+	cfg.Actualization.Timeout = time.Duration(getEnvAsInt("ACTUALIZATION_REQUEST_TIMEOUT_SECONDS", 30)) * time.Second
+
 	cfg.RabbitMQ.URL = os.Getenv("RABBITMQ_URL")
 	if cfg.RabbitMQ.URL == "" {
 		return nil, fmt.Errorf("RABBITMQ_URL environment variable is required")
@@ -99,10 +144,65 @@ func LoadConfig(envPath ...string) (*AppConfig, error) {
 
 	cfg.StdoutLogger.Level = getEnvAsString("STDOUT_LOG_LEVEL", "debug")
 
+	cfg.Watchdog.Enabled = getEnvAsBool("TASK_WATCHDOG_ENABLED", true)
+	cfg.Watchdog.CheckInterval = time.Duration(getEnvAsInt("TASK_WATCHDOG_INTERVAL_SECONDS", 60)) * time.Second
+	if cfg.Watchdog.CheckInterval <= 0 {
+		return nil, fmt.Errorf("TASK_WATCHDOG_INTERVAL_SECONDS must be positive")
+	}
+	cfg.Watchdog.DefaultTimeout = time.Duration(getEnvAsInt("TASK_TIMEOUT_DEFAULT_MINUTES", 30)) * time.Minute
+	// Формат: "FIND_NEW=60,ACTUALIZE_ACTIVE=45" - минуты по типам задач
+	cfg.Watchdog.TimeoutsByType, err = parseTimeoutsByType(getEnvAsString("TASK_TIMEOUTS_BY_TYPE", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Retention.Enabled = getEnvAsBool("TASK_RETENTION_ENABLED", true)
+	cfg.Retention.CheckInterval = time.Duration(getEnvAsInt("TASK_RETENTION_INTERVAL_MINUTES", 60)) * time.Minute
+	cfg.Retention.MaxAge = time.Duration(getEnvAsInt("TASK_RETENTION_DAYS", 90)) * 24 * time.Hour
+	cfg.Retention.BatchSize = getEnvAsInt("TASK_RETENTION_BATCH_SIZE", 500)
+	if cfg.Retention.CheckInterval <= 0 || cfg.Retention.MaxAge <= 0 || cfg.Retention.BatchSize <= 0 {
+		return nil, fmt.Errorf("TASK_RETENTION_INTERVAL_MINUTES, TASK_RETENTION_DAYS and TASK_RETENTION_BATCH_SIZE must be positive")
+	}
+	cfg.Retention.Mode = getEnvAsString("TASK_RETENTION_MODE", "archive")
+	if cfg.Retention.Mode == "" {
+		cfg.Retention.Mode = "archive"
+	}
+	if cfg.Retention.Mode != "archive" && cfg.Retention.Mode != "delete" {
+		return nil, fmt.Errorf("TASK_RETENTION_MODE must be 'archive' or 'delete', got %q", cfg.Retention.Mode)
+	}
+
+	cfg.SSE.FanoutEnabled = getEnvAsBool("SSE_FANOUT_ENABLED", true)
+	cfg.SSE.ClientBuffer = getEnvAsInt("SSE_CLIENT_BUFFER", 100)
+	cfg.SSE.ReplayLimit = getEnvAsInt("SSE_REPLAY_LIMIT", 500)
+	cfg.SSE.HeartbeatInterval = time.Duration(getEnvAsInt("SSE_HEARTBEAT_SECONDS", 15)) * time.Second
+	cfg.SSE.EventLogTTL = time.Duration(getEnvAsInt("SSE_EVENT_LOG_TTL_MINUTES", 60)) * time.Minute
+	cfg.SSE.CleanupInterval = time.Duration(getEnvAsInt("SSE_EVENT_LOG_CLEANUP_MINUTES", 5)) * time.Minute
+	if cfg.SSE.ClientBuffer <= 0 || cfg.SSE.ReplayLimit <= 0 || cfg.SSE.HeartbeatInterval <= 0 ||
+		cfg.SSE.EventLogTTL <= 0 || cfg.SSE.CleanupInterval <= 0 {
+		return nil, fmt.Errorf("SSE_* settings must be positive")
+	}
+
 	return cfg, nil
 }
 
 
+func parseTimeoutsByType(value string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		taskType, minutesStr, ok := strings.Cut(pair, "=")
+		minutes, err := strconv.Atoi(strings.TrimSpace(minutesStr))
+		if !ok || err != nil || minutes < 0 {
+			return nil, fmt.Errorf("invalid TASK_TIMEOUTS_BY_TYPE entry '%s', expected TYPE=minutes", pair)
+		}
+		timeouts[strings.TrimSpace(taskType)] = time.Duration(minutes) * time.Minute
+	}
+	return timeouts, nil
+}
+
 func getEnvAsString(key string, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 // Имена очередей
 const (
 	QueueTaskResults          = "task_results"
@@ -30,4 +32,10 @@ const (
 	LinkParsingTasksDlq = "link_parsing_tasks_final_dlq"
 	TasksForSearchDlq = "tasks_for_search_final_dlq"
 	ProcessedPropertiesDlq = "processed_properties_final_dlq"
-)
\ No newline at end of file
+)
+
+// Уровни ретраев для результатов задач
+var TaskResultsRetryDelays = []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}
+
+// Fanout-обменник событий для SSE: каждая реплика читает его через свою временную очередь
+const TaskEventsFanoutExchange = "task_events_fanout"
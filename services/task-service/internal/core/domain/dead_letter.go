@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterStatus - состояние сообщения, попавшего в финальную DLQ
+type DeadLetterStatus string
+
+const (
+	DeadLetterStatusNew       DeadLetterStatus = "new"
+	DeadLetterStatusReplayed  DeadLetterStatus = "replayed"
+	DeadLetterStatusDiscarded DeadLetterStatus = "discarded"
+)
+
+// DeathRecord - одна запись из заголовка x-death
+type DeathRecord struct {
+	Queue       string     `json:"queue"`
+	Reason      string     `json:"reason"`
+	Exchange    string     `json:"exchange"`
+	RoutingKeys []string   `json:"routing_keys"`
+	Count       int64      `json:"count"`
+	Time        *time.Time `json:"time,omitempty"`
+}
+
+// DeadLetter - сохраненное "мертвое" сообщение вместе с историей его ретраев
+type DeadLetter struct {
+	ID                 uuid.UUID
+	SourceQueue        string // Финальная DLQ, из которой было прочитано сообщение
+	OriginalQueue      string
+	OriginalExchange   string
+	OriginalRoutingKey string
+	FailureReason      string
+	TaskID             *uuid.UUID
+	ContentType        string
+	Body               []byte
+	Headers            map[string]interface{}
+	DeathHistory       []DeathRecord
+	Status             DeadLetterStatus
+	ReplayCount        int
+	ReceivedAt         time.Time
+	LastReplayedAt     *time.Time
+	DiscardedAt        *time.Time
+}
+
+// DeadLetterFilter - параметры выборки сообщений из DLQ
+type DeadLetterFilter struct {
+	SourceQueue string
+	Status      DeadLetterStatus
+	TaskID      *uuid.UUID
+	Reason      string // Поиск подстроки в причине ошибки
+	From        *time.Time
+	To          *time.Time
+}
+
+// ReplayResult - итог повторной отправки пачки сообщений
+type ReplayResult struct {
+	Replayed  int         `json:"replayed"`
+	Failed    int         `json:"failed"`
+	FailedIDs []uuid.UUID `json:"failed_ids,omitempty"`
+}
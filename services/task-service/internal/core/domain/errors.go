@@ -4,4 +4,18 @@ import "errors"
 
 var (
 	ErrTaskNotFound      = errors.New("user not found")
-)
\ No newline at end of file
+	// ErrTaskNotStale - задача успела продвинуться или завершиться, останавливать ее не нужно
+	ErrTaskNotStale      = errors.New("task is not stale")
+	// ErrTaskFinished - задача уже в финальном статусе, сменить его нельзя (например, поздний running после timed_out)
+	ErrTaskFinished      = errors.New("task is already finished")
+	// ErrTaskNotFinished - повторить можно только завершившуюся задачу
+	ErrTaskNotFinished   = errors.New("task is not finished yet")
+	// ErrRestartNotSupported - для задач этого типа повтор или продолжение не поддерживается
+	ErrRestartNotSupported = errors.New("restart is not supported for this task")
+	// ErrNothingToResume - по всем отправленным единицам работы уже пришли результаты
+	ErrNothingToResume   = errors.New("task has no unreported items to resume")
+
+	ErrDeadLetterNotFound  = errors.New("dead letter not found")
+	ErrDeadLetterDiscarded = errors.New("dead letter was discarded")
+	ErrDeadLetterNoRoute   = errors.New("dead letter has no original exchange or routing key")
+)
@@ -14,8 +14,15 @@ const (
 	StatusRunning   TaskStatus = "running"
 	StatusCompleted TaskStatus = "completed"
 	StatusFailed    TaskStatus = "failed"
+	// StatusTimedOut - задача долго не продвигалась и остановлена сторожем, в ResultSummary частичные результаты
+	StatusTimedOut TaskStatus = "timed_out"
 )
 
+// IsFinal - задача больше не меняет статус и не принимает результаты
+func (s TaskStatus) IsFinal() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusTimedOut
+}
+
 // ResultSummary - структура для хранения сводной информации о результатах
 // Использование map[string]interface{} делает ее гибкой для разных типов задач
 type ResultSummary map[string]interface{}
@@ -32,17 +39,26 @@ type Task struct {
 	FinishedAt       *time.Time 	`json:"finished_at"`
 	CreatedByUserID  uuid.UUID		`json:"created_by_user_id"`
 	TargetObjectID 	 *uuid.UUID 	`json:"target_object_id,omitempty"` 
+	LastProgressAt   time.Time		`json:"last_progress_at"`
+	Params           TaskParams		`json:"params"`
+	ParentTaskID     *uuid.UUID		`json:"parent_task_id,omitempty"`
+	RestartMode      RestartMode	`json:"restart_mode,omitempty"`
+	// Subtasks не хранится в tasks: при чтении по ID - все подзадачи, в SSE-событии - только изменившиеся
+	Subtasks         []TaskSubtask	`json:"subtasks,omitempty"`
 }
 
 // NewTask - конструктор для создания новой задачи
 func NewTask(name, taskType string, createdByUserID uuid.UUID) *Task {
+	now := time.Now().UTC()
 	return &Task{
 		ID:              uuid.New(),
 		Name:            name,
 		Type:            taskType,
 		Status:          StatusPending, // Начальный статус
-		CreatedAt:       time.Now().UTC(),
+		CreatedAt:       now,
 		CreatedByUserID: createdByUserID,
 		ResultSummary: make(ResultSummary),
+		LastProgressAt:  now,
+		Params:          make(TaskParams),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskEventRecord - событие для подписчиков, сохраненное в журнале. ID растет монотонно в порядке коммита
+// и используется как id SSE-события: по Last-Event-ID клиент получает пропущенное
+type TaskEventRecord struct {
+	ID        int64           `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskFilter - параметры админского поиска задач. Пустые поля не ограничивают выборку
+type TaskFilter struct {
+	Type            string
+	Status          TaskStatus
+	CreatedByUserID *uuid.UUID
+	From            *time.Time // по created_at
+	To              *time.Time
+	TargetObjectID  string // master_id объекта для ACTUALIZE_BY_ID
+}
+
+// TaskSortField - поле сортировки результатов поиска
+type TaskSortField string
+
+const (
+	TaskSortCreatedAt  TaskSortField = "created_at"
+	TaskSortFinishedAt TaskSortField = "finished_at"
+	TaskSortDuration   TaskSortField = "duration"
+	TaskSortType       TaskSortField = "type"
+	TaskSortStatus     TaskSortField = "status"
+)
+
+// IsValid - поле поддерживается поиском
+func (f TaskSortField) IsValid() bool {
+	switch f {
+	case TaskSortCreatedAt, TaskSortFinishedAt, TaskSortDuration, TaskSortType, TaskSortStatus:
+		return true
+	}
+	return false
+}
+
+// TaskSort - порядок результатов поиска
+type TaskSort struct {
+	Field TaskSortField
+	Desc  bool
+}
+
+// TaskTypeStats - сводка по задачам одного типа
+type TaskTypeStats struct {
+	Type               string  `json:"type"`
+	Total              int64   `json:"total"`
+	Completed          int64   `json:"completed"`
+	Failed             int64   `json:"failed"`
+	TimedOut           int64   `json:"timed_out"`
+	Active             int64   `json:"active"`               // pending и running
+	SuccessRate        float64 `json:"success_rate"`         // доля completed среди завершенных, 0..1
+	AvgDurationSeconds float64 `json:"avg_duration_seconds"` // по успешно завершенным
+}
+
+// RetentionMode - что делать со старыми завершенными задачами
+type RetentionMode string
+
+const (
+	RetentionArchive RetentionMode = "archive" // перенести в tasks_archive
+	RetentionDelete  RetentionMode = "delete"
+)
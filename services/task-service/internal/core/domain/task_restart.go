@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// TaskParams - входные параметры задачи, по которым ее можно запустить повторно.
+// Набор ключей зависит от типа задачи: categories, regions, category, limit_per_category, object_id, ...
+type TaskParams map[string]interface{}
+
+// RestartMode - как новая задача связана с исходной
+type RestartMode string
+
+const (
+	// RestartRerun - задача запущена заново с теми же параметрами
+	RestartRerun RestartMode = "rerun"
+	// RestartResume - повторно отправлены только единицы работы, по которым не пришли результаты
+	RestartResume RestartMode = "resume"
+)
+
+// Типы задач, которые умеет запускать actualization-service
+const (
+	TaskTypeFindNew           = "FIND_NEW"
+	TaskTypeActualizeActive   = "ACTUALIZE_ACTIVE"
+	TaskTypeActualizeArchived = "ACTUALIZE_ARCHIVED"
+	TaskTypeActualizeByID     = "ACTUALIZE_BY_ID"
+)
+
+// SupportsRestart - можно ли перезапустить задачу этого типа в заданном режиме.
+// Продолжение имеет смысл только для задач, которые отправляют много единиц работы
+func SupportsRestart(taskType string, mode RestartMode) bool {
+	switch taskType {
+	case TaskTypeFindNew, TaskTypeActualizeActive, TaskTypeActualizeArchived:
+		return mode == RestartRerun || mode == RestartResume
+	case TaskTypeActualizeByID:
+		return mode == RestartRerun
+	}
+	return false
+}
+
+// TaskItem - единица работы задачи: один поиск или одна ссылка
+type TaskItem struct {
+	Key        string                 `json:"key"`
+	Payload    map[string]interface{} `json:"payload"`
+	ReportedAt *time.Time             `json:"reported_at,omitempty"`
+}
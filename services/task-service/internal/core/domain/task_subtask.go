@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// TaskSubtask - накопленный прогресс одной подзадачи: поиска парсера по одному критерию
+// или сохранения результатов в storage-service
+type TaskSubtask struct {
+	Source     string    `json:"source"`
+	Name       string    `json:"name"`
+	LinksFound int       `json:"links_found"`
+	Processed  int       `json:"processed"`
+	Errors     int       `json:"errors"`
+	DurationMs int64     `json:"duration_ms"` // суммарно по всем отчетам
+	LastError  string    `json:"last_error,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
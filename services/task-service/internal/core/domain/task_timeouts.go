@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Ключи ResultSummary, которые записывает сторож при остановке задачи
+const (
+	SummaryTimeoutReason       = "timeout_reason"
+	SummaryInactiveSeconds     = "inactive_seconds"
+	SummaryStatusBeforeTimeout = "status_before_timeout"
+)
+
+// TaskTimeouts - сколько задача может не продвигаться, прежде чем сторож ее остановит.
+// Нулевое окно - задачи этого типа не останавливаются
+type TaskTimeouts struct {
+	Default time.Duration
+	ByType  map[string]time.Duration
+}
+
+// For возвращает окно бездействия для типа задачи
+func (t TaskTimeouts) For(taskType string) time.Duration {
+	if window, ok := t.ByType[taskType]; ok {
+		return window
+	}
+	return t.Default
+}
+
+// Shortest - наименьшее включенное окно. 0 - сторожу проверять нечего
+func (t TaskTimeouts) Shortest() time.Duration {
+	shortest := t.Default
+	for _, window := range t.ByType {
+		if window > 0 && (shortest <= 0 || window < shortest) {
+			shortest = window
+		}
+	}
+	return shortest
+}
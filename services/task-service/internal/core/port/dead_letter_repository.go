@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterRepositoryPort - хранилище сообщений из финальных DLQ
+type DeadLetterRepositoryPort interface {
+	Save(ctx context.Context, letter *domain.DeadLetter) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error)
+	FindAll(ctx context.Context, filter domain.DeadLetterFilter, limit, offset int) ([]domain.DeadLetter, int64, error)
+	// MarkReplayed увеличивает счетчик повторов и переводит сообщение в статус replayed
+	MarkReplayed(ctx context.Context, id uuid.UUID) error
+	MarkDiscarded(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+)
+
+// MessageReplayerPort - контракт для повторной отправки сообщения в исходный обменник
+type MessageReplayerPort interface {
+	Replay(ctx context.Context, letter *domain.DeadLetter) error
+}
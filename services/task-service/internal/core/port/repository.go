@@ -3,14 +3,23 @@ package port
 import (
     "context"
     "task-service/internal/core/domain"
+    "time"
+
     "github.com/google/uuid"
 )
 
 type TaskRepositoryPort interface {
     Create(ctx context.Context, task *domain.Task) error
+    // Update не трогает задачи в финальном статусе: для них domain.ErrTaskFinished
     Update(ctx context.Context, task *domain.Task) error
     FindByID(ctx context.Context, taskID uuid.UUID) (*domain.Task, error)
     FindAll(ctx context.Context, createdByUserID uuid.UUID, limit, offset int) ([]domain.Task, int64, error)
     // Метод для инкрементального обновления результатов
     IncrementSummary(ctx context.Context, taskID uuid.UUID, results map[string]int) (*domain.Task, error)
+    // FindStale - незавершенные задачи, которые к моменту now не продвигались дольше окна своего типа,
+    // самые давние первыми. Типы с нулевым окном не возвращаются
+    FindStale(ctx context.Context, timeouts domain.TaskTimeouts, now time.Time, limit int) ([]domain.Task, error)
+    // MarkTimedOut переводит задачу в timed_out и дописывает reason в result_summary,
+    // только если с момента before задача так и не продвинулась. Иначе domain.ErrTaskNotStale
+    MarkTimedOut(ctx context.Context, taskID uuid.UUID, before time.Time, reason domain.ResultSummary) (*domain.Task, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+)
+
+// TaskDispatcherPort - запуск работы по уже созданной задаче в сервисе, который ее выполняет.
+// items пусто - работа собирается заново по task.Params, иначе отправляются только эти единицы работы
+type TaskDispatcherPort interface {
+	Dispatch(ctx context.Context, task *domain.Task, items []domain.TaskItem) error
+}
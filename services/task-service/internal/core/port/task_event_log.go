@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskEventLogPort - короткий журнал отправленных событий для повторной выдачи после переподключения
+type TaskEventLogPort interface {
+	// AppendEvent сохраняет событие и возвращает его с присвоенным ID.
+	// События становятся видны читателям строго в порядке ID, иначе досылка по Last-Event-ID теряет события
+	AppendEvent(ctx context.Context, event domain.TaskEventRecord) (domain.TaskEventRecord, error)
+	// FindEventsAfter - события пользователя с ID больше afterID по возрастанию, не больше limit
+	FindEventsAfter(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.TaskEventRecord, error)
+	// OldestEventID - минимальный ID в журнале, 0 если журнал пуст
+	OldestEventID(ctx context.Context) (int64, error)
+	// DeleteEventsBefore удаляет события старше before
+	DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TaskEventFanoutPort - рассылка событий всем репликам task-service, у каждой свои SSE-клиенты
+type TaskEventFanoutPort interface {
+	PublishEvent(ctx context.Context, event domain.TaskEventRecord) error
+}
+
+// TaskEventReceiverPort - получатель событий, пришедших через fanout
+type TaskEventReceiverPort interface {
+	Deliver(ctx context.Context, event domain.TaskEventRecord)
+}
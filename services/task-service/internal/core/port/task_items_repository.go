@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TaskItemsRepositoryPort - единицы работы, отправленные в рамках задачи
+type TaskItemsRepositoryPort interface {
+	// RegisterItems сохраняет отправленные единицы работы. Повторная регистрация ключа ничего не меняет
+	RegisterItems(ctx context.Context, taskID uuid.UUID, items []domain.TaskItem) error
+	// MarkItemsReported отмечает единицы работы, по которым пришел результат. Незарегистрированные ключи пропускаются
+	MarkItemsReported(ctx context.Context, taskID uuid.UUID, keys []string) error
+	// FindUnreportedItems - единицы работы задачи, по которым результата так и не было
+	FindUnreportedItems(ctx context.Context, taskID uuid.UUID) ([]domain.TaskItem, error)
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+	"time"
+)
+
+// TaskSearchRepositoryPort - админские выборки по задачам всех пользователей
+type TaskSearchRepositoryPort interface {
+	Search(ctx context.Context, filter domain.TaskFilter, sort domain.TaskSort, limit, offset int) ([]domain.Task, int64, error)
+	// Stats - сводка по типам задач. Фильтр по статусу не применяется
+	Stats(ctx context.Context, filter domain.TaskFilter) ([]domain.TaskTypeStats, error)
+}
+
+// TaskRetentionRepositoryPort - очистка старых завершенных задач
+type TaskRetentionRepositoryPort interface {
+	// RemoveFinishedBefore убирает до limit завершенных задач, закончившихся раньше before,
+	// вместе с их единицами работы и подзадачами. В режиме archive задачи сначала копируются в архив
+	RemoveFinishedBefore(ctx context.Context, before time.Time, limit int, mode domain.RetentionMode) (int, error)
+}
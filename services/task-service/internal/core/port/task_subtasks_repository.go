@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TaskSubtasksRepositoryPort - прогресс задачи в разбивке по подзадачам
+type TaskSubtasksRepositoryPort interface {
+	// AddSubtaskProgress прибавляет счетчики отчета к подзадачам и возвращает их новое состояние
+	AddSubtaskProgress(ctx context.Context, taskID uuid.UUID, progress []domain.TaskSubtask) ([]domain.TaskSubtask, error)
+	// FindSubtasks - все подзадачи задачи
+	FindSubtasks(ctx context.Context, taskID uuid.UUID) ([]domain.TaskSubtask, error)
+}
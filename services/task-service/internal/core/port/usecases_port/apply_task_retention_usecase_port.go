@@ -0,0 +1,8 @@
+package usecases_port
+
+import "context"
+
+type ApplyTaskRetentionUseCasePort interface {
+	// Execute убирает задачи старше срока хранения и возвращает, сколько задач убрано
+	Execute(ctx context.Context) (int, error)
+}
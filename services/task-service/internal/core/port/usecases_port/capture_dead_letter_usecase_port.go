@@ -0,0 +1,11 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+)
+
+type CaptureDeadLetterUseCasePort interface {
+	Execute(ctx context.Context, letter *domain.DeadLetter) error
+}
\ No newline at end of file
@@ -8,5 +8,5 @@ import (
 )
 
 type CreateTaskUseCasePort interface {
-	Execute(ctx context.Context, name, taskType string, userID uuid.UUID, params... any) (*domain.Task, error)
+	Execute(ctx context.Context, name, taskType string, userID uuid.UUID, params domain.TaskParams) (*domain.Task, error)
 }
\ No newline at end of file
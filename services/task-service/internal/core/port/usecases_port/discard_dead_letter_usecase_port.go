@@ -0,0 +1,12 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type DiscardDeadLetterUseCasePort interface {
+	Execute(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error)
+}
\ No newline at end of file
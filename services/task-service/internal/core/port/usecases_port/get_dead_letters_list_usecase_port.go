@@ -0,0 +1,11 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+)
+
+type GetDeadLettersListUseCasePort interface {
+	Execute(ctx context.Context, filter domain.DeadLetterFilter, limit, offset int) ([]domain.DeadLetter, int64, error)
+}
\ No newline at end of file
@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+)
+
+type GetTaskStatsUseCasePort interface {
+	Execute(ctx context.Context, filter domain.TaskFilter) ([]domain.TaskTypeStats, error)
+}
@@ -2,9 +2,12 @@ package usecases_port
 
 import (
 	"context"
+	"task-service/internal/core/domain"
 	"github.com/google/uuid"
 )
 
 type ProcessTaskResultUseCasePort interface {
-	Execute(ctx context.Context, taskID uuid.UUID, results map[string]int) error
+	// items - ключи единиц работы, по которым пришел результат (может быть пустым),
+	// subtasks - прирост счетчиков по подзадачам
+	Execute(ctx context.Context, taskID uuid.UUID, results map[string]int, items []string, subtasks []domain.TaskSubtask) error
 }
\ No newline at end of file
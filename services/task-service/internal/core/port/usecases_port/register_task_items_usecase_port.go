@@ -0,0 +1,12 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type RegisterTaskItemsUseCasePort interface {
+	Execute(ctx context.Context, taskID uuid.UUID, items []domain.TaskItem) error
+}
@@ -0,0 +1,13 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type ReplayDeadLettersUseCasePort interface {
+	ReplayOne(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error)
+	ReplayBatch(ctx context.Context, filter domain.DeadLetterFilter, limit int) (*domain.ReplayResult, error)
+}
\ No newline at end of file
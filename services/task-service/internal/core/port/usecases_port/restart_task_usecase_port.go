@@ -0,0 +1,13 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type RestartTaskUseCasePort interface {
+	// Execute создает по завершенной задаче новую, связанную с ней, и запускает ее
+	Execute(ctx context.Context, taskID uuid.UUID, mode domain.RestartMode, userID uuid.UUID) (*domain.Task, error)
+}
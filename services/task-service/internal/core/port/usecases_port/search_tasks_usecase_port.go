@@ -0,0 +1,10 @@
+package usecases_port
+
+import (
+	"context"
+	"task-service/internal/core/domain"
+)
+
+type SearchTasksUseCasePort interface {
+	Execute(ctx context.Context, filter domain.TaskFilter, sort domain.TaskSort, limit, offset int) ([]domain.Task, int64, error)
+}
@@ -0,0 +1,8 @@
+package usecases_port
+
+import "context"
+
+type TimeoutStaleTasksUseCasePort interface {
+	// Execute останавливает зависшие задачи и возвращает, сколько задач остановлено
+	Execute(ctx context.Context) (int, error)
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+)
+
+// ApplyTaskRetentionUseCase архивирует или удаляет завершенные задачи старше срока хранения.
+// Задачи убираются пачками, чтобы не держать долгую блокировку на tasks
+type ApplyTaskRetentionUseCase struct {
+	repo      port.TaskRetentionRepositoryPort
+	maxAge    time.Duration
+	mode      domain.RetentionMode
+	batchSize int
+}
+
+func NewApplyTaskRetentionUseCase(repo port.TaskRetentionRepositoryPort, maxAge time.Duration, mode domain.RetentionMode, batchSize int) *ApplyTaskRetentionUseCase {
+	return &ApplyTaskRetentionUseCase{
+		repo:      repo,
+		maxAge:    maxAge,
+		mode:      mode,
+		batchSize: batchSize,
+	}
+}
+
+func (uc *ApplyTaskRetentionUseCase) Execute(ctx context.Context) (int, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "ApplyTaskRetention", "mode": uc.mode})
+
+	before := time.Now().UTC().Add(-uc.maxAge)
+	total := 0
+	for ctx.Err() == nil {
+		removed, err := uc.repo.RemoveFinishedBefore(ctx, before, uc.batchSize, uc.mode)
+		if err != nil {
+			ucLogger.Error("Repository failed to remove finished tasks", err, port.Fields{"removed_so_far": total})
+			return total, err
+		}
+		total += removed
+		if removed < uc.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		ucLogger.Info("Old finished tasks removed", port.Fields{"removed": total, "finished_before": before})
+	}
+	return total, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+)
+
+type CaptureDeadLetterUseCase struct {
+	repo port.DeadLetterRepositoryPort
+}
+
+func NewCaptureDeadLetterUseCase(repo port.DeadLetterRepositoryPort) *CaptureDeadLetterUseCase {
+	return &CaptureDeadLetterUseCase{
+		repo: repo,
+	}
+}
+
+// Execute сохраняет сообщение из финальной DLQ, чтобы его можно было изучить и отправить повторно
+func (uc *CaptureDeadLetterUseCase) Execute(ctx context.Context, letter *domain.DeadLetter) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":       "CaptureDeadLetter",
+		"dead_letter_id": letter.ID.String(),
+		"source_queue":   letter.SourceQueue,
+	})
+
+	ucLogger.Info("Use case started", nil)
+
+	if letter.Status == "" {
+		letter.Status = domain.DeadLetterStatusNew
+	}
+
+	if err := uc.repo.Save(ctx, letter); err != nil {
+		ucLogger.Error("Repository failed to save dead letter", err, nil)
+		return err
+	}
+
+	ucLogger.Info("Use case finished successfully", nil)
+	return nil
+}
@@ -21,7 +21,8 @@ func NewCreateTaskUseCase(repo port.TaskRepositoryPort, notifier port.NotifierPo
 	}
 }
 
-func (uc *CreateTaskUseCase) Execute(ctx context.Context, name, taskType string, userID uuid.UUID, params... any) (*domain.Task, error) {
+// Execute создает задачу. params сохраняются вместе с задачей, чтобы ее можно было запустить повторно
+func (uc *CreateTaskUseCase) Execute(ctx context.Context, name, taskType string, userID uuid.UUID, params domain.TaskParams) (*domain.Task, error) {
 	logger := contextkeys.LoggerFromContext(ctx)
 	ucLogger := logger.WithFields(port.Fields{
 		"use_case": "CreateTask",
@@ -33,8 +34,12 @@ func (uc *CreateTaskUseCase) Execute(ctx context.Context, name, taskType string,
 	ucLogger.Info("Use case started", nil)
 	
 	task := domain.NewTask(name, taskType, userID)
-	if len(params) > 0 {
-		task.ResultSummary["id"] = params[0]
+	if params != nil {
+		task.Params = params
+	}
+	// Клиенты показывают актуализируемый объект из сводки
+	if objectID, ok := params["object_id"]; ok {
+		task.ResultSummary["id"] = objectID
 	}
 
 	if err := uc.repo.Create(ctx, task); err != nil {
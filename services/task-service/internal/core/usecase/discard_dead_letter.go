@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+type DiscardDeadLetterUseCase struct {
+	repo port.DeadLetterRepositoryPort
+}
+
+func NewDiscardDeadLetterUseCase(repo port.DeadLetterRepositoryPort) *DiscardDeadLetterUseCase {
+	return &DiscardDeadLetterUseCase{
+		repo: repo,
+	}
+}
+
+// Execute помечает сообщение как отброшенное. Само сообщение остается в БД для истории
+func (uc *DiscardDeadLetterUseCase) Execute(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "DiscardDeadLetter", "dead_letter_id": id.String()})
+
+	ucLogger.Info("Use case started", nil)
+
+	if err := uc.repo.MarkDiscarded(ctx, id); err != nil {
+		ucLogger.Error("Repository failed to discard dead letter", err, nil)
+		return nil, err
+	}
+
+	letter, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		ucLogger.Error("Repository failed to find dead letter", err, nil)
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", nil)
+	return letter, nil
+}
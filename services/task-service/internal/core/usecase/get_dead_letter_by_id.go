@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+type GetDeadLetterByIdUseCase struct {
+	repo port.DeadLetterRepositoryPort
+}
+
+func NewGetDeadLetterByIdUseCase(repo port.DeadLetterRepositoryPort) *GetDeadLetterByIdUseCase {
+	return &GetDeadLetterByIdUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetDeadLetterByIdUseCase) Execute(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "GetDeadLetterById", "dead_letter_id": id.String()})
+
+	ucLogger.Info("Use case started", nil)
+
+	letter, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		ucLogger.Error("Repository failed to find dead letter", err, nil)
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", nil)
+	return letter, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+)
+
+type GetDeadLettersListUseCase struct {
+	repo port.DeadLetterRepositoryPort
+}
+
+func NewGetDeadLettersListUseCase(repo port.DeadLetterRepositoryPort) *GetDeadLettersListUseCase {
+	return &GetDeadLettersListUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetDeadLettersListUseCase) Execute(ctx context.Context, filter domain.DeadLetterFilter, limit, offset int) ([]domain.DeadLetter, int64, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "GetDeadLettersList", "filter": filter, "limit": limit, "offset": offset})
+
+	ucLogger.Info("Use case started", nil)
+
+	letters, count, err := uc.repo.FindAll(ctx, filter, limit, offset)
+	if err != nil {
+		ucLogger.Error("Repository failed to find dead letters", err, nil)
+		return nil, 0, err
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"found_on_page": len(letters), "total_count": count})
+	return letters, count, nil
+}
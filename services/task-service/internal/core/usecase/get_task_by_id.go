@@ -10,12 +10,14 @@ import (
 )
 
 type GetTaskByIdUseCase struct {
-	repo port.TaskRepositoryPort
+	repo     port.TaskRepositoryPort
+	subtasks port.TaskSubtasksRepositoryPort
 }
 
-func NewGetTaskByIdUseCase(repo port.TaskRepositoryPort) *GetTaskByIdUseCase {
+func NewGetTaskByIdUseCase(repo port.TaskRepositoryPort, subtasks port.TaskSubtasksRepositoryPort) *GetTaskByIdUseCase {
 	return &GetTaskByIdUseCase{
-		repo: repo,
+		repo:     repo,
+		subtasks: subtasks,
 	}
 }
 
@@ -31,6 +33,12 @@ func (uc *GetTaskByIdUseCase) Execute(ctx context.Context, taskId uuid.UUID) (*d
 		return nil, err
 	}
 
+	task.Subtasks, err = uc.subtasks.FindSubtasks(ctx, taskId)
+	if err != nil {
+		ucLogger.Error("Repository failed to find task subtasks", err, nil)
+		return nil, err
+	}
+
 	ucLogger.Info("Use case finished successfully", nil)
 	return task, nil
 }
\ No newline at end of file
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+)
+
+// GetTaskStatsUseCase - доля успешных задач и средняя длительность по типам
+type GetTaskStatsUseCase struct {
+	repo port.TaskSearchRepositoryPort
+}
+
+func NewGetTaskStatsUseCase(repo port.TaskSearchRepositoryPort) *GetTaskStatsUseCase {
+	return &GetTaskStatsUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetTaskStatsUseCase) Execute(ctx context.Context, filter domain.TaskFilter) ([]domain.TaskTypeStats, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "GetTaskStats"})
+
+	stats, err := uc.repo.Stats(ctx, filter)
+	if err != nil {
+		ucLogger.Error("Repository failed to compute task stats", err, nil)
+		return nil, err
+	}
+	return stats, nil
+}
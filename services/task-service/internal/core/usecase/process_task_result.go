@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	// "log"
 	"task-service/internal/contextkeys"
@@ -14,19 +15,23 @@ import (
 
 type ProcessTaskResultUseCase struct {
 	repo     port.TaskRepositoryPort
+	items    port.TaskItemsRepositoryPort
+	subtasks port.TaskSubtasksRepositoryPort
 	notifier port.NotifierPort
 }
 
-func NewProcessTaskResultUseCase(repo port.TaskRepositoryPort, notifier port.NotifierPort) *ProcessTaskResultUseCase {
+func NewProcessTaskResultUseCase(repo port.TaskRepositoryPort, items port.TaskItemsRepositoryPort, subtasks port.TaskSubtasksRepositoryPort, notifier port.NotifierPort) *ProcessTaskResultUseCase {
 	return &ProcessTaskResultUseCase{
 		repo:     repo,
+		items:    items,
+		subtasks: subtasks,
 		notifier: notifier,
 	}
 }
 
 
 
-func (uc *ProcessTaskResultUseCase) Execute(ctx context.Context, taskID uuid.UUID, results map[string]int) error {
+func (uc *ProcessTaskResultUseCase) Execute(ctx context.Context, taskID uuid.UUID, results map[string]int, items []string, subtasks []domain.TaskSubtask) error {
 	logger := contextkeys.LoggerFromContext(ctx)
 	ucLogger := logger.WithFields(port.Fields{
 		"use_case": "ProcessTaskResult",
@@ -41,8 +46,28 @@ func (uc *ProcessTaskResultUseCase) Execute(ctx context.Context, taskID uuid.UUI
 		return err // Если задача не найдена, RabbitMQ повторит попытку
 	}
 
-	if task.Status == domain.StatusCompleted || task.Status == domain.StatusFailed { //
-		ucLogger.Warn("Task is already failed or completed, skipping updating task summary", nil)
+	// Единицы работы отмечаем и для завершенной задачи: опоздавший результат не нужно отправлять повторно при продолжении
+	if err := uc.items.MarkItemsReported(ctx, taskID, items); err != nil {
+		ucLogger.Error("Repository failed to mark task items as reported", err, nil)
+		return err
+	}
+
+	// Прогресс подзадач тоже копится и после завершения: разбивка должна сходиться с фактическими отчетами
+	updatedSubtasks, err := uc.subtasks.AddSubtaskProgress(ctx, taskID, subtasks)
+	if err != nil {
+		ucLogger.Error("Repository failed to add subtask progress", err, nil)
+		return err
+	}
+
+	if task.Status.IsFinal() {
+		ucLogger.Warn("Task is already finished, skipping updating task summary", port.Fields{"status": task.Status})
+		return nil
+	}
+
+	// Отчет только о подзадачах (например, все поиски сообщения упали) сводку не меняет и задачу не продвигает
+	if len(results) == 0 {
+		task.Subtasks = updatedSubtasks
+		uc.notifier.Notify(ctx, port.TaskEvent{Type: "task_updated", Data: *task})
 		return nil
 	}
 
@@ -94,6 +119,7 @@ func (uc *ProcessTaskResultUseCase) Execute(ctx context.Context, taskID uuid.UUI
 
 	ucLogger.Debug("Notifying clients about task progress", nil)
 	// Отправляем уведомление о текущем состоянии
+	updatedTask.Subtasks = updatedSubtasks
 	uc.notifier.Notify(ctx, port.TaskEvent{Type: "task_updated", Data: *updatedTask})
 	ucLogger.Info("Use case finished successfully", port.Fields{
 		"task_status": updatedTask.Status,
@@ -123,84 +149,10 @@ func (uc *ProcessTaskResultUseCase) markTaskAsCompleted(ctx context.Context, tas
 	task.FinishedAt = &now
 	
 	if err := uc.repo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskFinished) {
+			ucLogger.Warn("Task was finished concurrently, keeping its status", nil)
+			return
+		}
 		ucLogger.Error("Repository failed to mark task as completed", err, nil)
 	}
 }
-
-
-
-
-
-// func (uc *ProcessTaskResultUseCase) Execute(ctx context.Context, taskID uuid.UUID, results map[string]int) error {
-// 	logger := contextkeys.LoggerFromContext(ctx)
-// 	ucLogger := logger.WithFields(port.Fields{
-// 		"use_case": "ProcessTaskResult",
-// 		"task_id":  taskID.String(),
-// 	})
-	
-// 	ucLogger.Info("Use case started: processing incoming result", port.Fields{"results": results})
-	
-// 	// Используем специальный метод репозитория для атомарного обновления
-// 	if err := uc.repo.IncrementSummary(ctx, taskID, results); err != nil {
-// 		ucLogger.Error("Repository failed to increment summary", err, nil)
-// 		return err
-// 	}
-// 	ucLogger.Debug("Summary incremented successfully", nil)
-
-// 	// log.Println("!!!!!!!!!!!!!!!!!!")
-// 	// log.Println(results)
-
-// 	// После обновления, получаем актуальное состояние задачи, чтобы отправить его подписчикам
-// 	updatedTask, err := uc.repo.FindByID(ctx, taskID)
-// 	if err != nil {
-// 		// Даже если не удалось получить, ошибка инкремента не произошла, так что не возвращаем ее
-// 		ucLogger.Error("Could not fetch task after incrementing summary, further processing stopped", err, nil)
-// 		return nil
-// 	}
-
-// 	if updatedTask.Status == domain.StatusCompleted || updatedTask.Status == domain.StatusFailed {
-// 		ucLogger.Debug("Task is already in a final state, no further action needed.", port.Fields{"status": updatedTask.Status})
-// 		return nil
-// 	}
-
-// 	summary := updatedTask.ResultSummary
-// 	expectedCount := getIntFromSummary(summary, "expected_results_count")
-
-// 	// log.Println("!!!!!!!!!!!!!!!!!!")
-// 	// log.Println(summary)
-// 	ucLogger.Debug("Checking task completion status after increment.", port.Fields{"summary": updatedTask.ResultSummary})
-
-// 	if updatedTask.Type != "FIND_NEW" {
-
-// 		totalProcessed := getIntFromSummary(summary, "total_processed")
-
-// 		// ---> Логика завершения <---
-// 		// Условие: мы знаем, сколько ждать (expected > 0), текущий статус еще не "completed",
-// 		// и мы достигли или превысили ожидаемое количество.
-// 		if expectedCount > 0 && totalProcessed >= expectedCount {
-// 			uc.markTaskAsCompleted(ctx, updatedTask, fmt.Sprintf("Final result received (%d/%d)", totalProcessed, expectedCount))
-// 		}
-
-// 	} else {
-
-// 		searchesCompleted := getIntFromSummary(summary, "searches_completed")
-// 		newLinksFound := getIntFromSummary(summary, "new_links_found")
-// 		totalProcessed := getIntFromSummary(summary, "total_processed") // `created`
-
-
-// 		// и мы достигли или превысили ожидаемое количество.
-// 		if expectedCount > 0 && searchesCompleted >= expectedCount && totalProcessed >= newLinksFound && updatedTask.Status != domain.StatusCompleted {
-// 			uc.markTaskAsCompleted(ctx, updatedTask, fmt.Sprintf("All searches completed (%d/%d) and all links processed (%d/%d)", searchesCompleted, expectedCount, totalProcessed, newLinksFound))
-// 		}
-
-// 	}
-
-// 	ucLogger.Debug("Notifying clients about task progress", nil)
-// 	// Отправляем уведомление о прогрессе
-// 	uc.notifier.Notify(ctx, port.TaskEvent{Type: "task_updated", Data: *updatedTask})
-// 	ucLogger.Info("Use case finished successfully", port.Fields{
-// 		"task_status": updatedTask.Status,
-// 	})
-
-// 	return nil
-// }
\ No newline at end of file
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// RegisterTaskItemsUseCase запоминает единицы работы, которые сервис-исполнитель отправил по задаче
+type RegisterTaskItemsUseCase struct {
+	repo  port.TaskRepositoryPort
+	items port.TaskItemsRepositoryPort
+}
+
+func NewRegisterTaskItemsUseCase(repo port.TaskRepositoryPort, items port.TaskItemsRepositoryPort) *RegisterTaskItemsUseCase {
+	return &RegisterTaskItemsUseCase{
+		repo:  repo,
+		items: items,
+	}
+}
+
+func (uc *RegisterTaskItemsUseCase) Execute(ctx context.Context, taskID uuid.UUID, items []domain.TaskItem) error {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "RegisterTaskItems", "task_id": taskID.String(), "item_count": len(items)})
+
+	if _, err := uc.repo.FindByID(ctx, taskID); err != nil {
+		ucLogger.Warn("Cannot register items: task lookup failed", port.Fields{"error": err.Error()})
+		return err
+	}
+
+	if err := uc.items.RegisterItems(ctx, taskID, items); err != nil {
+		ucLogger.Error("Repository failed to register task items", err, nil)
+		return err
+	}
+
+	ucLogger.Debug("Task items registered", nil)
+	return nil
+}
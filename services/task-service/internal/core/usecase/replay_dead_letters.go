@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReplayDeadLettersUseCase struct {
+	repo     port.DeadLetterRepositoryPort
+	replayer port.MessageReplayerPort
+}
+
+func NewReplayDeadLettersUseCase(repo port.DeadLetterRepositoryPort, replayer port.MessageReplayerPort) *ReplayDeadLettersUseCase {
+	return &ReplayDeadLettersUseCase{
+		repo:     repo,
+		replayer: replayer,
+	}
+}
+
+// ReplayOne отправляет одно сообщение обратно в исходный обменник
+func (uc *ReplayDeadLettersUseCase) ReplayOne(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "ReplayDeadLetter", "dead_letter_id": id.String()})
+
+	ucLogger.Info("Use case started", nil)
+
+	letter, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		ucLogger.Error("Repository failed to find dead letter", err, nil)
+		return nil, err
+	}
+
+	if err := uc.replay(ctx, letter); err != nil {
+		ucLogger.Error("Failed to replay dead letter", err, nil)
+		return nil, err
+	}
+
+	ucLogger.Info("Use case finished successfully", nil)
+	return letter, nil
+}
+
+// ReplayBatch отправляет повторно все сообщения, подходящие под фильтр (не больше limit).
+// Ошибка отдельного сообщения не прерывает обработку остальных
+func (uc *ReplayDeadLettersUseCase) ReplayBatch(ctx context.Context, filter domain.DeadLetterFilter, limit int) (*domain.ReplayResult, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "ReplayDeadLettersBatch", "filter": filter, "limit": limit})
+
+	ucLogger.Info("Use case started", nil)
+
+	letters, _, err := uc.repo.FindAll(ctx, filter, limit, 0)
+	if err != nil {
+		ucLogger.Error("Repository failed to find dead letters", err, nil)
+		return nil, err
+	}
+
+	result := &domain.ReplayResult{}
+	for i := range letters {
+		if err := uc.replay(ctx, &letters[i]); err != nil {
+			ucLogger.Warn("Failed to replay dead letter, skipping", port.Fields{
+				"dead_letter_id": letters[i].ID.String(),
+				"error":          err.Error(),
+			})
+			result.Failed++
+			result.FailedIDs = append(result.FailedIDs, letters[i].ID)
+			continue
+		}
+		result.Replayed++
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"replayed": result.Replayed, "failed": result.Failed})
+	return result, nil
+}
+
+func (uc *ReplayDeadLettersUseCase) replay(ctx context.Context, letter *domain.DeadLetter) error {
+	if letter.Status == domain.DeadLetterStatusDiscarded {
+		return domain.ErrDeadLetterDiscarded
+	}
+	if letter.OriginalExchange == "" && letter.OriginalRoutingKey == "" {
+		return domain.ErrDeadLetterNoRoute
+	}
+
+	if err := uc.replayer.Replay(ctx, letter); err != nil {
+		return err
+	}
+
+	if err := uc.repo.MarkReplayed(ctx, letter.ID); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	letter.Status = domain.DeadLetterStatusReplayed
+	letter.ReplayCount++
+	letter.LastReplayedAt = &now
+	return nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RestartTaskUseCase повторяет завершенную задачу: заново с теми же параметрами (rerun)
+// или только по единицам работы, по которым не пришли результаты (resume)
+type RestartTaskUseCase struct {
+	repo       port.TaskRepositoryPort
+	items      port.TaskItemsRepositoryPort
+	dispatcher port.TaskDispatcherPort
+	notifier   port.NotifierPort
+}
+
+func NewRestartTaskUseCase(repo port.TaskRepositoryPort, items port.TaskItemsRepositoryPort,
+	dispatcher port.TaskDispatcherPort, notifier port.NotifierPort) *RestartTaskUseCase {
+	return &RestartTaskUseCase{
+		repo:       repo,
+		items:      items,
+		dispatcher: dispatcher,
+		notifier:   notifier,
+	}
+}
+
+func (uc *RestartTaskUseCase) Execute(ctx context.Context, taskID uuid.UUID, mode domain.RestartMode, userID uuid.UUID) (*domain.Task, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{
+		"use_case":       "RestartTask",
+		"parent_task_id": taskID.String(),
+		"mode":           mode,
+	})
+
+	ucLogger.Info("Use case started", nil)
+
+	parent, err := uc.repo.FindByID(ctx, taskID)
+	if err != nil {
+		ucLogger.Warn("Parent task lookup failed", port.Fields{"error": err.Error()})
+		return nil, err
+	}
+	if !parent.Status.IsFinal() {
+		return nil, domain.ErrTaskNotFinished
+	}
+	if !domain.SupportsRestart(parent.Type, mode) {
+		return nil, domain.ErrRestartNotSupported
+	}
+
+	var pending []domain.TaskItem
+	if mode == domain.RestartResume {
+		pending, err = uc.items.FindUnreportedItems(ctx, parent.ID)
+		if err != nil {
+			ucLogger.Error("Repository failed to find unreported items", err, nil)
+			return nil, err
+		}
+		if len(pending) == 0 {
+			return nil, domain.ErrNothingToResume
+		}
+	}
+
+	task := domain.NewTask(restartedTaskName(parent.Name, mode), parent.Type, userID)
+	task.Params = parent.Params
+	task.ParentTaskID = &parent.ID
+	task.RestartMode = mode
+	if objectID, ok := parent.Params["object_id"]; ok {
+		task.ResultSummary["id"] = objectID
+	}
+
+	if err := uc.repo.Create(ctx, task); err != nil {
+		ucLogger.Error("Repository failed to create restarted task", err, nil)
+		return nil, err
+	}
+	ucLogger = ucLogger.WithFields(port.Fields{"task_id": task.ID.String(), "items_to_resume": len(pending)})
+	uc.notifier.Notify(ctx, port.TaskEvent{Type: "task_created", Data: *task})
+
+	if err := uc.dispatcher.Dispatch(ctx, task, pending); err != nil {
+		ucLogger.Error("Failed to dispatch restarted task", err, nil)
+		uc.markDispatchFailed(ctx, task, err)
+		return nil, fmt.Errorf("failed to dispatch restarted task: %w", err)
+	}
+
+	ucLogger.Info("Use case finished successfully", nil)
+	return task, nil
+}
+
+// markDispatchFailed завершает новую задачу, если исполнитель ее не принял: иначе она висела бы в pending до таймаута
+func (uc *RestartTaskUseCase) markDispatchFailed(ctx context.Context, task *domain.Task, dispatchErr error) {
+	now := time.Now().UTC()
+	task.Status = domain.StatusFailed
+	task.FinishedAt = &now
+	if err := uc.repo.Update(ctx, task); err != nil {
+		contextkeys.LoggerFromContext(ctx).Error("Repository failed to mark undispatched task as failed", err, port.Fields{
+			"task_id":        task.ID.String(),
+			"dispatch_error": dispatchErr.Error(),
+		})
+		return
+	}
+	uc.notifier.Notify(ctx, port.TaskEvent{Type: "task_updated", Data: *task})
+}
+
+func restartedTaskName(parentName string, mode domain.RestartMode) string {
+	if mode == domain.RestartResume {
+		return "Продолжение: " + parentName
+	}
+	return "Повтор: " + parentName
+}
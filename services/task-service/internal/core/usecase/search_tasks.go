@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+)
+
+// SearchTasksUseCase - поиск задач всех пользователей для админки
+type SearchTasksUseCase struct {
+	repo port.TaskSearchRepositoryPort
+}
+
+func NewSearchTasksUseCase(repo port.TaskSearchRepositoryPort) *SearchTasksUseCase {
+	return &SearchTasksUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *SearchTasksUseCase) Execute(ctx context.Context, filter domain.TaskFilter, sort domain.TaskSort, limit, offset int) ([]domain.Task, int64, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "SearchTasks", "limit": limit, "offset": offset})
+
+	ucLogger.Info("Use case started", nil)
+
+	tasks, count, err := uc.repo.Search(ctx, filter, sort, limit, offset)
+	if err != nil {
+		ucLogger.Error("Repository failed to search tasks", err, nil)
+		return nil, 0, err
+	}
+
+	ucLogger.Info("Use case finished successfully", port.Fields{"found_on_page": len(tasks), "total_count": count})
+	return tasks, count, nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-service/internal/contextkeys"
+	"task-service/internal/core/domain"
+	"task-service/internal/core/port"
+	"time"
+)
+
+// staleTasksBatchSize - сколько кандидатов сторож разбирает за один проход
+const staleTasksBatchSize = 100
+
+// TimeoutStaleTasksUseCase останавливает задачи, которые дольше окна бездействия своего типа
+// не получали результатов и не меняли статус: парсер упал или команда о завершении потерялась
+type TimeoutStaleTasksUseCase struct {
+	repo     port.TaskRepositoryPort
+	notifier port.NotifierPort
+	timeouts domain.TaskTimeouts
+}
+
+func NewTimeoutStaleTasksUseCase(repo port.TaskRepositoryPort, notifier port.NotifierPort, timeouts domain.TaskTimeouts) *TimeoutStaleTasksUseCase {
+	return &TimeoutStaleTasksUseCase{
+		repo:     repo,
+		notifier: notifier,
+		timeouts: timeouts,
+	}
+}
+
+func (uc *TimeoutStaleTasksUseCase) Execute(ctx context.Context) (int, error) {
+	logger := contextkeys.LoggerFromContext(ctx)
+	ucLogger := logger.WithFields(port.Fields{"use_case": "TimeoutStaleTasks"})
+
+	if uc.timeouts.Shortest() <= 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	candidates, err := uc.repo.FindStale(ctx, uc.timeouts, now, staleTasksBatchSize)
+	if err != nil {
+		ucLogger.Error("Repository failed to find stale tasks", err, nil)
+		return 0, err
+	}
+
+	timedOut := 0
+	for _, candidate := range candidates {
+		window := uc.timeouts.For(candidate.Type)
+		inactive := now.Sub(candidate.LastProgressAt)
+		if window <= 0 || inactive < window {
+			continue
+		}
+
+		taskLogger := ucLogger.WithFields(port.Fields{
+			"task_id":   candidate.ID.String(),
+			"task_type": candidate.Type,
+			"inactive":  inactive.String(),
+		})
+
+		reason := domain.ResultSummary{
+			domain.SummaryTimeoutReason:       fmt.Sprintf("no progress for %s (limit %s)", inactive.Round(time.Second), window),
+			domain.SummaryInactiveSeconds:     int(inactive.Seconds()),
+			domain.SummaryStatusBeforeTimeout: candidate.Status,
+		}
+		task, err := uc.repo.MarkTimedOut(ctx, candidate.ID, now.Add(-window), reason)
+		if errors.Is(err, domain.ErrTaskNotStale) {
+			taskLogger.Debug("Task made progress before timeout, skipping", nil)
+			continue
+		}
+		if err != nil {
+			taskLogger.Error("Repository failed to mark task as timed out", err, nil)
+			continue
+		}
+
+		taskLogger.Warn("Task timed out", nil)
+		uc.notifier.Notify(ctx, port.TaskEvent{Type: "task_updated", Data: *task})
+		timedOut++
+	}
+
+	if timedOut > 0 {
+		ucLogger.Info("Stale tasks timed out", port.Fields{"count": timedOut})
+	}
+	return timedOut, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"task-service/internal/contextkeys"
 	"task-service/internal/core/domain"
 	"task-service/internal/core/port"
@@ -34,12 +35,19 @@ func (uc *UpdateTaskStatusUseCase) Execute(ctx context.Context, taskID uuid.UUID
 		return nil, err
 	}
 
+	// Финальный статус окончательный: поздний running/completed не должен переписать timed_out и т.п.
+	// Гонку с параллельным завершением закрывает условие в repo.Update
+	if task.Status.IsFinal() {
+		ucLogger.Warn("Task is already finished, status is not changed", port.Fields{"status": task.Status})
+		return nil, domain.ErrTaskFinished
+	}
+
 	task.Status = status
 	now := time.Now().UTC()
 	if status == domain.StatusRunning && task.StartedAt == nil {
 		task.StartedAt = &now
 	}
-	if status == domain.StatusCompleted || status == domain.StatusFailed {
+	if status.IsFinal() {
 		task.FinishedAt = &now
 	}
 	// if summary != nil {
@@ -47,6 +55,10 @@ func (uc *UpdateTaskStatusUseCase) Execute(ctx context.Context, taskID uuid.UUID
 	// }
 
 	if err := uc.repo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrTaskFinished) {
+			ucLogger.Warn("Task finished concurrently, status is not changed", nil)
+			return nil, err
+		}
 		ucLogger.Error("Repository failed to update task", err, nil)
 		return nil, err
 	}
@@ -0,0 +1,28 @@
+-- Сообщения, исчерпавшие все ретраи и попавшие в финальные DLQ
+CREATE TABLE dead_letters (
+    id UUID PRIMARY KEY,
+
+    source_queue VARCHAR(255) NOT NULL,      -- финальная DLQ, например: "link_parsing_tasks_final_dlq"
+    original_queue VARCHAR(255),             -- очередь, в которой сообщение не смогли обработать
+    original_exchange VARCHAR(255),          -- куда отправлять сообщение при повторе
+    original_routing_key VARCHAR(255),
+    failure_reason TEXT,                     -- текст последней ошибки обработчика
+    task_id UUID,                            -- задача, к которой относится сообщение (если удалось определить)
+
+    content_type VARCHAR(100),
+    body BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+    death_history JSONB NOT NULL DEFAULT '[]'::jsonb, -- разобранный заголовок x-death
+
+    status VARCHAR(50) NOT NULL DEFAULT 'new', -- "new", "replayed", "discarded"
+    replay_count INT NOT NULL DEFAULT 0,
+
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_replayed_at TIMESTAMPTZ,
+    discarded_at TIMESTAMPTZ
+);
+
+-- Основной сценарий админки: новые сообщения конкретной очереди, свежие сверху
+CREATE INDEX idx_dead_letters_queue_status_received ON dead_letters (source_queue, status, received_at DESC);
+
+CREATE INDEX idx_dead_letters_task_id ON dead_letters (task_id);
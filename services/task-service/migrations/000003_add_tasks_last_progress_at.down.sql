@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_active_last_progress;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS last_progress_at;
@@ -0,0 +1,13 @@
+-- Время последнего прогресса задачи: создание, смена статуса или новые результаты.
+-- По нему сторож находит зависшие задачи
+ALTER TABLE tasks ADD COLUMN last_progress_at TIMESTAMPTZ;
+
+UPDATE tasks SET last_progress_at = COALESCE(finished_at, started_at, created_at);
+
+ALTER TABLE tasks
+    ALTER COLUMN last_progress_at SET NOT NULL,
+    ALTER COLUMN last_progress_at SET DEFAULT NOW();
+
+-- Сторож просматривает только незавершенные задачи
+CREATE INDEX idx_tasks_active_last_progress ON tasks (last_progress_at)
+    WHERE status IN ('pending', 'running');
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS task_items;
+
+DROP INDEX IF EXISTS idx_tasks_parent_task_id;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS restart_mode,
+    DROP COLUMN IF EXISTS parent_task_id,
+    DROP COLUMN IF EXISTS params;
@@ -0,0 +1,21 @@
+-- Входные параметры задачи и связь с исходной задачей при повторе
+ALTER TABLE tasks
+    ADD COLUMN params JSONB NOT NULL DEFAULT '{}'::jsonb,         -- например: {"categories": [...], "regions": [...], "limit_per_category": 100}
+    ADD COLUMN parent_task_id UUID REFERENCES tasks (id) ON DELETE SET NULL,
+    ADD COLUMN restart_mode VARCHAR(20);                        -- "rerun" или "resume", NULL - задача запущена впервые
+
+CREATE INDEX idx_tasks_parent_task_id ON tasks (parent_task_id) WHERE parent_task_id IS NOT NULL;
+
+-- Единицы работы, отправленные в рамках задачи: поиски для FIND_NEW, ссылки для актуализации.
+-- reported_at заполняется, когда по единице пришел результат. По незаполненным задачу можно продолжить
+CREATE TABLE task_items (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    item_key VARCHAR(512) NOT NULL,    -- например: "search:kufar:minsk:apartment" или "link:realt:123456"
+    payload JSONB NOT NULL,            -- все, что нужно для повторной отправки
+    dispatched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reported_at TIMESTAMPTZ,
+
+    PRIMARY KEY (task_id, item_key)
+);
+
+CREATE INDEX idx_task_items_unreported ON task_items (task_id) WHERE reported_at IS NULL;
@@ -0,0 +1,16 @@
+-- Разбивка прогресса задачи по подзадачам: поискам парсеров и сохранению в storage-service.
+-- Счетчики накапливаются из отчетов, как и result_summary задачи
+CREATE TABLE task_subtasks (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL,       -- например: "kufar", "realt"
+    name VARCHAR(255) NOT NULL,        -- имя критерия поиска, например: "FindNew_minsk_apartment_loc-..._sell", или "save"
+    links_found INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    errors INTEGER NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (task_id, source, name)
+);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS tasks_archive;
+
+DROP INDEX IF EXISTS idx_tasks_finished_at;
+DROP INDEX IF EXISTS idx_tasks_target_object_id;
+DROP INDEX IF EXISTS idx_tasks_type_created_at;
+DROP INDEX IF EXISTS idx_tasks_created_at;
+DROP INDEX IF EXISTS idx_tasks_status;
+DROP INDEX IF EXISTS idx_tasks_user_id_created_at;
@@ -0,0 +1,26 @@
+-- Индексы, заготовленные в 000001: список задач пользователя и выборка по статусу
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id_created_at ON tasks (created_by_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+
+-- Админский поиск задач всех пользователей
+CREATE INDEX idx_tasks_created_at ON tasks (created_at DESC);
+CREATE INDEX idx_tasks_type_created_at ON tasks (type, created_at DESC);
+CREATE INDEX idx_tasks_target_object_id ON tasks ((params->>'object_id')) WHERE (params->>'object_id') IS NOT NULL;
+
+-- Очистка старых завершенных задач
+CREATE INDEX idx_tasks_finished_at ON tasks (finished_at) WHERE finished_at IS NOT NULL;
+
+-- Архив задач, убранных по сроку хранения. Строка задачи хранится целиком в data,
+-- чтобы архив не приходилось менять вместе со схемой tasks
+CREATE TABLE tasks_archive (
+    id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_by_user_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    data JSONB NOT NULL,               -- строка tasks и ее подзадачи: {"task": {...}, "subtasks": [...]}
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tasks_archive_type_created_at ON tasks_archive (type, created_at DESC);
@@ -0,0 +1,13 @@
+-- Журнал событий, отправленных подписчикам по SSE. id - это id SSE-события:
+-- при переподключении с Last-Event-ID клиенту досылается все, что он пропустил.
+-- Хранится недолго, старые записи удаляются фоновой очисткой
+CREATE TABLE task_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,             -- адресат события (created_by_user_id задачи)
+    event_type VARCHAR(50) NOT NULL,   -- например: "task_created", "task_updated"
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_events_user_id_id ON task_events (user_id, id);
+CREATE INDEX idx_task_events_created_at ON task_events (created_at);
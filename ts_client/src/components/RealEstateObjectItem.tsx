@@ -9,9 +9,10 @@ interface PropertyItemProps {
     property: IObjectCardResponse;
     isFavoritePage: boolean;
     onRemoveFromFav?: () => void; // Функция обновления списка
+    onHide?: () => void; // Скрыть объект из выдачи (только для авторизованных)
 }
 
-const REObjectItem: React.FC<PropertyItemProps> = ({ property, isFavoritePage, onRemoveFromFav }) => {
+const REObjectItem: React.FC<PropertyItemProps> = ({ property, isFavoritePage, onRemoveFromFav, onHide }) => {
     const navigate = useNavigate();
 
     // Логика для архивных объектов
@@ -104,6 +105,12 @@ const REObjectItem: React.FC<PropertyItemProps> = ({ property, isFavoritePage, o
                                 />
                             )}
                             
+                            {onHide && (
+                                <Button variant="outline-secondary" onClick={onHide} title="Больше не показывать в поиске">
+                                    Скрыть
+                                </Button>
+                            )}
+
                             <Button 
                                 variant="outline-primary" 
                                 onClick={() => navigate(`/objects/${property.id}`)}
@@ -0,0 +1,61 @@
+import React, { useEffect, useState } from 'react';
+import { Row, Col, Card, Badge } from 'react-bootstrap';
+import { useNavigate } from 'react-router-dom';
+import { fetchSimilarObjects } from '../http/objectsAPI';
+import type { ISimilarListing } from '../types/realEstateObjects';
+
+interface Props {
+    objectId: string;
+}
+
+// Блок "Похожие объекты" на детальной странице. Пустой результат и ошибки просто скрывают блок
+const SimilarObjects: React.FC<Props> = ({ objectId }) => {
+    const navigate = useNavigate();
+    const [items, setItems] = useState<ISimilarListing[]>([]);
+
+    useEffect(() => {
+        fetchSimilarObjects(objectId)
+            .then(res => setItems(res.data || []))
+            .catch(err => {
+                console.error("Ошибка загрузки похожих объектов", err);
+                setItems([]);
+            });
+    }, [objectId]);
+
+    if (items.length === 0) return null;
+
+    return (
+        <div className="mt-5">
+            <h4 className="mb-3">Похожие объекты</h4>
+            <Row xs={1} md={3} className="g-3">
+                {items.map(item => (
+                    <Col key={item.id}>
+                        <Card
+                            className="h-100 shadow-sm"
+                            style={{ cursor: 'pointer' }}
+                            onClick={() => navigate(`/objects/${item.id}`)}
+                        >
+                            {item.images && item.images.length > 0 && (
+                                <Card.Img variant="top" src={item.images[0]} style={{ height: 160, objectFit: 'cover' }} />
+                            )}
+                            <Card.Body>
+                                <div className="d-flex justify-content-between align-items-start">
+                                    <Card.Title className="fs-6">{item.title}</Card.Title>
+                                    <Badge bg="success">{Math.round(item.score * 100)}%</Badge>
+                                </div>
+                                <div className="fw-bold">{item.price_usd.toLocaleString('ru-RU')} $</div>
+                                <div className="text-muted small mb-2">{item.address}</div>
+                                {/* Два самых весомых фактора - почему объект попал в список */}
+                                {item.explanation.slice(0, 2).map(f => (
+                                    <div key={f.factor} className="small text-secondary">{f.note}</div>
+                                ))}
+                            </Card.Body>
+                        </Card>
+                    </Col>
+                ))}
+            </Row>
+        </div>
+    );
+};
+
+export default SimilarObjects;
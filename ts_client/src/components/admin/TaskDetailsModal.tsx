@@ -1,6 +1,7 @@
-import React from 'react';
-import { Modal, Button, Row, Col, Card, Badge, Accordion } from 'react-bootstrap';
+import React, { useState } from 'react';
+import { Modal, Button, Row, Col, Card, Badge, Accordion, Alert, Table } from 'react-bootstrap';
 import type { ITask } from '../../types/task';
+import { rerunTask, resumeTask } from '../../http/adminAPI';
 
 interface Props {
     show: boolean;
@@ -9,8 +10,27 @@ interface Props {
 }
 
 const TaskDetailsModal: React.FC<Props> = ({ show, onHide, task }) => {
+    const [restarting, setRestarting] = useState(false);
+    const [restartError, setRestartError] = useState<string | null>(null);
+
     if (!task) return null;
 
+    const isFinished = ['completed', 'failed', 'timed_out'].includes(task.status);
+
+    // Повтор или продолжение: новая задача придет в список через SSE
+    const handleRestart = async (mode: 'rerun' | 'resume') => {
+        setRestarting(true);
+        setRestartError(null);
+        try {
+            await (mode === 'rerun' ? rerunTask(task.id) : resumeTask(task.id));
+            onHide();
+        } catch (e: any) {
+            setRestartError(e.response?.data?.error || 'Не удалось перезапустить задачу');
+        } finally {
+            setRestarting(false);
+        }
+    };
+
     const summary = task.result_summary;
 
     // Хелпер для форматирования даты
@@ -40,6 +60,7 @@ const TaskDetailsModal: React.FC<Props> = ({ show, onHide, task }) => {
             'completed': <Badge bg="success">Завершено</Badge>,
             'running': <Badge bg="primary">Выполняется</Badge>,
             'failed': <Badge bg="danger">Ошибка</Badge>,
+            'timed_out': <Badge bg="warning" text="dark">Таймаут</Badge>,
             'pending': <Badge bg="secondary">В очереди</Badge>
         };
         return map[status] || <Badge bg="light" text="dark">{status}</Badge>;
@@ -76,6 +97,13 @@ const TaskDetailsModal: React.FC<Props> = ({ show, onHide, task }) => {
                     </div>
                 </div>
 
+                {task.parent_task_id && (
+                    <div className="text-muted small mb-3">
+                        {task.restart_mode === 'resume' ? 'Продолжение' : 'Повтор'} задачи #{task.parent_task_id}
+                    </div>
+                )}
+                {restartError && <Alert variant="danger">{restartError}</Alert>}
+
                 {/* --- БЛОК 2: СТАТИСТИКА (Карточки) --- */}
                 {summary && (
                     <div className="mb-4">
@@ -140,6 +168,41 @@ const TaskDetailsModal: React.FC<Props> = ({ show, onHide, task }) => {
                     </div>
                 )}
 
+                {/* --- БЛОК 2.1: ПОДЗАДАЧИ --- */}
+                {task.subtasks && task.subtasks.length > 0 && (
+                    <div className="mb-4">
+                        <h6 className="text-uppercase text-muted small fw-bold mb-3">Подзадачи</h6>
+                        <div style={{ maxHeight: '300px', overflowY: 'auto' }}>
+                            <Table size="sm" hover className="small mb-0">
+                                <thead>
+                                    <tr>
+                                        <th>Источник</th>
+                                        <th>Подзадача</th>
+                                        <th className="text-end">Ссылок</th>
+                                        <th className="text-end">Обработано</th>
+                                        <th className="text-end">Ошибки</th>
+                                        <th className="text-end">Время</th>
+                                    </tr>
+                                </thead>
+                                <tbody>
+                                    {[...task.subtasks]
+                                        .sort((a, b) => b.errors - a.errors || b.duration_ms - a.duration_ms)
+                                        .map(sub => (
+                                            <tr key={`${sub.source}/${sub.name}`} className={sub.errors > 0 ? 'table-danger' : undefined}>
+                                                <td>{sub.source}</td>
+                                                <td title={sub.last_error}>{sub.name}</td>
+                                                <td className="text-end">{sub.links_found}</td>
+                                                <td className="text-end">{sub.processed}</td>
+                                                <td className="text-end">{sub.errors}</td>
+                                                <td className="text-end">{(sub.duration_ms / 1000).toFixed(1)} сек</td>
+                                            </tr>
+                                        ))}
+                                </tbody>
+                            </Table>
+                        </div>
+                    </div>
+                )}
+
                 {/* --- БЛОК 3: ВРЕМЕННЫЕ МЕТКИ --- */}
                 <Card className="mb-4 border-0 bg-light">
                     <Card.Body className="py-2">
@@ -174,6 +237,14 @@ const TaskDetailsModal: React.FC<Props> = ({ show, onHide, task }) => {
 
             </Modal.Body>
             <Modal.Footer>
+                {isFinished && (
+                    <>
+                        <Button variant="outline-primary" disabled={restarting} onClick={() => handleRestart('rerun')}>Повторить</Button>
+                        {task.type !== 'ACTUALIZE_BY_ID' && task.status !== 'completed' && (
+                            <Button variant="outline-warning" disabled={restarting} onClick={() => handleRestart('resume')}>Продолжить</Button>
+                        )}
+                    </>
+                )}
                 <Button variant="secondary" onClick={onHide}>Закрыть</Button>
             </Modal.Footer>
         </Modal>
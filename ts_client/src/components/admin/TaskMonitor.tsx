@@ -1,16 +1,34 @@
 import React, { useEffect, useState } from 'react';
 import { Table, ProgressBar, Badge, Spinner, Button, Modal } from 'react-bootstrap';
-import { fetchTasks, subscribeToTasks } from '../../http/adminAPI';
-import type { ITask } from '../../types/task';
+import { fetchTaskById, fetchTasks, subscribeToTasks } from '../../http/adminAPI';
+import type { ITask, ITaskSubtask } from '../../types/task';
 import TaskDetailsModal from './TaskDetailsModal';
 
+// SSE присылает только изменившиеся подзадачи - накладываем их на уже известные
+const mergeSubtasks = (known: ITaskSubtask[] = [], updated: ITaskSubtask[] = []): ITaskSubtask[] => {
+    const byKey = new Map(known.map(s => [`${s.source}/${s.name}`, s]));
+    updated.forEach(s => byKey.set(`${s.source}/${s.name}`, s));
+    return Array.from(byKey.values());
+};
+
 const TaskMonitor = () => {
     const [tasks, setTasks] = useState<ITask[]>([]);
     const [loading, setLoading] = useState(true);
     const [connected, setConnected] = useState(false);
 
     // Модалка для деталей (JSON view)
-    const [selectedTask, setSelectedTask] = useState<ITask | null>(null);
+    const [selectedTaskId, setSelectedTaskId] = useState<string | null>(null);
+    const selectedTask = tasks.find(t => t.id === selectedTaskId) || null;
+
+    // Детали открываем с полной разбивкой по подзадачам, дальше ее дополняет SSE
+    const openDetails = (task: ITask) => {
+        setSelectedTaskId(task.id);
+        fetchTaskById(task.id)
+            .then(full => setTasks(prev => prev.map(t => t.id === full.id
+                ? { ...t, subtasks: mergeSubtasks(full.subtasks, t.subtasks) }
+                : t)))
+            .catch(console.error);
+    };
 
     useEffect(() => {
         // 1. Загружаем сразу при монтировании компонента (вход на страницу)
@@ -51,7 +69,7 @@ const TaskMonitor = () => {
                     const index = prev.findIndex(t => t.id === updatedTask.id);
                     if (index !== -1) {
                         const newArr = [...prev];
-                        newArr[index] = updatedTask;
+                        newArr[index] = { ...updatedTask, subtasks: mergeSubtasks(prev[index].subtasks, updatedTask.subtasks) };
                         return newArr;
                     } else {
                         // Если новая задача - добавляем в начало
@@ -104,6 +122,7 @@ const TaskMonitor = () => {
             'completed': <Badge bg="success">Готово</Badge>,
             'running': <Badge bg="primary">В работе</Badge>,
             'failed': <Badge bg="danger">Ошибка</Badge>,
+            'timed_out': <Badge bg="warning" text="dark">Таймаут</Badge>,
             'pending': <Badge bg="secondary">В обработке</Badge>
         };
         return map[status] || <Badge bg="light" text="dark">{status}</Badge>;
@@ -160,6 +179,8 @@ const TaskMonitor = () => {
                                             <ProgressBar variant="success" now={100} label="100%" />
                                         ) : task.status === 'failed' ? (
                                             <span className="text-muted small">Ошибка</span>
+                                        ) : task.status === 'timed_out' ? (
+                                            <span className="text-muted small" title={summary?.timeout_reason}>Таймаут</span>
                                         ) : (
                                             <span className="text-muted small">Ожидание...</span>
                                         )}
@@ -182,7 +203,7 @@ const TaskMonitor = () => {
                                         {new Date(task.created_at).toLocaleTimeString()}
                                     </td>
                                     <td>
-                                        <Button size="sm" variant="outline-secondary" onClick={() => setSelectedTask(task)} style={{fontSize: '0.7rem'}}>
+                                        <Button size="sm" variant="outline-secondary" onClick={() => openDetails(task)} style={{fontSize: '0.7rem'}}>
                                             Детали
                                         </Button>
                                     </td>
@@ -201,7 +222,7 @@ const TaskMonitor = () => {
             </Modal> */}
             <TaskDetailsModal 
                 show={!!selectedTask} 
-                onHide={() => setSelectedTask(null)} 
+                onHide={() => setSelectedTaskId(null)} 
                 task={selectedTask} 
             />
         </div>
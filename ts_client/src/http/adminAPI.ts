@@ -48,6 +48,21 @@ export const fetchTaskById = async (id: string): Promise<ITask> => {
     return data;
 };
 
+// Повтор завершенной задачи с теми же параметрами
+export const rerunTask = async (id: string): Promise<ITask> => {
+    const { data } = await $authHost.post<ITask>(`tasks/${id}/rerun`);
+    return data;
+};
+
+// Продолжение: заново отправляются только подзадачи и ссылки без результата
+export const resumeTask = async (id: string): Promise<ITask> => {
+    const { data } = await $authHost.post<ITask>(`tasks/${id}/resume`);
+    return data;
+};
+
+// ID последнего полученного события: после перезагрузки страницы сервер дошлет пропущенное
+const LAST_EVENT_ID_KEY = 'tasksLastEventId';
+
 // Подписка на SSE
 export const subscribeToTasks = (
     onMessage: (task: ITask) => void, 
@@ -57,20 +72,32 @@ export const subscribeToTasks = (
     const token = localStorage.getItem('token');
     const ctrl = new AbortController();
 
+    // При автоматическом переподключении библиотека сама отправит заголовок Last-Event-ID
+    const lastEventId = sessionStorage.getItem(LAST_EVENT_ID_KEY);
+    const query = lastEventId ? `?lastEventId=${encodeURIComponent(lastEventId)}` : '';
+
     // SSE Эндпоинт: GET /api/v1/tasks/subscribe
-    fetchEventSource(`${import.meta.env.VITE_API_URL}/tasks/subscribe`, {
+    fetchEventSource(`${import.meta.env.VITE_API_URL}/tasks/subscribe${query}`, {
         method: 'GET',
         headers: {
             Authorization: `Bearer ${token}`,
         },
         signal: ctrl.signal,
         onmessage(ev) {
+            if (ev.id) sessionStorage.setItem(LAST_EVENT_ID_KEY, ev.id);
+
             if (ev.event === 'connected') {
                 if (onConnect) onConnect();
                 console.log('SSE Connected');
                 return;
             }
 
+            // Пропущенные события уже не восстановить - перезагружаем состояние целиком
+            if (ev.event === 'resync') {
+                if (onConnect) onConnect();
+                return;
+            }
+
             if (ev.data) {
                 try {
                     const updatedTask = JSON.parse(ev.data);
@@ -1,11 +1,11 @@
-import { $authHost } from "./index";
-import type { IFavoritesResponse } from "../types/favorites";
+import { $authHost, $host } from "./index";
+import type { IFavoriteCollection, IFavoritesQuery, IFavoritesResponse, IShareLink, ISharedFavoritesResponse, ListingMark } from "../types/favorites";
 
-// Получить список избранного
-export const fetchFavorites = async (page = 1, limit = 5): Promise<IFavoritesResponse> => {
+// Получить список избранного (с фильтром по подборке/тегу и сортировкой)
+export const fetchFavorites = async (page = 1, limit = 5, query: IFavoritesQuery = {}): Promise<IFavoritesResponse> => {
     const offset = (page - 1) * limit;
     const { data } = await $authHost.get<IFavoritesResponse>('favorites', {
-        params: { limit, offset }
+        params: { limit, offset, ...query }
     });
     return data;
 };
@@ -29,3 +29,96 @@ export const removeFromFavorites = async (masterObjectId: string) => {
     const { data } = await $authHost.delete(`favorites/${masterObjectId}`);
     return data;
 };
+
+// Изменить заметку и/или теги избранного объекта
+export const updateFavorite = async (masterObjectId: string, changes: { note?: string; tags?: string[] }) => {
+    const { data } = await $authHost.patch(`favorites/${masterObjectId}`, changes);
+    return data;
+};
+
+// Подборки
+export const fetchCollections = async (): Promise<IFavoriteCollection[]> => {
+    const { data } = await $authHost.get<{ data: IFavoriteCollection[] }>('favorites/collections');
+    return data.data;
+};
+
+export const createCollection = async (name: string): Promise<IFavoriteCollection> => {
+    const { data } = await $authHost.post<IFavoriteCollection>('favorites/collections', { name });
+    return data;
+};
+
+export const renameCollection = async (collectionId: string, name: string): Promise<IFavoriteCollection> => {
+    const { data } = await $authHost.patch<IFavoriteCollection>(`favorites/collections/${collectionId}`, { name });
+    return data;
+};
+
+export const deleteCollection = async (collectionId: string) => {
+    await $authHost.delete(`favorites/collections/${collectionId}`);
+};
+
+export const addToCollection = async (collectionId: string, masterObjectIds: string[]) => {
+    await $authHost.post(`favorites/collections/${collectionId}/items`, { master_object_ids: masterObjectIds });
+};
+
+export const removeFromCollection = async (collectionId: string, masterObjectId: string) => {
+    await $authHost.delete(`favorites/collections/${collectionId}/items/${masterObjectId}`);
+};
+
+// Перенести (move = true) или скопировать объекты в другую подборку
+export const transferCollectionItems = async (fromId: string, toId: string, masterObjectIds: string[], move: boolean): Promise<number> => {
+    const { data } = await $authHost.post<{ transferred: number }>(
+        `favorites/collections/${fromId}/${move ? 'move' : 'copy'}`,
+        { target_collection_id: toId, master_object_ids: masterObjectIds }
+    );
+    return data.transferred;
+};
+
+// Публичные ссылки. Без collectionId ссылка открывает все избранное
+export const createShareLink = async (collectionId?: string, expiresInHours?: number): Promise<IShareLink> => {
+    const { data } = await $authHost.post<IShareLink>('favorites/shares', {
+        collection_id: collectionId,
+        expires_in_hours: expiresInHours
+    });
+    return data;
+};
+
+export const fetchShareLinks = async (): Promise<IShareLink[]> => {
+    const { data } = await $authHost.get<{ data: IShareLink[] }>('favorites/shares');
+    return data.data;
+};
+
+export const revokeShareLink = async (shareId: string) => {
+    await $authHost.delete(`favorites/shares/${shareId}`);
+};
+
+// Просмотр по ссылке доступен без авторизации
+export const fetchSharedFavorites = async (token: string, page = 1, limit = 5): Promise<ISharedFavoritesResponse> => {
+    const offset = (page - 1) * limit;
+    const { data } = await $host.get<ISharedFavoritesResponse>(`shared/${token}`, {
+        params: { limit, offset }
+    });
+    return data;
+};
+
+// Отметки "просмотрено" и "скрыто". По ним поиск с only_unseen / exclude_hidden убирает объекты из выдачи
+export const fetchMarkedIds = async (mark: ListingMark): Promise<string[]> => {
+    const { data } = await $authHost.get<string[]>(`favorites/marks/${mark}/ids`);
+    return data;
+};
+
+export const markListings = async (mark: ListingMark, masterObjectIds: string[]) => {
+    await $authHost.post(`favorites/marks/${mark}`, { master_object_ids: masterObjectIds });
+};
+
+export const unmarkListings = async (mark: ListingMark, masterObjectIds: string[]): Promise<number> => {
+    const { data } = await $authHost.post<{ removed: number }>(`favorites/marks/${mark}/remove`, {
+        master_object_ids: masterObjectIds
+    });
+    return data.removed;
+};
+
+// Снимает все отметки этого типа, например "показать скрытые снова"
+export const clearListingMarks = async (mark: ListingMark): Promise<number> => {
+    const { data } = await $authHost.delete<{ removed: number }>(`favorites/marks/${mark}`);
+    return data.removed;
+};
@@ -1,10 +1,13 @@
-import { $host } from "./index";
-import type { IObjectDetailsResponse, IPaginatedObjectsResponse } from "../types/realEstateObjects";
+import { $authHost, $host } from "./index";
+import type { ICompareObjectsResponse, IObjectDetailsResponse, IPaginatedObjectsResponse, ISimilarListingsResponse } from "../types/realEstateObjects";
 
-// Принимаем параметры фильтрации как объект
+// Принимаем параметры фильтрации как объект.
+// exclude_hidden и only_unseen gateway применяет только по токену, поэтому с ними запрос идет авторизованным
 export const fetchObjects = async (params: any): Promise<IPaginatedObjectsResponse> => {
-    const { data } = await $host.get<IPaginatedObjectsResponse>('/objects', {
-        params: params // Axios сам превратит объект {category: '...'} в строку запроса
+    const host = params.exclude_hidden || params.only_unseen ? $authHost : $host;
+    const { data } = await host.get<IPaginatedObjectsResponse>('/objects', {
+        params: params, // Axios сам превратит объект {category: '...'} в строку запроса
+        paramsSerializer: { indexes: null }
     });
     return data;
 }
@@ -14,4 +17,20 @@ export const fetchObjectWithDeatils = async (id: string): Promise<IObjectDetails
     // Твой эндпоинт: /api/v1/objects/{objectID}
     const { data } = await $host.get<IObjectDetailsResponse>(`/objects/${id}`);
     return data;
-}
\ No newline at end of file
+}
+
+// Таблица сравнения от 2 до 5 объектов по master_object_id
+export const compareObjects = async (masterIds: string[]): Promise<ICompareObjectsResponse> => {
+    const { data } = await $host.post<ICompareObjectsResponse>('/objects/compare', {
+        master_ids: masterIds
+    });
+    return data;
+}
+
+// Похожие активные объявления с пояснениями, лучшие первыми
+export const fetchSimilarObjects = async (id: string, limit = 6): Promise<ISimilarListingsResponse> => {
+    const { data } = await $host.get<ISimilarListingsResponse>(`/objects/${id}/similar`, {
+        params: { limit }
+    });
+    return data;
+}
@@ -1,5 +1,5 @@
-import React, { useEffect, useState } from 'react';
-import { Container, Spinner, Alert } from 'react-bootstrap';
+import React, { useContext, useEffect, useState } from 'react';
+import { Container, Spinner, Alert, Form } from 'react-bootstrap';
 import { useSearchParams } from 'react-router-dom';
 import REObjectItem from '../components/RealEstateObjectItem';
 import AdvancedFilterBar from '../components/AdvancedFilterBar'; // <-- Обнови импорт
@@ -8,8 +8,15 @@ import { fetchObjects } from '../http/objectsAPI';
 import type { IObjectCardResponse } from '../types/realEstateObjects';
 import type { IFilterState } from '../types/filter';
 import { ARRAY_KEYS } from '../utils/filterUtils'; // <-- Импортируем константу
+import { markListings } from '../http/favoriteAPI';
+import { Context } from '../main';
+import { observer } from 'mobx-react-lite';
 
-const Listings = () => {
+// Персональные параметры поиска, их разрешает gateway по токену
+const PERSONAL_KEYS = ['exclude_hidden', 'only_unseen'];
+
+const Listings = observer(() => {
+    const { user } = useContext(Context);
     const [searchParams, setSearchParams] = useSearchParams();
     const [properties, setProperties] = useState<IObjectCardResponse[]>([]);
     const [loading, setLoading] = useState(true);
@@ -79,6 +86,12 @@ const Listings = () => {
             }
         });
         
+        // Персональные переключатели не входят в фильтры, сохраняем их
+        PERSONAL_KEYS.forEach(key => {
+            const value = searchParams.get(key);
+            if (value) params[key] = value;
+        });
+
         // Сбрасываем на 1 страницу при новом поиске
         params.page = '1'; 
 
@@ -94,10 +107,49 @@ const Listings = () => {
         setSearchParams(current);
     };
 
+    const handleTogglePersonal = (key: string, checked: boolean) => {
+        const current = new URLSearchParams(searchParams);
+        if (checked) {
+            current.set(key, 'true');
+        } else {
+            current.delete(key);
+        }
+        current.set('page', '1');
+        setSearchParams(current);
+    };
+
+    const handleHide = (masterObjectId: string) => {
+        markListings('hidden', [masterObjectId])
+            .then(() => {
+                setProperties(prev => prev.filter(p => p.master_object_id !== masterObjectId));
+                setTotalCount(prev => Math.max(prev - 1, 0));
+            })
+            .catch(err => console.error(err));
+    };
+
     return (
         <Container className="mt-4">
             <AdvancedFilterBar onSearch={handleSearch} />
 
+            {user.isAuth && (
+                <div className="d-flex gap-4 mb-2">
+                    <Form.Check
+                        type="switch"
+                        id="exclude-hidden"
+                        label="Не показывать скрытые"
+                        checked={searchParams.get('exclude_hidden') === 'true'}
+                        onChange={e => handleTogglePersonal('exclude_hidden', e.target.checked)}
+                    />
+                    <Form.Check
+                        type="switch"
+                        id="only-unseen"
+                        label="Только непросмотренные"
+                        checked={searchParams.get('only_unseen') === 'true'}
+                        onChange={e => handleTogglePersonal('only_unseen', e.target.checked)}
+                    />
+                </div>
+            )}
+
             <div className="mb-3 text-muted">
                 Найдено объявлений: <b>{totalCount}</b>
             </div>
@@ -109,7 +161,12 @@ const Listings = () => {
             ) : properties && properties.length > 0 ? (
                 <div>
                     {properties.map(property => (
-                        <REObjectItem key={property.id} isFavoritePage={false} property={property} />
+                        <REObjectItem
+                            key={property.id}
+                            isFavoritePage={false}
+                            property={property}
+                            onHide={user.isAuth ? () => handleHide(property.master_object_id) : undefined}
+                        />
                     ))}
                     <Pages 
                         totalCount={totalCount} 
@@ -125,6 +182,6 @@ const Listings = () => {
             )}
         </Container>
     );
-};
+});
 
 export default Listings;
\ No newline at end of file
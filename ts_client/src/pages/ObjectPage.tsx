@@ -5,11 +5,13 @@ import { fetchObjectWithDeatils } from '../http/objectsAPI';
 import type { IObjectDetailsResponse, IApartmentDetails, IHouseDetails, IRelatedOffer, ICommercialDetails } from '../types/realEstateObjects';
 import FavoriteButton from '../components/FavoriteButton';
 import ActualizeButton from '../components/ActualizeButton';
+import SimilarObjects from '../components/SimilarObjects';
+import { markListings } from '../http/favoriteAPI';
 import { Context } from '../main';
 import { observer } from 'mobx-react-lite';
 
 const ObjectPage = observer(() => {
-    const { actualization } = useContext(Context);
+    const { actualization, user } = useContext(Context);
     const { id } = useParams<{ id: string }>();
     const navigate = useNavigate();
     
@@ -24,7 +26,13 @@ const ObjectPage = observer(() => {
         if (!id) return;
         setLoading(true); // Можно сделать мягкую загрузку (без спиннера на весь экран), если хотите
         fetchObjectWithDeatils(id)
-            .then(setData)
+            .then(newData => {
+                setData(newData);
+                // Открытый объект больше не считается новым для поиска с only_unseen
+                if (user.isAuth) {
+                    markListings('seen', [newData.general.master_object_id]).catch(err => console.error(err));
+                }
+            })
             .catch(err => setError('Ошибка'))
             .finally(() => setLoading(false));
     };
@@ -456,6 +464,8 @@ const ObjectPage = observer(() => {
                     </div>
                 </Col>
             </Row>
+
+            <SimilarObjects objectId={general.id} />
         </Container>
     );
 });
@@ -0,0 +1,81 @@
+import React, { useEffect, useState } from 'react';
+import { Container, Spinner, Alert } from 'react-bootstrap';
+import { useParams } from 'react-router-dom';
+import axios from 'axios';
+import REObjectItem from '../components/RealEstateObjectItem';
+import Pages from '../components/Pages';
+import { fetchSharedFavorites } from '../http/favoriteAPI';
+import type { ISharedFavoritesResponse } from '../types/favorites';
+
+// Подборка по публичной ссылке - открывается без авторизации
+const SharedFavorites: React.FC = () => {
+    const { token } = useParams<{ token: string }>();
+    const [shared, setShared] = useState<ISharedFavoritesResponse | null>(null);
+    const [loading, setLoading] = useState(true);
+    const [error, setError] = useState<string | null>(null);
+    const [page, setPage] = useState(1);
+    const limit = 5;
+
+    useEffect(() => {
+        if (!token) return;
+        setLoading(true);
+        fetchSharedFavorites(token, page, limit)
+            .then(data => {
+                setShared(data);
+                setError(null);
+            })
+            .catch(err => {
+                // 410 - ссылку отозвали или она истекла, 404 - такой ссылки нет
+                if (axios.isAxiosError(err) && err.response?.status === 410) {
+                    setError("Срок действия ссылки истек или владелец ее отозвал.");
+                } else {
+                    setError("Ссылка не найдена.");
+                }
+            })
+            .finally(() => setLoading(false));
+    }, [token, page]);
+
+    if (loading) {
+        return (
+            <div className="d-flex justify-content-center mt-5">
+                <Spinner animation="border" variant="danger" />
+            </div>
+        );
+    }
+
+    if (error || !shared) {
+        return (
+            <Container className="mt-5">
+                <Alert variant="warning">{error}</Alert>
+            </Container>
+        );
+    }
+
+    return (
+        <Container className="mt-4">
+            <h2 className="mb-1">{shared.collection_name || "Избранное"} ({shared.total})</h2>
+            <p className="text-muted mb-4">
+                Ссылка действует до {new Date(shared.expires_at).toLocaleDateString()}
+            </p>
+
+            {shared.data.length > 0 ? (
+                <div>
+                    {shared.data.map(property => (
+                        <REObjectItem key={property.id} property={property} isFavoritePage={false} />
+                    ))}
+
+                    <Pages
+                        totalCount={shared.total}
+                        perPage={limit}
+                        currentPage={page}
+                        onPageChange={setPage}
+                    />
+                </div>
+            ) : (
+                <Alert variant="info">В этой подборке пока нет объектов.</Alert>
+            )}
+        </Container>
+    );
+};
+
+export default SharedFavorites;
@@ -4,8 +4,9 @@ import Favorites from './pages/Favorites';
 import Filters from './pages/FiltersPage';
 import Listings from './pages/Listings';
 import ObjectPage from './pages/ObjectPage';
+import SharedFavorites from './pages/SharedFavorites';
 
-import { ADMIN_ROUTE, LOGIN_ROUTE, REGISTRATION_ROUTE, MAIN_ROUTE, LISTINGS_ROUTE, OBJECT_ROUTE, FAVORITES_ROUTE } from './utils/consts';
+import { ADMIN_ROUTE, LOGIN_ROUTE, REGISTRATION_ROUTE, MAIN_ROUTE, LISTINGS_ROUTE, OBJECT_ROUTE, FAVORITES_ROUTE, SHARED_ROUTE } from './utils/consts';
 
 
 interface IRoute {
@@ -50,4 +51,8 @@ export const publicRoutes: IRoute[] = [
         path: OBJECT_ROUTE,
         Component: ObjectPage
     },
+    {
+        path: SHARED_ROUTE,
+        Component: SharedFavorites
+    },
 ]
\ No newline at end of file
@@ -1,8 +1,58 @@
 import type { IObjectCardResponse } from "./realEstateObjects";
 
+export interface IFavoriteCardResponse extends IObjectCardResponse {
+    added_at: string;
+    note: string;
+    tags: string[];
+    collection_ids: string[];
+}
+
 export interface IFavoritesResponse {
-    data: IObjectCardResponse[]; 
+    data: IFavoriteCardResponse[]; 
     total: number;               
     page: number;
     per_page: number;
-}
\ No newline at end of file
+}
+
+export type FavoritesSort = 'added_at' | 'price';
+
+export interface IFavoritesQuery {
+    collection_id?: string;
+    tag?: string;
+    sort?: FavoritesSort;
+    order?: 'asc' | 'desc';
+}
+
+export interface IFavoriteCollection {
+    id: string;
+    name: string;
+    items_count: number;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface IShareLink {
+    id: string;
+    token: string;
+    collection_id: string | null;
+    collection_name?: string;
+    active: boolean;
+    expires_at: string;
+    revoked_at?: string;
+    views_count: number;
+    last_viewed_at?: string;
+    created_at: string;
+}
+
+// Ответ публичной ссылки: карточки без заметок и тегов владельца
+export interface ISharedFavoritesResponse {
+    collection_name?: string;
+    expires_at: string;
+    data: (IObjectCardResponse & { added_at: string })[];
+    total: number;
+    page: number;
+    per_page: number;
+}
+
+// Отметки объектов выдачи: просмотренные и скрытые пользователем
+export type ListingMark = 'seen' | 'hidden';
@@ -135,4 +135,59 @@ export interface IObjectDetailsResponse {
     general: IObjectGeneralInfoResponse;
     details: IApartmentDetails | IHouseDetails | ICommercialDetails; // TS сам разберется, что там
     related_offers: IRelatedOffer[]; // Проверь JSON тег в Go: RelatedOffers -> related_offers?
-}
\ No newline at end of file
+}
+
+// Сравнение объектов (CompareObjectsResponse)
+export interface IComparedObject {
+    master_object_id: string;
+    id: string;
+    title: string;
+    images: string[];
+    source: string;
+    ad_link: string;
+    category: string;
+    deal_type: string;
+    status: string;
+    price_usd: number;
+    price_byn: number;
+    price_per_square_meter_usd: number | null;
+    price_per_square_meter_byn: number | null;
+    details: IApartmentDetails | IHouseDetails | ICommercialDetails | null;
+    related_offers: IRelatedOffer[];
+}
+
+// Строка таблицы: values в порядке objects, null - значения у объекта нет
+export interface IComparisonAttribute {
+    key: string;
+    group: 'general' | 'price' | 'details';
+    values: unknown[];
+    differs: boolean;
+}
+
+export interface ICompareObjectsResponse {
+    objects: IComparedObject[];
+    attributes: IComparisonAttribute[];
+    missing_ids: string[];
+}
+
+// Похожие объекты (SimilarListingsResponse)
+export interface ISimilarityFactor {
+    factor: 'distance' | 'price' | 'price_per_square_meter' | 'rooms' | 'area' | 'year_built';
+    weight: number;
+    score: number;
+    note: string;
+}
+
+export interface ISimilarListing extends IObjectCardResponse {
+    score: number;
+    distance_km: number;
+    explanation: ISimilarityFactor[];
+}
+
+export interface ISimilarListingsResponse {
+    object_id: string;
+    category: string;
+    deal_type: string;
+    candidates: number;
+    data: ISimilarListing[];
+}
@@ -3,10 +3,11 @@ export interface ITask {
     id: string;
     name: string;
     type: 'ACTUALIZE_BY_ID' | 'ACTUALIZE_ACTIVE' | 'FIND_NEW';
-    status: 'pending' | 'running' | 'completed' | 'failed';
+    status: 'pending' | 'running' | 'completed' | 'failed' | 'timed_out';
     created_at: string;
     started_at?: string;
     finished_at?: string;
+    last_progress_at?: string; // последний прогресс задачи, от него сторож отсчитывает таймаут
     
     // Результаты (обновляются в реальном времени)
     result_summary?: {
@@ -17,8 +18,29 @@ export interface ITask {
         archived: number;
         new_links_found: number;
         id?: string;
+        timeout_reason?: string; // заполняется, если задача остановлена по таймауту
     };
     created_by_user_id: string;
+
+    // Входные параметры запуска и связь с исходной задачей при повторе
+    params?: Record<string, unknown>;
+    parent_task_id?: string;
+    restart_mode?: 'rerun' | 'resume';
+
+    // Разбивка по подзадачам: в GET /tasks/{id} - все, в SSE-событии - только изменившиеся
+    subtasks?: ITaskSubtask[];
+}
+
+// Прогресс одной подзадачи: поиска парсера или сохранения в storage-service
+export interface ITaskSubtask {
+    source: string;
+    name: string;
+    links_found: number;
+    processed: number;
+    errors: number;
+    duration_ms: number;
+    last_error?: string;
+    updated_at: string;
 }
 
 // Ответ списка задач (с пагинацией, как у вас в JSON)
@@ -12,4 +12,5 @@ export const LOGIN_ROUTE = '/login'
 export const REGISTRATION_ROUTE = '/registration'
 export const LISTINGS_ROUTE = '/listings';
 export const OBJECT_ROUTE = '/objects/:id';
-export const FAVORITES_ROUTE = '/favorites';
\ No newline at end of file
+export const FAVORITES_ROUTE = '/favorites';
+export const SHARED_ROUTE = '/shared/:token';
\ No newline at end of file